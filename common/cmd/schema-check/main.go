@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"go-invoice-service/common/protocol/kafka"
+	"log"
+)
+
+const defaultRegistryPath = "../schemas/registry.json"
+
+// Checks the event schemas in proto/events against the latest versions in the
+// schema registry file and exits with an error on a breaking change.
+// With --register compatible changes are recorded as new versions.
+func main() {
+	registryPath := flag.String("registry", defaultRegistryPath, "Schema registry file")
+	register := flag.Bool("register", false, "Register new and changed schemas")
+	flag.Parse()
+
+	ctx := context.Background()
+	registry := kafka.NewFileSchemaRegistry(*registryPath)
+
+	var errs []error
+	for _, event := range kafka.Events {
+		subject := kafka.Subject(event.EventType())
+		schema := event.ToProto(nil).ProtoReflect().Descriptor()
+
+		latest, err := registry.Latest(ctx, subject)
+		switch {
+		case errors.Is(err, kafka.ErrSchemaNotFound):
+			fmt.Printf("%s: not registered\n", subject)
+		case err != nil:
+			log.Fatal(err)
+		default:
+			if err := kafka.CheckCompatibility(latest.Descriptor, schema); err != nil {
+				errs = append(errs, fmt.Errorf("%s (version %d): %w", subject, latest.Version, err))
+				continue
+			}
+			fmt.Printf("%s: compatible with version %d\n", subject, latest.Version)
+		}
+
+		if *register {
+			id, err := registry.Register(ctx, subject, schema)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("%s: registered with id %d\n", subject, id)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,212 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"go-invoice-service/common/pkg/logging"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State of a breaker, its value is exported by the state metric.
+type State int64
+
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	}
+	return "unknown"
+}
+
+type Metrics interface {
+	SetCircuitBreakerState(ctx context.Context, name string, state int64)
+}
+
+type Config struct {
+	// FailureThreshold consecutive failures open the breaker.
+	FailureThreshold int
+	// OpenTimeout is how long an open breaker rejects calls before it lets trial
+	// calls through.
+	OpenTimeout time.Duration
+	// SuccessThreshold trial calls are let through while half-open, the breaker
+	// closes once all of them succeed and opens again on the first failure.
+	SuccessThreshold int
+}
+
+// Breaker stops calls to a failing dependency. It opens after FailureThreshold
+// consecutive failures and rejects calls with ErrOpen for OpenTimeout. Then it is
+// half-open and lets SuccessThreshold trial calls through.
+//
+// Every state change starts a new generation. Results are reported with the
+// generation Allow returned, and results of calls allowed in an earlier generation
+// are ignored, so a late result of a call made before the breaker opened doesn't
+// count as a trial.
+type Breaker struct {
+	name    string
+	cfg     Config
+	metrics Metrics
+	logger  *logging.ZapLogger
+	now     func() time.Time
+
+	mu         sync.Mutex
+	state      State
+	generation uint64
+	failures   int
+	successes  int
+	trials     int
+	openedAt   time.Time
+}
+
+func New(name string, cfg Config, metrics Metrics, logger *logging.ZapLogger) *Breaker {
+	metrics.SetCircuitBreakerState(context.Background(), name, int64(StateClosed))
+	return &Breaker{
+		name:    name,
+		cfg:     cfg,
+		metrics: metrics,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// Allow returns ErrOpen if the call must not be made. An allowed call must be
+// reported with Success, Failure or Release and the returned generation.
+func (b *Breaker) Allow() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen {
+		if b.now().Sub(b.openedAt) < b.cfg.OpenTimeout {
+			return 0, ErrOpen
+		}
+		b.setState(StateHalfOpen)
+	}
+	if b.state == StateHalfOpen {
+		if b.trials >= b.cfg.SuccessThreshold {
+			return 0, ErrOpen
+		}
+		b.trials++
+	}
+	return b.generation, nil
+}
+
+// Ready reports whether Allow may let a call through, without reserving a trial call.
+func (b *Breaker) Ready() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state != StateOpen || b.now().Sub(b.openedAt) >= b.cfg.OpenTimeout
+}
+
+func (b *Breaker) Success(generation uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+	switch b.state {
+	case StateClosed:
+		b.failures = 0
+	case StateHalfOpen:
+		b.successes++
+		if b.successes >= b.cfg.SuccessThreshold {
+			b.setState(StateClosed)
+		}
+	}
+}
+
+func (b *Breaker) Failure(generation uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+	switch b.state {
+	case StateClosed:
+		b.failures++
+		if b.failures >= b.cfg.FailureThreshold {
+			b.setState(StateOpen)
+		}
+	case StateHalfOpen:
+		b.setState(StateOpen)
+	}
+}
+
+// Release ends an allowed call that neither succeeded nor failed, e.g. one cancelled
+// by the caller. A trial call is given back, so another call can be tried instead.
+func (b *Breaker) Release(generation uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+	if b.state == StateHalfOpen && b.trials > 0 {
+		b.trials--
+	}
+}
+
+// Do calls fn unless the breaker is open. Errors of fn count as failures, while
+// cancellation of the caller counts as neither success nor failure.
+func (b *Breaker) Do(fn func() error) error {
+	generation, err := b.Allow()
+	if err != nil {
+		return err
+	}
+	err = fn()
+	switch {
+	case errors.Is(err, context.Canceled):
+		b.Release(generation)
+	case err != nil:
+		b.Failure(generation)
+	default:
+		b.Success(generation)
+	}
+	return err
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+func (b *Breaker) setState(state State) {
+	ctx := context.Background()
+	switch state {
+	case StateOpen:
+		b.openedAt = b.now()
+		b.logger.WarnCtx(ctx, "circuit breaker opened",
+			zap.String("breaker", b.name),
+			zap.String("from", b.state.String()),
+			zap.Int("failures", b.failures),
+			zap.Duration("open-timeout", b.cfg.OpenTimeout),
+		)
+	default:
+		b.logger.InfoCtx(ctx, "circuit breaker "+state.String(),
+			zap.String("breaker", b.name),
+			zap.String("from", b.state.String()),
+		)
+	}
+
+	b.state = state
+	b.generation++
+	b.failures = 0
+	b.successes = 0
+	b.trials = 0
+	b.metrics.SetCircuitBreakerState(ctx, b.name, int64(state))
+}
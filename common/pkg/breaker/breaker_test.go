@@ -0,0 +1,226 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-invoice-service/common/pkg/logging"
+	"testing"
+	"time"
+)
+
+type breakerMetrics struct {
+	states []int64
+}
+
+func (m *breakerMetrics) SetCircuitBreakerState(_ context.Context, _ string, state int64) {
+	m.states = append(m.states, state)
+}
+
+// testBreaker returns a breaker with a clock moved by the returned function.
+func testBreaker(cfg Config) (*Breaker, *breakerMetrics, func(time.Duration)) {
+	metrics := &breakerMetrics{}
+	b := New("test", cfg, metrics, logging.NewNopLogger())
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	b.now = func() time.Time { return now }
+	return b, metrics, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestBreaker_Transitions(t *testing.T) {
+	cfg := Config{FailureThreshold: 3, OpenTimeout: time.Second, SuccessThreshold: 2}
+
+	// Steps: "s" and "f" are allowed calls that succeed and fail, "r" is a call that
+	// must be rejected, "w" waits for OpenTimeout.
+	tests := []struct {
+		name       string
+		steps      string
+		wantState  State
+		wantStates []int64
+	}{
+		{
+			name:       "closed_on_failures_below_threshold",
+			steps:      "ffsff",
+			wantState:  StateClosed,
+			wantStates: []int64{0},
+		},
+		{
+			name:       "opens_on_consecutive_failures",
+			steps:      "fffr",
+			wantState:  StateOpen,
+			wantStates: []int64{0, 2},
+		},
+		{
+			// The breaker turns half-open on the first call after the timeout.
+			name:       "open_until_called_after_timeout",
+			steps:      "fffw",
+			wantState:  StateOpen,
+			wantStates: []int64{0, 2},
+		},
+		{
+			name:       "half_open_until_trials_succeed",
+			steps:      "fffws",
+			wantState:  StateHalfOpen,
+			wantStates: []int64{0, 2, 1},
+		},
+		{
+			name:       "closes_after_successful_trials",
+			steps:      "fffwsss",
+			wantState:  StateClosed,
+			wantStates: []int64{0, 2, 1, 0},
+		},
+		{
+			name:       "opens_again_on_failed_trial",
+			steps:      "fffwsfr",
+			wantState:  StateOpen,
+			wantStates: []int64{0, 2, 1, 2},
+		},
+		{
+			name:       "reopened_breaker_recovers",
+			steps:      "fffwfwss",
+			wantState:  StateClosed,
+			wantStates: []int64{0, 2, 1, 2, 1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, metrics, advance := testBreaker(cfg)
+			for i, step := range tt.steps {
+				switch step {
+				case 'w':
+					advance(cfg.OpenTimeout)
+				case 'r':
+					_, err := b.Allow()
+					require.ErrorIs(t, err, ErrOpen, "step %d", i)
+				case 's', 'f':
+					generation, err := b.Allow()
+					require.NoError(t, err, "step %d", i)
+					if step == 's' {
+						b.Success(generation)
+					} else {
+						b.Failure(generation)
+					}
+				}
+			}
+			assert.Equal(t, tt.wantState, b.State())
+			assert.Equal(t, tt.wantStates, metrics.states)
+		})
+	}
+}
+
+func TestBreaker_HalfOpenTrials(t *testing.T) {
+	b, _, advance := testBreaker(Config{FailureThreshold: 1, OpenTimeout: time.Second, SuccessThreshold: 2})
+
+	generation, err := b.Allow()
+	require.NoError(t, err)
+	b.Failure(generation)
+	assert.False(t, b.Ready())
+
+	advance(time.Second)
+	assert.True(t, b.Ready())
+	first, err := b.Allow()
+	require.NoError(t, err)
+	second, err := b.Allow()
+	require.NoError(t, err)
+	_, err = b.Allow()
+	require.ErrorIs(t, err, ErrOpen)
+
+	b.Success(first)
+	assert.Equal(t, StateHalfOpen, b.State())
+	b.Success(second)
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_StaleResults(t *testing.T) {
+	b, _, advance := testBreaker(Config{FailureThreshold: 2, OpenTimeout: time.Second, SuccessThreshold: 1})
+
+	// Calls allowed while closed report after the breaker opened.
+	early, err := b.Allow()
+	require.NoError(t, err)
+	late, err := b.Allow()
+	require.NoError(t, err)
+	b.Failure(early)
+	b.Failure(early)
+	require.Equal(t, StateOpen, b.State())
+
+	advance(time.Second)
+	trial, err := b.Allow()
+	require.NoError(t, err)
+	require.Equal(t, StateHalfOpen, b.State())
+
+	// Neither closes nor reopens the half-open breaker.
+	b.Success(late)
+	b.Failure(late)
+	assert.Equal(t, StateHalfOpen, b.State())
+
+	b.Success(trial)
+	assert.Equal(t, StateClosed, b.State())
+
+	// A failure of the earlier generation doesn't count in the closed breaker.
+	b.Failure(late)
+	b.Failure(late)
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_Do(t *testing.T) {
+	b, _, _ := testBreaker(Config{FailureThreshold: 1, OpenTimeout: time.Second, SuccessThreshold: 1})
+
+	// Cancellation of the caller is not a failure of the dependency.
+	err := b.Do(func() error { return context.Canceled })
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, StateClosed, b.State())
+
+	callErr := errors.New("call failed")
+	err = b.Do(func() error { return callErr })
+	require.ErrorIs(t, err, callErr)
+	assert.Equal(t, StateOpen, b.State())
+
+	called := false
+	err = b.Do(func() error {
+		called = true
+		return nil
+	})
+	require.ErrorIs(t, err, ErrOpen)
+	assert.False(t, called)
+}
+
+func TestBreaker_Do_CanceledTrial(t *testing.T) {
+	b, _, advance := testBreaker(Config{FailureThreshold: 1, OpenTimeout: time.Second, SuccessThreshold: 1})
+
+	require.Error(t, b.Do(func() error { return errors.New("call failed") }))
+	advance(time.Second)
+
+	// A cancelled trial neither closes nor reopens the breaker, and the next call is
+	// let through as the trial instead.
+	err := b.Do(func() error { return context.Canceled })
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, StateHalfOpen, b.State())
+
+	require.NoError(t, b.Do(func() error { return nil }))
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_Release(t *testing.T) {
+	b, _, advance := testBreaker(Config{FailureThreshold: 1, OpenTimeout: time.Second, SuccessThreshold: 1})
+
+	generation, err := b.Allow()
+	require.NoError(t, err)
+	b.Failure(generation)
+	advance(time.Second)
+
+	trial, err := b.Allow()
+	require.NoError(t, err)
+	_, err = b.Allow()
+	require.ErrorIs(t, err, ErrOpen)
+
+	// A release of an earlier generation doesn't give back the trial.
+	b.Release(generation)
+	_, err = b.Allow()
+	require.ErrorIs(t, err, ErrOpen)
+
+	b.Release(trial)
+	assert.Equal(t, StateHalfOpen, b.State())
+	_, err = b.Allow()
+	require.NoError(t, err)
+}
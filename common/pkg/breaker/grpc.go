@@ -0,0 +1,70 @@
+package breaker
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor fails calls with ErrOpen while the breaker is open. Only
+// errors showing the server is unreachable or overloaded count as failures, so
+// errors of the application, returned as Unknown or Internal, don't open the breaker.
+func UnaryClientInterceptor(b *Breaker) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		generation, err := b.Allow()
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, method)
+		}
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		record(b, generation, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor counts only opening of streams.
+func StreamClientInterceptor(b *Breaker) grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		generation, err := b.Allow()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, method)
+		}
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		record(b, generation, err)
+		return stream, err
+	}
+}
+
+func record(b *Breaker, generation uint64, err error) {
+	switch {
+	case status.Code(err) == codes.Canceled:
+		b.Release(generation)
+	case isFailure(err):
+		b.Failure(generation)
+	default:
+		b.Success(generation)
+	}
+}
+
+func isFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
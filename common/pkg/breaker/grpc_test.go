@@ -0,0 +1,103 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantState State
+	}{
+		{name: "ok", err: nil, wantState: StateClosed},
+		{name: "unavailable", err: status.Error(codes.Unavailable, "down"), wantState: StateOpen},
+		{name: "deadline_exceeded", err: status.Error(codes.DeadlineExceeded, "slow"), wantState: StateOpen},
+		{name: "resource_exhausted", err: status.Error(codes.ResourceExhausted, "busy"), wantState: StateOpen},
+		// Servers return application errors as plain errors, they arrive as Unknown.
+		{name: "unknown", err: status.Error(codes.Unknown, "failed to get invoice"), wantState: StateClosed},
+		{name: "internal", err: status.Error(codes.Internal, "oops"), wantState: StateClosed},
+		{name: "not_found", err: status.Error(codes.NotFound, "no invoice"), wantState: StateClosed},
+		{name: "invalid_argument", err: status.Error(codes.InvalidArgument, "bad id"), wantState: StateClosed},
+		{name: "canceled", err: status.Error(codes.Canceled, "canceled"), wantState: StateClosed},
+		{name: "plain_error", err: errors.New("failed"), wantState: StateClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, _, _ := testBreaker(Config{FailureThreshold: 1, OpenTimeout: time.Second, SuccessThreshold: 1})
+			interceptor := UnaryClientInterceptor(b)
+
+			invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+				return tt.err
+			}
+			err := interceptor(context.Background(), "/test.Service/Call", nil, nil, nil, invoker)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.wantState, b.State())
+		})
+	}
+}
+
+func TestUnaryClientInterceptor_Open(t *testing.T) {
+	b, _, _ := testBreaker(Config{FailureThreshold: 1, OpenTimeout: time.Second, SuccessThreshold: 1})
+	interceptor := UnaryClientInterceptor(b)
+
+	calls := 0
+	invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.Unavailable, "down")
+	}
+	_ = interceptor(context.Background(), "/test.Service/Call", nil, nil, nil, invoker)
+
+	err := interceptor(context.Background(), "/test.Service/Call", nil, nil, nil, invoker)
+	require.ErrorIs(t, err, ErrOpen)
+	assert.Contains(t, err.Error(), "/test.Service/Call")
+	assert.Equal(t, 1, calls)
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	b, _, _ := testBreaker(Config{FailureThreshold: 2, OpenTimeout: time.Second, SuccessThreshold: 1})
+	interceptor := StreamClientInterceptor(b)
+
+	streamErr := status.Error(codes.Unavailable, "down")
+	streamer := func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+		return nil, streamErr
+	}
+	for range 2 {
+		_, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "/test.Service/Watch", streamer)
+		require.ErrorIs(t, err, streamErr)
+	}
+
+	_, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "/test.Service/Watch", streamer)
+	require.ErrorIs(t, err, ErrOpen)
+	assert.Equal(t, fmt.Sprintf("%s: /test.Service/Watch", ErrOpen), err.Error())
+}
+
+func TestUnaryClientInterceptor_CanceledTrial(t *testing.T) {
+	b, _, advance := testBreaker(Config{FailureThreshold: 1, OpenTimeout: time.Second, SuccessThreshold: 1})
+	interceptor := UnaryClientInterceptor(b)
+
+	callErr := status.Error(codes.Unavailable, "down")
+	invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		return callErr
+	}
+	_ = interceptor(context.Background(), "/test.Service/Call", nil, nil, nil, invoker)
+	advance(time.Second)
+
+	callErr = status.Error(codes.Canceled, "canceled")
+	_ = interceptor(context.Background(), "/test.Service/Call", nil, nil, nil, invoker)
+	assert.Equal(t, StateHalfOpen, b.State())
+
+	callErr = nil
+	require.NoError(t, interceptor(context.Background(), "/test.Service/Call", nil, nil, nil, invoker))
+	assert.Equal(t, StateClosed, b.State())
+}
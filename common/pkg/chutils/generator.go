@@ -5,11 +5,47 @@ import (
 	"time"
 )
 
+// Generator calls tick at most once per minInterval while it returns values or the
+// buffer is full. Once there is nothing to fetch, tick is called again on a wake
+// signal, or after idleInterval if no signal comes. With a nil wake channel the
+// generator just polls every idleInterval when idle.
 func Generator[T any](
 	ctx context.Context,
 	buffCap int32,
 	minInterval time.Duration,
+	idleInterval time.Duration,
+	wake <-chan struct{},
 	tick func(ctx context.Context, buffLen int32) ([]T, error),
+) (<-chan T, <-chan error) {
+	return PacedGenerator[T](
+		ctx,
+		buffCap,
+		idleInterval,
+		wake,
+		func(buffLen int32) (int32, time.Duration) {
+			return buffCap - buffLen, minInterval
+		},
+		func(ctx context.Context, limit int32) ([]T, error) {
+			return tick(ctx, buffCap-limit)
+		},
+	)
+}
+
+// Pace returns the max number of values to request while the buffer holds buffLen
+// values, and the min interval before the next request.
+type Pace func(buffLen int32) (int32, time.Duration)
+
+// PacedGenerator works like Generator, but the number of requested values and the
+// interval between requests are decided by pace before every request. Nothing is
+// requested while pace returns a zero limit. pace must not return a limit greater
+// than buffCap-buffLen.
+func PacedGenerator[T any](
+	ctx context.Context,
+	buffCap int32,
+	idleInterval time.Duration,
+	wake <-chan struct{},
+	pace Pace,
+	tick func(ctx context.Context, limit int32) ([]T, error),
 ) (<-chan T, <-chan error) {
 	out := make(chan T, buffCap)
 	errCh := make(chan error)
@@ -18,27 +54,38 @@ func Generator[T any](
 		defer close(out)
 		defer close(errCh)
 
-		var nextRequest time.Time
+		for ctx.Err() == nil {
+			requestedAt := time.Now()
+			limit, minInterval := pace(int32(len(out)))
+			busy := limit <= 0
 
-		LoopCtx(
-			ctx,
-			func(ctx context.Context) {
-				nextRequest = time.Now().Add(minInterval)
-
-				if val, err := tick(ctx, int32(len(out))); err != nil {
+			if !busy {
+				val, err := tick(ctx, limit)
+				if err != nil {
 					errCh <- err
 				} else {
 					for _, msg := range val {
 						out <- msg
 					}
+					busy = len(val) > 0
 				}
+			}
 
-				timeToWait := nextRequest.Sub(time.Now())
-				if timeToWait > 0 {
-					time.Sleep(timeToWait)
+			if !busy {
+				select {
+				case <-ctx.Done():
+					return
+				case <-wake:
+				case <-time.After(time.Until(requestedAt.Add(idleInterval))):
 				}
-			},
-		)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Until(requestedAt.Add(minInterval))):
+			}
+		}
 	}(ctx)
 
 	return out, errCh
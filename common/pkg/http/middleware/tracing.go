@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"go-invoice-service/common/pkg/tracing"
+	"net/http"
+)
+
+const CorrelationIDHeader = "X-Correlation-Id"
+
+// Tracing continues the trace and correlation ID of the request or starts new
+// ones, and returns the correlation ID in the response.
+type Tracing struct{}
+
+func NewTracing() *Tracing {
+	return &Tracing{}
+}
+
+func (t *Tracing) CreateHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		carrier := map[string]string{
+			tracing.HeaderTraceParent:   r.Header.Get(tracing.HeaderTraceParent),
+			tracing.HeaderTraceState:    r.Header.Get(tracing.HeaderTraceState),
+			tracing.HeaderCorrelationID: r.Header.Get(CorrelationIDHeader),
+		}
+		ctx := tracing.Ensure(tracing.Extract(r.Context(), carrier))
+		w.Header().Set(CorrelationIDHeader, tracing.CorrelationID(ctx))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
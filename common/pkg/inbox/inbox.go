@@ -0,0 +1,58 @@
+package inbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+// ErrAlreadyHandled is returned when the event was handled by the consumer group already.
+var ErrAlreadyHandled = errors.New("event already handled")
+
+// Key identifies an event handled by a consumer group.
+type Key struct {
+	EventID       uuid.UUID
+	ConsumerGroup string
+}
+
+// Store records handled events, so a redelivered event is not handled twice.
+type Store interface {
+	// Record marks the event handled in tx, so the record is committed together with the
+	// side effects of handling it. It returns false if the event is recorded already.
+	Record(ctx context.Context, tx *sql.Tx, key Key) (bool, error)
+	// DeleteExpired deletes up to limit records older than the store TTL.
+	DeleteExpired(ctx context.Context, limit int) (int64, error)
+}
+
+// RunCleanup deletes expired records every interval until ctx is done.
+func RunCleanup(
+	ctx context.Context,
+	store Store,
+	interval time.Duration,
+	batchSize int,
+	onError func(context.Context, error),
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for ctx.Err() == nil {
+			deleted, err := store.DeleteExpired(ctx, batchSize)
+			if err != nil {
+				onError(ctx, err)
+				break
+			}
+			if deleted < int64(batchSize) {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package inbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+// cleanupStore returns the next of deleted on every DeleteExpired call and stops the
+// cleanup after the last one.
+type cleanupStore struct {
+	mu      sync.Mutex
+	deleted []int64
+	err     error
+	limits  []int
+	stop    context.CancelFunc
+}
+
+func (s *cleanupStore) Record(context.Context, *sql.Tx, Key) (bool, error) {
+	return false, errors.New("not implemented")
+}
+
+func (s *cleanupStore) DeleteExpired(_ context.Context, limit int) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.limits = append(s.limits, limit)
+	if len(s.limits) >= len(s.deleted) {
+		s.stop()
+	}
+	if len(s.limits) > len(s.deleted) {
+		return 0, nil
+	}
+	return s.deleted[len(s.limits)-1], s.err
+}
+
+func (s *cleanupStore) calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.limits)
+}
+
+func TestRunCleanup(t *testing.T) {
+	tests := []struct {
+		name      string
+		deleted   []int64
+		err       error
+		wantCalls int
+		wantErrs  int
+	}{
+		{
+			// Full batches are followed by the next batch right away.
+			name:      "deletes_until_batch_not_full",
+			deleted:   []int64{10, 10, 3},
+			wantCalls: 3,
+		},
+		{
+			name:      "nothing_expired",
+			deleted:   []int64{0},
+			wantCalls: 1,
+		},
+		{
+			name:      "stops_on_error",
+			deleted:   []int64{10},
+			err:       errors.New("delete failed"),
+			wantCalls: 1,
+			wantErrs:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			store := &cleanupStore{deleted: tt.deleted, err: tt.err, stop: cancel}
+
+			var errs []error
+			RunCleanup(ctx, store, time.Millisecond, 10, func(_ context.Context, err error) {
+				errs = append(errs, err)
+			})
+
+			assert.Equal(t, tt.wantCalls, store.calls())
+			assert.Len(t, errs, tt.wantErrs)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package inbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const recordQuery = `insert into inbox (event_id, consumer_group, expires_at)
+values ($1, $2, $3)
+on conflict (event_id, consumer_group) do update set expires_at = excluded.expires_at
+where inbox.expires_at <= $4`
+
+const deleteExpiredQuery = `delete from inbox
+where ctid in (select ctid from inbox where expires_at <= $1 limit $2)`
+
+// PostgresStore keeps records in the inbox table:
+//
+//	create table inbox
+//	(
+//	    event_id       uuid      not null,
+//	    consumer_group text      not null,
+//	    expires_at     timestamp not null,
+//	    primary key (event_id, consumer_group)
+//	);
+//
+// A record of a concurrent handler locks its key, so a duplicate waits until the first
+// handler commits or rolls back instead of being handled in parallel.
+type PostgresStore struct {
+	db  *sql.DB
+	ttl time.Duration
+	now func() time.Time
+}
+
+func NewPostgresStore(db *sql.DB, ttl time.Duration) *PostgresStore {
+	return &PostgresStore{
+		db:  db,
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+func (s *PostgresStore) Record(ctx context.Context, tx *sql.Tx, key Key) (bool, error) {
+	now := s.now().UTC()
+	res, err := tx.ExecContext(ctx, recordQuery, key.EventID, key.ConsumerGroup, now.Add(s.ttl), now)
+	if err != nil {
+		return false, fmt.Errorf("failed to record event: %w", err)
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to record event: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (s *PostgresStore) DeleteExpired(ctx context.Context, limit int) (int64, error) {
+	res, err := s.db.ExecContext(ctx, deleteExpiredQuery, s.now().UTC(), limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired records: %w", err)
+	}
+	return res.RowsAffected()
+}
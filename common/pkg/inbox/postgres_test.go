@@ -0,0 +1,202 @@
+package inbox
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+// inboxTable is a database/sql connector keeping the inbox table in memory. It runs
+// only the queries of PostgresStore and follows their Postgres semantics.
+type inboxTable struct {
+	mu      sync.Mutex
+	records map[Key]time.Time
+}
+
+func (t *inboxTable) Connect(context.Context) (driver.Conn, error) {
+	return &inboxConn{table: t}, nil
+}
+
+func (t *inboxTable) Driver() driver.Driver {
+	return t
+}
+
+func (t *inboxTable) Open(string) (driver.Conn, error) {
+	return &inboxConn{table: t}, nil
+}
+
+type inboxConn struct {
+	table *inboxTable
+}
+
+func (c *inboxConn) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepared statements are not supported")
+}
+
+func (c *inboxConn) Close() error {
+	return nil
+}
+
+func (c *inboxConn) Begin() (driver.Tx, error) {
+	return inboxTx{}, nil
+}
+
+func (c *inboxConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	t := c.table
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch query {
+	case recordQuery:
+		eventID, err := uuid.Parse(args[0].Value.(string))
+		if err != nil {
+			return nil, err
+		}
+		key := Key{EventID: eventID, ConsumerGroup: args[1].Value.(string)}
+		expiresAt, now := args[2].Value.(time.Time), args[3].Value.(time.Time)
+
+		// on conflict ... do update ... where inbox.expires_at <= $4
+		if current, ok := t.records[key]; ok && current.After(now) {
+			return driver.RowsAffected(0), nil
+		}
+		t.records[key] = expiresAt
+		return driver.RowsAffected(1), nil
+	case deleteExpiredQuery:
+		now, limit := args[0].Value.(time.Time), args[1].Value.(int64)
+
+		var deleted int64
+		for key, expiresAt := range t.records {
+			if deleted == limit {
+				break
+			}
+			if !expiresAt.After(now) {
+				delete(t.records, key)
+				deleted++
+			}
+		}
+		return driver.RowsAffected(deleted), nil
+	}
+	return nil, fmt.Errorf("unexpected query: %s", query)
+}
+
+type inboxTx struct{}
+
+func (inboxTx) Commit() error {
+	return nil
+}
+
+func (inboxTx) Rollback() error {
+	return nil
+}
+
+// testStore returns a store on an in-memory inbox table with a clock moved by the
+// returned function.
+func testStore(t *testing.T, ttl time.Duration) (*PostgresStore, *inboxTable, func(time.Duration)) {
+	table := &inboxTable{records: map[Key]time.Time{}}
+	db := sql.OpenDB(table)
+	t.Cleanup(func() { _ = db.Close() })
+
+	store := NewPostgresStore(db, ttl)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	return store, table, func(d time.Duration) { now = now.Add(d) }
+}
+
+// record records the key in its own transaction.
+func record(t *testing.T, store *PostgresStore, key Key) bool {
+	tx, err := store.db.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+	recorded, err := store.Record(context.Background(), tx, key)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	return recorded
+}
+
+func TestPostgresStore_Record(t *testing.T) {
+	ttl := time.Hour
+	key := Key{EventID: uuid.New(), ConsumerGroup: "notifications"}
+
+	tests := []struct {
+		name string
+		// wait is the time between the first and the second record.
+		wait       time.Duration
+		second     Key
+		wantSecond bool
+	}{
+		{
+			name:       "duplicate_within_ttl",
+			wait:       ttl - time.Second,
+			second:     key,
+			wantSecond: false,
+		},
+		{
+			name:       "recorded_again_after_expiry",
+			wait:       ttl,
+			second:     key,
+			wantSecond: true,
+		},
+		{
+			name:       "other_consumer_group",
+			second:     Key{EventID: key.EventID, ConsumerGroup: "webhooks"},
+			wantSecond: true,
+		},
+		{
+			name:       "other_event",
+			second:     Key{EventID: uuid.New(), ConsumerGroup: key.ConsumerGroup},
+			wantSecond: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, table, advance := testStore(t, ttl)
+
+			require.True(t, record(t, store, key))
+			firstExpiresAt := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+			assert.Equal(t, firstExpiresAt, table.records[key])
+
+			advance(tt.wait)
+			assert.Equal(t, tt.wantSecond, record(t, store, tt.second))
+			if tt.wantSecond {
+				assert.Equal(t, store.now().Add(ttl), table.records[tt.second])
+			} else {
+				// A duplicate doesn't extend the record.
+				assert.Equal(t, firstExpiresAt, table.records[tt.second])
+			}
+		})
+	}
+}
+
+func TestPostgresStore_DeleteExpired(t *testing.T) {
+	store, table, advance := testStore(t, time.Hour)
+
+	for range 3 {
+		require.True(t, record(t, store, Key{EventID: uuid.New(), ConsumerGroup: "notifications"}))
+	}
+	advance(30 * time.Minute)
+	fresh := Key{EventID: uuid.New(), ConsumerGroup: "notifications"}
+	require.True(t, record(t, store, fresh))
+
+	deleted, err := store.DeleteExpired(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+
+	advance(30 * time.Minute)
+	deleted, err = store.DeleteExpired(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	deleted, err = store.DeleteExpired(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	assert.Len(t, table.records, 1)
+	assert.Contains(t, table.records, fresh)
+}
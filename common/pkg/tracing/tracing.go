@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"github.com/google/uuid"
+	"go-invoice-service/common/pkg/logging"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Keys of the trace context in Kafka headers and gRPC metadata.
+const (
+	HeaderTraceParent   = "traceparent"
+	HeaderTraceState    = "tracestate"
+	HeaderCorrelationID = "correlation-id"
+)
+
+var propagator = propagation.TraceContext{}
+
+type correlationIDKey struct{}
+
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	ctx = logging.WithContextFields(ctx, zap.String("correlation-id", id))
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// Ensure starts a new trace and correlation ID unless ctx already carries them.
+func Ensure(ctx context.Context) context.Context {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, newSpanContext())
+	}
+	if CorrelationID(ctx) == "" {
+		ctx = WithCorrelationID(ctx, uuid.NewString())
+	}
+	return ctx
+}
+
+// Inject writes the W3C trace context and correlation ID of ctx to carrier.
+func Inject(ctx context.Context, carrier map[string]string) {
+	propagator.Inject(ctx, propagation.MapCarrier(carrier))
+	if id := CorrelationID(ctx); id != "" {
+		carrier[HeaderCorrelationID] = id
+	}
+}
+
+// Extract returns ctx carrying the trace context and correlation ID written by Inject.
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	ctx = propagator.Extract(ctx, propagation.MapCarrier(carrier))
+	if id := carrier[HeaderCorrelationID]; id != "" {
+		ctx = WithCorrelationID(ctx, id)
+	}
+	return ctx
+}
+
+func newSpanContext() trace.SpanContext {
+	var traceID trace.TraceID
+	var spanID trace.SpanID
+	_, _ = rand.Read(traceID[:])
+	_, _ = rand.Read(spanID[:])
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+		Remote:  true,
+	})
+}
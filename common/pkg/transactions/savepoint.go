@@ -0,0 +1,28 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Savepoint runs f inside a savepoint of tx. If f fails, only its changes are rolled back
+// and the surrounding transaction stays usable.
+func Savepoint(ctx context.Context, tx *sql.Tx, name string, f func() error) error {
+	if _, err := tx.ExecContext(ctx, "savepoint "+name); err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	if err := f(); err != nil {
+		if _, rollbackErr := tx.ExecContext(ctx, "rollback to savepoint "+name); rollbackErr != nil {
+			return fmt.Errorf("failed to rollback to savepoint: %w", rollbackErr)
+		}
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "release savepoint "+name); err != nil {
+		return fmt.Errorf("failed to release savepoint: %w", err)
+	}
+
+	return nil
+}
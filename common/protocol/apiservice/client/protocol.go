@@ -1,6 +1,7 @@
 package client
 
 import (
+	"encoding/json"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"time"
@@ -45,3 +46,302 @@ type GetInvoiceResponse struct {
 	Invoice Invoice       `json:"invoice"`
 	Status  InvoiceStatus `json:"status"`
 }
+
+type Party struct {
+	Name        string `json:"name"`
+	VATID       string `json:"vat_id,omitempty"`
+	Street      string `json:"street"`
+	City        string `json:"city"`
+	PostalCode  string `json:"postal_code"`
+	CountryCode string `json:"country_code"`
+}
+
+type TaxCategory struct {
+	Code            string          `json:"code"`
+	Percent         decimal.Decimal `json:"percent"`
+	ExemptionReason string          `json:"exemption_reason,omitempty"`
+}
+
+type ExportUBLRequest struct {
+	ID       uuid.UUID   `json:"id"`
+	Supplier Party       `json:"supplier"`
+	Customer Party       `json:"customer"`
+	Tax      TaxCategory `json:"tax"`
+}
+
+type ImportJobStatus string
+
+const (
+	ImportStatusRunning   ImportJobStatus = "Running"
+	ImportStatusCompleted ImportJobStatus = "Completed"
+	ImportStatusFailed    ImportJobStatus = "Failed"
+)
+
+type ImportJob struct {
+	ID            uuid.UUID       `json:"id"`
+	Format        string          `json:"format"`
+	Status        ImportJobStatus `json:"status"`
+	ProcessedRows int64           `json:"processed_rows"`
+	SucceededRows int64           `json:"succeeded_rows"`
+	FailedRows    int64           `json:"failed_rows"`
+	Error         string          `json:"error,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+type ImportRowResult struct {
+	Row       int64      `json:"row"`
+	InvoiceID *uuid.UUID `json:"invoice_id,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+type GetImportJobRequest struct {
+	ID         uuid.UUID `json:"id"`
+	OnlyFailed bool      `json:"only_failed,omitempty"`
+	AfterRow   int64     `json:"after_row,omitempty"`
+	Limit      int32     `json:"limit,omitempty"`
+}
+
+type GetImportJobResponse struct {
+	Job     ImportJob         `json:"job"`
+	Results []ImportRowResult `json:"results"`
+}
+
+type ResumeImportJobRequest struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type ExportInvoicesRequest struct {
+	Format     string        `json:"format"`
+	Dataset    string        `json:"dataset,omitempty"`
+	From       *time.Time    `json:"from,omitempty"`
+	To         *time.Time    `json:"to,omitempty"`
+	Status     InvoiceStatus `json:"status,omitempty"`
+	CustomerID *uuid.UUID    `json:"customer_id,omitempty"`
+}
+
+type ExportedInvoice struct {
+	Invoice
+	Status InvoiceStatus `json:"status"`
+}
+
+type ExportedItem struct {
+	InvoiceID        uuid.UUID       `json:"invoice_id"`
+	CustomerID       uuid.UUID       `json:"customer_id"`
+	Currency         string          `json:"currency"`
+	Status           InvoiceStatus   `json:"status"`
+	InvoiceCreatedAt time.Time       `json:"invoice_created_at"`
+	Line             int32           `json:"line"`
+	Description      string          `json:"description"`
+	Quantity         int32           `json:"quantity"`
+	UnitPrice        decimal.Decimal `json:"unit_price"`
+	Total            decimal.Decimal `json:"total"`
+}
+
+type AgingReportRequest struct {
+	AsOf     string `json:"as_of,omitempty"`
+	TimeZone string `json:"time_zone,omitempty"`
+}
+
+type AgingBucket struct {
+	Currency string          `json:"currency"`
+	Bucket   string          `json:"bucket"`
+	Invoices int64           `json:"invoices"`
+	Amount   decimal.Decimal `json:"amount"`
+}
+
+type AgingReportResponse struct {
+	AsOf     string        `json:"as_of"`
+	TimeZone string        `json:"time_zone"`
+	Buckets  []AgingBucket `json:"buckets"`
+}
+
+type RevenueReportRequest struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	TimeZone   string `json:"time_zone,omitempty"`
+	ByCustomer bool   `json:"by_customer,omitempty"`
+}
+
+type RevenueRow struct {
+	Month      string          `json:"month"`
+	Currency   string          `json:"currency"`
+	CustomerID *uuid.UUID      `json:"customer_id,omitempty"`
+	Invoices   int64           `json:"invoices"`
+	Amount     decimal.Decimal `json:"amount"`
+}
+
+type RevenueReportResponse struct {
+	TimeZone string       `json:"time_zone"`
+	Rows     []RevenueRow `json:"rows"`
+}
+
+type DSOReportRequest struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	TimeZone string `json:"time_zone,omitempty"`
+}
+
+type DSORow struct {
+	Currency    string           `json:"currency"`
+	Receivables decimal.Decimal  `json:"receivables"`
+	Sales       decimal.Decimal  `json:"sales"`
+	Days        int32            `json:"days"`
+	DSO         *decimal.Decimal `json:"dso"`
+}
+
+type DSOReportResponse struct {
+	TimeZone string   `json:"time_zone"`
+	Rows     []DSORow `json:"rows"`
+}
+
+type InvoiceEventType string
+
+const (
+	InvoiceEventCreated       InvoiceEventType = "Created"
+	InvoiceEventStatusChanged InvoiceEventType = "StatusChanged"
+)
+
+type InvoiceEvent struct {
+	Cursor     string           `json:"cursor"`
+	Type       InvoiceEventType `json:"type"`
+	InvoiceID  uuid.UUID        `json:"invoice_id"`
+	Status     InvoiceStatus    `json:"status"`
+	OccurredAt time.Time        `json:"occurred_at"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookStatusPending   WebhookDeliveryStatus = "Pending"
+	WebhookStatusSucceeded WebhookDeliveryStatus = "Succeeded"
+	WebhookStatusDead      WebhookDeliveryStatus = "Dead"
+)
+
+type WebhookEndpoint struct {
+	ID         uuid.UUID `json:"id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type CreateWebhookEndpointRequest struct {
+	ID         uuid.UUID `json:"id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"event_types"`
+}
+
+type ListWebhookEndpointsResponse struct {
+	Endpoints []WebhookEndpoint `json:"endpoints"`
+}
+
+type DeleteWebhookEndpointRequest struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type WebhookDelivery struct {
+	ID            int64                 `json:"id"`
+	EndpointID    uuid.UUID             `json:"endpoint_id"`
+	EventType     string                `json:"event_type"`
+	Payload       json.RawMessage       `json:"payload"`
+	Status        WebhookDeliveryStatus `json:"status"`
+	Attempts      int32                 `json:"attempts"`
+	NextAttemptAt time.Time             `json:"next_attempt_at"`
+	LastError     string                `json:"last_error,omitempty"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
+
+type WebhookAttempt struct {
+	Attempt     int32     `json:"attempt"`
+	StatusCode  int32     `json:"status_code,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	Response    string    `json:"response,omitempty"`
+	DurationMS  int64     `json:"duration_ms"`
+	AttemptedAt time.Time `json:"attempted_at"`
+}
+
+type ListWebhookDeliveriesRequest struct {
+	EndpointID *uuid.UUID            `json:"endpoint_id,omitempty"`
+	Status     WebhookDeliveryStatus `json:"status,omitempty"`
+	BeforeID   int64                 `json:"before_id,omitempty"`
+	Limit      int32                 `json:"limit,omitempty"`
+}
+
+type ListWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+type GetWebhookDeliveryRequest struct {
+	ID int64 `json:"id"`
+}
+
+type GetWebhookDeliveryResponse struct {
+	Delivery WebhookDelivery  `json:"delivery"`
+	Attempts []WebhookAttempt `json:"attempts"`
+}
+
+type RedeliverWebhookRequest struct {
+	ID int64 `json:"id"`
+}
+
+type OutboxMessageStatus string
+
+const (
+	OutboxStatusPending   OutboxMessageStatus = "Pending"
+	OutboxStatusParked    OutboxMessageStatus = "Parked"
+	OutboxStatusPublished OutboxMessageStatus = "Published"
+)
+
+type OutboxMessage struct {
+	ID            int64               `json:"id"`
+	Topic         string              `json:"topic"`
+	Key           string              `json:"key,omitempty"`
+	Headers       map[string]string   `json:"headers,omitempty"`
+	Payload       []byte              `json:"payload"`
+	Data          json.RawMessage     `json:"data"`
+	AggregateID   *uuid.UUID          `json:"aggregate_id,omitempty"`
+	Sequence      int64               `json:"sequence,omitempty"`
+	Status        OutboxMessageStatus `json:"status"`
+	Attempts      int32               `json:"attempts"`
+	LastError     string              `json:"last_error,omitempty"`
+	FirstFailedAt *time.Time          `json:"first_failed_at,omitempty"`
+	NextSendAt    time.Time           `json:"next_send_at"`
+	CreatedAt     time.Time           `json:"created_at"`
+	PublishedAt   *time.Time          `json:"published_at,omitempty"`
+}
+
+type ListParkedOutboxMessagesRequest struct {
+	BeforeID int64 `json:"before_id,omitempty"`
+	Limit    int32 `json:"limit,omitempty"`
+}
+
+type ListParkedOutboxMessagesResponse struct {
+	Messages []OutboxMessage `json:"messages"`
+}
+
+// ListPublishedOutboxMessagesRequest lists the messages published for an invoice,
+// when the storage service keeps published messages.
+type ListPublishedOutboxMessagesRequest struct {
+	InvoiceID uuid.UUID `json:"invoice_id"`
+	BeforeID  int64     `json:"before_id,omitempty"`
+	Limit     int32     `json:"limit,omitempty"`
+}
+
+type ListPublishedOutboxMessagesResponse struct {
+	Messages []OutboxMessage `json:"messages"`
+}
+
+type GetOutboxMessageRequest struct {
+	ID int64 `json:"id"`
+}
+
+type RequeueOutboxMessageRequest struct {
+	ID int64 `json:"id"`
+}
+
+type PurgeOutboxMessageRequest struct {
+	ID int64 `json:"id"`
+}
@@ -0,0 +1,91 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var ErrIncompatibleSchema = errors.New("incompatible schema")
+
+// CheckCompatibility returns the breaking changes of next compared to prev, so
+// that consumers built with either schema can read messages written with the other.
+// Adding fields is allowed. Removing a field is allowed only when its number is
+// reserved, while changing the type or cardinality of a field is not.
+func CheckCompatibility(prev, next protoreflect.MessageDescriptor) error {
+	if prev.FullName() != next.FullName() {
+		return fmt.Errorf("%w: message %s replaced with %s", ErrIncompatibleSchema, prev.FullName(), next.FullName())
+	}
+
+	var errs []error
+	checkMessageCompatibility(prev, next, map[protoreflect.FullName]bool{}, &errs)
+	return errors.Join(errs...)
+}
+
+func checkMessageCompatibility(
+	prev, next protoreflect.MessageDescriptor,
+	checked map[protoreflect.FullName]bool,
+	errs *[]error,
+) {
+	if checked[prev.FullName()] {
+		return
+	}
+	checked[prev.FullName()] = true
+
+	prevFields := prev.Fields()
+	nextFields := next.Fields()
+	for i := range prevFields.Len() {
+		prevField := prevFields.Get(i)
+		nextField := nextFields.ByNumber(prevField.Number())
+
+		if nextField == nil {
+			if !next.ReservedRanges().Has(prevField.Number()) {
+				*errs = append(*errs, fmt.Errorf(
+					"%w: field %s (%d) removed without reserving its number",
+					ErrIncompatibleSchema, prevField.FullName(), prevField.Number(),
+				))
+			}
+			continue
+		}
+
+		if err := checkFieldCompatibility(prevField, nextField); err != nil {
+			*errs = append(*errs, err)
+			continue
+		}
+
+		if prevField.Message() != nil {
+			checkMessageCompatibility(prevField.Message(), nextField.Message(), checked, errs)
+		}
+	}
+}
+
+func checkFieldCompatibility(prev, next protoreflect.FieldDescriptor) error {
+	if prev.Cardinality() != next.Cardinality() || prev.IsMap() != next.IsMap() {
+		return fmt.Errorf(
+			"%w: cardinality of field %s (%d) changed",
+			ErrIncompatibleSchema, prev.FullName(), prev.Number(),
+		)
+	}
+
+	if prev.Kind() != next.Kind() {
+		return fmt.Errorf(
+			"%w: type of field %s (%d) changed from %s to %s",
+			ErrIncompatibleSchema, prev.FullName(), prev.Number(), prev.Kind(), next.Kind(),
+		)
+	}
+
+	switch {
+	case prev.Message() != nil && prev.Message().FullName() != next.Message().FullName():
+		return fmt.Errorf(
+			"%w: type of field %s (%d) changed from %s to %s",
+			ErrIncompatibleSchema, prev.FullName(), prev.Number(), prev.Message().FullName(), next.Message().FullName(),
+		)
+	case prev.Enum() != nil && prev.Enum().FullName() != next.Enum().FullName():
+		return fmt.Errorf(
+			"%w: type of field %s (%d) changed from %s to %s",
+			ErrIncompatibleSchema, prev.FullName(), prev.Number(), prev.Enum().FullName(), next.Enum().FullName(),
+		)
+	}
+
+	return nil
+}
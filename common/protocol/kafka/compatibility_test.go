@@ -0,0 +1,199 @@
+package kafka
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"testing"
+)
+
+// testSchema describes test.Event and the test.Money message it refers to, together
+// with the test.Amount message and test.Status and test.Kind enums used as
+// replacement types.
+type testSchema struct {
+	event    []*descriptorpb.FieldDescriptorProto
+	reserved []int32
+	money    []*descriptorpb.FieldDescriptorProto
+}
+
+func (s testSchema) descriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+
+	event := &descriptorpb.DescriptorProto{Name: proto.String("Event"), Field: s.event}
+	for _, number := range s.reserved {
+		event.ReservedRange = append(event.ReservedRange, &descriptorpb.DescriptorProto_ReservedRange{
+			Start: proto.Int32(number),
+			End:   proto.Int32(number + 1),
+		})
+	}
+	money := s.money
+	if money == nil {
+		money = []*descriptorpb.FieldDescriptorProto{
+			field("units", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+			field("currency", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+		}
+	}
+
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test/event.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			event,
+			{Name: proto.String("Money"), Field: money},
+			{Name: proto.String("Amount"), Field: []*descriptorpb.FieldDescriptorProto{
+				field("value", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+			}},
+		},
+		EnumType: []*descriptorpb.EnumDescriptorProto{
+			testEnum("Status", "STATUS_UNSPECIFIED"),
+			testEnum("Kind", "KIND_UNSPECIFIED"),
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	return file.Messages().ByName("Event")
+}
+
+func testEnum(name, zero string) *descriptorpb.EnumDescriptorProto {
+	return &descriptorpb.EnumDescriptorProto{
+		Name:  proto.String(name),
+		Value: []*descriptorpb.EnumValueDescriptorProto{{Name: proto.String(zero), Number: proto.Int32(0)}},
+	}
+}
+
+func field(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     typ.Enum(),
+	}
+}
+
+func repeated(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return f
+}
+
+func message(name string, number int32, typeName string) *descriptorpb.FieldDescriptorProto {
+	f := field(name, number, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)
+	f.TypeName = proto.String(".test." + typeName)
+	return f
+}
+
+func enum(name string, number int32, typeName string) *descriptorpb.FieldDescriptorProto {
+	f := field(name, number, descriptorpb.FieldDescriptorProto_TYPE_ENUM)
+	f.TypeName = proto.String(".test." + typeName)
+	return f
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	id := func() *descriptorpb.FieldDescriptorProto {
+		return field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	}
+	prev := testSchema{event: []*descriptorpb.FieldDescriptorProto{
+		id(),
+		field("count", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+		repeated(field("tags", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING)),
+		message("total", 4, "Money"),
+		enum("status", 5, "Status"),
+	}}
+
+	tests := []struct {
+		name    string
+		next    testSchema
+		wantErr string
+	}{
+		{
+			name: "unchanged",
+			next: prev,
+		},
+		{
+			name: "field_added",
+			next: testSchema{event: append(prev.event[:5:5], field("note", 6, descriptorpb.FieldDescriptorProto_TYPE_STRING))},
+		},
+		{
+			name: "field_renamed",
+			next: testSchema{event: []*descriptorpb.FieldDescriptorProto{
+				field("invoice_id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING), prev.event[1], prev.event[2], prev.event[3], prev.event[4],
+			}},
+		},
+		{
+			name: "field_removed_with_reserved_number",
+			next: testSchema{event: []*descriptorpb.FieldDescriptorProto{id(), prev.event[2], prev.event[3], prev.event[4]}, reserved: []int32{2}},
+		},
+		{
+			name:    "field_removed_without_reserved_number",
+			next:    testSchema{event: []*descriptorpb.FieldDescriptorProto{id(), prev.event[2], prev.event[3], prev.event[4]}},
+			wantErr: "field test.Event.count (2) removed without reserving its number",
+		},
+		{
+			name: "kind_changed",
+			next: testSchema{event: []*descriptorpb.FieldDescriptorProto{
+				id(), field("count", 2, descriptorpb.FieldDescriptorProto_TYPE_INT64), prev.event[2], prev.event[3], prev.event[4],
+			}},
+			wantErr: "type of field test.Event.count (2) changed from int32 to int64",
+		},
+		{
+			name: "singular_made_repeated",
+			next: testSchema{event: []*descriptorpb.FieldDescriptorProto{
+				id(), repeated(field("count", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32)), prev.event[2], prev.event[3], prev.event[4],
+			}},
+			wantErr: "cardinality of field test.Event.count (2) changed",
+		},
+		{
+			name: "repeated_made_singular",
+			next: testSchema{event: []*descriptorpb.FieldDescriptorProto{
+				id(), prev.event[1], field("tags", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING), prev.event[3], prev.event[4],
+			}},
+			wantErr: "cardinality of field test.Event.tags (3) changed",
+		},
+		{
+			name: "message_type_changed",
+			next: testSchema{event: []*descriptorpb.FieldDescriptorProto{
+				id(), prev.event[1], prev.event[2], message("total", 4, "Amount"), prev.event[4],
+			}},
+			wantErr: "type of field test.Event.total (4) changed from test.Money to test.Amount",
+		},
+		{
+			name: "enum_type_changed",
+			next: testSchema{event: []*descriptorpb.FieldDescriptorProto{
+				id(), prev.event[1], prev.event[2], prev.event[3], enum("status", 5, "Kind"),
+			}},
+			wantErr: "type of field test.Event.status (5) changed from test.Status to test.Kind",
+		},
+		{
+			name: "nested_field_removed",
+			next: testSchema{event: prev.event, money: []*descriptorpb.FieldDescriptorProto{
+				field("units", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+			}},
+			wantErr: "field test.Money.currency (2) removed without reserving its number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckCompatibility(prev.descriptor(t), tt.next.descriptor(t))
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrIncompatibleSchema)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCheckCompatibility_MessageReplaced(t *testing.T) {
+	prev := testSchema{}.descriptor(t)
+	next := prev.ParentFile().Messages().ByName("Amount")
+
+	err := CheckCompatibility(prev, next)
+	require.ErrorIs(t, err, ErrIncompatibleSchema)
+	assert.Contains(t, err.Error(), "message test.Event replaced with test.Amount")
+}
@@ -0,0 +1,11 @@
+package kafka
+
+// Headers attached to messages moved to a dead-letter topic.
+const (
+	DeadLetterHeaderSourceTopic     = "dlq-source-topic"
+	DeadLetterHeaderSourcePartition = "dlq-source-partition"
+	DeadLetterHeaderSourceOffset    = "dlq-source-offset"
+	DeadLetterHeaderError           = "dlq-error"
+	DeadLetterHeaderAttempts        = "dlq-attempts"
+	DeadLetterHeaderFailedAt        = "dlq-failed-at"
+)
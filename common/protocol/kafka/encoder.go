@@ -0,0 +1,93 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"sync"
+)
+
+type Format string
+
+const (
+	FormatJSON     Format = "json"
+	FormatProtobuf Format = "protobuf"
+)
+
+var ErrUnknownFormat = errors.New("unknown payload format")
+
+func ParseFormat(s string) (Format, error) {
+	switch format := Format(s); format {
+	case FormatJSON, FormatProtobuf:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w: '%s'", ErrUnknownFormat, s)
+	}
+}
+
+// Encoder writes event envelopes as JSON or as protobuf in the wire format.
+// Protobuf schemas are registered on first use and their IDs cached.
+type Encoder struct {
+	format    Format
+	registry  SchemaRegistry
+	mu        sync.Mutex
+	schemaIDs map[Topic]int32
+}
+
+func NewJSONEncoder() *Encoder {
+	return &Encoder{
+		format: FormatJSON,
+	}
+}
+
+func NewProtobufEncoder(registry SchemaRegistry) *Encoder {
+	return &Encoder{
+		format:    FormatProtobuf,
+		registry:  registry,
+		schemaIDs: make(map[Topic]int32),
+	}
+}
+
+// Encode returns the payload and headers of the envelope.
+func Encode[T Event](ctx context.Context, encoder *Encoder, envelope Envelope[T]) ([]byte, map[string]string, error) {
+	headers := envelope.Headers()
+
+	if encoder.format != FormatProtobuf {
+		payload, err := json.Marshal(envelope)
+		if err != nil {
+			return nil, nil, fmt.Errorf("marshalling %s event failed: %w", envelope.Type, err)
+		}
+		return payload, headers, nil
+	}
+
+	msg := envelope.protoMessage()
+	schemaID, err := encoder.schemaID(ctx, envelope.Type, msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshalling %s event failed: %w", envelope.Type, err)
+	}
+
+	headers[HeaderContentType] = ContentTypeProtobuf
+	return EncodeWireFormat(schemaID, messageIndexes(msg.ProtoReflect().Descriptor()), data), headers, nil
+}
+
+func (e *Encoder) schemaID(ctx context.Context, topic Topic, msg proto.Message) (int32, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if id, ok := e.schemaIDs[topic]; ok {
+		return id, nil
+	}
+
+	id, err := e.registry.Register(ctx, Subject(topic), msg.ProtoReflect().Descriptor())
+	if err != nil {
+		return 0, fmt.Errorf("registering %s schema failed: %w", topic, err)
+	}
+	e.schemaIDs[topic] = id
+	return id, nil
+}
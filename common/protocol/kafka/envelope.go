@@ -0,0 +1,169 @@
+package kafka
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go-invoice-service/common/protocol/proto/events"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Headers set on every event, next to the trace context.
+const (
+	HeaderEventID       = "event-id"
+	HeaderEventType     = "event-type"
+	HeaderSchemaVersion = "schema-version"
+	HeaderContentType   = "content-type"
+)
+
+// Headers set on events replayed from the outbox. A replayed event keeps the event
+// ID of the original, so consumers that handled the original skip it.
+const (
+	HeaderReplay   = "replay"
+	HeaderReplayOf = "replay-of"
+)
+
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+var (
+	ErrUnexpectedEventType      = errors.New("unexpected event type")
+	ErrUnsupportedSchemaVersion = errors.New("unsupported event schema version")
+)
+
+// Event is a payload of a topic. SchemaVersion is increased on breaking changes.
+// ToProto and FromProto convert the event to and from its message in proto/events.
+type Event interface {
+	EventType() Topic
+	SchemaVersion() int
+	ToProto(metadata *events.EventMetadata) proto.Message
+	FromProto(msg proto.Message) (Event, error)
+}
+
+type Envelope[T Event] struct {
+	EventID       uuid.UUID `json:"event_id"`
+	Type          Topic     `json:"type"`
+	SchemaVersion int       `json:"schema_version"`
+	OccurredAt    time.Time `json:"occurred_at"`
+	Tenant        string    `json:"tenant,omitempty"`
+	CorrelationID string    `json:"correlation_id,omitempty"`
+	Data          T         `json:"data"`
+}
+
+func NewEnvelope[T Event](data T, occurredAt time.Time, correlationID string) Envelope[T] {
+	return Envelope[T]{
+		EventID:       uuid.New(),
+		Type:          data.EventType(),
+		SchemaVersion: data.SchemaVersion(),
+		OccurredAt:    occurredAt,
+		CorrelationID: correlationID,
+		Data:          data,
+	}
+}
+
+func (e Envelope[T]) Headers() map[string]string {
+	return map[string]string{
+		HeaderEventID:       e.EventID.String(),
+		HeaderEventType:     string(e.Type),
+		HeaderSchemaVersion: strconv.Itoa(e.SchemaVersion),
+		HeaderContentType:   ContentTypeJSON,
+	}
+}
+
+func (e Envelope[T]) protoMessage() proto.Message {
+	return e.Data.ToProto(&events.EventMetadata{
+		EventId:       proto.String(e.EventID.String()),
+		Type:          proto.String(string(e.Type)),
+		SchemaVersion: proto.Int32(int32(e.SchemaVersion)),
+		OccurredAt:    timestamppb.New(e.OccurredAt),
+		Tenant:        proto.String(e.Tenant),
+		CorrelationId: proto.String(e.CorrelationID),
+	})
+}
+
+// DecodeEnvelope decodes an event of type T written either as JSON or as
+// protobuf in the wire format. Bare JSON payloads written before envelopes
+// were introduced are decoded as Data of schema version 0.
+func DecodeEnvelope[T Event](payload []byte) (Envelope[T], error) {
+	if IsWireFormat(payload) {
+		return decodeProtoEnvelope[T](payload)
+	}
+
+	var probe struct {
+		Type *Topic `json:"type"`
+	}
+	if err := json.Unmarshal(payload, &probe); err != nil {
+		return Envelope[T]{}, fmt.Errorf("failed to decode event: %w", err)
+	}
+
+	var res Envelope[T]
+	if probe.Type == nil {
+		if err := json.Unmarshal(payload, &res.Data); err != nil {
+			return Envelope[T]{}, fmt.Errorf("failed to decode event data: %w", err)
+		}
+		res.Type = res.Data.EventType()
+		return res, nil
+	}
+
+	if err := json.Unmarshal(payload, &res); err != nil {
+		return Envelope[T]{}, fmt.Errorf("failed to decode event: %w", err)
+	}
+	if res.Type != res.Data.EventType() {
+		return Envelope[T]{}, fmt.Errorf("%w: %s", ErrUnexpectedEventType, res.Type)
+	}
+	if res.SchemaVersion > res.Data.SchemaVersion() {
+		return Envelope[T]{}, fmt.Errorf("%w: %d", ErrUnsupportedSchemaVersion, res.SchemaVersion)
+	}
+	return res, nil
+}
+
+func decodeProtoEnvelope[T Event](payload []byte) (Envelope[T], error) {
+	_, indexes, data, err := DecodeWireFormat(payload)
+	if err != nil {
+		return Envelope[T]{}, fmt.Errorf("failed to decode event: %w", err)
+	}
+
+	var zero T
+	msg := zero.ToProto(nil).ProtoReflect().New().Interface()
+	if !slices.Equal(indexes, messageIndexes(msg.ProtoReflect().Descriptor())) {
+		return Envelope[T]{}, fmt.Errorf("%w: message indexes %v", ErrUnexpectedEventType, indexes)
+	}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return Envelope[T]{}, fmt.Errorf("failed to decode event: %w", err)
+	}
+
+	event, err := zero.FromProto(msg)
+	if err != nil {
+		return Envelope[T]{}, fmt.Errorf("failed to decode event data: %w", err)
+	}
+	metadata := msg.(interface{ GetMetadata() *events.EventMetadata }).GetMetadata()
+	if Topic(metadata.GetType()) != zero.EventType() {
+		return Envelope[T]{}, fmt.Errorf("%w: %s", ErrUnexpectedEventType, metadata.GetType())
+	}
+
+	res := Envelope[T]{
+		Type:          zero.EventType(),
+		SchemaVersion: int(metadata.GetSchemaVersion()),
+		OccurredAt:    metadata.GetOccurredAt().AsTime(),
+		Tenant:        metadata.GetTenant(),
+		CorrelationID: metadata.GetCorrelationId(),
+		Data:          event.(T),
+	}
+	if metadata.GetEventId() != "" {
+		res.EventID, err = uuid.Parse(metadata.GetEventId())
+		if err != nil {
+			return Envelope[T]{}, fmt.Errorf("failed to decode event id: %w", err)
+		}
+	}
+	if res.SchemaVersion > zero.SchemaVersion() {
+		return Envelope[T]{}, fmt.Errorf("%w: %d", ErrUnsupportedSchemaVersion, res.SchemaVersion)
+	}
+	return res, nil
+}
@@ -0,0 +1,219 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileSchemaRegistry keeps schemas in a single JSON file. It is meant for tests
+// and local development, and is safe for use by one process only.
+type FileSchemaRegistry struct {
+	path string
+	mu   sync.Mutex
+}
+
+type fileSchema struct {
+	ID      int32           `json:"id"`
+	Subject string          `json:"subject"`
+	Version int             `json:"version"`
+	Message string          `json:"message"`
+	Files   json.RawMessage `json:"files"`
+}
+
+func NewFileSchemaRegistry(path string) *FileSchemaRegistry {
+	return &FileSchemaRegistry{
+		path: path,
+	}
+}
+
+func (r *FileSchemaRegistry) Register(
+	_ context.Context,
+	subject string,
+	schema protoreflect.MessageDescriptor,
+) (int32, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	schemas, err := r.load()
+	if err != nil {
+		return 0, err
+	}
+
+	files := fileDescriptorSet(schema)
+	version := 1
+	id := int32(1)
+	for _, s := range schemas {
+		id = max(id, s.ID+1)
+	}
+
+	if latest, ok := latestFileSchema(schemas, subject); ok {
+		latestFiles, err := latest.fileDescriptorSet()
+		if err != nil {
+			return 0, err
+		}
+		if latest.Message == string(schema.FullName()) && proto.Equal(latestFiles, files) {
+			return latest.ID, nil
+		}
+
+		latestSchema, err := latest.schema()
+		if err != nil {
+			return 0, err
+		}
+		if err := CheckCompatibility(latestSchema.Descriptor, schema); err != nil {
+			return 0, fmt.Errorf("subject %s: %w", subject, err)
+		}
+		version = latest.Version + 1
+	}
+
+	filesJSON, err := protojson.Marshal(files)
+	if err != nil {
+		return 0, fmt.Errorf("marshalling schema of subject %s failed: %w", subject, err)
+	}
+	schemas = append(schemas, fileSchema{
+		ID:      id,
+		Subject: subject,
+		Version: version,
+		Message: string(schema.FullName()),
+		Files:   filesJSON,
+	})
+
+	if err := r.save(schemas); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *FileSchemaRegistry) Latest(_ context.Context, subject string) (Schema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	schemas, err := r.load()
+	if err != nil {
+		return Schema{}, err
+	}
+
+	latest, ok := latestFileSchema(schemas, subject)
+	if !ok {
+		return Schema{}, fmt.Errorf("%w: subject %s", ErrSchemaNotFound, subject)
+	}
+	return latest.schema()
+}
+
+func (r *FileSchemaRegistry) SchemaByID(_ context.Context, id int32) (Schema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	schemas, err := r.load()
+	if err != nil {
+		return Schema{}, err
+	}
+
+	for _, s := range schemas {
+		if s.ID == id {
+			return s.schema()
+		}
+	}
+	return Schema{}, fmt.Errorf("%w: id %d", ErrSchemaNotFound, id)
+}
+
+func (r *FileSchemaRegistry) load() ([]fileSchema, error) {
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading schema registry file failed: %w", err)
+	}
+
+	var res []fileSchema
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("decoding schema registry file failed: %w", err)
+	}
+	return res, nil
+}
+
+// save replaces the registry file at once, so a reader never sees it half written.
+func (r *FileSchemaRegistry) save(schemas []fileSchema) error {
+	data, err := json.MarshalIndent(schemas, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding schema registry file failed: %w", err)
+	}
+
+	dir := filepath.Dir(r.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating schema registry directory failed: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(r.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating schema registry file failed: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing schema registry file failed: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing schema registry file failed: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), r.path); err != nil {
+		return fmt.Errorf("replacing schema registry file failed: %w", err)
+	}
+	return nil
+}
+
+func latestFileSchema(schemas []fileSchema, subject string) (fileSchema, bool) {
+	var res fileSchema
+	found := false
+	for _, s := range schemas {
+		if s.Subject == subject && (!found || s.Version > res.Version) {
+			res = s
+			found = true
+		}
+	}
+	return res, found
+}
+
+func (s fileSchema) fileDescriptorSet() (*descriptorpb.FileDescriptorSet, error) {
+	res := &descriptorpb.FileDescriptorSet{}
+	if err := protojson.Unmarshal(s.Files, res); err != nil {
+		return nil, fmt.Errorf("decoding schema %d failed: %w", s.ID, err)
+	}
+	return res, nil
+}
+
+func (s fileSchema) schema() (Schema, error) {
+	set, err := s.fileDescriptorSet()
+	if err != nil {
+		return Schema{}, err
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return Schema{}, fmt.Errorf("building schema %d failed: %w", s.ID, err)
+	}
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(s.Message))
+	if err != nil {
+		return Schema{}, fmt.Errorf("schema %d: %w", s.ID, err)
+	}
+	message, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return Schema{}, fmt.Errorf("schema %d: %s is not a message", s.ID, s.Message)
+	}
+
+	return Schema{
+		ID:         s.ID,
+		Subject:    s.Subject,
+		Version:    s.Version,
+		Descriptor: message,
+	}, nil
+}
@@ -0,0 +1,66 @@
+package kafka
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileSchemaRegistry(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "schemas", "registry.json")
+	registry := NewFileSchemaRegistry(path)
+
+	v1 := testSchema{event: []*descriptorpb.FieldDescriptorProto{
+		field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+		field("count", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+	}}
+	v2 := testSchema{event: append(v1.event[:2:2], field("note", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING))}
+	incompatible := testSchema{event: v1.event[:1]}
+
+	_, err := registry.Latest(ctx, "invoice-value")
+	require.ErrorIs(t, err, ErrSchemaNotFound)
+
+	id, err := registry.Register(ctx, "invoice-value", v1.descriptor(t))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), id)
+
+	// An unchanged schema keeps its ID.
+	id, err = registry.Register(ctx, "invoice-value", v1.descriptor(t))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), id)
+
+	id, err = registry.Register(ctx, "invoice-value", v2.descriptor(t))
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), id)
+
+	_, err = registry.Register(ctx, "invoice-value", incompatible.descriptor(t))
+	require.ErrorIs(t, err, ErrIncompatibleSchema)
+
+	// Subjects are versioned separately, IDs are unique across them.
+	id, err = registry.Register(ctx, "rejected-value", incompatible.descriptor(t))
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), id)
+
+	// The schemas are read back from the file.
+	registry = NewFileSchemaRegistry(path)
+
+	latest, err := registry.Latest(ctx, "invoice-value")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), latest.ID)
+	assert.Equal(t, 2, latest.Version)
+	assert.Equal(t, "invoice-value", latest.Subject)
+	assert.Equal(t, "test.Event", string(latest.Descriptor.FullName()))
+	assert.Equal(t, 3, latest.Descriptor.Fields().Len())
+
+	first, err := registry.SchemaByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.Version)
+	assert.Equal(t, 2, first.Descriptor.Fields().Len())
+
+	_, err = registry.SchemaByID(ctx, 4)
+	require.ErrorIs(t, err, ErrSchemaNotFound)
+}
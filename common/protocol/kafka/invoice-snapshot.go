@@ -0,0 +1,113 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go-invoice-service/common/protocol/proto/events"
+	"go-invoice-service/common/protocol/proto/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+// EventDetail sets how much of the invoice an event of a topic carries.
+type EventDetail string
+
+const (
+	// EventDetailID events carry the invoice ID only.
+	EventDetailID EventDetail = "id"
+	// EventDetailSnapshot events also carry the invoice as it was when the event occurred.
+	EventDetailSnapshot EventDetail = "snapshot"
+)
+
+var ErrUnknownEventDetail = errors.New("unknown event detail")
+
+func ParseEventDetail(s string) (EventDetail, error) {
+	switch detail := EventDetail(s); detail {
+	case EventDetailID, EventDetailSnapshot:
+		return detail, nil
+	default:
+		return "", fmt.Errorf("%w: '%s'", ErrUnknownEventDetail, s)
+	}
+}
+
+type InvoiceSnapshot struct {
+	CustomerID uuid.UUID             `json:"customer_id"`
+	Amount     int64                 `json:"amount"`
+	Currency   string                `json:"currency"`
+	DueDate    time.Time             `json:"due_date"`
+	Status     string                `json:"status"`
+	Items      []InvoiceSnapshotItem `json:"items"`
+	Notes      string                `json:"notes,omitempty"`
+	CreatedAt  time.Time             `json:"created_at"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+}
+
+type InvoiceSnapshotItem struct {
+	Description string `json:"description"`
+	Quantity    int32  `json:"quantity"`
+	UnitPrice   int64  `json:"unit_price"`
+	Total       int64  `json:"total"`
+}
+
+func (s *InvoiceSnapshot) toProto() *events.InvoiceSnapshot {
+	if s == nil {
+		return nil
+	}
+
+	items := make([]*types.Item, len(s.Items))
+	for i, item := range s.Items {
+		items[i] = &types.Item{
+			Description: proto.String(item.Description),
+			Quantity:    proto.Int32(item.Quantity),
+			UnitPrice:   proto.Int64(item.UnitPrice),
+			Total:       proto.Int64(item.Total),
+		}
+	}
+
+	return &events.InvoiceSnapshot{
+		CustomerId: &types.UUID{Value: s.CustomerID[:]},
+		Amount:     proto.Int64(s.Amount),
+		Currency:   proto.String(s.Currency),
+		DueDate:    timestamppb.New(s.DueDate),
+		Status:     types.InvoiceStatus(types.InvoiceStatus_value[s.Status]).Enum(),
+		Items:      items,
+		Notes:      proto.String(s.Notes),
+		CreatedAt:  timestamppb.New(s.CreatedAt),
+		UpdatedAt:  timestamppb.New(s.UpdatedAt),
+	}
+}
+
+func invoiceSnapshotFromProto(s *events.InvoiceSnapshot) (*InvoiceSnapshot, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	customerID, err := uuid.FromBytes(s.GetCustomerId().GetValue())
+	if err != nil {
+		return nil, fmt.Errorf("invalid customer id: %w", err)
+	}
+
+	items := make([]InvoiceSnapshotItem, len(s.GetItems()))
+	for i, item := range s.GetItems() {
+		items[i] = InvoiceSnapshotItem{
+			Description: item.GetDescription(),
+			Quantity:    item.GetQuantity(),
+			UnitPrice:   item.GetUnitPrice(),
+			Total:       item.GetTotal(),
+		}
+	}
+
+	return &InvoiceSnapshot{
+		CustomerID: customerID,
+		Amount:     s.GetAmount(),
+		Currency:   s.GetCurrency(),
+		DueDate:    s.GetDueDate().AsTime(),
+		Status:     s.GetStatus().String(),
+		Items:      items,
+		Notes:      s.GetNotes(),
+		CreatedAt:  s.GetCreatedAt().AsTime(),
+		UpdatedAt:  s.GetUpdatedAt().AsTime(),
+	}, nil
+}
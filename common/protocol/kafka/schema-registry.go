@@ -0,0 +1,72 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var ErrSchemaNotFound = errors.New("schema not found")
+
+// SchemaRegistry stores versions of event schemas under subjects, like the
+// Confluent schema registry does.
+type SchemaRegistry interface {
+	// Register returns the ID of the schema. A schema that differs from the latest
+	// version of the subject is registered as a new version if it is compatible.
+	Register(ctx context.Context, subject string, schema protoreflect.MessageDescriptor) (int32, error)
+	Latest(ctx context.Context, subject string) (Schema, error)
+	SchemaByID(ctx context.Context, id int32) (Schema, error)
+}
+
+type Schema struct {
+	ID         int32
+	Subject    string
+	Version    int
+	Descriptor protoreflect.MessageDescriptor
+}
+
+// Subject returns the subject of the values of the topic.
+func Subject(topic Topic) string {
+	return string(topic) + "-value"
+}
+
+// fileDescriptorSet returns the file of the message together with all of its
+// dependencies, dependencies first.
+func fileDescriptorSet(message protoreflect.MessageDescriptor) *descriptorpb.FileDescriptorSet {
+	res := &descriptorpb.FileDescriptorSet{}
+	added := map[string]bool{}
+
+	var add func(file protoreflect.FileDescriptor)
+	add = func(file protoreflect.FileDescriptor) {
+		if added[file.Path()] {
+			return
+		}
+		added[file.Path()] = true
+
+		imports := file.Imports()
+		for i := range imports.Len() {
+			add(imports.Get(i).FileDescriptor)
+		}
+		res.File = append(res.File, protodesc.ToFileDescriptorProto(file))
+	}
+	add(message.ParentFile())
+
+	return res
+}
+
+// messageIndexes returns the path of the message within its file, as written
+// in the wire format.
+func messageIndexes(message protoreflect.MessageDescriptor) []int {
+	var res []int
+	var desc protoreflect.Descriptor = message
+	for {
+		if _, ok := desc.(protoreflect.MessageDescriptor); !ok {
+			break
+		}
+		res = append([]int{desc.Index()}, res...)
+		desc = desc.Parent()
+	}
+	return res
+}
@@ -1,6 +1,12 @@
 package kafka
 
-import "github.com/google/uuid"
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"go-invoice-service/common/protocol/proto/events"
+	"go-invoice-service/common/protocol/proto/types"
+	"google.golang.org/protobuf/proto"
+)
 
 type Topic string
 
@@ -8,18 +14,113 @@ const (
 	TopicNewInvoice      Topic = "new_invoice"
 	TopicInvoiceApproved Topic = "invoice_approved"
 	TopicInvoiceRejected Topic = "invoice_rejected"
+	TopicNewInvoiceDLQ   Topic = "new_invoice.dlq"
 )
 
 type NewInvoice struct {
 	ID uuid.UUID `json:"id"`
+	// Invoice is set when the topic is configured to carry invoice snapshots.
+	Invoice *InvoiceSnapshot `json:"invoice,omitempty"`
+}
+
+func (NewInvoice) EventType() Topic   { return TopicNewInvoice }
+func (NewInvoice) SchemaVersion() int { return 1 }
+
+func (e NewInvoice) ToProto(metadata *events.EventMetadata) proto.Message {
+	return &events.NewInvoice{
+		Metadata: metadata,
+		Id:       &types.UUID{Value: e.ID[:]},
+		Invoice:  e.Invoice.toProto(),
+	}
+}
+
+func (NewInvoice) FromProto(msg proto.Message) (Event, error) {
+	m, ok := msg.(*events.NewInvoice)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnexpectedEventType, msg)
+	}
+	id, err := uuid.FromBytes(m.GetId().GetValue())
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice id: %w", err)
+	}
+	invoice, err := invoiceSnapshotFromProto(m.GetInvoice())
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice snapshot: %w", err)
+	}
+	return NewInvoice{ID: id, Invoice: invoice}, nil
 }
 
 type ApprovedInvoice struct {
 	ID uuid.UUID `json:"id"`
+	// Invoice is set when the topic is configured to carry invoice snapshots.
+	Invoice *InvoiceSnapshot `json:"invoice,omitempty"`
+}
+
+func (ApprovedInvoice) EventType() Topic   { return TopicInvoiceApproved }
+func (ApprovedInvoice) SchemaVersion() int { return 1 }
+
+func (e ApprovedInvoice) ToProto(metadata *events.EventMetadata) proto.Message {
+	return &events.InvoiceApproved{
+		Metadata: metadata,
+		Id:       &types.UUID{Value: e.ID[:]},
+		Invoice:  e.Invoice.toProto(),
+	}
+}
+
+func (ApprovedInvoice) FromProto(msg proto.Message) (Event, error) {
+	m, ok := msg.(*events.InvoiceApproved)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnexpectedEventType, msg)
+	}
+	id, err := uuid.FromBytes(m.GetId().GetValue())
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice id: %w", err)
+	}
+	invoice, err := invoiceSnapshotFromProto(m.GetInvoice())
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice snapshot: %w", err)
+	}
+	return ApprovedInvoice{ID: id, Invoice: invoice}, nil
 }
 
 type RejectedInvoice struct {
 	ID uuid.UUID `json:"id"`
+	// Invoice is set when the topic is configured to carry invoice snapshots.
+	Invoice *InvoiceSnapshot `json:"invoice,omitempty"`
+}
+
+func (RejectedInvoice) EventType() Topic   { return TopicInvoiceRejected }
+func (RejectedInvoice) SchemaVersion() int { return 1 }
+
+func (e RejectedInvoice) ToProto(metadata *events.EventMetadata) proto.Message {
+	return &events.InvoiceRejected{
+		Metadata: metadata,
+		Id:       &types.UUID{Value: e.ID[:]},
+		Invoice:  e.Invoice.toProto(),
+	}
+}
+
+func (RejectedInvoice) FromProto(msg proto.Message) (Event, error) {
+	m, ok := msg.(*events.InvoiceRejected)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnexpectedEventType, msg)
+	}
+	id, err := uuid.FromBytes(m.GetId().GetValue())
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice id: %w", err)
+	}
+	invoice, err := invoiceSnapshotFromProto(m.GetInvoice())
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice snapshot: %w", err)
+	}
+	return RejectedInvoice{ID: id, Invoice: invoice}, nil
+}
+
+// Events holds a value of every event, to look up their schemas.
+var Events = []Event{
+	NewInvoice{},
+	ApprovedInvoice{},
+	RejectedInvoice{},
 }
 
 type TopicSettings struct {
@@ -45,3 +146,11 @@ var Topics = []TopicSettings{
 		ReplicationFactor: 3,
 	},
 }
+
+var DeadLetterTopics = []TopicSettings{
+	{
+		Topic:             TopicNewInvoiceDLQ,
+		PartitionsCount:   1,
+		ReplicationFactor: 3,
+	},
+}
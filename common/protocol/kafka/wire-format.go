@@ -0,0 +1,70 @@
+package kafka
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Confluent wire format: a zero magic byte, the big-endian schema ID and the
+// indexes of the message in its .proto file, followed by the protobuf payload.
+const (
+	wireFormatMagicByte  byte = 0
+	wireFormatHeaderSize      = 5
+)
+
+var ErrInvalidWireFormat = errors.New("invalid wire format")
+
+// IsWireFormat reports whether the payload starts with the wire format header.
+// JSON payloads never start with a zero byte.
+func IsWireFormat(payload []byte) bool {
+	return len(payload) >= wireFormatHeaderSize && payload[0] == wireFormatMagicByte
+}
+
+func EncodeWireFormat(schemaID int32, messageIndexes []int, payload []byte) []byte {
+	res := make([]byte, wireFormatHeaderSize, wireFormatHeaderSize+len(messageIndexes)+1+len(payload))
+	res[0] = wireFormatMagicByte
+	binary.BigEndian.PutUint32(res[1:], uint32(schemaID))
+
+	// The first message of a file, the most common case, is written as a single zero.
+	if len(messageIndexes) == 1 && messageIndexes[0] == 0 {
+		res = binary.AppendVarint(res, 0)
+	} else {
+		res = binary.AppendVarint(res, int64(len(messageIndexes)))
+		for _, index := range messageIndexes {
+			res = binary.AppendVarint(res, int64(index))
+		}
+	}
+
+	return append(res, payload...)
+}
+
+func DecodeWireFormat(data []byte) (schemaID int32, messageIndexes []int, payload []byte, err error) {
+	if !IsWireFormat(data) {
+		return 0, nil, nil, fmt.Errorf("%w: missing header", ErrInvalidWireFormat)
+	}
+	schemaID = int32(binary.BigEndian.Uint32(data[1:wireFormatHeaderSize]))
+	rest := data[wireFormatHeaderSize:]
+
+	count, n := binary.Varint(rest)
+	if n <= 0 || count < 0 || count > int64(len(rest)) {
+		return 0, nil, nil, fmt.Errorf("%w: invalid message indexes", ErrInvalidWireFormat)
+	}
+	rest = rest[n:]
+
+	if count == 0 {
+		return schemaID, []int{0}, rest, nil
+	}
+
+	messageIndexes = make([]int, count)
+	for i := range messageIndexes {
+		index, n := binary.Varint(rest)
+		if n <= 0 || index < 0 {
+			return 0, nil, nil, fmt.Errorf("%w: invalid message indexes", ErrInvalidWireFormat)
+		}
+		messageIndexes[i] = int(index)
+		rest = rest[n:]
+	}
+
+	return schemaID, messageIndexes, rest, nil
+}
@@ -0,0 +1,87 @@
+package kafka
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestWireFormat_RoundTrip(t *testing.T) {
+	payload := []byte{0x0a, 0x03, 'a', 'b', 'c'}
+
+	tests := []struct {
+		name           string
+		schemaID       int32
+		messageIndexes []int
+		wantHeader     []byte
+	}{
+		{
+			// The first message of a file is written as a single zero.
+			name:           "first_message",
+			schemaID:       42,
+			messageIndexes: []int{0},
+			wantHeader:     []byte{0, 0, 0, 0, 42, 0},
+		},
+		{
+			name:           "second_message",
+			schemaID:       42,
+			messageIndexes: []int{1},
+			wantHeader:     []byte{0, 0, 0, 0, 42, 2, 2},
+		},
+		{
+			name:           "nested_message",
+			schemaID:       0x01020304,
+			messageIndexes: []int{2, 0},
+			wantHeader:     []byte{0, 1, 2, 3, 4, 4, 4, 0},
+		},
+		{
+			name:           "large_index",
+			schemaID:       7,
+			messageIndexes: []int{100},
+			wantHeader:     []byte{0, 0, 0, 0, 7, 2, 0xc8, 0x01},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := EncodeWireFormat(tt.schemaID, tt.messageIndexes, payload)
+			assert.Equal(t, append(tt.wantHeader, payload...), data)
+			assert.True(t, IsWireFormat(data))
+
+			schemaID, messageIndexes, decoded, err := DecodeWireFormat(data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.schemaID, schemaID)
+			assert.Equal(t, tt.messageIndexes, messageIndexes)
+			assert.Equal(t, payload, decoded)
+		})
+	}
+}
+
+func TestDecodeWireFormat_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "json", data: []byte(`{"id":"1"}`)},
+		{name: "short", data: []byte{0, 0, 0, 1}},
+		{name: "no_message_indexes", data: []byte{0, 0, 0, 0, 1}},
+		{name: "negative_count", data: []byte{0, 0, 0, 0, 1, 1}},
+		{name: "count_past_end", data: []byte{0, 0, 0, 0, 1, 8, 2}},
+		{name: "missing_index", data: []byte{0, 0, 0, 0, 1, 4, 2}},
+		{name: "negative_index", data: []byte{0, 0, 0, 0, 1, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, err := DecodeWireFormat(tt.data)
+			require.ErrorIs(t, err, ErrInvalidWireFormat)
+		})
+	}
+}
+
+func TestMessageIndexes(t *testing.T) {
+	file := testSchema{}.descriptor(t).ParentFile()
+
+	assert.Equal(t, []int{0}, messageIndexes(file.Messages().ByName("Event")))
+	assert.Equal(t, []int{2}, messageIndexes(file.Messages().ByName("Amount")))
+}
@@ -0,0 +1,280 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: apiservice/export.proto
+
+package apiservice
+
+import (
+	types "go-invoice-service/common/protocol/proto/types"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExportInvoicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to" json:"to,omitempty"`
+	Status        *types.InvoiceStatus   `protobuf:"varint,3,opt,name=status,enum=protocol.types.InvoiceStatus" json:"status,omitempty"`
+	CustomerId    *types.UUID            `protobuf:"bytes,4,opt,name=customerId" json:"customerId,omitempty"`
+	BatchSize     *int32                 `protobuf:"varint,5,opt,name=batchSize" json:"batchSize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportInvoicesRequest) Reset() {
+	*x = ExportInvoicesRequest{}
+	mi := &file_apiservice_export_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportInvoicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportInvoicesRequest) ProtoMessage() {}
+
+func (x *ExportInvoicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_export_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportInvoicesRequest.ProtoReflect.Descriptor instead.
+func (*ExportInvoicesRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_export_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExportInvoicesRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ExportInvoicesRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ExportInvoicesRequest) GetStatus() types.InvoiceStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return types.InvoiceStatus(0)
+}
+
+func (x *ExportInvoicesRequest) GetCustomerId() *types.UUID {
+	if x != nil {
+		return x.CustomerId
+	}
+	return nil
+}
+
+func (x *ExportInvoicesRequest) GetBatchSize() int32 {
+	if x != nil && x.BatchSize != nil {
+		return *x.BatchSize
+	}
+	return 0
+}
+
+type ExportedInvoice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invoice       *types.Invoice         `protobuf:"bytes,1,opt,name=invoice" json:"invoice,omitempty"`
+	Status        *types.InvoiceStatus   `protobuf:"varint,2,opt,name=status,enum=protocol.types.InvoiceStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportedInvoice) Reset() {
+	*x = ExportedInvoice{}
+	mi := &file_apiservice_export_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportedInvoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedInvoice) ProtoMessage() {}
+
+func (x *ExportedInvoice) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_export_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedInvoice.ProtoReflect.Descriptor instead.
+func (*ExportedInvoice) Descriptor() ([]byte, []int) {
+	return file_apiservice_export_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExportedInvoice) GetInvoice() *types.Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+func (x *ExportedInvoice) GetStatus() types.InvoiceStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return types.InvoiceStatus(0)
+}
+
+type ExportInvoicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invoices      []*ExportedInvoice     `protobuf:"bytes,1,rep,name=invoices" json:"invoices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportInvoicesResponse) Reset() {
+	*x = ExportInvoicesResponse{}
+	mi := &file_apiservice_export_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportInvoicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportInvoicesResponse) ProtoMessage() {}
+
+func (x *ExportInvoicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_export_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportInvoicesResponse.ProtoReflect.Descriptor instead.
+func (*ExportInvoicesResponse) Descriptor() ([]byte, []int) {
+	return file_apiservice_export_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExportInvoicesResponse) GetInvoices() []*ExportedInvoice {
+	if x != nil {
+		return x.Invoices
+	}
+	return nil
+}
+
+var File_apiservice_export_proto protoreflect.FileDescriptor
+
+const file_apiservice_export_proto_rawDesc = "" +
+	"\n" +
+	"\x17apiservice/export.proto\x12\x1cprotocol.api_service.storage\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13types/invoice.proto\x1a\x10types/uuid.proto\"\xfe\x01\n" +
+	"\x15ExportInvoicesRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x125\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1d.protocol.types.InvoiceStatusR\x06status\x124\n" +
+	"\n" +
+	"customerId\x18\x04 \x01(\v2\x14.protocol.types.UUIDR\n" +
+	"customerId\x12\x1c\n" +
+	"\tbatchSize\x18\x05 \x01(\x05R\tbatchSize\"{\n" +
+	"\x0fExportedInvoice\x121\n" +
+	"\ainvoice\x18\x01 \x01(\v2\x17.protocol.types.InvoiceR\ainvoice\x125\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1d.protocol.types.InvoiceStatusR\x06status\"c\n" +
+	"\x16ExportInvoicesResponse\x12I\n" +
+	"\binvoices\x18\x01 \x03(\v2-.protocol.api_service.storage.ExportedInvoiceR\binvoices2\x86\x01\n" +
+	"\rInvoiceExport\x12u\n" +
+	"\x06Export\x123.protocol.api_service.storage.ExportInvoicesRequest\x1a4.protocol.api_service.storage.ExportInvoicesResponse0\x01B5Z3go-invoice-service/common/protocol/proto/apiserviceb\beditionsp\xe8\a"
+
+var (
+	file_apiservice_export_proto_rawDescOnce sync.Once
+	file_apiservice_export_proto_rawDescData []byte
+)
+
+func file_apiservice_export_proto_rawDescGZIP() []byte {
+	file_apiservice_export_proto_rawDescOnce.Do(func() {
+		file_apiservice_export_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiservice_export_proto_rawDesc), len(file_apiservice_export_proto_rawDesc)))
+	})
+	return file_apiservice_export_proto_rawDescData
+}
+
+var file_apiservice_export_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_apiservice_export_proto_goTypes = []any{
+	(*ExportInvoicesRequest)(nil),  // 0: protocol.api_service.storage.ExportInvoicesRequest
+	(*ExportedInvoice)(nil),        // 1: protocol.api_service.storage.ExportedInvoice
+	(*ExportInvoicesResponse)(nil), // 2: protocol.api_service.storage.ExportInvoicesResponse
+	(*timestamppb.Timestamp)(nil),  // 3: google.protobuf.Timestamp
+	(types.InvoiceStatus)(0),       // 4: protocol.types.InvoiceStatus
+	(*types.UUID)(nil),             // 5: protocol.types.UUID
+	(*types.Invoice)(nil),          // 6: protocol.types.Invoice
+}
+var file_apiservice_export_proto_depIdxs = []int32{
+	3, // 0: protocol.api_service.storage.ExportInvoicesRequest.from:type_name -> google.protobuf.Timestamp
+	3, // 1: protocol.api_service.storage.ExportInvoicesRequest.to:type_name -> google.protobuf.Timestamp
+	4, // 2: protocol.api_service.storage.ExportInvoicesRequest.status:type_name -> protocol.types.InvoiceStatus
+	5, // 3: protocol.api_service.storage.ExportInvoicesRequest.customerId:type_name -> protocol.types.UUID
+	6, // 4: protocol.api_service.storage.ExportedInvoice.invoice:type_name -> protocol.types.Invoice
+	4, // 5: protocol.api_service.storage.ExportedInvoice.status:type_name -> protocol.types.InvoiceStatus
+	1, // 6: protocol.api_service.storage.ExportInvoicesResponse.invoices:type_name -> protocol.api_service.storage.ExportedInvoice
+	0, // 7: protocol.api_service.storage.InvoiceExport.Export:input_type -> protocol.api_service.storage.ExportInvoicesRequest
+	2, // 8: protocol.api_service.storage.InvoiceExport.Export:output_type -> protocol.api_service.storage.ExportInvoicesResponse
+	8, // [8:9] is the sub-list for method output_type
+	7, // [7:8] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_apiservice_export_proto_init() }
+func file_apiservice_export_proto_init() {
+	if File_apiservice_export_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiservice_export_proto_rawDesc), len(file_apiservice_export_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_apiservice_export_proto_goTypes,
+		DependencyIndexes: file_apiservice_export_proto_depIdxs,
+		MessageInfos:      file_apiservice_export_proto_msgTypes,
+	}.Build()
+	File_apiservice_export_proto = out.File
+	file_apiservice_export_proto_goTypes = nil
+	file_apiservice_export_proto_depIdxs = nil
+}
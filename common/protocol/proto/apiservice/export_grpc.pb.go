@@ -0,0 +1,124 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: apiservice/export.proto
+
+package apiservice
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	InvoiceExport_Export_FullMethodName = "/protocol.api_service.storage.InvoiceExport/Export"
+)
+
+// InvoiceExportClient is the client API for InvoiceExport service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type InvoiceExportClient interface {
+	Export(ctx context.Context, in *ExportInvoicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportInvoicesResponse], error)
+}
+
+type invoiceExportClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInvoiceExportClient(cc grpc.ClientConnInterface) InvoiceExportClient {
+	return &invoiceExportClient{cc}
+}
+
+func (c *invoiceExportClient) Export(ctx context.Context, in *ExportInvoicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportInvoicesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &InvoiceExport_ServiceDesc.Streams[0], InvoiceExport_Export_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportInvoicesRequest, ExportInvoicesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InvoiceExport_ExportClient = grpc.ServerStreamingClient[ExportInvoicesResponse]
+
+// InvoiceExportServer is the server API for InvoiceExport service.
+// All implementations must embed UnimplementedInvoiceExportServer
+// for forward compatibility.
+type InvoiceExportServer interface {
+	Export(*ExportInvoicesRequest, grpc.ServerStreamingServer[ExportInvoicesResponse]) error
+	mustEmbedUnimplementedInvoiceExportServer()
+}
+
+// UnimplementedInvoiceExportServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedInvoiceExportServer struct{}
+
+func (UnimplementedInvoiceExportServer) Export(*ExportInvoicesRequest, grpc.ServerStreamingServer[ExportInvoicesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (UnimplementedInvoiceExportServer) mustEmbedUnimplementedInvoiceExportServer() {}
+func (UnimplementedInvoiceExportServer) testEmbeddedByValue()                       {}
+
+// UnsafeInvoiceExportServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InvoiceExportServer will
+// result in compilation errors.
+type UnsafeInvoiceExportServer interface {
+	mustEmbedUnimplementedInvoiceExportServer()
+}
+
+func RegisterInvoiceExportServer(s grpc.ServiceRegistrar, srv InvoiceExportServer) {
+	// If the following call pancis, it indicates UnimplementedInvoiceExportServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&InvoiceExport_ServiceDesc, srv)
+}
+
+func _InvoiceExport_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportInvoicesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InvoiceExportServer).Export(m, &grpc.GenericServerStream[ExportInvoicesRequest, ExportInvoicesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InvoiceExport_ExportServer = grpc.ServerStreamingServer[ExportInvoicesResponse]
+
+// InvoiceExport_ServiceDesc is the grpc.ServiceDesc for InvoiceExport service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InvoiceExport_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "protocol.api_service.storage.InvoiceExport",
+	HandlerType: (*InvoiceExportServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Export",
+			Handler:       _InvoiceExport_Export_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "apiservice/export.proto",
+}
@@ -0,0 +1,284 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: apiservice/feed.proto
+
+package apiservice
+
+import (
+	types "go-invoice-service/common/protocol/proto/types"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type InvoiceEventType int32
+
+const (
+	InvoiceEventType_Created       InvoiceEventType = 0
+	InvoiceEventType_StatusChanged InvoiceEventType = 1
+)
+
+// Enum value maps for InvoiceEventType.
+var (
+	InvoiceEventType_name = map[int32]string{
+		0: "Created",
+		1: "StatusChanged",
+	}
+	InvoiceEventType_value = map[string]int32{
+		"Created":       0,
+		"StatusChanged": 1,
+	}
+)
+
+func (x InvoiceEventType) Enum() *InvoiceEventType {
+	p := new(InvoiceEventType)
+	*p = x
+	return p
+}
+
+func (x InvoiceEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InvoiceEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_apiservice_feed_proto_enumTypes[0].Descriptor()
+}
+
+func (InvoiceEventType) Type() protoreflect.EnumType {
+	return &file_apiservice_feed_proto_enumTypes[0]
+}
+
+func (x InvoiceEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InvoiceEventType.Descriptor instead.
+func (InvoiceEventType) EnumDescriptor() ([]byte, []int) {
+	return file_apiservice_feed_proto_rawDescGZIP(), []int{0}
+}
+
+type InvoiceEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Opaque position of the event in the feed, pass it to WatchInvoices to resume after it.
+	Cursor        *string                `protobuf:"bytes,1,opt,name=cursor" json:"cursor,omitempty"`
+	Type          *InvoiceEventType      `protobuf:"varint,2,opt,name=type,enum=protocol.api_service.storage.InvoiceEventType" json:"type,omitempty"`
+	InvoiceId     *types.UUID            `protobuf:"bytes,3,opt,name=invoiceId" json:"invoiceId,omitempty"`
+	Status        *types.InvoiceStatus   `protobuf:"varint,4,opt,name=status,enum=protocol.types.InvoiceStatus" json:"status,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurredAt" json:"occurredAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvoiceEvent) Reset() {
+	*x = InvoiceEvent{}
+	mi := &file_apiservice_feed_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoiceEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceEvent) ProtoMessage() {}
+
+func (x *InvoiceEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_feed_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceEvent.ProtoReflect.Descriptor instead.
+func (*InvoiceEvent) Descriptor() ([]byte, []int) {
+	return file_apiservice_feed_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *InvoiceEvent) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *InvoiceEvent) GetType() InvoiceEventType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return InvoiceEventType_Created
+}
+
+func (x *InvoiceEvent) GetInvoiceId() *types.UUID {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return nil
+}
+
+func (x *InvoiceEvent) GetStatus() types.InvoiceStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return types.InvoiceStatus(0)
+}
+
+func (x *InvoiceEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type WatchInvoicesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Events after the cursor are sent. Without a cursor only new events are sent,
+	// unless fromStart is set.
+	Cursor        *string `protobuf:"bytes,1,opt,name=cursor" json:"cursor,omitempty"`
+	FromStart     *bool   `protobuf:"varint,2,opt,name=fromStart" json:"fromStart,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchInvoicesRequest) Reset() {
+	*x = WatchInvoicesRequest{}
+	mi := &file_apiservice_feed_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchInvoicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchInvoicesRequest) ProtoMessage() {}
+
+func (x *WatchInvoicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_feed_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchInvoicesRequest.ProtoReflect.Descriptor instead.
+func (*WatchInvoicesRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_feed_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WatchInvoicesRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *WatchInvoicesRequest) GetFromStart() bool {
+	if x != nil && x.FromStart != nil {
+		return *x.FromStart
+	}
+	return false
+}
+
+var File_apiservice_feed_proto protoreflect.FileDescriptor
+
+const file_apiservice_feed_proto_rawDesc = "" +
+	"\n" +
+	"\x15apiservice/feed.proto\x12\x1cprotocol.api_service.storage\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13types/invoice.proto\x1a\x10types/uuid.proto\"\x91\x02\n" +
+	"\fInvoiceEvent\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12B\n" +
+	"\x04type\x18\x02 \x01(\x0e2..protocol.api_service.storage.InvoiceEventTypeR\x04type\x122\n" +
+	"\tinvoiceId\x18\x03 \x01(\v2\x14.protocol.types.UUIDR\tinvoiceId\x125\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1d.protocol.types.InvoiceStatusR\x06status\x12:\n" +
+	"\n" +
+	"occurredAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"L\n" +
+	"\x14WatchInvoicesRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x1c\n" +
+	"\tfromStart\x18\x02 \x01(\bR\tfromStart*2\n" +
+	"\x10InvoiceEventType\x12\v\n" +
+	"\aCreated\x10\x00\x12\x11\n" +
+	"\rStatusChanged\x10\x012\x80\x01\n" +
+	"\vInvoiceFeed\x12q\n" +
+	"\rWatchInvoices\x122.protocol.api_service.storage.WatchInvoicesRequest\x1a*.protocol.api_service.storage.InvoiceEvent0\x01B5Z3go-invoice-service/common/protocol/proto/apiserviceb\beditionsp\xe8\a"
+
+var (
+	file_apiservice_feed_proto_rawDescOnce sync.Once
+	file_apiservice_feed_proto_rawDescData []byte
+)
+
+func file_apiservice_feed_proto_rawDescGZIP() []byte {
+	file_apiservice_feed_proto_rawDescOnce.Do(func() {
+		file_apiservice_feed_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiservice_feed_proto_rawDesc), len(file_apiservice_feed_proto_rawDesc)))
+	})
+	return file_apiservice_feed_proto_rawDescData
+}
+
+var file_apiservice_feed_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_apiservice_feed_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_apiservice_feed_proto_goTypes = []any{
+	(InvoiceEventType)(0),         // 0: protocol.api_service.storage.InvoiceEventType
+	(*InvoiceEvent)(nil),          // 1: protocol.api_service.storage.InvoiceEvent
+	(*WatchInvoicesRequest)(nil),  // 2: protocol.api_service.storage.WatchInvoicesRequest
+	(*types.UUID)(nil),            // 3: protocol.types.UUID
+	(types.InvoiceStatus)(0),      // 4: protocol.types.InvoiceStatus
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+}
+var file_apiservice_feed_proto_depIdxs = []int32{
+	0, // 0: protocol.api_service.storage.InvoiceEvent.type:type_name -> protocol.api_service.storage.InvoiceEventType
+	3, // 1: protocol.api_service.storage.InvoiceEvent.invoiceId:type_name -> protocol.types.UUID
+	4, // 2: protocol.api_service.storage.InvoiceEvent.status:type_name -> protocol.types.InvoiceStatus
+	5, // 3: protocol.api_service.storage.InvoiceEvent.occurredAt:type_name -> google.protobuf.Timestamp
+	2, // 4: protocol.api_service.storage.InvoiceFeed.WatchInvoices:input_type -> protocol.api_service.storage.WatchInvoicesRequest
+	1, // 5: protocol.api_service.storage.InvoiceFeed.WatchInvoices:output_type -> protocol.api_service.storage.InvoiceEvent
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_apiservice_feed_proto_init() }
+func file_apiservice_feed_proto_init() {
+	if File_apiservice_feed_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiservice_feed_proto_rawDesc), len(file_apiservice_feed_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_apiservice_feed_proto_goTypes,
+		DependencyIndexes: file_apiservice_feed_proto_depIdxs,
+		EnumInfos:         file_apiservice_feed_proto_enumTypes,
+		MessageInfos:      file_apiservice_feed_proto_msgTypes,
+	}.Build()
+	File_apiservice_feed_proto = out.File
+	file_apiservice_feed_proto_goTypes = nil
+	file_apiservice_feed_proto_depIdxs = nil
+}
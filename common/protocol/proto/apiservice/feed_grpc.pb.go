@@ -0,0 +1,124 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: apiservice/feed.proto
+
+package apiservice
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	InvoiceFeed_WatchInvoices_FullMethodName = "/protocol.api_service.storage.InvoiceFeed/WatchInvoices"
+)
+
+// InvoiceFeedClient is the client API for InvoiceFeed service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type InvoiceFeedClient interface {
+	WatchInvoices(ctx context.Context, in *WatchInvoicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[InvoiceEvent], error)
+}
+
+type invoiceFeedClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInvoiceFeedClient(cc grpc.ClientConnInterface) InvoiceFeedClient {
+	return &invoiceFeedClient{cc}
+}
+
+func (c *invoiceFeedClient) WatchInvoices(ctx context.Context, in *WatchInvoicesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[InvoiceEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &InvoiceFeed_ServiceDesc.Streams[0], InvoiceFeed_WatchInvoices_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchInvoicesRequest, InvoiceEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InvoiceFeed_WatchInvoicesClient = grpc.ServerStreamingClient[InvoiceEvent]
+
+// InvoiceFeedServer is the server API for InvoiceFeed service.
+// All implementations must embed UnimplementedInvoiceFeedServer
+// for forward compatibility.
+type InvoiceFeedServer interface {
+	WatchInvoices(*WatchInvoicesRequest, grpc.ServerStreamingServer[InvoiceEvent]) error
+	mustEmbedUnimplementedInvoiceFeedServer()
+}
+
+// UnimplementedInvoiceFeedServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedInvoiceFeedServer struct{}
+
+func (UnimplementedInvoiceFeedServer) WatchInvoices(*WatchInvoicesRequest, grpc.ServerStreamingServer[InvoiceEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchInvoices not implemented")
+}
+func (UnimplementedInvoiceFeedServer) mustEmbedUnimplementedInvoiceFeedServer() {}
+func (UnimplementedInvoiceFeedServer) testEmbeddedByValue()                     {}
+
+// UnsafeInvoiceFeedServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InvoiceFeedServer will
+// result in compilation errors.
+type UnsafeInvoiceFeedServer interface {
+	mustEmbedUnimplementedInvoiceFeedServer()
+}
+
+func RegisterInvoiceFeedServer(s grpc.ServiceRegistrar, srv InvoiceFeedServer) {
+	// If the following call pancis, it indicates UnimplementedInvoiceFeedServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&InvoiceFeed_ServiceDesc, srv)
+}
+
+func _InvoiceFeed_WatchInvoices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchInvoicesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InvoiceFeedServer).WatchInvoices(m, &grpc.GenericServerStream[WatchInvoicesRequest, InvoiceEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InvoiceFeed_WatchInvoicesServer = grpc.ServerStreamingServer[InvoiceEvent]
+
+// InvoiceFeed_ServiceDesc is the grpc.ServiceDesc for InvoiceFeed service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InvoiceFeed_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "protocol.api_service.storage.InvoiceFeed",
+	HandlerType: (*InvoiceFeedServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchInvoices",
+			Handler:       _InvoiceFeed_WatchInvoices_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "apiservice/feed.proto",
+}
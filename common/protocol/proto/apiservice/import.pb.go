@@ -0,0 +1,836 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: apiservice/import.proto
+
+package apiservice
+
+import (
+	types "go-invoice-service/common/protocol/proto/types"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ImportJobStatus int32
+
+const (
+	ImportJobStatus_Running   ImportJobStatus = 0
+	ImportJobStatus_Completed ImportJobStatus = 1
+	ImportJobStatus_Failed    ImportJobStatus = 2
+)
+
+// Enum value maps for ImportJobStatus.
+var (
+	ImportJobStatus_name = map[int32]string{
+		0: "Running",
+		1: "Completed",
+		2: "Failed",
+	}
+	ImportJobStatus_value = map[string]int32{
+		"Running":   0,
+		"Completed": 1,
+		"Failed":    2,
+	}
+)
+
+func (x ImportJobStatus) Enum() *ImportJobStatus {
+	p := new(ImportJobStatus)
+	*p = x
+	return p
+}
+
+func (x ImportJobStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportJobStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_apiservice_import_proto_enumTypes[0].Descriptor()
+}
+
+func (ImportJobStatus) Type() protoreflect.EnumType {
+	return &file_apiservice_import_proto_enumTypes[0]
+}
+
+func (x ImportJobStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportJobStatus.Descriptor instead.
+func (ImportJobStatus) EnumDescriptor() ([]byte, []int) {
+	return file_apiservice_import_proto_rawDescGZIP(), []int{0}
+}
+
+type ImportJob struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *types.UUID            `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Format        *string                `protobuf:"bytes,2,opt,name=format" json:"format,omitempty"`
+	Status        *ImportJobStatus       `protobuf:"varint,3,opt,name=status,enum=protocol.api_service.storage.ImportJobStatus" json:"status,omitempty"`
+	ProcessedRows *int64                 `protobuf:"varint,4,opt,name=processedRows" json:"processedRows,omitempty"`
+	SucceededRows *int64                 `protobuf:"varint,5,opt,name=succeededRows" json:"succeededRows,omitempty"`
+	FailedRows    *int64                 `protobuf:"varint,6,opt,name=failedRows" json:"failedRows,omitempty"`
+	Error         *string                `protobuf:"bytes,7,opt,name=error" json:"error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=createdAt" json:"createdAt,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updatedAt" json:"updatedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportJob) Reset() {
+	*x = ImportJob{}
+	mi := &file_apiservice_import_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportJob) ProtoMessage() {}
+
+func (x *ImportJob) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_import_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportJob.ProtoReflect.Descriptor instead.
+func (*ImportJob) Descriptor() ([]byte, []int) {
+	return file_apiservice_import_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ImportJob) GetId() *types.UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *ImportJob) GetFormat() string {
+	if x != nil && x.Format != nil {
+		return *x.Format
+	}
+	return ""
+}
+
+func (x *ImportJob) GetStatus() ImportJobStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ImportJobStatus_Running
+}
+
+func (x *ImportJob) GetProcessedRows() int64 {
+	if x != nil && x.ProcessedRows != nil {
+		return *x.ProcessedRows
+	}
+	return 0
+}
+
+func (x *ImportJob) GetSucceededRows() int64 {
+	if x != nil && x.SucceededRows != nil {
+		return *x.SucceededRows
+	}
+	return 0
+}
+
+func (x *ImportJob) GetFailedRows() int64 {
+	if x != nil && x.FailedRows != nil {
+		return *x.FailedRows
+	}
+	return 0
+}
+
+func (x *ImportJob) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *ImportJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ImportJob) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ImportRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        *int64                 `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
+	Invoice       *types.Invoice         `protobuf:"bytes,2,opt,name=invoice" json:"invoice,omitempty"`
+	Error         *string                `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRow) Reset() {
+	*x = ImportRow{}
+	mi := &file_apiservice_import_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRow) ProtoMessage() {}
+
+func (x *ImportRow) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_import_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRow.ProtoReflect.Descriptor instead.
+func (*ImportRow) Descriptor() ([]byte, []int) {
+	return file_apiservice_import_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ImportRow) GetNumber() int64 {
+	if x != nil && x.Number != nil {
+		return *x.Number
+	}
+	return 0
+}
+
+func (x *ImportRow) GetInvoice() *types.Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+func (x *ImportRow) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type ImportRowResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        *int64                 `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
+	InvoiceId     *types.UUID            `protobuf:"bytes,2,opt,name=invoiceId" json:"invoiceId,omitempty"`
+	Error         *string                `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowResult) Reset() {
+	*x = ImportRowResult{}
+	mi := &file_apiservice_import_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowResult) ProtoMessage() {}
+
+func (x *ImportRowResult) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_import_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowResult.ProtoReflect.Descriptor instead.
+func (*ImportRowResult) Descriptor() ([]byte, []int) {
+	return file_apiservice_import_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ImportRowResult) GetNumber() int64 {
+	if x != nil && x.Number != nil {
+		return *x.Number
+	}
+	return 0
+}
+
+func (x *ImportRowResult) GetInvoiceId() *types.UUID {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return nil
+}
+
+func (x *ImportRowResult) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type CreateImportJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *types.UUID            `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Format        *string                `protobuf:"bytes,2,opt,name=format" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateImportJobRequest) Reset() {
+	*x = CreateImportJobRequest{}
+	mi := &file_apiservice_import_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateImportJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateImportJobRequest) ProtoMessage() {}
+
+func (x *CreateImportJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_import_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateImportJobRequest.ProtoReflect.Descriptor instead.
+func (*CreateImportJobRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_import_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateImportJobRequest) GetId() *types.UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *CreateImportJobRequest) GetFormat() string {
+	if x != nil && x.Format != nil {
+		return *x.Format
+	}
+	return ""
+}
+
+type ImportBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         *types.UUID            `protobuf:"bytes,1,opt,name=jobId" json:"jobId,omitempty"`
+	Rows          []*ImportRow           `protobuf:"bytes,2,rep,name=rows" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportBatchRequest) Reset() {
+	*x = ImportBatchRequest{}
+	mi := &file_apiservice_import_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportBatchRequest) ProtoMessage() {}
+
+func (x *ImportBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_import_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportBatchRequest.ProtoReflect.Descriptor instead.
+func (*ImportBatchRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_import_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ImportBatchRequest) GetJobId() *types.UUID {
+	if x != nil {
+		return x.JobId
+	}
+	return nil
+}
+
+func (x *ImportBatchRequest) GetRows() []*ImportRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type FinishImportJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *types.UUID            `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Status        *ImportJobStatus       `protobuf:"varint,2,opt,name=status,enum=protocol.api_service.storage.ImportJobStatus" json:"status,omitempty"`
+	Error         *string                `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinishImportJobRequest) Reset() {
+	*x = FinishImportJobRequest{}
+	mi := &file_apiservice_import_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishImportJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishImportJobRequest) ProtoMessage() {}
+
+func (x *FinishImportJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_import_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishImportJobRequest.ProtoReflect.Descriptor instead.
+func (*FinishImportJobRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_import_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FinishImportJobRequest) GetId() *types.UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *FinishImportJobRequest) GetStatus() ImportJobStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ImportJobStatus_Running
+}
+
+func (x *FinishImportJobRequest) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type GetImportJobRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         *types.UUID            `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	OnlyFailed *bool                  `protobuf:"varint,2,opt,name=onlyFailed" json:"onlyFailed,omitempty"`
+	// Results of rows numbered after afterRow are returned, at most limit of them.
+	AfterRow *int64 `protobuf:"varint,3,opt,name=afterRow" json:"afterRow,omitempty"`
+	Limit    *int32 `protobuf:"varint,4,opt,name=limit" json:"limit,omitempty"`
+	// The job is returned without its results.
+	WithoutResults *bool `protobuf:"varint,5,opt,name=withoutResults" json:"withoutResults,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetImportJobRequest) Reset() {
+	*x = GetImportJobRequest{}
+	mi := &file_apiservice_import_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImportJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImportJobRequest) ProtoMessage() {}
+
+func (x *GetImportJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_import_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImportJobRequest.ProtoReflect.Descriptor instead.
+func (*GetImportJobRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_import_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetImportJobRequest) GetId() *types.UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *GetImportJobRequest) GetOnlyFailed() bool {
+	if x != nil && x.OnlyFailed != nil {
+		return *x.OnlyFailed
+	}
+	return false
+}
+
+func (x *GetImportJobRequest) GetAfterRow() int64 {
+	if x != nil && x.AfterRow != nil {
+		return *x.AfterRow
+	}
+	return 0
+}
+
+func (x *GetImportJobRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *GetImportJobRequest) GetWithoutResults() bool {
+	if x != nil && x.WithoutResults != nil {
+		return *x.WithoutResults
+	}
+	return false
+}
+
+type GetImportJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *ImportJob             `protobuf:"bytes,1,opt,name=job" json:"job,omitempty"`
+	Results       []*ImportRowResult     `protobuf:"bytes,2,rep,name=results" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImportJobResponse) Reset() {
+	*x = GetImportJobResponse{}
+	mi := &file_apiservice_import_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImportJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImportJobResponse) ProtoMessage() {}
+
+func (x *GetImportJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_import_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImportJobResponse.ProtoReflect.Descriptor instead.
+func (*GetImportJobResponse) Descriptor() ([]byte, []int) {
+	return file_apiservice_import_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetImportJobResponse) GetJob() *ImportJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *GetImportJobResponse) GetResults() []*ImportRowResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ListImportJobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *ImportJobStatus       `protobuf:"varint,1,opt,name=status,enum=protocol.api_service.storage.ImportJobStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListImportJobsRequest) Reset() {
+	*x = ListImportJobsRequest{}
+	mi := &file_apiservice_import_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListImportJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListImportJobsRequest) ProtoMessage() {}
+
+func (x *ListImportJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_import_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListImportJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListImportJobsRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_import_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListImportJobsRequest) GetStatus() ImportJobStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ImportJobStatus_Running
+}
+
+type ListImportJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*ImportJob           `protobuf:"bytes,1,rep,name=jobs" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListImportJobsResponse) Reset() {
+	*x = ListImportJobsResponse{}
+	mi := &file_apiservice_import_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListImportJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListImportJobsResponse) ProtoMessage() {}
+
+func (x *ListImportJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_import_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListImportJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListImportJobsResponse) Descriptor() ([]byte, []int) {
+	return file_apiservice_import_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListImportJobsResponse) GetJobs() []*ImportJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+var File_apiservice_import_proto protoreflect.FileDescriptor
+
+const file_apiservice_import_proto_rawDesc = "" +
+	"\n" +
+	"\x17apiservice/import.proto\x12\x1cprotocol.api_service.storage\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13types/invoice.proto\x1a\x10types/uuid.proto\"\x86\x03\n" +
+	"\tImportJob\x12$\n" +
+	"\x02id\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\x02id\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12E\n" +
+	"\x06status\x18\x03 \x01(\x0e2-.protocol.api_service.storage.ImportJobStatusR\x06status\x12$\n" +
+	"\rprocessedRows\x18\x04 \x01(\x03R\rprocessedRows\x12$\n" +
+	"\rsucceededRows\x18\x05 \x01(\x03R\rsucceededRows\x12\x1e\n" +
+	"\n" +
+	"failedRows\x18\x06 \x01(\x03R\n" +
+	"failedRows\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x128\n" +
+	"\tcreatedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
+	"\tupdatedAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"l\n" +
+	"\tImportRow\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x03R\x06number\x121\n" +
+	"\ainvoice\x18\x02 \x01(\v2\x17.protocol.types.InvoiceR\ainvoice\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"s\n" +
+	"\x0fImportRowResult\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x03R\x06number\x122\n" +
+	"\tinvoiceId\x18\x02 \x01(\v2\x14.protocol.types.UUIDR\tinvoiceId\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"V\n" +
+	"\x16CreateImportJobRequest\x12$\n" +
+	"\x02id\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\x02id\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"}\n" +
+	"\x12ImportBatchRequest\x12*\n" +
+	"\x05jobId\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\x05jobId\x12;\n" +
+	"\x04rows\x18\x02 \x03(\v2'.protocol.api_service.storage.ImportRowR\x04rows\"\x9b\x01\n" +
+	"\x16FinishImportJobRequest\x12$\n" +
+	"\x02id\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\x02id\x12E\n" +
+	"\x06status\x18\x02 \x01(\x0e2-.protocol.api_service.storage.ImportJobStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xb5\x01\n" +
+	"\x13GetImportJobRequest\x12$\n" +
+	"\x02id\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\x02id\x12\x1e\n" +
+	"\n" +
+	"onlyFailed\x18\x02 \x01(\bR\n" +
+	"onlyFailed\x12\x1a\n" +
+	"\bafterRow\x18\x03 \x01(\x03R\bafterRow\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12&\n" +
+	"\x0ewithoutResults\x18\x05 \x01(\bR\x0ewithoutResults\"\x9a\x01\n" +
+	"\x14GetImportJobResponse\x129\n" +
+	"\x03job\x18\x01 \x01(\v2'.protocol.api_service.storage.ImportJobR\x03job\x12G\n" +
+	"\aresults\x18\x02 \x03(\v2-.protocol.api_service.storage.ImportRowResultR\aresults\"^\n" +
+	"\x15ListImportJobsRequest\x12E\n" +
+	"\x06status\x18\x01 \x01(\x0e2-.protocol.api_service.storage.ImportJobStatusR\x06status\"U\n" +
+	"\x16ListImportJobsResponse\x12;\n" +
+	"\x04jobs\x18\x01 \x03(\v2'.protocol.api_service.storage.ImportJobR\x04jobs*9\n" +
+	"\x0fImportJobStatus\x12\v\n" +
+	"\aRunning\x10\x00\x12\r\n" +
+	"\tCompleted\x10\x01\x12\n" +
+	"\n" +
+	"\x06Failed\x10\x022\xa8\x04\n" +
+	"\rInvoiceImport\x12j\n" +
+	"\tCreateJob\x124.protocol.api_service.storage.CreateImportJobRequest\x1a'.protocol.api_service.storage.ImportJob\x12h\n" +
+	"\vImportBatch\x120.protocol.api_service.storage.ImportBatchRequest\x1a'.protocol.api_service.storage.ImportJob\x12Y\n" +
+	"\tFinishJob\x124.protocol.api_service.storage.FinishImportJobRequest\x1a\x16.google.protobuf.Empty\x12o\n" +
+	"\x06GetJob\x121.protocol.api_service.storage.GetImportJobRequest\x1a2.protocol.api_service.storage.GetImportJobResponse\x12u\n" +
+	"\bListJobs\x123.protocol.api_service.storage.ListImportJobsRequest\x1a4.protocol.api_service.storage.ListImportJobsResponseB5Z3go-invoice-service/common/protocol/proto/apiserviceb\beditionsp\xe8\a"
+
+var (
+	file_apiservice_import_proto_rawDescOnce sync.Once
+	file_apiservice_import_proto_rawDescData []byte
+)
+
+func file_apiservice_import_proto_rawDescGZIP() []byte {
+	file_apiservice_import_proto_rawDescOnce.Do(func() {
+		file_apiservice_import_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiservice_import_proto_rawDesc), len(file_apiservice_import_proto_rawDesc)))
+	})
+	return file_apiservice_import_proto_rawDescData
+}
+
+var file_apiservice_import_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_apiservice_import_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_apiservice_import_proto_goTypes = []any{
+	(ImportJobStatus)(0),           // 0: protocol.api_service.storage.ImportJobStatus
+	(*ImportJob)(nil),              // 1: protocol.api_service.storage.ImportJob
+	(*ImportRow)(nil),              // 2: protocol.api_service.storage.ImportRow
+	(*ImportRowResult)(nil),        // 3: protocol.api_service.storage.ImportRowResult
+	(*CreateImportJobRequest)(nil), // 4: protocol.api_service.storage.CreateImportJobRequest
+	(*ImportBatchRequest)(nil),     // 5: protocol.api_service.storage.ImportBatchRequest
+	(*FinishImportJobRequest)(nil), // 6: protocol.api_service.storage.FinishImportJobRequest
+	(*GetImportJobRequest)(nil),    // 7: protocol.api_service.storage.GetImportJobRequest
+	(*GetImportJobResponse)(nil),   // 8: protocol.api_service.storage.GetImportJobResponse
+	(*ListImportJobsRequest)(nil),  // 9: protocol.api_service.storage.ListImportJobsRequest
+	(*ListImportJobsResponse)(nil), // 10: protocol.api_service.storage.ListImportJobsResponse
+	(*types.UUID)(nil),             // 11: protocol.types.UUID
+	(*timestamppb.Timestamp)(nil),  // 12: google.protobuf.Timestamp
+	(*types.Invoice)(nil),          // 13: protocol.types.Invoice
+	(*emptypb.Empty)(nil),          // 14: google.protobuf.Empty
+}
+var file_apiservice_import_proto_depIdxs = []int32{
+	11, // 0: protocol.api_service.storage.ImportJob.id:type_name -> protocol.types.UUID
+	0,  // 1: protocol.api_service.storage.ImportJob.status:type_name -> protocol.api_service.storage.ImportJobStatus
+	12, // 2: protocol.api_service.storage.ImportJob.createdAt:type_name -> google.protobuf.Timestamp
+	12, // 3: protocol.api_service.storage.ImportJob.updatedAt:type_name -> google.protobuf.Timestamp
+	13, // 4: protocol.api_service.storage.ImportRow.invoice:type_name -> protocol.types.Invoice
+	11, // 5: protocol.api_service.storage.ImportRowResult.invoiceId:type_name -> protocol.types.UUID
+	11, // 6: protocol.api_service.storage.CreateImportJobRequest.id:type_name -> protocol.types.UUID
+	11, // 7: protocol.api_service.storage.ImportBatchRequest.jobId:type_name -> protocol.types.UUID
+	2,  // 8: protocol.api_service.storage.ImportBatchRequest.rows:type_name -> protocol.api_service.storage.ImportRow
+	11, // 9: protocol.api_service.storage.FinishImportJobRequest.id:type_name -> protocol.types.UUID
+	0,  // 10: protocol.api_service.storage.FinishImportJobRequest.status:type_name -> protocol.api_service.storage.ImportJobStatus
+	11, // 11: protocol.api_service.storage.GetImportJobRequest.id:type_name -> protocol.types.UUID
+	1,  // 12: protocol.api_service.storage.GetImportJobResponse.job:type_name -> protocol.api_service.storage.ImportJob
+	3,  // 13: protocol.api_service.storage.GetImportJobResponse.results:type_name -> protocol.api_service.storage.ImportRowResult
+	0,  // 14: protocol.api_service.storage.ListImportJobsRequest.status:type_name -> protocol.api_service.storage.ImportJobStatus
+	1,  // 15: protocol.api_service.storage.ListImportJobsResponse.jobs:type_name -> protocol.api_service.storage.ImportJob
+	4,  // 16: protocol.api_service.storage.InvoiceImport.CreateJob:input_type -> protocol.api_service.storage.CreateImportJobRequest
+	5,  // 17: protocol.api_service.storage.InvoiceImport.ImportBatch:input_type -> protocol.api_service.storage.ImportBatchRequest
+	6,  // 18: protocol.api_service.storage.InvoiceImport.FinishJob:input_type -> protocol.api_service.storage.FinishImportJobRequest
+	7,  // 19: protocol.api_service.storage.InvoiceImport.GetJob:input_type -> protocol.api_service.storage.GetImportJobRequest
+	9,  // 20: protocol.api_service.storage.InvoiceImport.ListJobs:input_type -> protocol.api_service.storage.ListImportJobsRequest
+	1,  // 21: protocol.api_service.storage.InvoiceImport.CreateJob:output_type -> protocol.api_service.storage.ImportJob
+	1,  // 22: protocol.api_service.storage.InvoiceImport.ImportBatch:output_type -> protocol.api_service.storage.ImportJob
+	14, // 23: protocol.api_service.storage.InvoiceImport.FinishJob:output_type -> google.protobuf.Empty
+	8,  // 24: protocol.api_service.storage.InvoiceImport.GetJob:output_type -> protocol.api_service.storage.GetImportJobResponse
+	10, // 25: protocol.api_service.storage.InvoiceImport.ListJobs:output_type -> protocol.api_service.storage.ListImportJobsResponse
+	21, // [21:26] is the sub-list for method output_type
+	16, // [16:21] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_apiservice_import_proto_init() }
+func file_apiservice_import_proto_init() {
+	if File_apiservice_import_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiservice_import_proto_rawDesc), len(file_apiservice_import_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_apiservice_import_proto_goTypes,
+		DependencyIndexes: file_apiservice_import_proto_depIdxs,
+		EnumInfos:         file_apiservice_import_proto_enumTypes,
+		MessageInfos:      file_apiservice_import_proto_msgTypes,
+	}.Build()
+	File_apiservice_import_proto = out.File
+	file_apiservice_import_proto_goTypes = nil
+	file_apiservice_import_proto_depIdxs = nil
+}
@@ -0,0 +1,274 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: apiservice/import.proto
+
+package apiservice
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	InvoiceImport_CreateJob_FullMethodName   = "/protocol.api_service.storage.InvoiceImport/CreateJob"
+	InvoiceImport_ImportBatch_FullMethodName = "/protocol.api_service.storage.InvoiceImport/ImportBatch"
+	InvoiceImport_FinishJob_FullMethodName   = "/protocol.api_service.storage.InvoiceImport/FinishJob"
+	InvoiceImport_GetJob_FullMethodName      = "/protocol.api_service.storage.InvoiceImport/GetJob"
+	InvoiceImport_ListJobs_FullMethodName    = "/protocol.api_service.storage.InvoiceImport/ListJobs"
+)
+
+// InvoiceImportClient is the client API for InvoiceImport service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type InvoiceImportClient interface {
+	CreateJob(ctx context.Context, in *CreateImportJobRequest, opts ...grpc.CallOption) (*ImportJob, error)
+	ImportBatch(ctx context.Context, in *ImportBatchRequest, opts ...grpc.CallOption) (*ImportJob, error)
+	FinishJob(ctx context.Context, in *FinishImportJobRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetJob(ctx context.Context, in *GetImportJobRequest, opts ...grpc.CallOption) (*GetImportJobResponse, error)
+	ListJobs(ctx context.Context, in *ListImportJobsRequest, opts ...grpc.CallOption) (*ListImportJobsResponse, error)
+}
+
+type invoiceImportClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInvoiceImportClient(cc grpc.ClientConnInterface) InvoiceImportClient {
+	return &invoiceImportClient{cc}
+}
+
+func (c *invoiceImportClient) CreateJob(ctx context.Context, in *CreateImportJobRequest, opts ...grpc.CallOption) (*ImportJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportJob)
+	err := c.cc.Invoke(ctx, InvoiceImport_CreateJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *invoiceImportClient) ImportBatch(ctx context.Context, in *ImportBatchRequest, opts ...grpc.CallOption) (*ImportJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportJob)
+	err := c.cc.Invoke(ctx, InvoiceImport_ImportBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *invoiceImportClient) FinishJob(ctx context.Context, in *FinishImportJobRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, InvoiceImport_FinishJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *invoiceImportClient) GetJob(ctx context.Context, in *GetImportJobRequest, opts ...grpc.CallOption) (*GetImportJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetImportJobResponse)
+	err := c.cc.Invoke(ctx, InvoiceImport_GetJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *invoiceImportClient) ListJobs(ctx context.Context, in *ListImportJobsRequest, opts ...grpc.CallOption) (*ListImportJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListImportJobsResponse)
+	err := c.cc.Invoke(ctx, InvoiceImport_ListJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InvoiceImportServer is the server API for InvoiceImport service.
+// All implementations must embed UnimplementedInvoiceImportServer
+// for forward compatibility.
+type InvoiceImportServer interface {
+	CreateJob(context.Context, *CreateImportJobRequest) (*ImportJob, error)
+	ImportBatch(context.Context, *ImportBatchRequest) (*ImportJob, error)
+	FinishJob(context.Context, *FinishImportJobRequest) (*emptypb.Empty, error)
+	GetJob(context.Context, *GetImportJobRequest) (*GetImportJobResponse, error)
+	ListJobs(context.Context, *ListImportJobsRequest) (*ListImportJobsResponse, error)
+	mustEmbedUnimplementedInvoiceImportServer()
+}
+
+// UnimplementedInvoiceImportServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedInvoiceImportServer struct{}
+
+func (UnimplementedInvoiceImportServer) CreateJob(context.Context, *CreateImportJobRequest) (*ImportJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateJob not implemented")
+}
+func (UnimplementedInvoiceImportServer) ImportBatch(context.Context, *ImportBatchRequest) (*ImportJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportBatch not implemented")
+}
+func (UnimplementedInvoiceImportServer) FinishJob(context.Context, *FinishImportJobRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishJob not implemented")
+}
+func (UnimplementedInvoiceImportServer) GetJob(context.Context, *GetImportJobRequest) (*GetImportJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (UnimplementedInvoiceImportServer) ListJobs(context.Context, *ListImportJobsRequest) (*ListImportJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (UnimplementedInvoiceImportServer) mustEmbedUnimplementedInvoiceImportServer() {}
+func (UnimplementedInvoiceImportServer) testEmbeddedByValue()                       {}
+
+// UnsafeInvoiceImportServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InvoiceImportServer will
+// result in compilation errors.
+type UnsafeInvoiceImportServer interface {
+	mustEmbedUnimplementedInvoiceImportServer()
+}
+
+func RegisterInvoiceImportServer(s grpc.ServiceRegistrar, srv InvoiceImportServer) {
+	// If the following call pancis, it indicates UnimplementedInvoiceImportServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&InvoiceImport_ServiceDesc, srv)
+}
+
+func _InvoiceImport_CreateJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateImportJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoiceImportServer).CreateJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InvoiceImport_CreateJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoiceImportServer).CreateJob(ctx, req.(*CreateImportJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InvoiceImport_ImportBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoiceImportServer).ImportBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InvoiceImport_ImportBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoiceImportServer).ImportBatch(ctx, req.(*ImportBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InvoiceImport_FinishJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishImportJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoiceImportServer).FinishJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InvoiceImport_FinishJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoiceImportServer).FinishJob(ctx, req.(*FinishImportJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InvoiceImport_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetImportJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoiceImportServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InvoiceImport_GetJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoiceImportServer).GetJob(ctx, req.(*GetImportJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InvoiceImport_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListImportJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoiceImportServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InvoiceImport_ListJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoiceImportServer).ListJobs(ctx, req.(*ListImportJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// InvoiceImport_ServiceDesc is the grpc.ServiceDesc for InvoiceImport service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InvoiceImport_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "protocol.api_service.storage.InvoiceImport",
+	HandlerType: (*InvoiceImportServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateJob",
+			Handler:    _InvoiceImport_CreateJob_Handler,
+		},
+		{
+			MethodName: "ImportBatch",
+			Handler:    _InvoiceImport_ImportBatch_Handler,
+		},
+		{
+			MethodName: "FinishJob",
+			Handler:    _InvoiceImport_FinishJob_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _InvoiceImport_GetJob_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _InvoiceImport_ListJobs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "apiservice/import.proto",
+}
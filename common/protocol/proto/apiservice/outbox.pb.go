@@ -0,0 +1,704 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: apiservice/outbox.proto
+
+package apiservice
+
+import (
+	types "go-invoice-service/common/protocol/proto/types"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OutboxMessageStatus int32
+
+const (
+	OutboxMessageStatus_OutboxPending   OutboxMessageStatus = 0
+	OutboxMessageStatus_OutboxParked    OutboxMessageStatus = 1
+	OutboxMessageStatus_OutboxPublished OutboxMessageStatus = 2
+)
+
+// Enum value maps for OutboxMessageStatus.
+var (
+	OutboxMessageStatus_name = map[int32]string{
+		0: "OutboxPending",
+		1: "OutboxParked",
+		2: "OutboxPublished",
+	}
+	OutboxMessageStatus_value = map[string]int32{
+		"OutboxPending":   0,
+		"OutboxParked":    1,
+		"OutboxPublished": 2,
+	}
+)
+
+func (x OutboxMessageStatus) Enum() *OutboxMessageStatus {
+	p := new(OutboxMessageStatus)
+	*p = x
+	return p
+}
+
+func (x OutboxMessageStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutboxMessageStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_apiservice_outbox_proto_enumTypes[0].Descriptor()
+}
+
+func (OutboxMessageStatus) Type() protoreflect.EnumType {
+	return &file_apiservice_outbox_proto_enumTypes[0]
+}
+
+func (x OutboxMessageStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutboxMessageStatus.Descriptor instead.
+func (OutboxMessageStatus) EnumDescriptor() ([]byte, []int) {
+	return file_apiservice_outbox_proto_rawDescGZIP(), []int{0}
+}
+
+type OutboxMessage struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      *int64                 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Topic   *string                `protobuf:"bytes,2,opt,name=topic" json:"topic,omitempty"`
+	Key     *string                `protobuf:"bytes,3,opt,name=key" json:"key,omitempty"`
+	Headers map[string]string      `protobuf:"bytes,4,rep,name=headers" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Payload []byte                 `protobuf:"bytes,5,opt,name=payload" json:"payload,omitempty"`
+	// The event data as JSON.
+	Data          []byte                 `protobuf:"bytes,6,opt,name=data" json:"data,omitempty"`
+	AggregateId   *types.UUID            `protobuf:"bytes,7,opt,name=aggregateId" json:"aggregateId,omitempty"`
+	Sequence      *int64                 `protobuf:"varint,8,opt,name=sequence" json:"sequence,omitempty"`
+	Status        *OutboxMessageStatus   `protobuf:"varint,9,opt,name=status,enum=protocol.api_service.storage.OutboxMessageStatus" json:"status,omitempty"`
+	Attempts      *int32                 `protobuf:"varint,10,opt,name=attempts" json:"attempts,omitempty"`
+	LastError     *string                `protobuf:"bytes,11,opt,name=lastError" json:"lastError,omitempty"`
+	FirstFailedAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=firstFailedAt" json:"firstFailedAt,omitempty"`
+	NextSendAt    *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=nextSendAt" json:"nextSendAt,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=createdAt" json:"createdAt,omitempty"`
+	// Set for published messages kept in the outbox or its archive.
+	PublishedAt   *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=publishedAt" json:"publishedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OutboxMessage) Reset() {
+	*x = OutboxMessage{}
+	mi := &file_apiservice_outbox_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutboxMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutboxMessage) ProtoMessage() {}
+
+func (x *OutboxMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_outbox_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutboxMessage.ProtoReflect.Descriptor instead.
+func (*OutboxMessage) Descriptor() ([]byte, []int) {
+	return file_apiservice_outbox_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OutboxMessage) GetId() int64 {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return 0
+}
+
+func (x *OutboxMessage) GetTopic() string {
+	if x != nil && x.Topic != nil {
+		return *x.Topic
+	}
+	return ""
+}
+
+func (x *OutboxMessage) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *OutboxMessage) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *OutboxMessage) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *OutboxMessage) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *OutboxMessage) GetAggregateId() *types.UUID {
+	if x != nil {
+		return x.AggregateId
+	}
+	return nil
+}
+
+func (x *OutboxMessage) GetSequence() int64 {
+	if x != nil && x.Sequence != nil {
+		return *x.Sequence
+	}
+	return 0
+}
+
+func (x *OutboxMessage) GetStatus() OutboxMessageStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return OutboxMessageStatus_OutboxPending
+}
+
+func (x *OutboxMessage) GetAttempts() int32 {
+	if x != nil && x.Attempts != nil {
+		return *x.Attempts
+	}
+	return 0
+}
+
+func (x *OutboxMessage) GetLastError() string {
+	if x != nil && x.LastError != nil {
+		return *x.LastError
+	}
+	return ""
+}
+
+func (x *OutboxMessage) GetFirstFailedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstFailedAt
+	}
+	return nil
+}
+
+func (x *OutboxMessage) GetNextSendAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextSendAt
+	}
+	return nil
+}
+
+func (x *OutboxMessage) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OutboxMessage) GetPublishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return nil
+}
+
+type ListParkedOutboxMessagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Messages are listed from the newest, pass the smallest received id to get the next page.
+	BeforeId      *int64 `protobuf:"varint,1,opt,name=beforeId" json:"beforeId,omitempty"`
+	Limit         *int32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListParkedOutboxMessagesRequest) Reset() {
+	*x = ListParkedOutboxMessagesRequest{}
+	mi := &file_apiservice_outbox_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListParkedOutboxMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListParkedOutboxMessagesRequest) ProtoMessage() {}
+
+func (x *ListParkedOutboxMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_outbox_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListParkedOutboxMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListParkedOutboxMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_outbox_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListParkedOutboxMessagesRequest) GetBeforeId() int64 {
+	if x != nil && x.BeforeId != nil {
+		return *x.BeforeId
+	}
+	return 0
+}
+
+func (x *ListParkedOutboxMessagesRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type ListParkedOutboxMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*OutboxMessage       `protobuf:"bytes,1,rep,name=messages" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListParkedOutboxMessagesResponse) Reset() {
+	*x = ListParkedOutboxMessagesResponse{}
+	mi := &file_apiservice_outbox_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListParkedOutboxMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListParkedOutboxMessagesResponse) ProtoMessage() {}
+
+func (x *ListParkedOutboxMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_outbox_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListParkedOutboxMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListParkedOutboxMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_apiservice_outbox_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListParkedOutboxMessagesResponse) GetMessages() []*OutboxMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type ListPublishedOutboxMessagesRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AggregateId *types.UUID            `protobuf:"bytes,1,opt,name=aggregateId" json:"aggregateId,omitempty"`
+	// Messages are listed from the newest, pass the smallest received id to get the next page.
+	BeforeId      *int64 `protobuf:"varint,2,opt,name=beforeId" json:"beforeId,omitempty"`
+	Limit         *int32 `protobuf:"varint,3,opt,name=limit" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublishedOutboxMessagesRequest) Reset() {
+	*x = ListPublishedOutboxMessagesRequest{}
+	mi := &file_apiservice_outbox_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublishedOutboxMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublishedOutboxMessagesRequest) ProtoMessage() {}
+
+func (x *ListPublishedOutboxMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_outbox_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublishedOutboxMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListPublishedOutboxMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_outbox_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPublishedOutboxMessagesRequest) GetAggregateId() *types.UUID {
+	if x != nil {
+		return x.AggregateId
+	}
+	return nil
+}
+
+func (x *ListPublishedOutboxMessagesRequest) GetBeforeId() int64 {
+	if x != nil && x.BeforeId != nil {
+		return *x.BeforeId
+	}
+	return 0
+}
+
+func (x *ListPublishedOutboxMessagesRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type ListPublishedOutboxMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*OutboxMessage       `protobuf:"bytes,1,rep,name=messages" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublishedOutboxMessagesResponse) Reset() {
+	*x = ListPublishedOutboxMessagesResponse{}
+	mi := &file_apiservice_outbox_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublishedOutboxMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublishedOutboxMessagesResponse) ProtoMessage() {}
+
+func (x *ListPublishedOutboxMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_outbox_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublishedOutboxMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListPublishedOutboxMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_apiservice_outbox_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListPublishedOutboxMessagesResponse) GetMessages() []*OutboxMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type GetOutboxMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *int64                 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOutboxMessageRequest) Reset() {
+	*x = GetOutboxMessageRequest{}
+	mi := &file_apiservice_outbox_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOutboxMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOutboxMessageRequest) ProtoMessage() {}
+
+func (x *GetOutboxMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_outbox_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOutboxMessageRequest.ProtoReflect.Descriptor instead.
+func (*GetOutboxMessageRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_outbox_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetOutboxMessageRequest) GetId() int64 {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return 0
+}
+
+type RequeueOutboxMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *int64                 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequeueOutboxMessageRequest) Reset() {
+	*x = RequeueOutboxMessageRequest{}
+	mi := &file_apiservice_outbox_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequeueOutboxMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueOutboxMessageRequest) ProtoMessage() {}
+
+func (x *RequeueOutboxMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_outbox_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueOutboxMessageRequest.ProtoReflect.Descriptor instead.
+func (*RequeueOutboxMessageRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_outbox_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RequeueOutboxMessageRequest) GetId() int64 {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return 0
+}
+
+type PurgeOutboxMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *int64                 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeOutboxMessageRequest) Reset() {
+	*x = PurgeOutboxMessageRequest{}
+	mi := &file_apiservice_outbox_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeOutboxMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeOutboxMessageRequest) ProtoMessage() {}
+
+func (x *PurgeOutboxMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_outbox_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeOutboxMessageRequest.ProtoReflect.Descriptor instead.
+func (*PurgeOutboxMessageRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_outbox_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PurgeOutboxMessageRequest) GetId() int64 {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return 0
+}
+
+var File_apiservice_outbox_proto protoreflect.FileDescriptor
+
+const file_apiservice_outbox_proto_rawDesc = "" +
+	"\n" +
+	"\x17apiservice/outbox.proto\x12\x1cprotocol.api_service.storage\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x10types/uuid.proto\"\xd4\x05\n" +
+	"\rOutboxMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05topic\x18\x02 \x01(\tR\x05topic\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\x12R\n" +
+	"\aheaders\x18\x04 \x03(\v28.protocol.api_service.storage.OutboxMessage.HeadersEntryR\aheaders\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\fR\apayload\x12\x12\n" +
+	"\x04data\x18\x06 \x01(\fR\x04data\x126\n" +
+	"\vaggregateId\x18\a \x01(\v2\x14.protocol.types.UUIDR\vaggregateId\x12\x1a\n" +
+	"\bsequence\x18\b \x01(\x03R\bsequence\x12I\n" +
+	"\x06status\x18\t \x01(\x0e21.protocol.api_service.storage.OutboxMessageStatusR\x06status\x12\x1a\n" +
+	"\battempts\x18\n" +
+	" \x01(\x05R\battempts\x12\x1c\n" +
+	"\tlastError\x18\v \x01(\tR\tlastError\x12@\n" +
+	"\rfirstFailedAt\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\rfirstFailedAt\x12:\n" +
+	"\n" +
+	"nextSendAt\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"nextSendAt\x128\n" +
+	"\tcreatedAt\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\vpublishedAt\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vpublishedAt\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"S\n" +
+	"\x1fListParkedOutboxMessagesRequest\x12\x1a\n" +
+	"\bbeforeId\x18\x01 \x01(\x03R\bbeforeId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"k\n" +
+	" ListParkedOutboxMessagesResponse\x12G\n" +
+	"\bmessages\x18\x01 \x03(\v2+.protocol.api_service.storage.OutboxMessageR\bmessages\"\x8e\x01\n" +
+	"\"ListPublishedOutboxMessagesRequest\x126\n" +
+	"\vaggregateId\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\vaggregateId\x12\x1a\n" +
+	"\bbeforeId\x18\x02 \x01(\x03R\bbeforeId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"n\n" +
+	"#ListPublishedOutboxMessagesResponse\x12G\n" +
+	"\bmessages\x18\x01 \x03(\v2+.protocol.api_service.storage.OutboxMessageR\bmessages\")\n" +
+	"\x17GetOutboxMessageRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"-\n" +
+	"\x1bRequeueOutboxMessageRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"+\n" +
+	"\x19PurgeOutboxMessageRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id*O\n" +
+	"\x13OutboxMessageStatus\x12\x11\n" +
+	"\rOutboxPending\x10\x00\x12\x10\n" +
+	"\fOutboxParked\x10\x01\x12\x13\n" +
+	"\x0fOutboxPublished\x10\x022\xea\x04\n" +
+	"\vOutboxAdmin\x12\x8b\x01\n" +
+	"\n" +
+	"ListParked\x12=.protocol.api_service.storage.ListParkedOutboxMessagesRequest\x1a>.protocol.api_service.storage.ListParkedOutboxMessagesResponse\x12\x94\x01\n" +
+	"\rListPublished\x12@.protocol.api_service.storage.ListPublishedOutboxMessagesRequest\x1aA.protocol.api_service.storage.ListPublishedOutboxMessagesResponse\x12i\n" +
+	"\x03Get\x125.protocol.api_service.storage.GetOutboxMessageRequest\x1a+.protocol.api_service.storage.OutboxMessage\x12q\n" +
+	"\aRequeue\x129.protocol.api_service.storage.RequeueOutboxMessageRequest\x1a+.protocol.api_service.storage.OutboxMessage\x12X\n" +
+	"\x05Purge\x127.protocol.api_service.storage.PurgeOutboxMessageRequest\x1a\x16.google.protobuf.EmptyB5Z3go-invoice-service/common/protocol/proto/apiserviceb\beditionsp\xe8\a"
+
+var (
+	file_apiservice_outbox_proto_rawDescOnce sync.Once
+	file_apiservice_outbox_proto_rawDescData []byte
+)
+
+func file_apiservice_outbox_proto_rawDescGZIP() []byte {
+	file_apiservice_outbox_proto_rawDescOnce.Do(func() {
+		file_apiservice_outbox_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiservice_outbox_proto_rawDesc), len(file_apiservice_outbox_proto_rawDesc)))
+	})
+	return file_apiservice_outbox_proto_rawDescData
+}
+
+var file_apiservice_outbox_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_apiservice_outbox_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_apiservice_outbox_proto_goTypes = []any{
+	(OutboxMessageStatus)(0),                    // 0: protocol.api_service.storage.OutboxMessageStatus
+	(*OutboxMessage)(nil),                       // 1: protocol.api_service.storage.OutboxMessage
+	(*ListParkedOutboxMessagesRequest)(nil),     // 2: protocol.api_service.storage.ListParkedOutboxMessagesRequest
+	(*ListParkedOutboxMessagesResponse)(nil),    // 3: protocol.api_service.storage.ListParkedOutboxMessagesResponse
+	(*ListPublishedOutboxMessagesRequest)(nil),  // 4: protocol.api_service.storage.ListPublishedOutboxMessagesRequest
+	(*ListPublishedOutboxMessagesResponse)(nil), // 5: protocol.api_service.storage.ListPublishedOutboxMessagesResponse
+	(*GetOutboxMessageRequest)(nil),             // 6: protocol.api_service.storage.GetOutboxMessageRequest
+	(*RequeueOutboxMessageRequest)(nil),         // 7: protocol.api_service.storage.RequeueOutboxMessageRequest
+	(*PurgeOutboxMessageRequest)(nil),           // 8: protocol.api_service.storage.PurgeOutboxMessageRequest
+	nil,                                         // 9: protocol.api_service.storage.OutboxMessage.HeadersEntry
+	(*types.UUID)(nil),                          // 10: protocol.types.UUID
+	(*timestamppb.Timestamp)(nil),               // 11: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                       // 12: google.protobuf.Empty
+}
+var file_apiservice_outbox_proto_depIdxs = []int32{
+	9,  // 0: protocol.api_service.storage.OutboxMessage.headers:type_name -> protocol.api_service.storage.OutboxMessage.HeadersEntry
+	10, // 1: protocol.api_service.storage.OutboxMessage.aggregateId:type_name -> protocol.types.UUID
+	0,  // 2: protocol.api_service.storage.OutboxMessage.status:type_name -> protocol.api_service.storage.OutboxMessageStatus
+	11, // 3: protocol.api_service.storage.OutboxMessage.firstFailedAt:type_name -> google.protobuf.Timestamp
+	11, // 4: protocol.api_service.storage.OutboxMessage.nextSendAt:type_name -> google.protobuf.Timestamp
+	11, // 5: protocol.api_service.storage.OutboxMessage.createdAt:type_name -> google.protobuf.Timestamp
+	11, // 6: protocol.api_service.storage.OutboxMessage.publishedAt:type_name -> google.protobuf.Timestamp
+	1,  // 7: protocol.api_service.storage.ListParkedOutboxMessagesResponse.messages:type_name -> protocol.api_service.storage.OutboxMessage
+	10, // 8: protocol.api_service.storage.ListPublishedOutboxMessagesRequest.aggregateId:type_name -> protocol.types.UUID
+	1,  // 9: protocol.api_service.storage.ListPublishedOutboxMessagesResponse.messages:type_name -> protocol.api_service.storage.OutboxMessage
+	2,  // 10: protocol.api_service.storage.OutboxAdmin.ListParked:input_type -> protocol.api_service.storage.ListParkedOutboxMessagesRequest
+	4,  // 11: protocol.api_service.storage.OutboxAdmin.ListPublished:input_type -> protocol.api_service.storage.ListPublishedOutboxMessagesRequest
+	6,  // 12: protocol.api_service.storage.OutboxAdmin.Get:input_type -> protocol.api_service.storage.GetOutboxMessageRequest
+	7,  // 13: protocol.api_service.storage.OutboxAdmin.Requeue:input_type -> protocol.api_service.storage.RequeueOutboxMessageRequest
+	8,  // 14: protocol.api_service.storage.OutboxAdmin.Purge:input_type -> protocol.api_service.storage.PurgeOutboxMessageRequest
+	3,  // 15: protocol.api_service.storage.OutboxAdmin.ListParked:output_type -> protocol.api_service.storage.ListParkedOutboxMessagesResponse
+	5,  // 16: protocol.api_service.storage.OutboxAdmin.ListPublished:output_type -> protocol.api_service.storage.ListPublishedOutboxMessagesResponse
+	1,  // 17: protocol.api_service.storage.OutboxAdmin.Get:output_type -> protocol.api_service.storage.OutboxMessage
+	1,  // 18: protocol.api_service.storage.OutboxAdmin.Requeue:output_type -> protocol.api_service.storage.OutboxMessage
+	12, // 19: protocol.api_service.storage.OutboxAdmin.Purge:output_type -> google.protobuf.Empty
+	15, // [15:20] is the sub-list for method output_type
+	10, // [10:15] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_apiservice_outbox_proto_init() }
+func file_apiservice_outbox_proto_init() {
+	if File_apiservice_outbox_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiservice_outbox_proto_rawDesc), len(file_apiservice_outbox_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_apiservice_outbox_proto_goTypes,
+		DependencyIndexes: file_apiservice_outbox_proto_depIdxs,
+		EnumInfos:         file_apiservice_outbox_proto_enumTypes,
+		MessageInfos:      file_apiservice_outbox_proto_msgTypes,
+	}.Build()
+	File_apiservice_outbox_proto = out.File
+	file_apiservice_outbox_proto_goTypes = nil
+	file_apiservice_outbox_proto_depIdxs = nil
+}
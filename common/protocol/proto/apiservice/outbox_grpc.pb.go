@@ -0,0 +1,280 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: apiservice/outbox.proto
+
+package apiservice
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	OutboxAdmin_ListParked_FullMethodName    = "/protocol.api_service.storage.OutboxAdmin/ListParked"
+	OutboxAdmin_ListPublished_FullMethodName = "/protocol.api_service.storage.OutboxAdmin/ListPublished"
+	OutboxAdmin_Get_FullMethodName           = "/protocol.api_service.storage.OutboxAdmin/Get"
+	OutboxAdmin_Requeue_FullMethodName       = "/protocol.api_service.storage.OutboxAdmin/Requeue"
+	OutboxAdmin_Purge_FullMethodName         = "/protocol.api_service.storage.OutboxAdmin/Purge"
+)
+
+// OutboxAdminClient is the client API for OutboxAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OutboxAdminClient interface {
+	ListParked(ctx context.Context, in *ListParkedOutboxMessagesRequest, opts ...grpc.CallOption) (*ListParkedOutboxMessagesResponse, error)
+	// ListPublished lists messages published for an invoice, when published messages are kept.
+	ListPublished(ctx context.Context, in *ListPublishedOutboxMessagesRequest, opts ...grpc.CallOption) (*ListPublishedOutboxMessagesResponse, error)
+	Get(ctx context.Context, in *GetOutboxMessageRequest, opts ...grpc.CallOption) (*OutboxMessage, error)
+	// Requeue moves a parked message back to pending with a fresh attempts budget.
+	Requeue(ctx context.Context, in *RequeueOutboxMessageRequest, opts ...grpc.CallOption) (*OutboxMessage, error)
+	// Purge deletes a parked message, so the next messages of its invoice can be sent.
+	Purge(ctx context.Context, in *PurgeOutboxMessageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type outboxAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOutboxAdminClient(cc grpc.ClientConnInterface) OutboxAdminClient {
+	return &outboxAdminClient{cc}
+}
+
+func (c *outboxAdminClient) ListParked(ctx context.Context, in *ListParkedOutboxMessagesRequest, opts ...grpc.CallOption) (*ListParkedOutboxMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListParkedOutboxMessagesResponse)
+	err := c.cc.Invoke(ctx, OutboxAdmin_ListParked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxAdminClient) ListPublished(ctx context.Context, in *ListPublishedOutboxMessagesRequest, opts ...grpc.CallOption) (*ListPublishedOutboxMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPublishedOutboxMessagesResponse)
+	err := c.cc.Invoke(ctx, OutboxAdmin_ListPublished_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxAdminClient) Get(ctx context.Context, in *GetOutboxMessageRequest, opts ...grpc.CallOption) (*OutboxMessage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OutboxMessage)
+	err := c.cc.Invoke(ctx, OutboxAdmin_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxAdminClient) Requeue(ctx context.Context, in *RequeueOutboxMessageRequest, opts ...grpc.CallOption) (*OutboxMessage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OutboxMessage)
+	err := c.cc.Invoke(ctx, OutboxAdmin_Requeue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxAdminClient) Purge(ctx context.Context, in *PurgeOutboxMessageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, OutboxAdmin_Purge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OutboxAdminServer is the server API for OutboxAdmin service.
+// All implementations must embed UnimplementedOutboxAdminServer
+// for forward compatibility.
+type OutboxAdminServer interface {
+	ListParked(context.Context, *ListParkedOutboxMessagesRequest) (*ListParkedOutboxMessagesResponse, error)
+	// ListPublished lists messages published for an invoice, when published messages are kept.
+	ListPublished(context.Context, *ListPublishedOutboxMessagesRequest) (*ListPublishedOutboxMessagesResponse, error)
+	Get(context.Context, *GetOutboxMessageRequest) (*OutboxMessage, error)
+	// Requeue moves a parked message back to pending with a fresh attempts budget.
+	Requeue(context.Context, *RequeueOutboxMessageRequest) (*OutboxMessage, error)
+	// Purge deletes a parked message, so the next messages of its invoice can be sent.
+	Purge(context.Context, *PurgeOutboxMessageRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedOutboxAdminServer()
+}
+
+// UnimplementedOutboxAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOutboxAdminServer struct{}
+
+func (UnimplementedOutboxAdminServer) ListParked(context.Context, *ListParkedOutboxMessagesRequest) (*ListParkedOutboxMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListParked not implemented")
+}
+func (UnimplementedOutboxAdminServer) ListPublished(context.Context, *ListPublishedOutboxMessagesRequest) (*ListPublishedOutboxMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPublished not implemented")
+}
+func (UnimplementedOutboxAdminServer) Get(context.Context, *GetOutboxMessageRequest) (*OutboxMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedOutboxAdminServer) Requeue(context.Context, *RequeueOutboxMessageRequest) (*OutboxMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Requeue not implemented")
+}
+func (UnimplementedOutboxAdminServer) Purge(context.Context, *PurgeOutboxMessageRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Purge not implemented")
+}
+func (UnimplementedOutboxAdminServer) mustEmbedUnimplementedOutboxAdminServer() {}
+func (UnimplementedOutboxAdminServer) testEmbeddedByValue()                     {}
+
+// UnsafeOutboxAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OutboxAdminServer will
+// result in compilation errors.
+type UnsafeOutboxAdminServer interface {
+	mustEmbedUnimplementedOutboxAdminServer()
+}
+
+func RegisterOutboxAdminServer(s grpc.ServiceRegistrar, srv OutboxAdminServer) {
+	// If the following call pancis, it indicates UnimplementedOutboxAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OutboxAdmin_ServiceDesc, srv)
+}
+
+func _OutboxAdmin_ListParked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListParkedOutboxMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxAdminServer).ListParked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OutboxAdmin_ListParked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxAdminServer).ListParked(ctx, req.(*ListParkedOutboxMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxAdmin_ListPublished_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPublishedOutboxMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxAdminServer).ListPublished(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OutboxAdmin_ListPublished_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxAdminServer).ListPublished(ctx, req.(*ListPublishedOutboxMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxAdmin_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOutboxMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxAdminServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OutboxAdmin_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxAdminServer).Get(ctx, req.(*GetOutboxMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxAdmin_Requeue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequeueOutboxMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxAdminServer).Requeue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OutboxAdmin_Requeue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxAdminServer).Requeue(ctx, req.(*RequeueOutboxMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxAdmin_Purge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeOutboxMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxAdminServer).Purge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OutboxAdmin_Purge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxAdminServer).Purge(ctx, req.(*PurgeOutboxMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OutboxAdmin_ServiceDesc is the grpc.ServiceDesc for OutboxAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OutboxAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "protocol.api_service.storage.OutboxAdmin",
+	HandlerType: (*OutboxAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListParked",
+			Handler:    _OutboxAdmin_ListParked_Handler,
+		},
+		{
+			MethodName: "ListPublished",
+			Handler:    _OutboxAdmin_ListPublished_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _OutboxAdmin_Get_Handler,
+		},
+		{
+			MethodName: "Requeue",
+			Handler:    _OutboxAdmin_Requeue_Handler,
+		},
+		{
+			MethodName: "Purge",
+			Handler:    _OutboxAdmin_Purge_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "apiservice/outbox.proto",
+}
@@ -0,0 +1,683 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: apiservice/reporting.proto
+
+package apiservice
+
+import (
+	types "go-invoice-service/common/protocol/proto/types"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AgingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AsOf          *string                `protobuf:"bytes,1,opt,name=asOf" json:"asOf,omitempty"`
+	TimeZone      *string                `protobuf:"bytes,2,opt,name=timeZone" json:"timeZone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgingRequest) Reset() {
+	*x = AgingRequest{}
+	mi := &file_apiservice_reporting_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgingRequest) ProtoMessage() {}
+
+func (x *AgingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_reporting_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgingRequest.ProtoReflect.Descriptor instead.
+func (*AgingRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_reporting_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AgingRequest) GetAsOf() string {
+	if x != nil && x.AsOf != nil {
+		return *x.AsOf
+	}
+	return ""
+}
+
+func (x *AgingRequest) GetTimeZone() string {
+	if x != nil && x.TimeZone != nil {
+		return *x.TimeZone
+	}
+	return ""
+}
+
+type AgingBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      *string                `protobuf:"bytes,1,opt,name=currency" json:"currency,omitempty"`
+	Bucket        *string                `protobuf:"bytes,2,opt,name=bucket" json:"bucket,omitempty"`
+	Invoices      *int64                 `protobuf:"varint,3,opt,name=invoices" json:"invoices,omitempty"`
+	Amount        *int64                 `protobuf:"varint,4,opt,name=amount" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgingBucket) Reset() {
+	*x = AgingBucket{}
+	mi := &file_apiservice_reporting_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgingBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgingBucket) ProtoMessage() {}
+
+func (x *AgingBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_reporting_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgingBucket.ProtoReflect.Descriptor instead.
+func (*AgingBucket) Descriptor() ([]byte, []int) {
+	return file_apiservice_reporting_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AgingBucket) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *AgingBucket) GetBucket() string {
+	if x != nil && x.Bucket != nil {
+		return *x.Bucket
+	}
+	return ""
+}
+
+func (x *AgingBucket) GetInvoices() int64 {
+	if x != nil && x.Invoices != nil {
+		return *x.Invoices
+	}
+	return 0
+}
+
+func (x *AgingBucket) GetAmount() int64 {
+	if x != nil && x.Amount != nil {
+		return *x.Amount
+	}
+	return 0
+}
+
+type AgingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AsOf          *string                `protobuf:"bytes,1,opt,name=asOf" json:"asOf,omitempty"`
+	Buckets       []*AgingBucket         `protobuf:"bytes,2,rep,name=buckets" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgingResponse) Reset() {
+	*x = AgingResponse{}
+	mi := &file_apiservice_reporting_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgingResponse) ProtoMessage() {}
+
+func (x *AgingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_reporting_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgingResponse.ProtoReflect.Descriptor instead.
+func (*AgingResponse) Descriptor() ([]byte, []int) {
+	return file_apiservice_reporting_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AgingResponse) GetAsOf() string {
+	if x != nil && x.AsOf != nil {
+		return *x.AsOf
+	}
+	return ""
+}
+
+func (x *AgingResponse) GetBuckets() []*AgingBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type RevenueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *string                `protobuf:"bytes,1,opt,name=from" json:"from,omitempty"`
+	To            *string                `protobuf:"bytes,2,opt,name=to" json:"to,omitempty"`
+	TimeZone      *string                `protobuf:"bytes,3,opt,name=timeZone" json:"timeZone,omitempty"`
+	ByCustomer    *bool                  `protobuf:"varint,4,opt,name=byCustomer" json:"byCustomer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevenueRequest) Reset() {
+	*x = RevenueRequest{}
+	mi := &file_apiservice_reporting_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevenueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevenueRequest) ProtoMessage() {}
+
+func (x *RevenueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_reporting_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevenueRequest.ProtoReflect.Descriptor instead.
+func (*RevenueRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_reporting_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RevenueRequest) GetFrom() string {
+	if x != nil && x.From != nil {
+		return *x.From
+	}
+	return ""
+}
+
+func (x *RevenueRequest) GetTo() string {
+	if x != nil && x.To != nil {
+		return *x.To
+	}
+	return ""
+}
+
+func (x *RevenueRequest) GetTimeZone() string {
+	if x != nil && x.TimeZone != nil {
+		return *x.TimeZone
+	}
+	return ""
+}
+
+func (x *RevenueRequest) GetByCustomer() bool {
+	if x != nil && x.ByCustomer != nil {
+		return *x.ByCustomer
+	}
+	return false
+}
+
+type RevenueRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Month         *string                `protobuf:"bytes,1,opt,name=month" json:"month,omitempty"`
+	Currency      *string                `protobuf:"bytes,2,opt,name=currency" json:"currency,omitempty"`
+	CustomerId    *types.UUID            `protobuf:"bytes,3,opt,name=customerId" json:"customerId,omitempty"`
+	Invoices      *int64                 `protobuf:"varint,4,opt,name=invoices" json:"invoices,omitempty"`
+	Amount        *int64                 `protobuf:"varint,5,opt,name=amount" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevenueRow) Reset() {
+	*x = RevenueRow{}
+	mi := &file_apiservice_reporting_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevenueRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevenueRow) ProtoMessage() {}
+
+func (x *RevenueRow) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_reporting_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevenueRow.ProtoReflect.Descriptor instead.
+func (*RevenueRow) Descriptor() ([]byte, []int) {
+	return file_apiservice_reporting_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RevenueRow) GetMonth() string {
+	if x != nil && x.Month != nil {
+		return *x.Month
+	}
+	return ""
+}
+
+func (x *RevenueRow) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *RevenueRow) GetCustomerId() *types.UUID {
+	if x != nil {
+		return x.CustomerId
+	}
+	return nil
+}
+
+func (x *RevenueRow) GetInvoices() int64 {
+	if x != nil && x.Invoices != nil {
+		return *x.Invoices
+	}
+	return 0
+}
+
+func (x *RevenueRow) GetAmount() int64 {
+	if x != nil && x.Amount != nil {
+		return *x.Amount
+	}
+	return 0
+}
+
+type RevenueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          []*RevenueRow          `protobuf:"bytes,1,rep,name=rows" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevenueResponse) Reset() {
+	*x = RevenueResponse{}
+	mi := &file_apiservice_reporting_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevenueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevenueResponse) ProtoMessage() {}
+
+func (x *RevenueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_reporting_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevenueResponse.ProtoReflect.Descriptor instead.
+func (*RevenueResponse) Descriptor() ([]byte, []int) {
+	return file_apiservice_reporting_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RevenueResponse) GetRows() []*RevenueRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type DSORequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *string                `protobuf:"bytes,1,opt,name=from" json:"from,omitempty"`
+	To            *string                `protobuf:"bytes,2,opt,name=to" json:"to,omitempty"`
+	TimeZone      *string                `protobuf:"bytes,3,opt,name=timeZone" json:"timeZone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DSORequest) Reset() {
+	*x = DSORequest{}
+	mi := &file_apiservice_reporting_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DSORequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DSORequest) ProtoMessage() {}
+
+func (x *DSORequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_reporting_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DSORequest.ProtoReflect.Descriptor instead.
+func (*DSORequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_reporting_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DSORequest) GetFrom() string {
+	if x != nil && x.From != nil {
+		return *x.From
+	}
+	return ""
+}
+
+func (x *DSORequest) GetTo() string {
+	if x != nil && x.To != nil {
+		return *x.To
+	}
+	return ""
+}
+
+func (x *DSORequest) GetTimeZone() string {
+	if x != nil && x.TimeZone != nil {
+		return *x.TimeZone
+	}
+	return ""
+}
+
+type DSORow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      *string                `protobuf:"bytes,1,opt,name=currency" json:"currency,omitempty"`
+	Receivables   *int64                 `protobuf:"varint,2,opt,name=receivables" json:"receivables,omitempty"`
+	Sales         *int64                 `protobuf:"varint,3,opt,name=sales" json:"sales,omitempty"`
+	Days          *int32                 `protobuf:"varint,4,opt,name=days" json:"days,omitempty"`
+	Dso           *float64               `protobuf:"fixed64,5,opt,name=dso" json:"dso,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DSORow) Reset() {
+	*x = DSORow{}
+	mi := &file_apiservice_reporting_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DSORow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DSORow) ProtoMessage() {}
+
+func (x *DSORow) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_reporting_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DSORow.ProtoReflect.Descriptor instead.
+func (*DSORow) Descriptor() ([]byte, []int) {
+	return file_apiservice_reporting_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DSORow) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *DSORow) GetReceivables() int64 {
+	if x != nil && x.Receivables != nil {
+		return *x.Receivables
+	}
+	return 0
+}
+
+func (x *DSORow) GetSales() int64 {
+	if x != nil && x.Sales != nil {
+		return *x.Sales
+	}
+	return 0
+}
+
+func (x *DSORow) GetDays() int32 {
+	if x != nil && x.Days != nil {
+		return *x.Days
+	}
+	return 0
+}
+
+func (x *DSORow) GetDso() float64 {
+	if x != nil && x.Dso != nil {
+		return *x.Dso
+	}
+	return 0
+}
+
+type DSOResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          []*DSORow              `protobuf:"bytes,1,rep,name=rows" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DSOResponse) Reset() {
+	*x = DSOResponse{}
+	mi := &file_apiservice_reporting_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DSOResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DSOResponse) ProtoMessage() {}
+
+func (x *DSOResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_reporting_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DSOResponse.ProtoReflect.Descriptor instead.
+func (*DSOResponse) Descriptor() ([]byte, []int) {
+	return file_apiservice_reporting_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DSOResponse) GetRows() []*DSORow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+var File_apiservice_reporting_proto protoreflect.FileDescriptor
+
+const file_apiservice_reporting_proto_rawDesc = "" +
+	"\n" +
+	"\x1aapiservice/reporting.proto\x12\x1cprotocol.api_service.storage\x1a\x10types/uuid.proto\">\n" +
+	"\fAgingRequest\x12\x12\n" +
+	"\x04asOf\x18\x01 \x01(\tR\x04asOf\x12\x1a\n" +
+	"\btimeZone\x18\x02 \x01(\tR\btimeZone\"u\n" +
+	"\vAgingBucket\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06bucket\x18\x02 \x01(\tR\x06bucket\x12\x1a\n" +
+	"\binvoices\x18\x03 \x01(\x03R\binvoices\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\"h\n" +
+	"\rAgingResponse\x12\x12\n" +
+	"\x04asOf\x18\x01 \x01(\tR\x04asOf\x12C\n" +
+	"\abuckets\x18\x02 \x03(\v2).protocol.api_service.storage.AgingBucketR\abuckets\"p\n" +
+	"\x0eRevenueRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x1a\n" +
+	"\btimeZone\x18\x03 \x01(\tR\btimeZone\x12\x1e\n" +
+	"\n" +
+	"byCustomer\x18\x04 \x01(\bR\n" +
+	"byCustomer\"\xa8\x01\n" +
+	"\n" +
+	"RevenueRow\x12\x14\n" +
+	"\x05month\x18\x01 \x01(\tR\x05month\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x124\n" +
+	"\n" +
+	"customerId\x18\x03 \x01(\v2\x14.protocol.types.UUIDR\n" +
+	"customerId\x12\x1a\n" +
+	"\binvoices\x18\x04 \x01(\x03R\binvoices\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x03R\x06amount\"O\n" +
+	"\x0fRevenueResponse\x12<\n" +
+	"\x04rows\x18\x01 \x03(\v2(.protocol.api_service.storage.RevenueRowR\x04rows\"L\n" +
+	"\n" +
+	"DSORequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x1a\n" +
+	"\btimeZone\x18\x03 \x01(\tR\btimeZone\"\x82\x01\n" +
+	"\x06DSORow\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12 \n" +
+	"\vreceivables\x18\x02 \x01(\x03R\vreceivables\x12\x14\n" +
+	"\x05sales\x18\x03 \x01(\x03R\x05sales\x12\x12\n" +
+	"\x04days\x18\x04 \x01(\x05R\x04days\x12\x10\n" +
+	"\x03dso\x18\x05 \x01(\x01R\x03dso\"G\n" +
+	"\vDSOResponse\x128\n" +
+	"\x04rows\x18\x01 \x03(\v2$.protocol.api_service.storage.DSORowR\x04rows2\xc1\x02\n" +
+	"\x10InvoiceReporting\x12c\n" +
+	"\bGetAging\x12*.protocol.api_service.storage.AgingRequest\x1a+.protocol.api_service.storage.AgingResponse\x12i\n" +
+	"\n" +
+	"GetRevenue\x12,.protocol.api_service.storage.RevenueRequest\x1a-.protocol.api_service.storage.RevenueResponse\x12]\n" +
+	"\x06GetDSO\x12(.protocol.api_service.storage.DSORequest\x1a).protocol.api_service.storage.DSOResponseB5Z3go-invoice-service/common/protocol/proto/apiserviceb\beditionsp\xe8\a"
+
+var (
+	file_apiservice_reporting_proto_rawDescOnce sync.Once
+	file_apiservice_reporting_proto_rawDescData []byte
+)
+
+func file_apiservice_reporting_proto_rawDescGZIP() []byte {
+	file_apiservice_reporting_proto_rawDescOnce.Do(func() {
+		file_apiservice_reporting_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiservice_reporting_proto_rawDesc), len(file_apiservice_reporting_proto_rawDesc)))
+	})
+	return file_apiservice_reporting_proto_rawDescData
+}
+
+var file_apiservice_reporting_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_apiservice_reporting_proto_goTypes = []any{
+	(*AgingRequest)(nil),    // 0: protocol.api_service.storage.AgingRequest
+	(*AgingBucket)(nil),     // 1: protocol.api_service.storage.AgingBucket
+	(*AgingResponse)(nil),   // 2: protocol.api_service.storage.AgingResponse
+	(*RevenueRequest)(nil),  // 3: protocol.api_service.storage.RevenueRequest
+	(*RevenueRow)(nil),      // 4: protocol.api_service.storage.RevenueRow
+	(*RevenueResponse)(nil), // 5: protocol.api_service.storage.RevenueResponse
+	(*DSORequest)(nil),      // 6: protocol.api_service.storage.DSORequest
+	(*DSORow)(nil),          // 7: protocol.api_service.storage.DSORow
+	(*DSOResponse)(nil),     // 8: protocol.api_service.storage.DSOResponse
+	(*types.UUID)(nil),      // 9: protocol.types.UUID
+}
+var file_apiservice_reporting_proto_depIdxs = []int32{
+	1, // 0: protocol.api_service.storage.AgingResponse.buckets:type_name -> protocol.api_service.storage.AgingBucket
+	9, // 1: protocol.api_service.storage.RevenueRow.customerId:type_name -> protocol.types.UUID
+	4, // 2: protocol.api_service.storage.RevenueResponse.rows:type_name -> protocol.api_service.storage.RevenueRow
+	7, // 3: protocol.api_service.storage.DSOResponse.rows:type_name -> protocol.api_service.storage.DSORow
+	0, // 4: protocol.api_service.storage.InvoiceReporting.GetAging:input_type -> protocol.api_service.storage.AgingRequest
+	3, // 5: protocol.api_service.storage.InvoiceReporting.GetRevenue:input_type -> protocol.api_service.storage.RevenueRequest
+	6, // 6: protocol.api_service.storage.InvoiceReporting.GetDSO:input_type -> protocol.api_service.storage.DSORequest
+	2, // 7: protocol.api_service.storage.InvoiceReporting.GetAging:output_type -> protocol.api_service.storage.AgingResponse
+	5, // 8: protocol.api_service.storage.InvoiceReporting.GetRevenue:output_type -> protocol.api_service.storage.RevenueResponse
+	8, // 9: protocol.api_service.storage.InvoiceReporting.GetDSO:output_type -> protocol.api_service.storage.DSOResponse
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_apiservice_reporting_proto_init() }
+func file_apiservice_reporting_proto_init() {
+	if File_apiservice_reporting_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiservice_reporting_proto_rawDesc), len(file_apiservice_reporting_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_apiservice_reporting_proto_goTypes,
+		DependencyIndexes: file_apiservice_reporting_proto_depIdxs,
+		MessageInfos:      file_apiservice_reporting_proto_msgTypes,
+	}.Build()
+	File_apiservice_reporting_proto = out.File
+	file_apiservice_reporting_proto_goTypes = nil
+	file_apiservice_reporting_proto_depIdxs = nil
+}
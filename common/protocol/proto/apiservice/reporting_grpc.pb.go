@@ -0,0 +1,197 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: apiservice/reporting.proto
+
+package apiservice
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	InvoiceReporting_GetAging_FullMethodName   = "/protocol.api_service.storage.InvoiceReporting/GetAging"
+	InvoiceReporting_GetRevenue_FullMethodName = "/protocol.api_service.storage.InvoiceReporting/GetRevenue"
+	InvoiceReporting_GetDSO_FullMethodName     = "/protocol.api_service.storage.InvoiceReporting/GetDSO"
+)
+
+// InvoiceReportingClient is the client API for InvoiceReporting service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type InvoiceReportingClient interface {
+	GetAging(ctx context.Context, in *AgingRequest, opts ...grpc.CallOption) (*AgingResponse, error)
+	GetRevenue(ctx context.Context, in *RevenueRequest, opts ...grpc.CallOption) (*RevenueResponse, error)
+	GetDSO(ctx context.Context, in *DSORequest, opts ...grpc.CallOption) (*DSOResponse, error)
+}
+
+type invoiceReportingClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInvoiceReportingClient(cc grpc.ClientConnInterface) InvoiceReportingClient {
+	return &invoiceReportingClient{cc}
+}
+
+func (c *invoiceReportingClient) GetAging(ctx context.Context, in *AgingRequest, opts ...grpc.CallOption) (*AgingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AgingResponse)
+	err := c.cc.Invoke(ctx, InvoiceReporting_GetAging_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *invoiceReportingClient) GetRevenue(ctx context.Context, in *RevenueRequest, opts ...grpc.CallOption) (*RevenueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevenueResponse)
+	err := c.cc.Invoke(ctx, InvoiceReporting_GetRevenue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *invoiceReportingClient) GetDSO(ctx context.Context, in *DSORequest, opts ...grpc.CallOption) (*DSOResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DSOResponse)
+	err := c.cc.Invoke(ctx, InvoiceReporting_GetDSO_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InvoiceReportingServer is the server API for InvoiceReporting service.
+// All implementations must embed UnimplementedInvoiceReportingServer
+// for forward compatibility.
+type InvoiceReportingServer interface {
+	GetAging(context.Context, *AgingRequest) (*AgingResponse, error)
+	GetRevenue(context.Context, *RevenueRequest) (*RevenueResponse, error)
+	GetDSO(context.Context, *DSORequest) (*DSOResponse, error)
+	mustEmbedUnimplementedInvoiceReportingServer()
+}
+
+// UnimplementedInvoiceReportingServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedInvoiceReportingServer struct{}
+
+func (UnimplementedInvoiceReportingServer) GetAging(context.Context, *AgingRequest) (*AgingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAging not implemented")
+}
+func (UnimplementedInvoiceReportingServer) GetRevenue(context.Context, *RevenueRequest) (*RevenueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevenue not implemented")
+}
+func (UnimplementedInvoiceReportingServer) GetDSO(context.Context, *DSORequest) (*DSOResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDSO not implemented")
+}
+func (UnimplementedInvoiceReportingServer) mustEmbedUnimplementedInvoiceReportingServer() {}
+func (UnimplementedInvoiceReportingServer) testEmbeddedByValue()                          {}
+
+// UnsafeInvoiceReportingServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InvoiceReportingServer will
+// result in compilation errors.
+type UnsafeInvoiceReportingServer interface {
+	mustEmbedUnimplementedInvoiceReportingServer()
+}
+
+func RegisterInvoiceReportingServer(s grpc.ServiceRegistrar, srv InvoiceReportingServer) {
+	// If the following call pancis, it indicates UnimplementedInvoiceReportingServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&InvoiceReporting_ServiceDesc, srv)
+}
+
+func _InvoiceReporting_GetAging_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoiceReportingServer).GetAging(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InvoiceReporting_GetAging_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoiceReportingServer).GetAging(ctx, req.(*AgingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InvoiceReporting_GetRevenue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevenueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoiceReportingServer).GetRevenue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InvoiceReporting_GetRevenue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoiceReportingServer).GetRevenue(ctx, req.(*RevenueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InvoiceReporting_GetDSO_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DSORequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoiceReportingServer).GetDSO(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InvoiceReporting_GetDSO_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoiceReportingServer).GetDSO(ctx, req.(*DSORequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// InvoiceReporting_ServiceDesc is the grpc.ServiceDesc for InvoiceReporting service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InvoiceReporting_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "protocol.api_service.storage.InvoiceReporting",
+	HandlerType: (*InvoiceReportingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAging",
+			Handler:    _InvoiceReporting_GetAging_Handler,
+		},
+		{
+			MethodName: "GetRevenue",
+			Handler:    _InvoiceReporting_GetRevenue_Handler,
+		},
+		{
+			MethodName: "GetDSO",
+			Handler:    _InvoiceReporting_GetDSO_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "apiservice/reporting.proto",
+}
@@ -0,0 +1,932 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: apiservice/webhook.proto
+
+package apiservice
+
+import (
+	types "go-invoice-service/common/protocol/proto/types"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WebhookDeliveryStatus int32
+
+const (
+	WebhookDeliveryStatus_Pending   WebhookDeliveryStatus = 0
+	WebhookDeliveryStatus_Succeeded WebhookDeliveryStatus = 1
+	WebhookDeliveryStatus_Dead      WebhookDeliveryStatus = 2
+)
+
+// Enum value maps for WebhookDeliveryStatus.
+var (
+	WebhookDeliveryStatus_name = map[int32]string{
+		0: "Pending",
+		1: "Succeeded",
+		2: "Dead",
+	}
+	WebhookDeliveryStatus_value = map[string]int32{
+		"Pending":   0,
+		"Succeeded": 1,
+		"Dead":      2,
+	}
+)
+
+func (x WebhookDeliveryStatus) Enum() *WebhookDeliveryStatus {
+	p := new(WebhookDeliveryStatus)
+	*p = x
+	return p
+}
+
+func (x WebhookDeliveryStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookDeliveryStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_apiservice_webhook_proto_enumTypes[0].Descriptor()
+}
+
+func (WebhookDeliveryStatus) Type() protoreflect.EnumType {
+	return &file_apiservice_webhook_proto_enumTypes[0]
+}
+
+func (x WebhookDeliveryStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookDeliveryStatus.Descriptor instead.
+func (WebhookDeliveryStatus) EnumDescriptor() ([]byte, []int) {
+	return file_apiservice_webhook_proto_rawDescGZIP(), []int{0}
+}
+
+type WebhookEndpoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    *types.UUID            `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Url   *string                `protobuf:"bytes,2,opt,name=url" json:"url,omitempty"`
+	// Only returned when the endpoint is created.
+	Secret        *string                `protobuf:"bytes,3,opt,name=secret" json:"secret,omitempty"`
+	EventTypes    []string               `protobuf:"bytes,4,rep,name=eventTypes" json:"eventTypes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=createdAt" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookEndpoint) Reset() {
+	*x = WebhookEndpoint{}
+	mi := &file_apiservice_webhook_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookEndpoint) ProtoMessage() {}
+
+func (x *WebhookEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_webhook_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookEndpoint.ProtoReflect.Descriptor instead.
+func (*WebhookEndpoint) Descriptor() ([]byte, []int) {
+	return file_apiservice_webhook_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WebhookEndpoint) GetId() *types.UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *WebhookEndpoint) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetSecret() string {
+	if x != nil && x.Secret != nil {
+		return *x.Secret
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *WebhookEndpoint) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateWebhookEndpointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *types.UUID            `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Url           *string                `protobuf:"bytes,2,opt,name=url" json:"url,omitempty"`
+	Secret        *string                `protobuf:"bytes,3,opt,name=secret" json:"secret,omitempty"`
+	EventTypes    []string               `protobuf:"bytes,4,rep,name=eventTypes" json:"eventTypes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookEndpointRequest) Reset() {
+	*x = CreateWebhookEndpointRequest{}
+	mi := &file_apiservice_webhook_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookEndpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookEndpointRequest) ProtoMessage() {}
+
+func (x *CreateWebhookEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_webhook_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookEndpointRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_webhook_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateWebhookEndpointRequest) GetId() *types.UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *CreateWebhookEndpointRequest) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookEndpointRequest) GetSecret() string {
+	if x != nil && x.Secret != nil {
+		return *x.Secret
+	}
+	return ""
+}
+
+func (x *CreateWebhookEndpointRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+type ListWebhookEndpointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoints     []*WebhookEndpoint     `protobuf:"bytes,1,rep,name=endpoints" json:"endpoints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookEndpointsResponse) Reset() {
+	*x = ListWebhookEndpointsResponse{}
+	mi := &file_apiservice_webhook_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookEndpointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookEndpointsResponse) ProtoMessage() {}
+
+func (x *ListWebhookEndpointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_webhook_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookEndpointsResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookEndpointsResponse) Descriptor() ([]byte, []int) {
+	return file_apiservice_webhook_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListWebhookEndpointsResponse) GetEndpoints() []*WebhookEndpoint {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+type DeleteWebhookEndpointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *types.UUID            `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookEndpointRequest) Reset() {
+	*x = DeleteWebhookEndpointRequest{}
+	mi := &file_apiservice_webhook_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookEndpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookEndpointRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_webhook_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookEndpointRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_webhook_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeleteWebhookEndpointRequest) GetId() *types.UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type WebhookDelivery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *int64                 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	EndpointId    *types.UUID            `protobuf:"bytes,2,opt,name=endpointId" json:"endpointId,omitempty"`
+	EventType     *string                `protobuf:"bytes,3,opt,name=eventType" json:"eventType,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,4,opt,name=payload" json:"payload,omitempty"`
+	Status        *WebhookDeliveryStatus `protobuf:"varint,5,opt,name=status,enum=protocol.api_service.storage.WebhookDeliveryStatus" json:"status,omitempty"`
+	Attempts      *int32                 `protobuf:"varint,6,opt,name=attempts" json:"attempts,omitempty"`
+	NextAttemptAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=nextAttemptAt" json:"nextAttemptAt,omitempty"`
+	LastError     *string                `protobuf:"bytes,8,opt,name=lastError" json:"lastError,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=createdAt" json:"createdAt,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updatedAt" json:"updatedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_apiservice_webhook_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_webhook_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_apiservice_webhook_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WebhookDelivery) GetId() int64 {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetEndpointId() *types.UUID {
+	if x != nil {
+		return x.EndpointId
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetEventType() string {
+	if x != nil && x.EventType != nil {
+		return *x.EventType
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetStatus() WebhookDeliveryStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return WebhookDeliveryStatus_Pending
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil && x.Attempts != nil {
+		return *x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil && x.LastError != nil {
+		return *x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type WebhookAttempt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attempt       *int32                 `protobuf:"varint,1,opt,name=attempt" json:"attempt,omitempty"`
+	StatusCode    *int32                 `protobuf:"varint,2,opt,name=statusCode" json:"statusCode,omitempty"`
+	Error         *string                `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	Response      *string                `protobuf:"bytes,4,opt,name=response" json:"response,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,5,opt,name=duration" json:"duration,omitempty"`
+	AttemptedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=attemptedAt" json:"attemptedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookAttempt) Reset() {
+	*x = WebhookAttempt{}
+	mi := &file_apiservice_webhook_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookAttempt) ProtoMessage() {}
+
+func (x *WebhookAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_webhook_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookAttempt.ProtoReflect.Descriptor instead.
+func (*WebhookAttempt) Descriptor() ([]byte, []int) {
+	return file_apiservice_webhook_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WebhookAttempt) GetAttempt() int32 {
+	if x != nil && x.Attempt != nil {
+		return *x.Attempt
+	}
+	return 0
+}
+
+func (x *WebhookAttempt) GetStatusCode() int32 {
+	if x != nil && x.StatusCode != nil {
+		return *x.StatusCode
+	}
+	return 0
+}
+
+func (x *WebhookAttempt) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *WebhookAttempt) GetResponse() string {
+	if x != nil && x.Response != nil {
+		return *x.Response
+	}
+	return ""
+}
+
+func (x *WebhookAttempt) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *WebhookAttempt) GetAttemptedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AttemptedAt
+	}
+	return nil
+}
+
+type ListWebhookDeliveriesRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	EndpointId *types.UUID            `protobuf:"bytes,1,opt,name=endpointId" json:"endpointId,omitempty"`
+	Status     *WebhookDeliveryStatus `protobuf:"varint,2,opt,name=status,enum=protocol.api_service.storage.WebhookDeliveryStatus" json:"status,omitempty"`
+	// Deliveries are listed from the newest, pass the smallest received id to get the next page.
+	BeforeId      *int64 `protobuf:"varint,3,opt,name=beforeId" json:"beforeId,omitempty"`
+	Limit         *int32 `protobuf:"varint,4,opt,name=limit" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_apiservice_webhook_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_webhook_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_webhook_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetEndpointId() *types.UUID {
+	if x != nil {
+		return x.EndpointId
+	}
+	return nil
+}
+
+func (x *ListWebhookDeliveriesRequest) GetStatus() WebhookDeliveryStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return WebhookDeliveryStatus_Pending
+}
+
+func (x *ListWebhookDeliveriesRequest) GetBeforeId() int64 {
+	if x != nil && x.BeforeId != nil {
+		return *x.BeforeId
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type ListWebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_apiservice_webhook_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_webhook_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_apiservice_webhook_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+type GetWebhookDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *int64                 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookDeliveryRequest) Reset() {
+	*x = GetWebhookDeliveryRequest{}
+	mi := &file_apiservice_webhook_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookDeliveryRequest) ProtoMessage() {}
+
+func (x *GetWebhookDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_webhook_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*GetWebhookDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_webhook_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetWebhookDeliveryRequest) GetId() int64 {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return 0
+}
+
+type GetWebhookDeliveryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delivery      *WebhookDelivery       `protobuf:"bytes,1,opt,name=delivery" json:"delivery,omitempty"`
+	Attempts      []*WebhookAttempt      `protobuf:"bytes,2,rep,name=attempts" json:"attempts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookDeliveryResponse) Reset() {
+	*x = GetWebhookDeliveryResponse{}
+	mi := &file_apiservice_webhook_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookDeliveryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookDeliveryResponse) ProtoMessage() {}
+
+func (x *GetWebhookDeliveryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_webhook_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookDeliveryResponse.ProtoReflect.Descriptor instead.
+func (*GetWebhookDeliveryResponse) Descriptor() ([]byte, []int) {
+	return file_apiservice_webhook_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetWebhookDeliveryResponse) GetDelivery() *WebhookDelivery {
+	if x != nil {
+		return x.Delivery
+	}
+	return nil
+}
+
+func (x *GetWebhookDeliveryResponse) GetAttempts() []*WebhookAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+type RedeliverWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *int64                 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeliverWebhookRequest) Reset() {
+	*x = RedeliverWebhookRequest{}
+	mi := &file_apiservice_webhook_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeliverWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeliverWebhookRequest) ProtoMessage() {}
+
+func (x *RedeliverWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiservice_webhook_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeliverWebhookRequest.ProtoReflect.Descriptor instead.
+func (*RedeliverWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_apiservice_webhook_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RedeliverWebhookRequest) GetId() int64 {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return 0
+}
+
+var File_apiservice_webhook_proto protoreflect.FileDescriptor
+
+const file_apiservice_webhook_proto_rawDesc = "" +
+	"\n" +
+	"\x18apiservice/webhook.proto\x12\x1cprotocol.api_service.storage\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x10types/uuid.proto\"\xbb\x01\n" +
+	"\x0fWebhookEndpoint\x12$\n" +
+	"\x02id\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x03 \x01(\tR\x06secret\x12\x1e\n" +
+	"\n" +
+	"eventTypes\x18\x04 \x03(\tR\n" +
+	"eventTypes\x128\n" +
+	"\tcreatedAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x8e\x01\n" +
+	"\x1cCreateWebhookEndpointRequest\x12$\n" +
+	"\x02id\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x03 \x01(\tR\x06secret\x12\x1e\n" +
+	"\n" +
+	"eventTypes\x18\x04 \x03(\tR\n" +
+	"eventTypes\"k\n" +
+	"\x1cListWebhookEndpointsResponse\x12K\n" +
+	"\tendpoints\x18\x01 \x03(\v2-.protocol.api_service.storage.WebhookEndpointR\tendpoints\"D\n" +
+	"\x1cDeleteWebhookEndpointRequest\x12$\n" +
+	"\x02id\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\x02id\"\xcc\x03\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x124\n" +
+	"\n" +
+	"endpointId\x18\x02 \x01(\v2\x14.protocol.types.UUIDR\n" +
+	"endpointId\x12\x1c\n" +
+	"\teventType\x18\x03 \x01(\tR\teventType\x12\x18\n" +
+	"\apayload\x18\x04 \x01(\fR\apayload\x12K\n" +
+	"\x06status\x18\x05 \x01(\x0e23.protocol.api_service.storage.WebhookDeliveryStatusR\x06status\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\x05R\battempts\x12@\n" +
+	"\rnextAttemptAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x12\x1c\n" +
+	"\tlastError\x18\b \x01(\tR\tlastError\x128\n" +
+	"\tcreatedAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
+	"\tupdatedAt\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf1\x01\n" +
+	"\x0eWebhookAttempt\x12\x18\n" +
+	"\aattempt\x18\x01 \x01(\x05R\aattempt\x12\x1e\n" +
+	"\n" +
+	"statusCode\x18\x02 \x01(\x05R\n" +
+	"statusCode\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1a\n" +
+	"\bresponse\x18\x04 \x01(\tR\bresponse\x125\n" +
+	"\bduration\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12<\n" +
+	"\vattemptedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vattemptedAt\"\xd3\x01\n" +
+	"\x1cListWebhookDeliveriesRequest\x124\n" +
+	"\n" +
+	"endpointId\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\n" +
+	"endpointId\x12K\n" +
+	"\x06status\x18\x02 \x01(\x0e23.protocol.api_service.storage.WebhookDeliveryStatusR\x06status\x12\x1a\n" +
+	"\bbeforeId\x18\x03 \x01(\x03R\bbeforeId\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"n\n" +
+	"\x1dListWebhookDeliveriesResponse\x12M\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2-.protocol.api_service.storage.WebhookDeliveryR\n" +
+	"deliveries\"+\n" +
+	"\x19GetWebhookDeliveryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xb1\x01\n" +
+	"\x1aGetWebhookDeliveryResponse\x12I\n" +
+	"\bdelivery\x18\x01 \x01(\v2-.protocol.api_service.storage.WebhookDeliveryR\bdelivery\x12H\n" +
+	"\battempts\x18\x02 \x03(\v2,.protocol.api_service.storage.WebhookAttemptR\battempts\")\n" +
+	"\x17RedeliverWebhookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id*=\n" +
+	"\x15WebhookDeliveryStatus\x12\v\n" +
+	"\aPending\x10\x00\x12\r\n" +
+	"\tSucceeded\x10\x01\x12\b\n" +
+	"\x04Dead\x10\x022\xda\x05\n" +
+	"\x0eWebhookStorage\x12{\n" +
+	"\x0eCreateEndpoint\x12:.protocol.api_service.storage.CreateWebhookEndpointRequest\x1a-.protocol.api_service.storage.WebhookEndpoint\x12c\n" +
+	"\rListEndpoints\x12\x16.google.protobuf.Empty\x1a:.protocol.api_service.storage.ListWebhookEndpointsResponse\x12d\n" +
+	"\x0eDeleteEndpoint\x12:.protocol.api_service.storage.DeleteWebhookEndpointRequest\x1a\x16.google.protobuf.Empty\x12\x89\x01\n" +
+	"\x0eListDeliveries\x12:.protocol.api_service.storage.ListWebhookDeliveriesRequest\x1a;.protocol.api_service.storage.ListWebhookDeliveriesResponse\x12\x80\x01\n" +
+	"\vGetDelivery\x127.protocol.api_service.storage.GetWebhookDeliveryRequest\x1a8.protocol.api_service.storage.GetWebhookDeliveryResponse\x12q\n" +
+	"\tRedeliver\x125.protocol.api_service.storage.RedeliverWebhookRequest\x1a-.protocol.api_service.storage.WebhookDeliveryB5Z3go-invoice-service/common/protocol/proto/apiserviceb\beditionsp\xe8\a"
+
+var (
+	file_apiservice_webhook_proto_rawDescOnce sync.Once
+	file_apiservice_webhook_proto_rawDescData []byte
+)
+
+func file_apiservice_webhook_proto_rawDescGZIP() []byte {
+	file_apiservice_webhook_proto_rawDescOnce.Do(func() {
+		file_apiservice_webhook_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiservice_webhook_proto_rawDesc), len(file_apiservice_webhook_proto_rawDesc)))
+	})
+	return file_apiservice_webhook_proto_rawDescData
+}
+
+var file_apiservice_webhook_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_apiservice_webhook_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_apiservice_webhook_proto_goTypes = []any{
+	(WebhookDeliveryStatus)(0),            // 0: protocol.api_service.storage.WebhookDeliveryStatus
+	(*WebhookEndpoint)(nil),               // 1: protocol.api_service.storage.WebhookEndpoint
+	(*CreateWebhookEndpointRequest)(nil),  // 2: protocol.api_service.storage.CreateWebhookEndpointRequest
+	(*ListWebhookEndpointsResponse)(nil),  // 3: protocol.api_service.storage.ListWebhookEndpointsResponse
+	(*DeleteWebhookEndpointRequest)(nil),  // 4: protocol.api_service.storage.DeleteWebhookEndpointRequest
+	(*WebhookDelivery)(nil),               // 5: protocol.api_service.storage.WebhookDelivery
+	(*WebhookAttempt)(nil),                // 6: protocol.api_service.storage.WebhookAttempt
+	(*ListWebhookDeliveriesRequest)(nil),  // 7: protocol.api_service.storage.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil), // 8: protocol.api_service.storage.ListWebhookDeliveriesResponse
+	(*GetWebhookDeliveryRequest)(nil),     // 9: protocol.api_service.storage.GetWebhookDeliveryRequest
+	(*GetWebhookDeliveryResponse)(nil),    // 10: protocol.api_service.storage.GetWebhookDeliveryResponse
+	(*RedeliverWebhookRequest)(nil),       // 11: protocol.api_service.storage.RedeliverWebhookRequest
+	(*types.UUID)(nil),                    // 12: protocol.types.UUID
+	(*timestamppb.Timestamp)(nil),         // 13: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),           // 14: google.protobuf.Duration
+	(*emptypb.Empty)(nil),                 // 15: google.protobuf.Empty
+}
+var file_apiservice_webhook_proto_depIdxs = []int32{
+	12, // 0: protocol.api_service.storage.WebhookEndpoint.id:type_name -> protocol.types.UUID
+	13, // 1: protocol.api_service.storage.WebhookEndpoint.createdAt:type_name -> google.protobuf.Timestamp
+	12, // 2: protocol.api_service.storage.CreateWebhookEndpointRequest.id:type_name -> protocol.types.UUID
+	1,  // 3: protocol.api_service.storage.ListWebhookEndpointsResponse.endpoints:type_name -> protocol.api_service.storage.WebhookEndpoint
+	12, // 4: protocol.api_service.storage.DeleteWebhookEndpointRequest.id:type_name -> protocol.types.UUID
+	12, // 5: protocol.api_service.storage.WebhookDelivery.endpointId:type_name -> protocol.types.UUID
+	0,  // 6: protocol.api_service.storage.WebhookDelivery.status:type_name -> protocol.api_service.storage.WebhookDeliveryStatus
+	13, // 7: protocol.api_service.storage.WebhookDelivery.nextAttemptAt:type_name -> google.protobuf.Timestamp
+	13, // 8: protocol.api_service.storage.WebhookDelivery.createdAt:type_name -> google.protobuf.Timestamp
+	13, // 9: protocol.api_service.storage.WebhookDelivery.updatedAt:type_name -> google.protobuf.Timestamp
+	14, // 10: protocol.api_service.storage.WebhookAttempt.duration:type_name -> google.protobuf.Duration
+	13, // 11: protocol.api_service.storage.WebhookAttempt.attemptedAt:type_name -> google.protobuf.Timestamp
+	12, // 12: protocol.api_service.storage.ListWebhookDeliveriesRequest.endpointId:type_name -> protocol.types.UUID
+	0,  // 13: protocol.api_service.storage.ListWebhookDeliveriesRequest.status:type_name -> protocol.api_service.storage.WebhookDeliveryStatus
+	5,  // 14: protocol.api_service.storage.ListWebhookDeliveriesResponse.deliveries:type_name -> protocol.api_service.storage.WebhookDelivery
+	5,  // 15: protocol.api_service.storage.GetWebhookDeliveryResponse.delivery:type_name -> protocol.api_service.storage.WebhookDelivery
+	6,  // 16: protocol.api_service.storage.GetWebhookDeliveryResponse.attempts:type_name -> protocol.api_service.storage.WebhookAttempt
+	2,  // 17: protocol.api_service.storage.WebhookStorage.CreateEndpoint:input_type -> protocol.api_service.storage.CreateWebhookEndpointRequest
+	15, // 18: protocol.api_service.storage.WebhookStorage.ListEndpoints:input_type -> google.protobuf.Empty
+	4,  // 19: protocol.api_service.storage.WebhookStorage.DeleteEndpoint:input_type -> protocol.api_service.storage.DeleteWebhookEndpointRequest
+	7,  // 20: protocol.api_service.storage.WebhookStorage.ListDeliveries:input_type -> protocol.api_service.storage.ListWebhookDeliveriesRequest
+	9,  // 21: protocol.api_service.storage.WebhookStorage.GetDelivery:input_type -> protocol.api_service.storage.GetWebhookDeliveryRequest
+	11, // 22: protocol.api_service.storage.WebhookStorage.Redeliver:input_type -> protocol.api_service.storage.RedeliverWebhookRequest
+	1,  // 23: protocol.api_service.storage.WebhookStorage.CreateEndpoint:output_type -> protocol.api_service.storage.WebhookEndpoint
+	3,  // 24: protocol.api_service.storage.WebhookStorage.ListEndpoints:output_type -> protocol.api_service.storage.ListWebhookEndpointsResponse
+	15, // 25: protocol.api_service.storage.WebhookStorage.DeleteEndpoint:output_type -> google.protobuf.Empty
+	8,  // 26: protocol.api_service.storage.WebhookStorage.ListDeliveries:output_type -> protocol.api_service.storage.ListWebhookDeliveriesResponse
+	10, // 27: protocol.api_service.storage.WebhookStorage.GetDelivery:output_type -> protocol.api_service.storage.GetWebhookDeliveryResponse
+	5,  // 28: protocol.api_service.storage.WebhookStorage.Redeliver:output_type -> protocol.api_service.storage.WebhookDelivery
+	23, // [23:29] is the sub-list for method output_type
+	17, // [17:23] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_apiservice_webhook_proto_init() }
+func file_apiservice_webhook_proto_init() {
+	if File_apiservice_webhook_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiservice_webhook_proto_rawDesc), len(file_apiservice_webhook_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_apiservice_webhook_proto_goTypes,
+		DependencyIndexes: file_apiservice_webhook_proto_depIdxs,
+		EnumInfos:         file_apiservice_webhook_proto_enumTypes,
+		MessageInfos:      file_apiservice_webhook_proto_msgTypes,
+	}.Build()
+	File_apiservice_webhook_proto = out.File
+	file_apiservice_webhook_proto_goTypes = nil
+	file_apiservice_webhook_proto_depIdxs = nil
+}
@@ -0,0 +1,149 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: events/invoice-approved.proto
+
+package events
+
+import (
+	types "go-invoice-service/common/protocol/proto/types"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type InvoiceApproved struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *EventMetadata         `protobuf:"bytes,1,opt,name=metadata" json:"metadata,omitempty"`
+	Id            *types.UUID            `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
+	Invoice       *InvoiceSnapshot       `protobuf:"bytes,3,opt,name=invoice" json:"invoice,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvoiceApproved) Reset() {
+	*x = InvoiceApproved{}
+	mi := &file_events_invoice_approved_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoiceApproved) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceApproved) ProtoMessage() {}
+
+func (x *InvoiceApproved) ProtoReflect() protoreflect.Message {
+	mi := &file_events_invoice_approved_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceApproved.ProtoReflect.Descriptor instead.
+func (*InvoiceApproved) Descriptor() ([]byte, []int) {
+	return file_events_invoice_approved_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *InvoiceApproved) GetMetadata() *EventMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *InvoiceApproved) GetId() *types.UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *InvoiceApproved) GetInvoice() *InvoiceSnapshot {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+var File_events_invoice_approved_proto protoreflect.FileDescriptor
+
+const file_events_invoice_approved_proto_rawDesc = "" +
+	"\n" +
+	"\x1devents/invoice-approved.proto\x12\x0fprotocol.events\x1a\x1devents/invoice-snapshot.proto\x1a\x15events/metadata.proto\x1a\x10types/uuid.proto\"\xaf\x01\n" +
+	"\x0fInvoiceApproved\x12:\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x1e.protocol.events.EventMetadataR\bmetadata\x12$\n" +
+	"\x02id\x18\x02 \x01(\v2\x14.protocol.types.UUIDR\x02id\x12:\n" +
+	"\ainvoice\x18\x03 \x01(\v2 .protocol.events.InvoiceSnapshotR\ainvoiceB1Z/go-invoice-service/common/protocol/proto/eventsb\beditionsp\xe8\a"
+
+var (
+	file_events_invoice_approved_proto_rawDescOnce sync.Once
+	file_events_invoice_approved_proto_rawDescData []byte
+)
+
+func file_events_invoice_approved_proto_rawDescGZIP() []byte {
+	file_events_invoice_approved_proto_rawDescOnce.Do(func() {
+		file_events_invoice_approved_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_invoice_approved_proto_rawDesc), len(file_events_invoice_approved_proto_rawDesc)))
+	})
+	return file_events_invoice_approved_proto_rawDescData
+}
+
+var file_events_invoice_approved_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_events_invoice_approved_proto_goTypes = []any{
+	(*InvoiceApproved)(nil), // 0: protocol.events.InvoiceApproved
+	(*EventMetadata)(nil),   // 1: protocol.events.EventMetadata
+	(*types.UUID)(nil),      // 2: protocol.types.UUID
+	(*InvoiceSnapshot)(nil), // 3: protocol.events.InvoiceSnapshot
+}
+var file_events_invoice_approved_proto_depIdxs = []int32{
+	1, // 0: protocol.events.InvoiceApproved.metadata:type_name -> protocol.events.EventMetadata
+	2, // 1: protocol.events.InvoiceApproved.id:type_name -> protocol.types.UUID
+	3, // 2: protocol.events.InvoiceApproved.invoice:type_name -> protocol.events.InvoiceSnapshot
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_events_invoice_approved_proto_init() }
+func file_events_invoice_approved_proto_init() {
+	if File_events_invoice_approved_proto != nil {
+		return
+	}
+	file_events_invoice_snapshot_proto_init()
+	file_events_metadata_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_invoice_approved_proto_rawDesc), len(file_events_invoice_approved_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_invoice_approved_proto_goTypes,
+		DependencyIndexes: file_events_invoice_approved_proto_depIdxs,
+		MessageInfos:      file_events_invoice_approved_proto_msgTypes,
+	}.Build()
+	File_events_invoice_approved_proto = out.File
+	file_events_invoice_approved_proto_goTypes = nil
+	file_events_invoice_approved_proto_depIdxs = nil
+}
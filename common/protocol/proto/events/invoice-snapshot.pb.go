@@ -0,0 +1,209 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: events/invoice-snapshot.proto
+
+package events
+
+import (
+	types "go-invoice-service/common/protocol/proto/types"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type InvoiceSnapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    *types.UUID            `protobuf:"bytes,1,opt,name=customer_id,json=customerId" json:"customer_id,omitempty"`
+	Amount        *int64                 `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+	Currency      *string                `protobuf:"bytes,3,opt,name=currency" json:"currency,omitempty"`
+	DueDate       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=due_date,json=dueDate" json:"due_date,omitempty"`
+	Status        *types.InvoiceStatus   `protobuf:"varint,5,opt,name=status,enum=protocol.types.InvoiceStatus" json:"status,omitempty"`
+	Items         []*types.Item          `protobuf:"bytes,6,rep,name=items" json:"items,omitempty"`
+	Notes         *string                `protobuf:"bytes,7,opt,name=notes" json:"notes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvoiceSnapshot) Reset() {
+	*x = InvoiceSnapshot{}
+	mi := &file_events_invoice_snapshot_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoiceSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceSnapshot) ProtoMessage() {}
+
+func (x *InvoiceSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_events_invoice_snapshot_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceSnapshot.ProtoReflect.Descriptor instead.
+func (*InvoiceSnapshot) Descriptor() ([]byte, []int) {
+	return file_events_invoice_snapshot_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *InvoiceSnapshot) GetCustomerId() *types.UUID {
+	if x != nil {
+		return x.CustomerId
+	}
+	return nil
+}
+
+func (x *InvoiceSnapshot) GetAmount() int64 {
+	if x != nil && x.Amount != nil {
+		return *x.Amount
+	}
+	return 0
+}
+
+func (x *InvoiceSnapshot) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *InvoiceSnapshot) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *InvoiceSnapshot) GetStatus() types.InvoiceStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return types.InvoiceStatus(0)
+}
+
+func (x *InvoiceSnapshot) GetItems() []*types.Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *InvoiceSnapshot) GetNotes() string {
+	if x != nil && x.Notes != nil {
+		return *x.Notes
+	}
+	return ""
+}
+
+func (x *InvoiceSnapshot) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *InvoiceSnapshot) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+var File_events_invoice_snapshot_proto protoreflect.FileDescriptor
+
+const file_events_invoice_snapshot_proto_rawDesc = "" +
+	"\n" +
+	"\x1devents/invoice-snapshot.proto\x12\x0fprotocol.events\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13types/invoice.proto\x1a\x10types/uuid.proto\"\xa2\x03\n" +
+	"\x0fInvoiceSnapshot\x125\n" +
+	"\vcustomer_id\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\n" +
+	"customerId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x125\n" +
+	"\bdue_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x125\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1d.protocol.types.InvoiceStatusR\x06status\x12*\n" +
+	"\x05items\x18\x06 \x03(\v2\x14.protocol.types.ItemR\x05items\x12\x14\n" +
+	"\x05notes\x18\a \x01(\tR\x05notes\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB1Z/go-invoice-service/common/protocol/proto/eventsb\beditionsp\xe8\a"
+
+var (
+	file_events_invoice_snapshot_proto_rawDescOnce sync.Once
+	file_events_invoice_snapshot_proto_rawDescData []byte
+)
+
+func file_events_invoice_snapshot_proto_rawDescGZIP() []byte {
+	file_events_invoice_snapshot_proto_rawDescOnce.Do(func() {
+		file_events_invoice_snapshot_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_invoice_snapshot_proto_rawDesc), len(file_events_invoice_snapshot_proto_rawDesc)))
+	})
+	return file_events_invoice_snapshot_proto_rawDescData
+}
+
+var file_events_invoice_snapshot_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_events_invoice_snapshot_proto_goTypes = []any{
+	(*InvoiceSnapshot)(nil),       // 0: protocol.events.InvoiceSnapshot
+	(*types.UUID)(nil),            // 1: protocol.types.UUID
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(types.InvoiceStatus)(0),      // 3: protocol.types.InvoiceStatus
+	(*types.Item)(nil),            // 4: protocol.types.Item
+}
+var file_events_invoice_snapshot_proto_depIdxs = []int32{
+	1, // 0: protocol.events.InvoiceSnapshot.customer_id:type_name -> protocol.types.UUID
+	2, // 1: protocol.events.InvoiceSnapshot.due_date:type_name -> google.protobuf.Timestamp
+	3, // 2: protocol.events.InvoiceSnapshot.status:type_name -> protocol.types.InvoiceStatus
+	4, // 3: protocol.events.InvoiceSnapshot.items:type_name -> protocol.types.Item
+	2, // 4: protocol.events.InvoiceSnapshot.created_at:type_name -> google.protobuf.Timestamp
+	2, // 5: protocol.events.InvoiceSnapshot.updated_at:type_name -> google.protobuf.Timestamp
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_events_invoice_snapshot_proto_init() }
+func file_events_invoice_snapshot_proto_init() {
+	if File_events_invoice_snapshot_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_invoice_snapshot_proto_rawDesc), len(file_events_invoice_snapshot_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_invoice_snapshot_proto_goTypes,
+		DependencyIndexes: file_events_invoice_snapshot_proto_depIdxs,
+		MessageInfos:      file_events_invoice_snapshot_proto_msgTypes,
+	}.Build()
+	File_events_invoice_snapshot_proto = out.File
+	file_events_invoice_snapshot_proto_goTypes = nil
+	file_events_invoice_snapshot_proto_depIdxs = nil
+}
@@ -0,0 +1,171 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: events/metadata.proto
+
+package events
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EventMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       *string                `protobuf:"bytes,1,opt,name=event_id,json=eventId" json:"event_id,omitempty"`
+	Type          *string                `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
+	SchemaVersion *int32                 `protobuf:"varint,3,opt,name=schema_version,json=schemaVersion" json:"schema_version,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt" json:"occurred_at,omitempty"`
+	Tenant        *string                `protobuf:"bytes,5,opt,name=tenant" json:"tenant,omitempty"`
+	CorrelationId *string                `protobuf:"bytes,6,opt,name=correlation_id,json=correlationId" json:"correlation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventMetadata) Reset() {
+	*x = EventMetadata{}
+	mi := &file_events_metadata_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventMetadata) ProtoMessage() {}
+
+func (x *EventMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_events_metadata_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventMetadata.ProtoReflect.Descriptor instead.
+func (*EventMetadata) Descriptor() ([]byte, []int) {
+	return file_events_metadata_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EventMetadata) GetEventId() string {
+	if x != nil && x.EventId != nil {
+		return *x.EventId
+	}
+	return ""
+}
+
+func (x *EventMetadata) GetType() string {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ""
+}
+
+func (x *EventMetadata) GetSchemaVersion() int32 {
+	if x != nil && x.SchemaVersion != nil {
+		return *x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *EventMetadata) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *EventMetadata) GetTenant() string {
+	if x != nil && x.Tenant != nil {
+		return *x.Tenant
+	}
+	return ""
+}
+
+func (x *EventMetadata) GetCorrelationId() string {
+	if x != nil && x.CorrelationId != nil {
+		return *x.CorrelationId
+	}
+	return ""
+}
+
+var File_events_metadata_proto protoreflect.FileDescriptor
+
+const file_events_metadata_proto_rawDesc = "" +
+	"\n" +
+	"\x15events/metadata.proto\x12\x0fprotocol.events\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe1\x01\n" +
+	"\rEventMetadata\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12%\n" +
+	"\x0eschema_version\x18\x03 \x01(\x05R\rschemaVersion\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x16\n" +
+	"\x06tenant\x18\x05 \x01(\tR\x06tenant\x12%\n" +
+	"\x0ecorrelation_id\x18\x06 \x01(\tR\rcorrelationIdB1Z/go-invoice-service/common/protocol/proto/eventsb\beditionsp\xe8\a"
+
+var (
+	file_events_metadata_proto_rawDescOnce sync.Once
+	file_events_metadata_proto_rawDescData []byte
+)
+
+func file_events_metadata_proto_rawDescGZIP() []byte {
+	file_events_metadata_proto_rawDescOnce.Do(func() {
+		file_events_metadata_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_metadata_proto_rawDesc), len(file_events_metadata_proto_rawDesc)))
+	})
+	return file_events_metadata_proto_rawDescData
+}
+
+var file_events_metadata_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_events_metadata_proto_goTypes = []any{
+	(*EventMetadata)(nil),         // 0: protocol.events.EventMetadata
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_events_metadata_proto_depIdxs = []int32{
+	1, // 0: protocol.events.EventMetadata.occurred_at:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_events_metadata_proto_init() }
+func file_events_metadata_proto_init() {
+	if File_events_metadata_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_metadata_proto_rawDesc), len(file_events_metadata_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_metadata_proto_goTypes,
+		DependencyIndexes: file_events_metadata_proto_depIdxs,
+		MessageInfos:      file_events_metadata_proto_msgTypes,
+	}.Build()
+	File_events_metadata_proto = out.File
+	file_events_metadata_proto_goTypes = nil
+	file_events_metadata_proto_depIdxs = nil
+}
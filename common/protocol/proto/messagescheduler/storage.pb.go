@@ -12,6 +12,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -25,9 +26,12 @@ const (
 )
 
 type GetMessagesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MaxCount      *int32                 `protobuf:"varint,1,opt,name=maxCount" json:"maxCount,omitempty"`
-	RetryAfter    *durationpb.Duration   `protobuf:"bytes,2,opt,name=retryAfter" json:"retryAfter,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MaxCount *int32                 `protobuf:"varint,1,opt,name=maxCount" json:"maxCount,omitempty"`
+	// Messages are leased to leaseOwner for retryAfter, and sent to other owners only
+	// after the lease expires.
+	RetryAfter    *durationpb.Duration `protobuf:"bytes,2,opt,name=retryAfter" json:"retryAfter,omitempty"`
+	LeaseOwner    *string              `protobuf:"bytes,3,opt,name=leaseOwner" json:"leaseOwner,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -76,11 +80,20 @@ func (x *GetMessagesRequest) GetRetryAfter() *durationpb.Duration {
 	return nil
 }
 
+func (x *GetMessagesRequest) GetLeaseOwner() string {
+	if x != nil && x.LeaseOwner != nil {
+		return *x.LeaseOwner
+	}
+	return ""
+}
+
 type GetMessagesResponse struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	OutboxMessages []*types.OutboxMessage `protobuf:"bytes,1,rep,name=outboxMessages" json:"outboxMessages,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// backlog is the number of messages due for sending left after this batch.
+	Backlog       *int64 `protobuf:"varint,2,opt,name=backlog" json:"backlog,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetMessagesResponse) Reset() {
@@ -120,9 +133,17 @@ func (x *GetMessagesResponse) GetOutboxMessages() []*types.OutboxMessage {
 	return nil
 }
 
+func (x *GetMessagesResponse) GetBacklog() int64 {
+	if x != nil && x.Backlog != nil {
+		return *x.Backlog
+	}
+	return 0
+}
+
 type DeleteMessageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            *int64                 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	LeaseOwner    *string                `protobuf:"bytes,2,opt,name=leaseOwner" json:"leaseOwner,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -164,23 +185,562 @@ func (x *DeleteMessageRequest) GetId() int64 {
 	return 0
 }
 
+func (x *DeleteMessageRequest) GetLeaseOwner() string {
+	if x != nil && x.LeaseOwner != nil {
+		return *x.LeaseOwner
+	}
+	return ""
+}
+
+type DeleteBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids" json:"ids,omitempty"`
+	LeaseOwner    *string                `protobuf:"bytes,2,opt,name=leaseOwner" json:"leaseOwner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBatchRequest) Reset() {
+	*x = DeleteBatchRequest{}
+	mi := &file_messagescheduler_storage_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBatchRequest) ProtoMessage() {}
+
+func (x *DeleteBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_messagescheduler_storage_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBatchRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBatchRequest) Descriptor() ([]byte, []int) {
+	return file_messagescheduler_storage_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeleteBatchRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *DeleteBatchRequest) GetLeaseOwner() string {
+	if x != nil && x.LeaseOwner != nil {
+		return *x.LeaseOwner
+	}
+	return ""
+}
+
+type DeleteBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Messages not deleted because they are leased to another owner now.
+	RejectedIds   []int64 `protobuf:"varint,1,rep,packed,name=rejectedIds" json:"rejectedIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBatchResponse) Reset() {
+	*x = DeleteBatchResponse{}
+	mi := &file_messagescheduler_storage_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBatchResponse) ProtoMessage() {}
+
+func (x *DeleteBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_messagescheduler_storage_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBatchResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBatchResponse) Descriptor() ([]byte, []int) {
+	return file_messagescheduler_storage_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteBatchResponse) GetRejectedIds() []int64 {
+	if x != nil {
+		return x.RejectedIds
+	}
+	return nil
+}
+
+type ReleaseMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids" json:"ids,omitempty"`
+	LeaseOwner    *string                `protobuf:"bytes,2,opt,name=leaseOwner" json:"leaseOwner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseMessagesRequest) Reset() {
+	*x = ReleaseMessagesRequest{}
+	mi := &file_messagescheduler_storage_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseMessagesRequest) ProtoMessage() {}
+
+func (x *ReleaseMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_messagescheduler_storage_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_messagescheduler_storage_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReleaseMessagesRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *ReleaseMessagesRequest) GetLeaseOwner() string {
+	if x != nil && x.LeaseOwner != nil {
+		return *x.LeaseOwner
+	}
+	return ""
+}
+
+type ReleaseMessagesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Messages leased to another owner now are not released.
+	ReleasedCount *int64 `protobuf:"varint,1,opt,name=releasedCount" json:"releasedCount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseMessagesResponse) Reset() {
+	*x = ReleaseMessagesResponse{}
+	mi := &file_messagescheduler_storage_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseMessagesResponse) ProtoMessage() {}
+
+func (x *ReleaseMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_messagescheduler_storage_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_messagescheduler_storage_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReleaseMessagesResponse) GetReleasedCount() int64 {
+	if x != nil && x.ReleasedCount != nil {
+		return *x.ReleasedCount
+	}
+	return 0
+}
+
+type FailMessageRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         *int64                 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	LeaseOwner *string                `protobuf:"bytes,2,opt,name=leaseOwner" json:"leaseOwner,omitempty"`
+	Error      *string                `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	// A parked message is not sent again until it is requeued.
+	Park          *bool                  `protobuf:"varint,4,opt,name=park" json:"park,omitempty"`
+	NextSendAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=nextSendAt" json:"nextSendAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FailMessageRequest) Reset() {
+	*x = FailMessageRequest{}
+	mi := &file_messagescheduler_storage_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FailMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailMessageRequest) ProtoMessage() {}
+
+func (x *FailMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_messagescheduler_storage_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailMessageRequest.ProtoReflect.Descriptor instead.
+func (*FailMessageRequest) Descriptor() ([]byte, []int) {
+	return file_messagescheduler_storage_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FailMessageRequest) GetId() int64 {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return 0
+}
+
+func (x *FailMessageRequest) GetLeaseOwner() string {
+	if x != nil && x.LeaseOwner != nil {
+		return *x.LeaseOwner
+	}
+	return ""
+}
+
+func (x *FailMessageRequest) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *FailMessageRequest) GetPark() bool {
+	if x != nil && x.Park != nil {
+		return *x.Park
+	}
+	return false
+}
+
+func (x *FailMessageRequest) GetNextSendAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextSendAt
+	}
+	return nil
+}
+
+// ReplayMessagesRequest selects published messages, kept in the outbox or archived,
+// by the topic, the invoice and the time they were created. Empty filters match all.
+type ReplayMessagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Messages with an id greater than afterId are replayed in the order of ids.
+	AfterId       *int64                 `protobuf:"varint,1,opt,name=afterId" json:"afterId,omitempty"`
+	MaxCount      *int32                 `protobuf:"varint,2,opt,name=maxCount" json:"maxCount,omitempty"`
+	Topic         *string                `protobuf:"bytes,3,opt,name=topic" json:"topic,omitempty"`
+	AggregateId   *types.UUID            `protobuf:"bytes,4,opt,name=aggregateId" json:"aggregateId,omitempty"`
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=createdFrom" json:"createdFrom,omitempty"`
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=createdTo" json:"createdTo,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayMessagesRequest) Reset() {
+	*x = ReplayMessagesRequest{}
+	mi := &file_messagescheduler_storage_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayMessagesRequest) ProtoMessage() {}
+
+func (x *ReplayMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_messagescheduler_storage_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ReplayMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_messagescheduler_storage_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReplayMessagesRequest) GetAfterId() int64 {
+	if x != nil && x.AfterId != nil {
+		return *x.AfterId
+	}
+	return 0
+}
+
+func (x *ReplayMessagesRequest) GetMaxCount() int32 {
+	if x != nil && x.MaxCount != nil {
+		return *x.MaxCount
+	}
+	return 0
+}
+
+func (x *ReplayMessagesRequest) GetTopic() string {
+	if x != nil && x.Topic != nil {
+		return *x.Topic
+	}
+	return ""
+}
+
+func (x *ReplayMessagesRequest) GetAggregateId() *types.UUID {
+	if x != nil {
+		return x.AggregateId
+	}
+	return nil
+}
+
+func (x *ReplayMessagesRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ReplayMessagesRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+type ReplayMessagesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Count *int32                 `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+	// The id of the last replayed message, to continue from.
+	LastId        *int64 `protobuf:"varint,2,opt,name=lastId" json:"lastId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayMessagesResponse) Reset() {
+	*x = ReplayMessagesResponse{}
+	mi := &file_messagescheduler_storage_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayMessagesResponse) ProtoMessage() {}
+
+func (x *ReplayMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_messagescheduler_storage_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ReplayMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_messagescheduler_storage_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReplayMessagesResponse) GetCount() int32 {
+	if x != nil && x.Count != nil {
+		return *x.Count
+	}
+	return 0
+}
+
+func (x *ReplayMessagesResponse) GetLastId() int64 {
+	if x != nil && x.LastId != nil {
+		return *x.LastId
+	}
+	return 0
+}
+
+type WatchOutboxRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOutboxRequest) Reset() {
+	*x = WatchOutboxRequest{}
+	mi := &file_messagescheduler_storage_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOutboxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOutboxRequest) ProtoMessage() {}
+
+func (x *WatchOutboxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_messagescheduler_storage_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOutboxRequest.ProtoReflect.Descriptor instead.
+func (*WatchOutboxRequest) Descriptor() ([]byte, []int) {
+	return file_messagescheduler_storage_proto_rawDescGZIP(), []int{10}
+}
+
+// OutboxSignal tells that messages may be ready to be sent.
+type OutboxSignal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OutboxSignal) Reset() {
+	*x = OutboxSignal{}
+	mi := &file_messagescheduler_storage_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutboxSignal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutboxSignal) ProtoMessage() {}
+
+func (x *OutboxSignal) ProtoReflect() protoreflect.Message {
+	mi := &file_messagescheduler_storage_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutboxSignal.ProtoReflect.Descriptor instead.
+func (*OutboxSignal) Descriptor() ([]byte, []int) {
+	return file_messagescheduler_storage_proto_rawDescGZIP(), []int{11}
+}
+
 var File_messagescheduler_storage_proto protoreflect.FileDescriptor
 
 const file_messagescheduler_storage_proto_rawDesc = "" +
 	"\n" +
-	"\x1emessagescheduler/storage.proto\x12#protocol.messages_scheduler.storage\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1atypes/outbox-message.proto\"k\n" +
+	"\x1emessagescheduler/storage.proto\x12#protocol.messages_scheduler.storage\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1atypes/outbox-message.proto\x1a\x10types/uuid.proto\"\x8b\x01\n" +
 	"\x12GetMessagesRequest\x12\x1a\n" +
 	"\bmaxCount\x18\x01 \x01(\x05R\bmaxCount\x129\n" +
 	"\n" +
 	"retryAfter\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\n" +
-	"retryAfter\"\\\n" +
+	"retryAfter\x12\x1e\n" +
+	"\n" +
+	"leaseOwner\x18\x03 \x01(\tR\n" +
+	"leaseOwner\"v\n" +
 	"\x13GetMessagesResponse\x12E\n" +
-	"\x0eoutboxMessages\x18\x01 \x03(\v2\x1d.protocol.types.OutboxMessageR\x0eoutboxMessages\"&\n" +
+	"\x0eoutboxMessages\x18\x01 \x03(\v2\x1d.protocol.types.OutboxMessageR\x0eoutboxMessages\x12\x18\n" +
+	"\abacklog\x18\x02 \x01(\x03R\abacklog\"F\n" +
 	"\x14DeleteMessageRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id2\xe6\x01\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1e\n" +
+	"\n" +
+	"leaseOwner\x18\x02 \x01(\tR\n" +
+	"leaseOwner\"F\n" +
+	"\x12DeleteBatchRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\x12\x1e\n" +
+	"\n" +
+	"leaseOwner\x18\x02 \x01(\tR\n" +
+	"leaseOwner\"7\n" +
+	"\x13DeleteBatchResponse\x12 \n" +
+	"\vrejectedIds\x18\x01 \x03(\x03R\vrejectedIds\"J\n" +
+	"\x16ReleaseMessagesRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\x12\x1e\n" +
+	"\n" +
+	"leaseOwner\x18\x02 \x01(\tR\n" +
+	"leaseOwner\"?\n" +
+	"\x17ReleaseMessagesResponse\x12$\n" +
+	"\rreleasedCount\x18\x01 \x01(\x03R\rreleasedCount\"\xaa\x01\n" +
+	"\x12FailMessageRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1e\n" +
+	"\n" +
+	"leaseOwner\x18\x02 \x01(\tR\n" +
+	"leaseOwner\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x12\n" +
+	"\x04park\x18\x04 \x01(\bR\x04park\x12:\n" +
+	"\n" +
+	"nextSendAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"nextSendAt\"\x93\x02\n" +
+	"\x15ReplayMessagesRequest\x12\x18\n" +
+	"\aafterId\x18\x01 \x01(\x03R\aafterId\x12\x1a\n" +
+	"\bmaxCount\x18\x02 \x01(\x05R\bmaxCount\x12\x14\n" +
+	"\x05topic\x18\x03 \x01(\tR\x05topic\x126\n" +
+	"\vaggregateId\x18\x04 \x01(\v2\x14.protocol.types.UUIDR\vaggregateId\x12<\n" +
+	"\vcreatedFrom\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x128\n" +
+	"\tcreatedTo\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\"F\n" +
+	"\x16ReplayMessagesResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x16\n" +
+	"\x06lastId\x18\x02 \x01(\x03R\x06lastId\"\x14\n" +
+	"\x12WatchOutboxRequest\"\x0e\n" +
+	"\fOutboxSignal2\xca\x06\n" +
 	"\rOutboxStorage\x12x\n" +
 	"\x03Get\x127.protocol.messages_scheduler.storage.GetMessagesRequest\x1a8.protocol.messages_scheduler.storage.GetMessagesResponse\x12[\n" +
-	"\x06Delete\x129.protocol.messages_scheduler.storage.DeleteMessageRequest\x1a\x16.google.protobuf.EmptyB;Z9go-invoice-service/common/protocol/proto/messageschedulerb\beditionsp\xe8\a"
+	"\x06Delete\x129.protocol.messages_scheduler.storage.DeleteMessageRequest\x1a\x16.google.protobuf.Empty\x12\x80\x01\n" +
+	"\vDeleteBatch\x127.protocol.messages_scheduler.storage.DeleteBatchRequest\x1a8.protocol.messages_scheduler.storage.DeleteBatchResponse\x12\x84\x01\n" +
+	"\aRelease\x12;.protocol.messages_scheduler.storage.ReleaseMessagesRequest\x1a<.protocol.messages_scheduler.storage.ReleaseMessagesResponse\x12W\n" +
+	"\x04Fail\x127.protocol.messages_scheduler.storage.FailMessageRequest\x1a\x16.google.protobuf.Empty\x12{\n" +
+	"\vWatchOutbox\x127.protocol.messages_scheduler.storage.WatchOutboxRequest\x1a1.protocol.messages_scheduler.storage.OutboxSignal0\x01\x12\x81\x01\n" +
+	"\x06Replay\x12:.protocol.messages_scheduler.storage.ReplayMessagesRequest\x1a;.protocol.messages_scheduler.storage.ReplayMessagesResponseB;Z9go-invoice-service/common/protocol/proto/messageschedulerb\beditionsp\xe8\a"
 
 var (
 	file_messagescheduler_storage_proto_rawDescOnce sync.Once
@@ -194,27 +754,52 @@ func file_messagescheduler_storage_proto_rawDescGZIP() []byte {
 	return file_messagescheduler_storage_proto_rawDescData
 }
 
-var file_messagescheduler_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_messagescheduler_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_messagescheduler_storage_proto_goTypes = []any{
-	(*GetMessagesRequest)(nil),   // 0: protocol.messages_scheduler.storage.GetMessagesRequest
-	(*GetMessagesResponse)(nil),  // 1: protocol.messages_scheduler.storage.GetMessagesResponse
-	(*DeleteMessageRequest)(nil), // 2: protocol.messages_scheduler.storage.DeleteMessageRequest
-	(*durationpb.Duration)(nil),  // 3: google.protobuf.Duration
-	(*types.OutboxMessage)(nil),  // 4: protocol.types.OutboxMessage
-	(*emptypb.Empty)(nil),        // 5: google.protobuf.Empty
+	(*GetMessagesRequest)(nil),      // 0: protocol.messages_scheduler.storage.GetMessagesRequest
+	(*GetMessagesResponse)(nil),     // 1: protocol.messages_scheduler.storage.GetMessagesResponse
+	(*DeleteMessageRequest)(nil),    // 2: protocol.messages_scheduler.storage.DeleteMessageRequest
+	(*DeleteBatchRequest)(nil),      // 3: protocol.messages_scheduler.storage.DeleteBatchRequest
+	(*DeleteBatchResponse)(nil),     // 4: protocol.messages_scheduler.storage.DeleteBatchResponse
+	(*ReleaseMessagesRequest)(nil),  // 5: protocol.messages_scheduler.storage.ReleaseMessagesRequest
+	(*ReleaseMessagesResponse)(nil), // 6: protocol.messages_scheduler.storage.ReleaseMessagesResponse
+	(*FailMessageRequest)(nil),      // 7: protocol.messages_scheduler.storage.FailMessageRequest
+	(*ReplayMessagesRequest)(nil),   // 8: protocol.messages_scheduler.storage.ReplayMessagesRequest
+	(*ReplayMessagesResponse)(nil),  // 9: protocol.messages_scheduler.storage.ReplayMessagesResponse
+	(*WatchOutboxRequest)(nil),      // 10: protocol.messages_scheduler.storage.WatchOutboxRequest
+	(*OutboxSignal)(nil),            // 11: protocol.messages_scheduler.storage.OutboxSignal
+	(*durationpb.Duration)(nil),     // 12: google.protobuf.Duration
+	(*types.OutboxMessage)(nil),     // 13: protocol.types.OutboxMessage
+	(*timestamppb.Timestamp)(nil),   // 14: google.protobuf.Timestamp
+	(*types.UUID)(nil),              // 15: protocol.types.UUID
+	(*emptypb.Empty)(nil),           // 16: google.protobuf.Empty
 }
 var file_messagescheduler_storage_proto_depIdxs = []int32{
-	3, // 0: protocol.messages_scheduler.storage.GetMessagesRequest.retryAfter:type_name -> google.protobuf.Duration
-	4, // 1: protocol.messages_scheduler.storage.GetMessagesResponse.outboxMessages:type_name -> protocol.types.OutboxMessage
-	0, // 2: protocol.messages_scheduler.storage.OutboxStorage.Get:input_type -> protocol.messages_scheduler.storage.GetMessagesRequest
-	2, // 3: protocol.messages_scheduler.storage.OutboxStorage.Delete:input_type -> protocol.messages_scheduler.storage.DeleteMessageRequest
-	1, // 4: protocol.messages_scheduler.storage.OutboxStorage.Get:output_type -> protocol.messages_scheduler.storage.GetMessagesResponse
-	5, // 5: protocol.messages_scheduler.storage.OutboxStorage.Delete:output_type -> google.protobuf.Empty
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	12, // 0: protocol.messages_scheduler.storage.GetMessagesRequest.retryAfter:type_name -> google.protobuf.Duration
+	13, // 1: protocol.messages_scheduler.storage.GetMessagesResponse.outboxMessages:type_name -> protocol.types.OutboxMessage
+	14, // 2: protocol.messages_scheduler.storage.FailMessageRequest.nextSendAt:type_name -> google.protobuf.Timestamp
+	15, // 3: protocol.messages_scheduler.storage.ReplayMessagesRequest.aggregateId:type_name -> protocol.types.UUID
+	14, // 4: protocol.messages_scheduler.storage.ReplayMessagesRequest.createdFrom:type_name -> google.protobuf.Timestamp
+	14, // 5: protocol.messages_scheduler.storage.ReplayMessagesRequest.createdTo:type_name -> google.protobuf.Timestamp
+	0,  // 6: protocol.messages_scheduler.storage.OutboxStorage.Get:input_type -> protocol.messages_scheduler.storage.GetMessagesRequest
+	2,  // 7: protocol.messages_scheduler.storage.OutboxStorage.Delete:input_type -> protocol.messages_scheduler.storage.DeleteMessageRequest
+	3,  // 8: protocol.messages_scheduler.storage.OutboxStorage.DeleteBatch:input_type -> protocol.messages_scheduler.storage.DeleteBatchRequest
+	5,  // 9: protocol.messages_scheduler.storage.OutboxStorage.Release:input_type -> protocol.messages_scheduler.storage.ReleaseMessagesRequest
+	7,  // 10: protocol.messages_scheduler.storage.OutboxStorage.Fail:input_type -> protocol.messages_scheduler.storage.FailMessageRequest
+	10, // 11: protocol.messages_scheduler.storage.OutboxStorage.WatchOutbox:input_type -> protocol.messages_scheduler.storage.WatchOutboxRequest
+	8,  // 12: protocol.messages_scheduler.storage.OutboxStorage.Replay:input_type -> protocol.messages_scheduler.storage.ReplayMessagesRequest
+	1,  // 13: protocol.messages_scheduler.storage.OutboxStorage.Get:output_type -> protocol.messages_scheduler.storage.GetMessagesResponse
+	16, // 14: protocol.messages_scheduler.storage.OutboxStorage.Delete:output_type -> google.protobuf.Empty
+	4,  // 15: protocol.messages_scheduler.storage.OutboxStorage.DeleteBatch:output_type -> protocol.messages_scheduler.storage.DeleteBatchResponse
+	6,  // 16: protocol.messages_scheduler.storage.OutboxStorage.Release:output_type -> protocol.messages_scheduler.storage.ReleaseMessagesResponse
+	16, // 17: protocol.messages_scheduler.storage.OutboxStorage.Fail:output_type -> google.protobuf.Empty
+	11, // 18: protocol.messages_scheduler.storage.OutboxStorage.WatchOutbox:output_type -> protocol.messages_scheduler.storage.OutboxSignal
+	9,  // 19: protocol.messages_scheduler.storage.OutboxStorage.Replay:output_type -> protocol.messages_scheduler.storage.ReplayMessagesResponse
+	13, // [13:20] is the sub-list for method output_type
+	6,  // [6:13] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_messagescheduler_storage_proto_init() }
@@ -228,7 +813,7 @@ func file_messagescheduler_storage_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_messagescheduler_storage_proto_rawDesc), len(file_messagescheduler_storage_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
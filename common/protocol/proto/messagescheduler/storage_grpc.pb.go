@@ -20,8 +20,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	OutboxStorage_Get_FullMethodName    = "/protocol.messages_scheduler.storage.OutboxStorage/Get"
-	OutboxStorage_Delete_FullMethodName = "/protocol.messages_scheduler.storage.OutboxStorage/Delete"
+	OutboxStorage_Get_FullMethodName         = "/protocol.messages_scheduler.storage.OutboxStorage/Get"
+	OutboxStorage_Delete_FullMethodName      = "/protocol.messages_scheduler.storage.OutboxStorage/Delete"
+	OutboxStorage_DeleteBatch_FullMethodName = "/protocol.messages_scheduler.storage.OutboxStorage/DeleteBatch"
+	OutboxStorage_Release_FullMethodName     = "/protocol.messages_scheduler.storage.OutboxStorage/Release"
+	OutboxStorage_Fail_FullMethodName        = "/protocol.messages_scheduler.storage.OutboxStorage/Fail"
+	OutboxStorage_WatchOutbox_FullMethodName = "/protocol.messages_scheduler.storage.OutboxStorage/WatchOutbox"
+	OutboxStorage_Replay_FullMethodName      = "/protocol.messages_scheduler.storage.OutboxStorage/Replay"
 )
 
 // OutboxStorageClient is the client API for OutboxStorage service.
@@ -30,6 +35,17 @@ const (
 type OutboxStorageClient interface {
 	Get(ctx context.Context, in *GetMessagesRequest, opts ...grpc.CallOption) (*GetMessagesResponse, error)
 	Delete(ctx context.Context, in *DeleteMessageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteBatch(ctx context.Context, in *DeleteBatchRequest, opts ...grpc.CallOption) (*DeleteBatchResponse, error)
+	// Release gives back the leases of messages the owner won't send, so they are
+	// sent by other owners without waiting for the leases to expire.
+	Release(ctx context.Context, in *ReleaseMessagesRequest, opts ...grpc.CallOption) (*ReleaseMessagesResponse, error)
+	// Fail records a failed publish attempt and releases the lease of the message.
+	Fail(ctx context.Context, in *FailMessageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// WatchOutbox sends a signal right away and then whenever messages are scheduled
+	// or deleted, so the outbox can be polled only when it may have changed.
+	WatchOutbox(ctx context.Context, in *WatchOutboxRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OutboxSignal], error)
+	// Replay schedules copies of published messages marked with the replay headers.
+	Replay(ctx context.Context, in *ReplayMessagesRequest, opts ...grpc.CallOption) (*ReplayMessagesResponse, error)
 }
 
 type outboxStorageClient struct {
@@ -60,12 +76,82 @@ func (c *outboxStorageClient) Delete(ctx context.Context, in *DeleteMessageReque
 	return out, nil
 }
 
+func (c *outboxStorageClient) DeleteBatch(ctx context.Context, in *DeleteBatchRequest, opts ...grpc.CallOption) (*DeleteBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBatchResponse)
+	err := c.cc.Invoke(ctx, OutboxStorage_DeleteBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxStorageClient) Release(ctx context.Context, in *ReleaseMessagesRequest, opts ...grpc.CallOption) (*ReleaseMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseMessagesResponse)
+	err := c.cc.Invoke(ctx, OutboxStorage_Release_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxStorageClient) Fail(ctx context.Context, in *FailMessageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, OutboxStorage_Fail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outboxStorageClient) WatchOutbox(ctx context.Context, in *WatchOutboxRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OutboxSignal], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OutboxStorage_ServiceDesc.Streams[0], OutboxStorage_WatchOutbox_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchOutboxRequest, OutboxSignal]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OutboxStorage_WatchOutboxClient = grpc.ServerStreamingClient[OutboxSignal]
+
+func (c *outboxStorageClient) Replay(ctx context.Context, in *ReplayMessagesRequest, opts ...grpc.CallOption) (*ReplayMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplayMessagesResponse)
+	err := c.cc.Invoke(ctx, OutboxStorage_Replay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OutboxStorageServer is the server API for OutboxStorage service.
 // All implementations must embed UnimplementedOutboxStorageServer
 // for forward compatibility.
 type OutboxStorageServer interface {
 	Get(context.Context, *GetMessagesRequest) (*GetMessagesResponse, error)
 	Delete(context.Context, *DeleteMessageRequest) (*emptypb.Empty, error)
+	DeleteBatch(context.Context, *DeleteBatchRequest) (*DeleteBatchResponse, error)
+	// Release gives back the leases of messages the owner won't send, so they are
+	// sent by other owners without waiting for the leases to expire.
+	Release(context.Context, *ReleaseMessagesRequest) (*ReleaseMessagesResponse, error)
+	// Fail records a failed publish attempt and releases the lease of the message.
+	Fail(context.Context, *FailMessageRequest) (*emptypb.Empty, error)
+	// WatchOutbox sends a signal right away and then whenever messages are scheduled
+	// or deleted, so the outbox can be polled only when it may have changed.
+	WatchOutbox(*WatchOutboxRequest, grpc.ServerStreamingServer[OutboxSignal]) error
+	// Replay schedules copies of published messages marked with the replay headers.
+	Replay(context.Context, *ReplayMessagesRequest) (*ReplayMessagesResponse, error)
 	mustEmbedUnimplementedOutboxStorageServer()
 }
 
@@ -82,6 +168,21 @@ func (UnimplementedOutboxStorageServer) Get(context.Context, *GetMessagesRequest
 func (UnimplementedOutboxStorageServer) Delete(context.Context, *DeleteMessageRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedOutboxStorageServer) DeleteBatch(context.Context, *DeleteBatchRequest) (*DeleteBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBatch not implemented")
+}
+func (UnimplementedOutboxStorageServer) Release(context.Context, *ReleaseMessagesRequest) (*ReleaseMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Release not implemented")
+}
+func (UnimplementedOutboxStorageServer) Fail(context.Context, *FailMessageRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fail not implemented")
+}
+func (UnimplementedOutboxStorageServer) WatchOutbox(*WatchOutboxRequest, grpc.ServerStreamingServer[OutboxSignal]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOutbox not implemented")
+}
+func (UnimplementedOutboxStorageServer) Replay(context.Context, *ReplayMessagesRequest) (*ReplayMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Replay not implemented")
+}
 func (UnimplementedOutboxStorageServer) mustEmbedUnimplementedOutboxStorageServer() {}
 func (UnimplementedOutboxStorageServer) testEmbeddedByValue()                       {}
 
@@ -139,6 +240,89 @@ func _OutboxStorage_Delete_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OutboxStorage_DeleteBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxStorageServer).DeleteBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OutboxStorage_DeleteBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxStorageServer).DeleteBatch(ctx, req.(*DeleteBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxStorage_Release_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxStorageServer).Release(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OutboxStorage_Release_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxStorageServer).Release(ctx, req.(*ReleaseMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxStorage_Fail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FailMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxStorageServer).Fail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OutboxStorage_Fail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxStorageServer).Fail(ctx, req.(*FailMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutboxStorage_WatchOutbox_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOutboxRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OutboxStorageServer).WatchOutbox(m, &grpc.GenericServerStream[WatchOutboxRequest, OutboxSignal]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OutboxStorage_WatchOutboxServer = grpc.ServerStreamingServer[OutboxSignal]
+
+func _OutboxStorage_Replay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxStorageServer).Replay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OutboxStorage_Replay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxStorageServer).Replay(ctx, req.(*ReplayMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OutboxStorage_ServiceDesc is the grpc.ServiceDesc for OutboxStorage service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -154,7 +338,29 @@ var OutboxStorage_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _OutboxStorage_Delete_Handler,
 		},
+		{
+			MethodName: "DeleteBatch",
+			Handler:    _OutboxStorage_DeleteBatch_Handler,
+		},
+		{
+			MethodName: "Release",
+			Handler:    _OutboxStorage_Release_Handler,
+		},
+		{
+			MethodName: "Fail",
+			Handler:    _OutboxStorage_Fail_Handler,
+		},
+		{
+			MethodName: "Replay",
+			Handler:    _OutboxStorage_Replay_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchOutbox",
+			Handler:       _OutboxStorage_WatchOutbox_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "messagescheduler/storage.proto",
 }
@@ -0,0 +1,476 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: messagescheduler/webhook.proto
+
+package messagescheduler
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WebhookAttemptOutcome int32
+
+const (
+	WebhookAttemptOutcome_Succeeded WebhookAttemptOutcome = 0
+	WebhookAttemptOutcome_Retry     WebhookAttemptOutcome = 1
+	WebhookAttemptOutcome_Dead      WebhookAttemptOutcome = 2
+)
+
+// Enum value maps for WebhookAttemptOutcome.
+var (
+	WebhookAttemptOutcome_name = map[int32]string{
+		0: "Succeeded",
+		1: "Retry",
+		2: "Dead",
+	}
+	WebhookAttemptOutcome_value = map[string]int32{
+		"Succeeded": 0,
+		"Retry":     1,
+		"Dead":      2,
+	}
+)
+
+func (x WebhookAttemptOutcome) Enum() *WebhookAttemptOutcome {
+	p := new(WebhookAttemptOutcome)
+	*p = x
+	return p
+}
+
+func (x WebhookAttemptOutcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookAttemptOutcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_messagescheduler_webhook_proto_enumTypes[0].Descriptor()
+}
+
+func (WebhookAttemptOutcome) Type() protoreflect.EnumType {
+	return &file_messagescheduler_webhook_proto_enumTypes[0]
+}
+
+func (x WebhookAttemptOutcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookAttemptOutcome.Descriptor instead.
+func (WebhookAttemptOutcome) EnumDescriptor() ([]byte, []int) {
+	return file_messagescheduler_webhook_proto_rawDescGZIP(), []int{0}
+}
+
+type WebhookDelivery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *int64                 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	EventType     *string                `protobuf:"bytes,2,opt,name=eventType" json:"eventType,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,3,opt,name=payload" json:"payload,omitempty"`
+	Attempts      *int32                 `protobuf:"varint,4,opt,name=attempts" json:"attempts,omitempty"`
+	Url           *string                `protobuf:"bytes,5,opt,name=url" json:"url,omitempty"`
+	Secret        *string                `protobuf:"bytes,6,opt,name=secret" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_messagescheduler_webhook_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_messagescheduler_webhook_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_messagescheduler_webhook_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WebhookDelivery) GetId() int64 {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetEventType() string {
+	if x != nil && x.EventType != nil {
+		return *x.EventType
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil && x.Attempts != nil {
+		return *x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetSecret() string {
+	if x != nil && x.Secret != nil {
+		return *x.Secret
+	}
+	return ""
+}
+
+type GetWebhookDeliveriesRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MaxCount *int32                 `protobuf:"varint,1,opt,name=maxCount" json:"maxCount,omitempty"`
+	// Returned deliveries are leased to leaseOwner and not returned again until
+	// the lease expires.
+	LeaseFor      *durationpb.Duration `protobuf:"bytes,2,opt,name=leaseFor" json:"leaseFor,omitempty"`
+	LeaseOwner    *string              `protobuf:"bytes,3,opt,name=leaseOwner" json:"leaseOwner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookDeliveriesRequest) Reset() {
+	*x = GetWebhookDeliveriesRequest{}
+	mi := &file_messagescheduler_webhook_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *GetWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_messagescheduler_webhook_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*GetWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_messagescheduler_webhook_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetWebhookDeliveriesRequest) GetMaxCount() int32 {
+	if x != nil && x.MaxCount != nil {
+		return *x.MaxCount
+	}
+	return 0
+}
+
+func (x *GetWebhookDeliveriesRequest) GetLeaseFor() *durationpb.Duration {
+	if x != nil {
+		return x.LeaseFor
+	}
+	return nil
+}
+
+func (x *GetWebhookDeliveriesRequest) GetLeaseOwner() string {
+	if x != nil && x.LeaseOwner != nil {
+		return *x.LeaseOwner
+	}
+	return ""
+}
+
+type GetWebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookDeliveriesResponse) Reset() {
+	*x = GetWebhookDeliveriesResponse{}
+	mi := &file_messagescheduler_webhook_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *GetWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_messagescheduler_webhook_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*GetWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_messagescheduler_webhook_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+type CompleteWebhookAttemptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryId    *int64                 `protobuf:"varint,1,opt,name=deliveryId" json:"deliveryId,omitempty"`
+	Outcome       *WebhookAttemptOutcome `protobuf:"varint,2,opt,name=outcome,enum=protocol.messages_scheduler.storage.WebhookAttemptOutcome" json:"outcome,omitempty"`
+	StatusCode    *int32                 `protobuf:"varint,3,opt,name=statusCode" json:"statusCode,omitempty"`
+	Error         *string                `protobuf:"bytes,4,opt,name=error" json:"error,omitempty"`
+	Response      *string                `protobuf:"bytes,5,opt,name=response" json:"response,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,6,opt,name=duration" json:"duration,omitempty"`
+	NextAttemptAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=nextAttemptAt" json:"nextAttemptAt,omitempty"`
+	// An attempt is recorded only while the delivery is leased to leaseOwner.
+	LeaseOwner    *string `protobuf:"bytes,8,opt,name=leaseOwner" json:"leaseOwner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteWebhookAttemptRequest) Reset() {
+	*x = CompleteWebhookAttemptRequest{}
+	mi := &file_messagescheduler_webhook_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteWebhookAttemptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteWebhookAttemptRequest) ProtoMessage() {}
+
+func (x *CompleteWebhookAttemptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_messagescheduler_webhook_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteWebhookAttemptRequest.ProtoReflect.Descriptor instead.
+func (*CompleteWebhookAttemptRequest) Descriptor() ([]byte, []int) {
+	return file_messagescheduler_webhook_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CompleteWebhookAttemptRequest) GetDeliveryId() int64 {
+	if x != nil && x.DeliveryId != nil {
+		return *x.DeliveryId
+	}
+	return 0
+}
+
+func (x *CompleteWebhookAttemptRequest) GetOutcome() WebhookAttemptOutcome {
+	if x != nil && x.Outcome != nil {
+		return *x.Outcome
+	}
+	return WebhookAttemptOutcome_Succeeded
+}
+
+func (x *CompleteWebhookAttemptRequest) GetStatusCode() int32 {
+	if x != nil && x.StatusCode != nil {
+		return *x.StatusCode
+	}
+	return 0
+}
+
+func (x *CompleteWebhookAttemptRequest) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *CompleteWebhookAttemptRequest) GetResponse() string {
+	if x != nil && x.Response != nil {
+		return *x.Response
+	}
+	return ""
+}
+
+func (x *CompleteWebhookAttemptRequest) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *CompleteWebhookAttemptRequest) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return nil
+}
+
+func (x *CompleteWebhookAttemptRequest) GetLeaseOwner() string {
+	if x != nil && x.LeaseOwner != nil {
+		return *x.LeaseOwner
+	}
+	return ""
+}
+
+var File_messagescheduler_webhook_proto protoreflect.FileDescriptor
+
+const file_messagescheduler_webhook_proto_rawDesc = "" +
+	"\n" +
+	"\x1emessagescheduler/webhook.proto\x12#protocol.messages_scheduler.storage\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9f\x01\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1c\n" +
+	"\teventType\x18\x02 \x01(\tR\teventType\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\fR\apayload\x12\x1a\n" +
+	"\battempts\x18\x04 \x01(\x05R\battempts\x12\x10\n" +
+	"\x03url\x18\x05 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x06 \x01(\tR\x06secret\"\x90\x01\n" +
+	"\x1bGetWebhookDeliveriesRequest\x12\x1a\n" +
+	"\bmaxCount\x18\x01 \x01(\x05R\bmaxCount\x125\n" +
+	"\bleaseFor\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bleaseFor\x12\x1e\n" +
+	"\n" +
+	"leaseOwner\x18\x03 \x01(\tR\n" +
+	"leaseOwner\"t\n" +
+	"\x1cGetWebhookDeliveriesResponse\x12T\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v24.protocol.messages_scheduler.storage.WebhookDeliveryR\n" +
+	"deliveries\"\x80\x03\n" +
+	"\x1dCompleteWebhookAttemptRequest\x12\x1e\n" +
+	"\n" +
+	"deliveryId\x18\x01 \x01(\x03R\n" +
+	"deliveryId\x12T\n" +
+	"\aoutcome\x18\x02 \x01(\x0e2:.protocol.messages_scheduler.storage.WebhookAttemptOutcomeR\aoutcome\x12\x1e\n" +
+	"\n" +
+	"statusCode\x18\x03 \x01(\x05R\n" +
+	"statusCode\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1a\n" +
+	"\bresponse\x18\x05 \x01(\tR\bresponse\x125\n" +
+	"\bduration\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12@\n" +
+	"\rnextAttemptAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x12\x1e\n" +
+	"\n" +
+	"leaseOwner\x18\b \x01(\tR\n" +
+	"leaseOwner*;\n" +
+	"\x15WebhookAttemptOutcome\x12\r\n" +
+	"\tSucceeded\x10\x00\x12\t\n" +
+	"\x05Retry\x10\x01\x12\b\n" +
+	"\x04Dead\x10\x022\x9e\x02\n" +
+	"\x16WebhookDeliveryStorage\x12\x94\x01\n" +
+	"\rGetDeliveries\x12@.protocol.messages_scheduler.storage.GetWebhookDeliveriesRequest\x1aA.protocol.messages_scheduler.storage.GetWebhookDeliveriesResponse\x12m\n" +
+	"\x0fCompleteAttempt\x12B.protocol.messages_scheduler.storage.CompleteWebhookAttemptRequest\x1a\x16.google.protobuf.EmptyB;Z9go-invoice-service/common/protocol/proto/messageschedulerb\beditionsp\xe8\a"
+
+var (
+	file_messagescheduler_webhook_proto_rawDescOnce sync.Once
+	file_messagescheduler_webhook_proto_rawDescData []byte
+)
+
+func file_messagescheduler_webhook_proto_rawDescGZIP() []byte {
+	file_messagescheduler_webhook_proto_rawDescOnce.Do(func() {
+		file_messagescheduler_webhook_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_messagescheduler_webhook_proto_rawDesc), len(file_messagescheduler_webhook_proto_rawDesc)))
+	})
+	return file_messagescheduler_webhook_proto_rawDescData
+}
+
+var file_messagescheduler_webhook_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_messagescheduler_webhook_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_messagescheduler_webhook_proto_goTypes = []any{
+	(WebhookAttemptOutcome)(0),            // 0: protocol.messages_scheduler.storage.WebhookAttemptOutcome
+	(*WebhookDelivery)(nil),               // 1: protocol.messages_scheduler.storage.WebhookDelivery
+	(*GetWebhookDeliveriesRequest)(nil),   // 2: protocol.messages_scheduler.storage.GetWebhookDeliveriesRequest
+	(*GetWebhookDeliveriesResponse)(nil),  // 3: protocol.messages_scheduler.storage.GetWebhookDeliveriesResponse
+	(*CompleteWebhookAttemptRequest)(nil), // 4: protocol.messages_scheduler.storage.CompleteWebhookAttemptRequest
+	(*durationpb.Duration)(nil),           // 5: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),         // 6: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                 // 7: google.protobuf.Empty
+}
+var file_messagescheduler_webhook_proto_depIdxs = []int32{
+	5, // 0: protocol.messages_scheduler.storage.GetWebhookDeliveriesRequest.leaseFor:type_name -> google.protobuf.Duration
+	1, // 1: protocol.messages_scheduler.storage.GetWebhookDeliveriesResponse.deliveries:type_name -> protocol.messages_scheduler.storage.WebhookDelivery
+	0, // 2: protocol.messages_scheduler.storage.CompleteWebhookAttemptRequest.outcome:type_name -> protocol.messages_scheduler.storage.WebhookAttemptOutcome
+	5, // 3: protocol.messages_scheduler.storage.CompleteWebhookAttemptRequest.duration:type_name -> google.protobuf.Duration
+	6, // 4: protocol.messages_scheduler.storage.CompleteWebhookAttemptRequest.nextAttemptAt:type_name -> google.protobuf.Timestamp
+	2, // 5: protocol.messages_scheduler.storage.WebhookDeliveryStorage.GetDeliveries:input_type -> protocol.messages_scheduler.storage.GetWebhookDeliveriesRequest
+	4, // 6: protocol.messages_scheduler.storage.WebhookDeliveryStorage.CompleteAttempt:input_type -> protocol.messages_scheduler.storage.CompleteWebhookAttemptRequest
+	3, // 7: protocol.messages_scheduler.storage.WebhookDeliveryStorage.GetDeliveries:output_type -> protocol.messages_scheduler.storage.GetWebhookDeliveriesResponse
+	7, // 8: protocol.messages_scheduler.storage.WebhookDeliveryStorage.CompleteAttempt:output_type -> google.protobuf.Empty
+	7, // [7:9] is the sub-list for method output_type
+	5, // [5:7] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_messagescheduler_webhook_proto_init() }
+func file_messagescheduler_webhook_proto_init() {
+	if File_messagescheduler_webhook_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_messagescheduler_webhook_proto_rawDesc), len(file_messagescheduler_webhook_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_messagescheduler_webhook_proto_goTypes,
+		DependencyIndexes: file_messagescheduler_webhook_proto_depIdxs,
+		EnumInfos:         file_messagescheduler_webhook_proto_enumTypes,
+		MessageInfos:      file_messagescheduler_webhook_proto_msgTypes,
+	}.Build()
+	File_messagescheduler_webhook_proto = out.File
+	file_messagescheduler_webhook_proto_goTypes = nil
+	file_messagescheduler_webhook_proto_depIdxs = nil
+}
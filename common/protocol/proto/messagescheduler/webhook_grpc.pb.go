@@ -0,0 +1,161 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: messagescheduler/webhook.proto
+
+package messagescheduler
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	WebhookDeliveryStorage_GetDeliveries_FullMethodName   = "/protocol.messages_scheduler.storage.WebhookDeliveryStorage/GetDeliveries"
+	WebhookDeliveryStorage_CompleteAttempt_FullMethodName = "/protocol.messages_scheduler.storage.WebhookDeliveryStorage/CompleteAttempt"
+)
+
+// WebhookDeliveryStorageClient is the client API for WebhookDeliveryStorage service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WebhookDeliveryStorageClient interface {
+	GetDeliveries(ctx context.Context, in *GetWebhookDeliveriesRequest, opts ...grpc.CallOption) (*GetWebhookDeliveriesResponse, error)
+	CompleteAttempt(ctx context.Context, in *CompleteWebhookAttemptRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type webhookDeliveryStorageClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebhookDeliveryStorageClient(cc grpc.ClientConnInterface) WebhookDeliveryStorageClient {
+	return &webhookDeliveryStorageClient{cc}
+}
+
+func (c *webhookDeliveryStorageClient) GetDeliveries(ctx context.Context, in *GetWebhookDeliveriesRequest, opts ...grpc.CallOption) (*GetWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, WebhookDeliveryStorage_GetDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookDeliveryStorageClient) CompleteAttempt(ctx context.Context, in *CompleteWebhookAttemptRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, WebhookDeliveryStorage_CompleteAttempt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebhookDeliveryStorageServer is the server API for WebhookDeliveryStorage service.
+// All implementations must embed UnimplementedWebhookDeliveryStorageServer
+// for forward compatibility.
+type WebhookDeliveryStorageServer interface {
+	GetDeliveries(context.Context, *GetWebhookDeliveriesRequest) (*GetWebhookDeliveriesResponse, error)
+	CompleteAttempt(context.Context, *CompleteWebhookAttemptRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedWebhookDeliveryStorageServer()
+}
+
+// UnimplementedWebhookDeliveryStorageServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWebhookDeliveryStorageServer struct{}
+
+func (UnimplementedWebhookDeliveryStorageServer) GetDeliveries(context.Context, *GetWebhookDeliveriesRequest) (*GetWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeliveries not implemented")
+}
+func (UnimplementedWebhookDeliveryStorageServer) CompleteAttempt(context.Context, *CompleteWebhookAttemptRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteAttempt not implemented")
+}
+func (UnimplementedWebhookDeliveryStorageServer) mustEmbedUnimplementedWebhookDeliveryStorageServer() {
+}
+func (UnimplementedWebhookDeliveryStorageServer) testEmbeddedByValue() {}
+
+// UnsafeWebhookDeliveryStorageServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebhookDeliveryStorageServer will
+// result in compilation errors.
+type UnsafeWebhookDeliveryStorageServer interface {
+	mustEmbedUnimplementedWebhookDeliveryStorageServer()
+}
+
+func RegisterWebhookDeliveryStorageServer(s grpc.ServiceRegistrar, srv WebhookDeliveryStorageServer) {
+	// If the following call pancis, it indicates UnimplementedWebhookDeliveryStorageServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WebhookDeliveryStorage_ServiceDesc, srv)
+}
+
+func _WebhookDeliveryStorage_GetDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookDeliveryStorageServer).GetDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookDeliveryStorage_GetDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookDeliveryStorageServer).GetDeliveries(ctx, req.(*GetWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookDeliveryStorage_CompleteAttempt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteWebhookAttemptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookDeliveryStorageServer).CompleteAttempt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookDeliveryStorage_CompleteAttempt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookDeliveryStorageServer).CompleteAttempt(ctx, req.(*CompleteWebhookAttemptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WebhookDeliveryStorage_ServiceDesc is the grpc.ServiceDesc for WebhookDeliveryStorage service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WebhookDeliveryStorage_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "protocol.messages_scheduler.storage.WebhookDeliveryStorage",
+	HandlerType: (*WebhookDeliveryStorageServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetDeliveries",
+			Handler:    _WebhookDeliveryStorage_GetDeliveries_Handler,
+		},
+		{
+			MethodName: "CompleteAttempt",
+			Handler:    _WebhookDeliveryStorage_CompleteAttempt_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "messagescheduler/webhook.proto",
+}
@@ -0,0 +1,433 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: notifications/storage.proto
+
+package notifications
+
+import (
+	types "go-invoice-service/common/protocol/proto/types"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type NotificationClaimResult int32
+
+const (
+	NotificationClaimResult_Claimed     NotificationClaimResult = 0
+	NotificationClaimResult_AlreadySent NotificationClaimResult = 1
+	NotificationClaimResult_InProgress  NotificationClaimResult = 2
+)
+
+// Enum value maps for NotificationClaimResult.
+var (
+	NotificationClaimResult_name = map[int32]string{
+		0: "Claimed",
+		1: "AlreadySent",
+		2: "InProgress",
+	}
+	NotificationClaimResult_value = map[string]int32{
+		"Claimed":     0,
+		"AlreadySent": 1,
+		"InProgress":  2,
+	}
+)
+
+func (x NotificationClaimResult) Enum() *NotificationClaimResult {
+	p := new(NotificationClaimResult)
+	*p = x
+	return p
+}
+
+func (x NotificationClaimResult) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NotificationClaimResult) Descriptor() protoreflect.EnumDescriptor {
+	return file_notifications_storage_proto_enumTypes[0].Descriptor()
+}
+
+func (NotificationClaimResult) Type() protoreflect.EnumType {
+	return &file_notifications_storage_proto_enumTypes[0]
+}
+
+func (x NotificationClaimResult) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NotificationClaimResult.Descriptor instead.
+func (NotificationClaimResult) EnumDescriptor() ([]byte, []int) {
+	return file_notifications_storage_proto_rawDescGZIP(), []int{0}
+}
+
+type GetInvoiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *types.UUID            `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoiceRequest) Reset() {
+	*x = GetInvoiceRequest{}
+	mi := &file_notifications_storage_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceRequest) ProtoMessage() {}
+
+func (x *GetInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_storage_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*GetInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_storage_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetInvoiceRequest) GetId() *types.UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type GetInvoiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invoice       *types.Invoice         `protobuf:"bytes,1,opt,name=invoice" json:"invoice,omitempty"`
+	Status        *types.InvoiceStatus   `protobuf:"varint,2,opt,name=status,enum=protocol.types.InvoiceStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoiceResponse) Reset() {
+	*x = GetInvoiceResponse{}
+	mi := &file_notifications_storage_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceResponse) ProtoMessage() {}
+
+func (x *GetInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_storage_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*GetInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_notifications_storage_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetInvoiceResponse) GetInvoice() *types.Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+func (x *GetInvoiceResponse) GetStatus() types.InvoiceStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return types.InvoiceStatus(0)
+}
+
+type NotificationKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InvoiceId     *types.UUID            `protobuf:"bytes,1,opt,name=invoiceId" json:"invoiceId,omitempty"`
+	EventType     *string                `protobuf:"bytes,2,opt,name=eventType" json:"eventType,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationKey) Reset() {
+	*x = NotificationKey{}
+	mi := &file_notifications_storage_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationKey) ProtoMessage() {}
+
+func (x *NotificationKey) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_storage_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationKey.ProtoReflect.Descriptor instead.
+func (*NotificationKey) Descriptor() ([]byte, []int) {
+	return file_notifications_storage_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NotificationKey) GetInvoiceId() *types.UUID {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return nil
+}
+
+func (x *NotificationKey) GetEventType() string {
+	if x != nil && x.EventType != nil {
+		return *x.EventType
+	}
+	return ""
+}
+
+type ClaimNotificationRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Key       *NotificationKey       `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Recipient *string                `protobuf:"bytes,2,opt,name=recipient" json:"recipient,omitempty"`
+	// The claim expires after this time, so a notification is sent again if the claimer crashed.
+	LeaseFor      *durationpb.Duration `protobuf:"bytes,3,opt,name=leaseFor" json:"leaseFor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimNotificationRequest) Reset() {
+	*x = ClaimNotificationRequest{}
+	mi := &file_notifications_storage_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimNotificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimNotificationRequest) ProtoMessage() {}
+
+func (x *ClaimNotificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_storage_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimNotificationRequest.ProtoReflect.Descriptor instead.
+func (*ClaimNotificationRequest) Descriptor() ([]byte, []int) {
+	return file_notifications_storage_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ClaimNotificationRequest) GetKey() *NotificationKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *ClaimNotificationRequest) GetRecipient() string {
+	if x != nil && x.Recipient != nil {
+		return *x.Recipient
+	}
+	return ""
+}
+
+func (x *ClaimNotificationRequest) GetLeaseFor() *durationpb.Duration {
+	if x != nil {
+		return x.LeaseFor
+	}
+	return nil
+}
+
+type ClaimNotificationResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Result        *NotificationClaimResult `protobuf:"varint,1,opt,name=result,enum=protocol.notifications.storage.NotificationClaimResult" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimNotificationResponse) Reset() {
+	*x = ClaimNotificationResponse{}
+	mi := &file_notifications_storage_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimNotificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimNotificationResponse) ProtoMessage() {}
+
+func (x *ClaimNotificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_storage_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimNotificationResponse.ProtoReflect.Descriptor instead.
+func (*ClaimNotificationResponse) Descriptor() ([]byte, []int) {
+	return file_notifications_storage_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ClaimNotificationResponse) GetResult() NotificationClaimResult {
+	if x != nil && x.Result != nil {
+		return *x.Result
+	}
+	return NotificationClaimResult_Claimed
+}
+
+var File_notifications_storage_proto protoreflect.FileDescriptor
+
+const file_notifications_storage_proto_rawDesc = "" +
+	"\n" +
+	"\x1bnotifications/storage.proto\x12\x1eprotocol.notifications.storage\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x10types/uuid.proto\x1a\x13types/invoice.proto\"9\n" +
+	"\x11GetInvoiceRequest\x12$\n" +
+	"\x02id\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\x02id\"~\n" +
+	"\x12GetInvoiceResponse\x121\n" +
+	"\ainvoice\x18\x01 \x01(\v2\x17.protocol.types.InvoiceR\ainvoice\x125\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1d.protocol.types.InvoiceStatusR\x06status\"c\n" +
+	"\x0fNotificationKey\x122\n" +
+	"\tinvoiceId\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\tinvoiceId\x12\x1c\n" +
+	"\teventType\x18\x02 \x01(\tR\teventType\"\xb2\x01\n" +
+	"\x18ClaimNotificationRequest\x12A\n" +
+	"\x03key\x18\x01 \x01(\v2/.protocol.notifications.storage.NotificationKeyR\x03key\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\tR\trecipient\x125\n" +
+	"\bleaseFor\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bleaseFor\"l\n" +
+	"\x19ClaimNotificationResponse\x12O\n" +
+	"\x06result\x18\x01 \x01(\x0e27.protocol.notifications.storage.NotificationClaimResultR\x06result*G\n" +
+	"\x17NotificationClaimResult\x12\v\n" +
+	"\aClaimed\x10\x00\x12\x0f\n" +
+	"\vAlreadySent\x10\x01\x12\x0e\n" +
+	"\n" +
+	"InProgress\x10\x022\xd6\x03\n" +
+	"\x13NotificationStorage\x12s\n" +
+	"\n" +
+	"GetInvoice\x121.protocol.notifications.storage.GetInvoiceRequest\x1a2.protocol.notifications.storage.GetInvoiceResponse\x12\x88\x01\n" +
+	"\x11ClaimNotification\x128.protocol.notifications.storage.ClaimNotificationRequest\x1a9.protocol.notifications.storage.ClaimNotificationResponse\x12_\n" +
+	"\x14MarkNotificationSent\x12/.protocol.notifications.storage.NotificationKey\x1a\x16.google.protobuf.Empty\x12^\n" +
+	"\x13ReleaseNotification\x12/.protocol.notifications.storage.NotificationKey\x1a\x16.google.protobuf.EmptyB8Z6go-invoice-service/common/protocol/proto/notificationsb\beditionsp\xe8\a"
+
+var (
+	file_notifications_storage_proto_rawDescOnce sync.Once
+	file_notifications_storage_proto_rawDescData []byte
+)
+
+func file_notifications_storage_proto_rawDescGZIP() []byte {
+	file_notifications_storage_proto_rawDescOnce.Do(func() {
+		file_notifications_storage_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_notifications_storage_proto_rawDesc), len(file_notifications_storage_proto_rawDesc)))
+	})
+	return file_notifications_storage_proto_rawDescData
+}
+
+var file_notifications_storage_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_notifications_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_notifications_storage_proto_goTypes = []any{
+	(NotificationClaimResult)(0),      // 0: protocol.notifications.storage.NotificationClaimResult
+	(*GetInvoiceRequest)(nil),         // 1: protocol.notifications.storage.GetInvoiceRequest
+	(*GetInvoiceResponse)(nil),        // 2: protocol.notifications.storage.GetInvoiceResponse
+	(*NotificationKey)(nil),           // 3: protocol.notifications.storage.NotificationKey
+	(*ClaimNotificationRequest)(nil),  // 4: protocol.notifications.storage.ClaimNotificationRequest
+	(*ClaimNotificationResponse)(nil), // 5: protocol.notifications.storage.ClaimNotificationResponse
+	(*types.UUID)(nil),                // 6: protocol.types.UUID
+	(*types.Invoice)(nil),             // 7: protocol.types.Invoice
+	(types.InvoiceStatus)(0),          // 8: protocol.types.InvoiceStatus
+	(*durationpb.Duration)(nil),       // 9: google.protobuf.Duration
+	(*emptypb.Empty)(nil),             // 10: google.protobuf.Empty
+}
+var file_notifications_storage_proto_depIdxs = []int32{
+	6,  // 0: protocol.notifications.storage.GetInvoiceRequest.id:type_name -> protocol.types.UUID
+	7,  // 1: protocol.notifications.storage.GetInvoiceResponse.invoice:type_name -> protocol.types.Invoice
+	8,  // 2: protocol.notifications.storage.GetInvoiceResponse.status:type_name -> protocol.types.InvoiceStatus
+	6,  // 3: protocol.notifications.storage.NotificationKey.invoiceId:type_name -> protocol.types.UUID
+	3,  // 4: protocol.notifications.storage.ClaimNotificationRequest.key:type_name -> protocol.notifications.storage.NotificationKey
+	9,  // 5: protocol.notifications.storage.ClaimNotificationRequest.leaseFor:type_name -> google.protobuf.Duration
+	0,  // 6: protocol.notifications.storage.ClaimNotificationResponse.result:type_name -> protocol.notifications.storage.NotificationClaimResult
+	1,  // 7: protocol.notifications.storage.NotificationStorage.GetInvoice:input_type -> protocol.notifications.storage.GetInvoiceRequest
+	4,  // 8: protocol.notifications.storage.NotificationStorage.ClaimNotification:input_type -> protocol.notifications.storage.ClaimNotificationRequest
+	3,  // 9: protocol.notifications.storage.NotificationStorage.MarkNotificationSent:input_type -> protocol.notifications.storage.NotificationKey
+	3,  // 10: protocol.notifications.storage.NotificationStorage.ReleaseNotification:input_type -> protocol.notifications.storage.NotificationKey
+	2,  // 11: protocol.notifications.storage.NotificationStorage.GetInvoice:output_type -> protocol.notifications.storage.GetInvoiceResponse
+	5,  // 12: protocol.notifications.storage.NotificationStorage.ClaimNotification:output_type -> protocol.notifications.storage.ClaimNotificationResponse
+	10, // 13: protocol.notifications.storage.NotificationStorage.MarkNotificationSent:output_type -> google.protobuf.Empty
+	10, // 14: protocol.notifications.storage.NotificationStorage.ReleaseNotification:output_type -> google.protobuf.Empty
+	11, // [11:15] is the sub-list for method output_type
+	7,  // [7:11] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_notifications_storage_proto_init() }
+func file_notifications_storage_proto_init() {
+	if File_notifications_storage_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notifications_storage_proto_rawDesc), len(file_notifications_storage_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_notifications_storage_proto_goTypes,
+		DependencyIndexes: file_notifications_storage_proto_depIdxs,
+		EnumInfos:         file_notifications_storage_proto_enumTypes,
+		MessageInfos:      file_notifications_storage_proto_msgTypes,
+	}.Build()
+	File_notifications_storage_proto = out.File
+	file_notifications_storage_proto_goTypes = nil
+	file_notifications_storage_proto_depIdxs = nil
+}
@@ -0,0 +1,236 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: notifications/storage.proto
+
+package notifications
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	NotificationStorage_GetInvoice_FullMethodName           = "/protocol.notifications.storage.NotificationStorage/GetInvoice"
+	NotificationStorage_ClaimNotification_FullMethodName    = "/protocol.notifications.storage.NotificationStorage/ClaimNotification"
+	NotificationStorage_MarkNotificationSent_FullMethodName = "/protocol.notifications.storage.NotificationStorage/MarkNotificationSent"
+	NotificationStorage_ReleaseNotification_FullMethodName  = "/protocol.notifications.storage.NotificationStorage/ReleaseNotification"
+)
+
+// NotificationStorageClient is the client API for NotificationStorage service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NotificationStorageClient interface {
+	GetInvoice(ctx context.Context, in *GetInvoiceRequest, opts ...grpc.CallOption) (*GetInvoiceResponse, error)
+	ClaimNotification(ctx context.Context, in *ClaimNotificationRequest, opts ...grpc.CallOption) (*ClaimNotificationResponse, error)
+	MarkNotificationSent(ctx context.Context, in *NotificationKey, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ReleaseNotification(ctx context.Context, in *NotificationKey, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type notificationStorageClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNotificationStorageClient(cc grpc.ClientConnInterface) NotificationStorageClient {
+	return &notificationStorageClient{cc}
+}
+
+func (c *notificationStorageClient) GetInvoice(ctx context.Context, in *GetInvoiceRequest, opts ...grpc.CallOption) (*GetInvoiceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInvoiceResponse)
+	err := c.cc.Invoke(ctx, NotificationStorage_GetInvoice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationStorageClient) ClaimNotification(ctx context.Context, in *ClaimNotificationRequest, opts ...grpc.CallOption) (*ClaimNotificationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClaimNotificationResponse)
+	err := c.cc.Invoke(ctx, NotificationStorage_ClaimNotification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationStorageClient) MarkNotificationSent(ctx context.Context, in *NotificationKey, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NotificationStorage_MarkNotificationSent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationStorageClient) ReleaseNotification(ctx context.Context, in *NotificationKey, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NotificationStorage_ReleaseNotification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NotificationStorageServer is the server API for NotificationStorage service.
+// All implementations must embed UnimplementedNotificationStorageServer
+// for forward compatibility.
+type NotificationStorageServer interface {
+	GetInvoice(context.Context, *GetInvoiceRequest) (*GetInvoiceResponse, error)
+	ClaimNotification(context.Context, *ClaimNotificationRequest) (*ClaimNotificationResponse, error)
+	MarkNotificationSent(context.Context, *NotificationKey) (*emptypb.Empty, error)
+	ReleaseNotification(context.Context, *NotificationKey) (*emptypb.Empty, error)
+	mustEmbedUnimplementedNotificationStorageServer()
+}
+
+// UnimplementedNotificationStorageServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedNotificationStorageServer struct{}
+
+func (UnimplementedNotificationStorageServer) GetInvoice(context.Context, *GetInvoiceRequest) (*GetInvoiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInvoice not implemented")
+}
+func (UnimplementedNotificationStorageServer) ClaimNotification(context.Context, *ClaimNotificationRequest) (*ClaimNotificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimNotification not implemented")
+}
+func (UnimplementedNotificationStorageServer) MarkNotificationSent(context.Context, *NotificationKey) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkNotificationSent not implemented")
+}
+func (UnimplementedNotificationStorageServer) ReleaseNotification(context.Context, *NotificationKey) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseNotification not implemented")
+}
+func (UnimplementedNotificationStorageServer) mustEmbedUnimplementedNotificationStorageServer() {}
+func (UnimplementedNotificationStorageServer) testEmbeddedByValue()                             {}
+
+// UnsafeNotificationStorageServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NotificationStorageServer will
+// result in compilation errors.
+type UnsafeNotificationStorageServer interface {
+	mustEmbedUnimplementedNotificationStorageServer()
+}
+
+func RegisterNotificationStorageServer(s grpc.ServiceRegistrar, srv NotificationStorageServer) {
+	// If the following call pancis, it indicates UnimplementedNotificationStorageServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&NotificationStorage_ServiceDesc, srv)
+}
+
+func _NotificationStorage_GetInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationStorageServer).GetInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationStorage_GetInvoice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationStorageServer).GetInvoice(ctx, req.(*GetInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationStorage_ClaimNotification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimNotificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationStorageServer).ClaimNotification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationStorage_ClaimNotification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationStorageServer).ClaimNotification(ctx, req.(*ClaimNotificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationStorage_MarkNotificationSent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotificationKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationStorageServer).MarkNotificationSent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationStorage_MarkNotificationSent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationStorageServer).MarkNotificationSent(ctx, req.(*NotificationKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationStorage_ReleaseNotification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotificationKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationStorageServer).ReleaseNotification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationStorage_ReleaseNotification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationStorageServer).ReleaseNotification(ctx, req.(*NotificationKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NotificationStorage_ServiceDesc is the grpc.ServiceDesc for NotificationStorage service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NotificationStorage_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "protocol.notifications.storage.NotificationStorage",
+	HandlerType: (*NotificationStorageServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetInvoice",
+			Handler:    _NotificationStorage_GetInvoice_Handler,
+		},
+		{
+			MethodName: "ClaimNotification",
+			Handler:    _NotificationStorage_ClaimNotification_Handler,
+		},
+		{
+			MethodName: "MarkNotificationSent",
+			Handler:    _NotificationStorage_MarkNotificationSent_Handler,
+		},
+		{
+			MethodName: "ReleaseNotification",
+			Handler:    _NotificationStorage_ReleaseNotification_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "notifications/storage.proto",
+}
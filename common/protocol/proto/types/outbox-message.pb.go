@@ -22,10 +22,18 @@ const (
 )
 
 type OutboxMessage struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            *int64                 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
-	Topic         *string                `protobuf:"bytes,2,opt,name=topic" json:"topic,omitempty"`
-	Payload       []byte                 `protobuf:"bytes,3,opt,name=payload" json:"payload,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          *int64                 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Topic       *string                `protobuf:"bytes,2,opt,name=topic" json:"topic,omitempty"`
+	Payload     []byte                 `protobuf:"bytes,3,opt,name=payload" json:"payload,omitempty"`
+	Key         *string                `protobuf:"bytes,4,opt,name=key" json:"key,omitempty"`
+	Headers     map[string]string      `protobuf:"bytes,5,rep,name=headers" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	AggregateId *UUID                  `protobuf:"bytes,6,opt,name=aggregate_id,json=aggregateId" json:"aggregate_id,omitempty"`
+	Sequence    *int64                 `protobuf:"varint,7,opt,name=sequence" json:"sequence,omitempty"`
+	// The message was leased before and the lease expired without the message being deleted.
+	LeaseExpired *bool `protobuf:"varint,8,opt,name=lease_expired,json=leaseExpired" json:"lease_expired,omitempty"`
+	// Failed publish attempts so far.
+	Attempts      *int32 `protobuf:"varint,9,opt,name=attempts" json:"attempts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -81,15 +89,66 @@ func (x *OutboxMessage) GetPayload() []byte {
 	return nil
 }
 
+func (x *OutboxMessage) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *OutboxMessage) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *OutboxMessage) GetAggregateId() *UUID {
+	if x != nil {
+		return x.AggregateId
+	}
+	return nil
+}
+
+func (x *OutboxMessage) GetSequence() int64 {
+	if x != nil && x.Sequence != nil {
+		return *x.Sequence
+	}
+	return 0
+}
+
+func (x *OutboxMessage) GetLeaseExpired() bool {
+	if x != nil && x.LeaseExpired != nil {
+		return *x.LeaseExpired
+	}
+	return false
+}
+
+func (x *OutboxMessage) GetAttempts() int32 {
+	if x != nil && x.Attempts != nil {
+		return *x.Attempts
+	}
+	return 0
+}
+
 var File_types_outbox_message_proto protoreflect.FileDescriptor
 
 const file_types_outbox_message_proto_rawDesc = "" +
 	"\n" +
-	"\x1atypes/outbox-message.proto\x12\x0eprotocol.types\"O\n" +
+	"\x1atypes/outbox-message.proto\x12\x0eprotocol.types\x1a\x10types/uuid.proto\"\xf9\x02\n" +
 	"\rOutboxMessage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
 	"\x05topic\x18\x02 \x01(\tR\x05topic\x12\x18\n" +
-	"\apayload\x18\x03 \x01(\fR\apayloadB0Z.go-invoice-service/common/protocol/proto/typesb\beditionsp\xe8\a"
+	"\apayload\x18\x03 \x01(\fR\apayload\x12\x10\n" +
+	"\x03key\x18\x04 \x01(\tR\x03key\x12D\n" +
+	"\aheaders\x18\x05 \x03(\v2*.protocol.types.OutboxMessage.HeadersEntryR\aheaders\x127\n" +
+	"\faggregate_id\x18\x06 \x01(\v2\x14.protocol.types.UUIDR\vaggregateId\x12\x1a\n" +
+	"\bsequence\x18\a \x01(\x03R\bsequence\x12#\n" +
+	"\rlease_expired\x18\b \x01(\bR\fleaseExpired\x12\x1a\n" +
+	"\battempts\x18\t \x01(\x05R\battempts\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B0Z.go-invoice-service/common/protocol/proto/typesb\beditionsp\xe8\a"
 
 var (
 	file_types_outbox_message_proto_rawDescOnce sync.Once
@@ -103,16 +162,20 @@ func file_types_outbox_message_proto_rawDescGZIP() []byte {
 	return file_types_outbox_message_proto_rawDescData
 }
 
-var file_types_outbox_message_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_types_outbox_message_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_types_outbox_message_proto_goTypes = []any{
 	(*OutboxMessage)(nil), // 0: protocol.types.OutboxMessage
+	nil,                   // 1: protocol.types.OutboxMessage.HeadersEntry
+	(*UUID)(nil),          // 2: protocol.types.UUID
 }
 var file_types_outbox_message_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: protocol.types.OutboxMessage.headers:type_name -> protocol.types.OutboxMessage.HeadersEntry
+	2, // 1: protocol.types.OutboxMessage.aggregate_id:type_name -> protocol.types.UUID
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_types_outbox_message_proto_init() }
@@ -120,13 +183,14 @@ func file_types_outbox_message_proto_init() {
 	if File_types_outbox_message_proto != nil {
 		return
 	}
+	file_types_uuid_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_types_outbox_message_proto_rawDesc), len(file_types_outbox_message_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -119,16 +119,71 @@ func (x *GetInvoiceResponse) GetStatus() types.InvoiceStatus {
 	return types.InvoiceStatus(0)
 }
 
+// InboxKey identifies the handled event. The status is not changed again, and the call fails
+// with ALREADY_EXISTS, if the consumer group handled the event already.
+type InboxKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       *types.UUID            `protobuf:"bytes,1,opt,name=eventId" json:"eventId,omitempty"`
+	ConsumerGroup *string                `protobuf:"bytes,2,opt,name=consumerGroup" json:"consumerGroup,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InboxKey) Reset() {
+	*x = InboxKey{}
+	mi := &file_validation_storage_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InboxKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InboxKey) ProtoMessage() {}
+
+func (x *InboxKey) ProtoReflect() protoreflect.Message {
+	mi := &file_validation_storage_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InboxKey.ProtoReflect.Descriptor instead.
+func (*InboxKey) Descriptor() ([]byte, []int) {
+	return file_validation_storage_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *InboxKey) GetEventId() *types.UUID {
+	if x != nil {
+		return x.EventId
+	}
+	return nil
+}
+
+func (x *InboxKey) GetConsumerGroup() string {
+	if x != nil && x.ConsumerGroup != nil {
+		return *x.ConsumerGroup
+	}
+	return ""
+}
+
 type SetApprovedRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            *types.UUID            `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	InboxKey      *InboxKey              `protobuf:"bytes,2,opt,name=inboxKey" json:"inboxKey,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SetApprovedRequest) Reset() {
 	*x = SetApprovedRequest{}
-	mi := &file_validation_storage_proto_msgTypes[2]
+	mi := &file_validation_storage_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -140,7 +195,7 @@ func (x *SetApprovedRequest) String() string {
 func (*SetApprovedRequest) ProtoMessage() {}
 
 func (x *SetApprovedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_validation_storage_proto_msgTypes[2]
+	mi := &file_validation_storage_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -153,7 +208,7 @@ func (x *SetApprovedRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetApprovedRequest.ProtoReflect.Descriptor instead.
 func (*SetApprovedRequest) Descriptor() ([]byte, []int) {
-	return file_validation_storage_proto_rawDescGZIP(), []int{2}
+	return file_validation_storage_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SetApprovedRequest) GetId() *types.UUID {
@@ -163,16 +218,24 @@ func (x *SetApprovedRequest) GetId() *types.UUID {
 	return nil
 }
 
+func (x *SetApprovedRequest) GetInboxKey() *InboxKey {
+	if x != nil {
+		return x.InboxKey
+	}
+	return nil
+}
+
 type SetRejectedRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            *types.UUID            `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	InboxKey      *InboxKey              `protobuf:"bytes,2,opt,name=inboxKey" json:"inboxKey,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SetRejectedRequest) Reset() {
 	*x = SetRejectedRequest{}
-	mi := &file_validation_storage_proto_msgTypes[3]
+	mi := &file_validation_storage_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -184,7 +247,7 @@ func (x *SetRejectedRequest) String() string {
 func (*SetRejectedRequest) ProtoMessage() {}
 
 func (x *SetRejectedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_validation_storage_proto_msgTypes[3]
+	mi := &file_validation_storage_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -197,7 +260,7 @@ func (x *SetRejectedRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetRejectedRequest.ProtoReflect.Descriptor instead.
 func (*SetRejectedRequest) Descriptor() ([]byte, []int) {
-	return file_validation_storage_proto_rawDescGZIP(), []int{3}
+	return file_validation_storage_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *SetRejectedRequest) GetId() *types.UUID {
@@ -207,6 +270,13 @@ func (x *SetRejectedRequest) GetId() *types.UUID {
 	return nil
 }
 
+func (x *SetRejectedRequest) GetInboxKey() *InboxKey {
+	if x != nil {
+		return x.InboxKey
+	}
+	return nil
+}
+
 var File_validation_storage_proto protoreflect.FileDescriptor
 
 const file_validation_storage_proto_rawDesc = "" +
@@ -216,11 +286,16 @@ const file_validation_storage_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\x02id\"~\n" +
 	"\x12GetInvoiceResponse\x121\n" +
 	"\ainvoice\x18\x01 \x01(\v2\x17.protocol.types.InvoiceR\ainvoice\x125\n" +
-	"\x06status\x18\x02 \x01(\x0e2\x1d.protocol.types.InvoiceStatusR\x06status\":\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1d.protocol.types.InvoiceStatusR\x06status\"`\n" +
+	"\bInboxKey\x12.\n" +
+	"\aeventId\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\aeventId\x12$\n" +
+	"\rconsumerGroup\x18\x02 \x01(\tR\rconsumerGroup\"}\n" +
 	"\x12SetApprovedRequest\x12$\n" +
-	"\x02id\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\x02id\":\n" +
+	"\x02id\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\x02id\x12A\n" +
+	"\binboxKey\x18\x02 \x01(\v2%.protocol.validation.storage.InboxKeyR\binboxKey\"}\n" +
 	"\x12SetRejectedRequest\x12$\n" +
-	"\x02id\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\x02id2\xa8\x02\n" +
+	"\x02id\x18\x01 \x01(\v2\x14.protocol.types.UUIDR\x02id\x12A\n" +
+	"\binboxKey\x18\x02 \x01(\v2%.protocol.validation.storage.InboxKeyR\binboxKey2\xa8\x02\n" +
 	"\x0eInvoiceStorage\x12f\n" +
 	"\x03Get\x12..protocol.validation.storage.GetInvoiceRequest\x1a/.protocol.validation.storage.GetInvoiceResponse\x12V\n" +
 	"\vSetApproved\x12/.protocol.validation.storage.SetApprovedRequest\x1a\x16.google.protobuf.Empty\x12V\n" +
@@ -238,34 +313,38 @@ func file_validation_storage_proto_rawDescGZIP() []byte {
 	return file_validation_storage_proto_rawDescData
 }
 
-var file_validation_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_validation_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_validation_storage_proto_goTypes = []any{
 	(*GetInvoiceRequest)(nil),  // 0: protocol.validation.storage.GetInvoiceRequest
 	(*GetInvoiceResponse)(nil), // 1: protocol.validation.storage.GetInvoiceResponse
-	(*SetApprovedRequest)(nil), // 2: protocol.validation.storage.SetApprovedRequest
-	(*SetRejectedRequest)(nil), // 3: protocol.validation.storage.SetRejectedRequest
-	(*types.UUID)(nil),         // 4: protocol.types.UUID
-	(*types.Invoice)(nil),      // 5: protocol.types.Invoice
-	(types.InvoiceStatus)(0),   // 6: protocol.types.InvoiceStatus
-	(*emptypb.Empty)(nil),      // 7: google.protobuf.Empty
+	(*InboxKey)(nil),           // 2: protocol.validation.storage.InboxKey
+	(*SetApprovedRequest)(nil), // 3: protocol.validation.storage.SetApprovedRequest
+	(*SetRejectedRequest)(nil), // 4: protocol.validation.storage.SetRejectedRequest
+	(*types.UUID)(nil),         // 5: protocol.types.UUID
+	(*types.Invoice)(nil),      // 6: protocol.types.Invoice
+	(types.InvoiceStatus)(0),   // 7: protocol.types.InvoiceStatus
+	(*emptypb.Empty)(nil),      // 8: google.protobuf.Empty
 }
 var file_validation_storage_proto_depIdxs = []int32{
-	4, // 0: protocol.validation.storage.GetInvoiceRequest.id:type_name -> protocol.types.UUID
-	5, // 1: protocol.validation.storage.GetInvoiceResponse.invoice:type_name -> protocol.types.Invoice
-	6, // 2: protocol.validation.storage.GetInvoiceResponse.status:type_name -> protocol.types.InvoiceStatus
-	4, // 3: protocol.validation.storage.SetApprovedRequest.id:type_name -> protocol.types.UUID
-	4, // 4: protocol.validation.storage.SetRejectedRequest.id:type_name -> protocol.types.UUID
-	0, // 5: protocol.validation.storage.InvoiceStorage.Get:input_type -> protocol.validation.storage.GetInvoiceRequest
-	2, // 6: protocol.validation.storage.InvoiceStorage.SetApproved:input_type -> protocol.validation.storage.SetApprovedRequest
-	3, // 7: protocol.validation.storage.InvoiceStorage.SetRejected:input_type -> protocol.validation.storage.SetRejectedRequest
-	1, // 8: protocol.validation.storage.InvoiceStorage.Get:output_type -> protocol.validation.storage.GetInvoiceResponse
-	7, // 9: protocol.validation.storage.InvoiceStorage.SetApproved:output_type -> google.protobuf.Empty
-	7, // 10: protocol.validation.storage.InvoiceStorage.SetRejected:output_type -> google.protobuf.Empty
-	8, // [8:11] is the sub-list for method output_type
-	5, // [5:8] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	5,  // 0: protocol.validation.storage.GetInvoiceRequest.id:type_name -> protocol.types.UUID
+	6,  // 1: protocol.validation.storage.GetInvoiceResponse.invoice:type_name -> protocol.types.Invoice
+	7,  // 2: protocol.validation.storage.GetInvoiceResponse.status:type_name -> protocol.types.InvoiceStatus
+	5,  // 3: protocol.validation.storage.InboxKey.eventId:type_name -> protocol.types.UUID
+	5,  // 4: protocol.validation.storage.SetApprovedRequest.id:type_name -> protocol.types.UUID
+	2,  // 5: protocol.validation.storage.SetApprovedRequest.inboxKey:type_name -> protocol.validation.storage.InboxKey
+	5,  // 6: protocol.validation.storage.SetRejectedRequest.id:type_name -> protocol.types.UUID
+	2,  // 7: protocol.validation.storage.SetRejectedRequest.inboxKey:type_name -> protocol.validation.storage.InboxKey
+	0,  // 8: protocol.validation.storage.InvoiceStorage.Get:input_type -> protocol.validation.storage.GetInvoiceRequest
+	3,  // 9: protocol.validation.storage.InvoiceStorage.SetApproved:input_type -> protocol.validation.storage.SetApprovedRequest
+	4,  // 10: protocol.validation.storage.InvoiceStorage.SetRejected:input_type -> protocol.validation.storage.SetRejectedRequest
+	1,  // 11: protocol.validation.storage.InvoiceStorage.Get:output_type -> protocol.validation.storage.GetInvoiceResponse
+	8,  // 12: protocol.validation.storage.InvoiceStorage.SetApproved:output_type -> google.protobuf.Empty
+	8,  // 13: protocol.validation.storage.InvoiceStorage.SetRejected:output_type -> google.protobuf.Empty
+	11, // [11:14] is the sub-list for method output_type
+	8,  // [8:11] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_validation_storage_proto_init() }
@@ -279,7 +358,7 @@ func file_validation_storage_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_validation_storage_proto_rawDesc), len(file_validation_storage_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -4,12 +4,14 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"go-invoice-service/api-service/internal/bulkimport"
 	"go-invoice-service/api-service/internal/httpserver"
 	"go-invoice-service/api-service/internal/services"
 	"go-invoice-service/common/pkg/flagtypes"
 	"go-invoice-service/common/pkg/jwtfactory"
 	"go-invoice-service/common/pkg/meterutils"
 	"os"
+	"path/filepath"
 	"strconv"
 	"time"
 )
@@ -25,6 +27,14 @@ const (
 	prometheusPortEnv        = "PROMETHEUS_PORT"
 	otelCollectorAddressFlag = "otel-collector-address"
 	otelCollectorAddressEnv  = "OTEL_COLLECTOR_ADDRESS"
+	importSpoolDirFlag       = "import-spool-dir"
+	importSpoolDirEnv        = "IMPORT_SPOOL_DIR"
+	importBatchSizeFlag      = "import-batch-size"
+	importBatchSizeEnv       = "IMPORT_BATCH_SIZE"
+	importWorkersFlag        = "import-workers"
+	importWorkersEnv         = "IMPORT_WORKERS"
+	importMaxFileBytesFlag   = "import-max-file-bytes"
+	importMaxFileBytesEnv    = "IMPORT_MAX_FILE_BYTES"
 )
 
 const (
@@ -34,12 +44,18 @@ const (
 	defaultShutdownTimeout      = 5 * time.Second
 	defaultPrometheusPort       = 9090
 	defaultOtelCollectorAddress = "localhost:4318"
+	defaultImportBatchSize      = 500
+	defaultImportWorkers        = 2
+	defaultImportMaxFileBytes   = 100 << 20
 )
 
+var defaultImportSpoolDir = filepath.Join(os.TempDir(), "invoice-imports")
+
 var defaultRetryAttempts = []time.Duration{time.Second, 3 * time.Second, 5 * time.Second}
 
 type Config struct {
 	StorageConfig       services.StorageConfig
+	ImportConfig        bulkimport.Config
 	JWTConfig           jwtfactory.Config
 	HTTPServerConfig    httpserver.Config
 	PrometheusConfig    meterutils.PrometheusConfig
@@ -54,6 +70,10 @@ func Load() (*Config, error) {
 	jwtPrivateKey := defaultJWTPrivateKey
 	prometheusPort := defaultPrometheusPort
 	otelCollectorAddress := defaultOtelCollectorAddress
+	importSpoolDir := defaultImportSpoolDir
+	importBatchSize := defaultImportBatchSize
+	importWorkers := defaultImportWorkers
+	importMaxFileBytes := int64(defaultImportMaxFileBytes)
 
 	// Flags Definition.
 
@@ -72,6 +92,18 @@ func Load() (*Config, error) {
 	otelCollectorAddressFlagVal := flagtypes.NewString()
 	flag.Var(otelCollectorAddressFlagVal, otelCollectorAddressFlag, "OpenTelemetry Collector address")
 
+	importSpoolDirFlagVal := flagtypes.NewString()
+	flag.Var(importSpoolDirFlagVal, importSpoolDirFlag, "Directory for uploaded import files")
+
+	importBatchSizeFlagVal := flagtypes.NewInt()
+	flag.Var(importBatchSizeFlagVal, importBatchSizeFlag, "Rows per import batch")
+
+	importWorkersFlagVal := flagtypes.NewInt()
+	flag.Var(importWorkersFlagVal, importWorkersFlag, "Number of concurrently running import jobs")
+
+	importMaxFileBytesFlagVal := flagtypes.NewInt()
+	flag.Var(importMaxFileBytesFlagVal, importMaxFileBytesFlag, "Maximum size of an uploaded import file (bytes)")
+
 	flag.Parse()
 
 	// Flags Parse.
@@ -96,6 +128,22 @@ func Load() (*Config, error) {
 		otelCollectorAddress = val
 	}
 
+	if val, ok := importSpoolDirFlagVal.Value(); ok {
+		importSpoolDir = val
+	}
+
+	if val, ok := importBatchSizeFlagVal.Value(); ok {
+		importBatchSize = val
+	}
+
+	if val, ok := importWorkersFlagVal.Value(); ok {
+		importWorkers = val
+	}
+
+	if val, ok := importMaxFileBytesFlagVal.Value(); ok {
+		importMaxFileBytes = int64(val)
+	}
+
 	// Environment Variables.
 
 	if valStr, ok := os.LookupEnv(httpAddressEnv); ok {
@@ -122,12 +170,56 @@ func Load() (*Config, error) {
 		otelCollectorAddress = valStr
 	}
 
+	if valStr, ok := os.LookupEnv(importSpoolDirEnv); ok {
+		importSpoolDir = valStr
+	}
+
+	if valStr, ok := os.LookupEnv(importBatchSizeEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, importBatchSizeEnv)
+		}
+		importBatchSize = val
+	}
+
+	if valStr, ok := os.LookupEnv(importWorkersEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, importWorkersEnv)
+		}
+		importWorkers = val
+	}
+
+	if valStr, ok := os.LookupEnv(importMaxFileBytesEnv); ok {
+		val, err := strconv.ParseInt(valStr, 10, 64)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, importMaxFileBytesEnv)
+		}
+		importMaxFileBytes = val
+	}
+
 	// Validation.
 
 	if prometheusPort < 0 || prometheusPort > 65535 {
 		return &Config{}, errors.New("prometheus port must be between 0 and 65535")
 	}
 
+	if importSpoolDir == "" {
+		return &Config{}, errors.New("import spool directory must not be empty")
+	}
+
+	if importBatchSize <= 0 {
+		return &Config{}, errors.New("import batch size must be positive")
+	}
+
+	if importWorkers <= 0 {
+		return &Config{}, errors.New("import workers count must be positive")
+	}
+
+	if importMaxFileBytes <= 0 {
+		return &Config{}, errors.New("import max file size must be positive")
+	}
+
 	return &Config{
 		JWTConfig: jwtfactory.Config{
 			Algorithm:      "HS256",
@@ -137,9 +229,15 @@ func Load() (*Config, error) {
 		StorageConfig: services.StorageConfig{
 			ServerAddress: storageAddress,
 		},
+		ImportConfig: bulkimport.Config{
+			SpoolDir:   importSpoolDir,
+			BatchSize:  importBatchSize,
+			NumWorkers: importWorkers,
+		},
 		HTTPServerConfig: httpserver.Config{
-			ServerAddress:   httpAddress,
-			ShutdownTimeout: defaultShutdownTimeout,
+			ServerAddress:      httpAddress,
+			ShutdownTimeout:    defaultShutdownTimeout,
+			MaxImportFileBytes: importMaxFileBytes,
 		},
 		PrometheusConfig: meterutils.PrometheusConfig{
 			PortToListen:    uint16(prometheusPort),
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"go-invoice-service/api-service/cmd/config"
+	"go-invoice-service/api-service/internal/bulkimport"
 	"go-invoice-service/api-service/internal/httpserver"
 	"go-invoice-service/api-service/internal/metrics"
 	"go-invoice-service/api-service/internal/services"
@@ -62,17 +63,20 @@ func main() {
 	}
 	defer storageService.Close()
 
+	importManager := bulkimport.NewManager(cfg.ImportConfig, storageService, logger)
+
 	tokenFactory := jwtfactory.New(cfg.JWTConfig)
 
 	httpServer := httpserver.New(
 		cfg.HTTPServerConfig,
 		tokenFactory.GetJWTAuth(),
 		storageService,
+		importManager,
 		metricsCollector,
 		logger,
 	)
 
-	if err := run(rootCtx, cfg, httpServer, logger); err != nil {
+	if err := run(rootCtx, cfg, httpServer, importManager, logger); err != nil {
 		logger.ErrorCtx(rootCtx, "Service shutdown with error", zap.Error(err))
 	} else {
 		logger.InfoCtx(rootCtx, "Service shutdown gracefully")
@@ -83,6 +87,7 @@ func run(
 	rootCtx context.Context,
 	cfg *config.Config,
 	httpServer *httpserver.Server,
+	importManager *bulkimport.Manager,
 	logger *logging.ZapLogger,
 ) error {
 	g, ctx := errgroup.WithContext(rootCtx)
@@ -115,6 +120,15 @@ func run(
 		return nil
 	})
 
+	g.Go(func() error {
+		defer logger.InfoCtx(ctx, "Import jobs processing finished")
+		errCh := importManager.Run(ctx)
+		for err := range errCh {
+			logger.ErrorCtx(ctx, "Import job error", zap.Error(err))
+		}
+		return nil
+	})
+
 	promServer := meterutils.NewPrometheusServer(cfg.PrometheusConfig)
 
 	g.Go(func() error {
@@ -0,0 +1,225 @@
+package bulkimport
+
+import (
+	"context"
+	"errors"
+	"go-invoice-service/api-service/internal/dto"
+	"go-invoice-service/common/pkg/logging"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	csvImport = `id,customer_id,amount,currency,due_date,notes,items
+9b2f0ab8-3a33-4a43-9a6a-7d1a1c1e0b01,2f1b7f0e-5d2c-4f4b-8f8e-0c6a6d1e2a01,1050.00,EUR,2025-06-30,First,"[{""description"":""Design"",""quantity"":1,""unit_price"":""1050.00"",""total"":""1050.00""}]"
+not-a-uuid,2f1b7f0e-5d2c-4f4b-8f8e-0c6a6d1e2a01,10,EUR,2025-06-30,,"[]"
+9b2f0ab8-3a33-4a43-9a6a-7d1a1c1e0b03,2f1b7f0e-5d2c-4f4b-8f8e-0c6a6d1e2a01,10,EUR,2025-06-30,No items,"[]"
+`
+	jsonlImport = `{"id":"9b2f0ab8-3a33-4a43-9a6a-7d1a1c1e0b01","customer_id":"2f1b7f0e-5d2c-4f4b-8f8e-0c6a6d1e2a01","amount":"20.5","currency":"USD","due_date":"2025-06-30T00:00:00Z","items":[{"description":"Hosting","quantity":2,"unit_price":"10.25","total":"20.5"}]}
+
+{"id":
+{"id":"9b2f0ab8-3a33-4a43-9a6a-7d1a1c1e0b03","customer_id":"2f1b7f0e-5d2c-4f4b-8f8e-0c6a6d1e2a01","amount":"1","due_date":"2025-06-30T00:00:00Z","items":[{"description":"Fee","quantity":1,"unit_price":"1","total":"1"}]}`
+)
+
+func TestReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  dto.ImportFormat
+		input   string
+		amounts []int64
+		errors  []string
+	}{
+		{
+			name:    "csv",
+			format:  dto.ImportFormatCSV,
+			input:   csvImport,
+			amounts: []int64{1_050_000, 0, 0},
+			errors:  []string{"", "invalid id", "at least one item is required"},
+		},
+		{
+			name:    "jsonl",
+			format:  dto.ImportFormatJSONL,
+			input:   jsonlImport,
+			amounts: []int64{20_500, 0, 0},
+			errors:  []string{"", "invalid json", "currency is required"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reader, err := NewReader(test.format, strings.NewReader(test.input))
+			require.NoError(t, err)
+
+			rows := readAll(t, reader)
+			require.Len(t, rows, len(test.errors))
+			for i, row := range rows {
+				assert.Equal(t, int64(i+1), row.Number)
+				assert.Equal(t, test.amounts[i], row.Invoice.Amount)
+				if test.errors[i] == "" {
+					assert.Empty(t, row.Error)
+				} else {
+					assert.Contains(t, row.Error, test.errors[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReader_CSVMissingColumn(t *testing.T) {
+	_, err := NewReader(dto.ImportFormatCSV, strings.NewReader("id,amount\n"))
+	require.Error(t, err)
+}
+
+func TestManager_ResumesFromCheckpoint(t *testing.T) {
+	logger, err := logging.NewZapLogger(zapcore.ErrorLevel)
+	require.NoError(t, err)
+
+	storage := newFakeStorage()
+	cfg := Config{
+		SpoolDir:   t.TempDir(),
+		BatchSize:  2,
+		NumWorkers: 1,
+	}
+	manager := NewManager(cfg, storage, logger)
+
+	// The first batch is acknowledged, the second one fails as if storage-service went down.
+	storage.failBatch = 2
+	job, err := manager.Start(context.Background(), dto.ImportFormatJSONL, strings.NewReader(jsonlImport))
+	require.NoError(t, err)
+	require.ErrorIs(t, manager.process(context.Background(), job.ID), errStorageDown)
+	manager.release(job.ID)
+
+	assert.Equal(t, int64(2), storage.job(job.ID).ProcessedRows)
+	assert.FileExists(t, filepath.Join(cfg.SpoolDir, job.ID.String()+".jsonl"))
+
+	storage.failBatch = 0
+	_, err = manager.Resume(context.Background(), job.ID)
+	require.NoError(t, err)
+	require.NoError(t, manager.process(context.Background(), job.ID))
+
+	finished := storage.job(job.ID)
+	assert.Equal(t, dto.ImportStatusCompleted, finished.Status)
+	assert.Equal(t, int64(3), finished.ProcessedRows)
+	assert.Equal(t, int64(1), finished.SucceededRows)
+	assert.Equal(t, int64(2), finished.FailedRows)
+	assert.Equal(t, []int64{1, 2, 3}, storage.imported)
+	_, err = os.Stat(filepath.Join(cfg.SpoolDir, job.ID.String()+".jsonl"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func readAll(t *testing.T, reader RowReader) []dto.ImportRow {
+	t.Helper()
+
+	var rows []dto.ImportRow
+	for {
+		row, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return rows
+		}
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+}
+
+var errStorageDown = errors.New("storage is down")
+
+type fakeStorage struct {
+	mu        sync.Mutex
+	jobs      map[uuid.UUID]dto.ImportJob
+	batches   int
+	failBatch int
+	imported  []int64
+}
+
+func newFakeStorage() *fakeStorage {
+	return &fakeStorage{
+		jobs: make(map[uuid.UUID]dto.ImportJob),
+	}
+}
+
+func (f *fakeStorage) job(id uuid.UUID) dto.ImportJob {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.jobs[id]
+}
+
+func (f *fakeStorage) CreateImportJob(_ context.Context, id uuid.UUID, format dto.ImportFormat) (dto.ImportJob, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	job := dto.ImportJob{
+		ID:        id,
+		Format:    format,
+		Status:    dto.ImportStatusRunning,
+		CreatedAt: time.Now(),
+	}
+	f.jobs[id] = job
+	return job, nil
+}
+
+func (f *fakeStorage) ImportBatch(_ context.Context, jobID uuid.UUID, rows []dto.ImportRow) (dto.ImportJob, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.batches++
+	if f.batches == f.failBatch {
+		return dto.ImportJob{}, errStorageDown
+	}
+	job := f.jobs[jobID]
+	for _, row := range rows {
+		if row.Number <= job.ProcessedRows {
+			continue
+		}
+		if row.Error != "" {
+			job.FailedRows++
+		} else {
+			job.SucceededRows++
+		}
+		job.ProcessedRows = row.Number
+		f.imported = append(f.imported, row.Number)
+	}
+	f.jobs[jobID] = job
+	return job, nil
+}
+
+func (f *fakeStorage) FinishImportJob(_ context.Context, id uuid.UUID, status dto.ImportJobStatus, errorMessage string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	job := f.jobs[id]
+	job.Status = status
+	job.Error = errorMessage
+	f.jobs[id] = job
+	return nil
+}
+
+func (f *fakeStorage) GetImportJob(_ context.Context, id uuid.UUID) (dto.ImportJob, error) {
+	return f.job(id), nil
+}
+
+func (f *fakeStorage) GetImportJobResults(
+	_ context.Context,
+	id uuid.UUID,
+	_ dto.ImportResultFilter,
+) (dto.ImportJob, []dto.ImportRowResult, error) {
+	return f.job(id), nil, nil
+}
+
+func (f *fakeStorage) ListImportJobs(_ context.Context, status dto.ImportJobStatus) ([]dto.ImportJob, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var res []dto.ImportJob
+	for _, job := range f.jobs {
+		if job.Status == status {
+			res = append(res, job)
+		}
+	}
+	return res, nil
+}
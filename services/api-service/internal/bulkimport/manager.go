@@ -0,0 +1,329 @@
+package bulkimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-invoice-service/api-service/internal/dto"
+	"go-invoice-service/common/pkg/logging"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrJobFinished   = errors.New("import job is already finished")
+	ErrJobInProgress = errors.New("import job is already in progress")
+	ErrSpoolFileGone = errors.New("import file is no longer available")
+)
+
+type StorageService interface {
+	CreateImportJob(ctx context.Context, id uuid.UUID, format dto.ImportFormat) (dto.ImportJob, error)
+	ImportBatch(ctx context.Context, jobID uuid.UUID, rows []dto.ImportRow) (dto.ImportJob, error)
+	FinishImportJob(ctx context.Context, id uuid.UUID, status dto.ImportJobStatus, errorMessage string) error
+	GetImportJob(ctx context.Context, id uuid.UUID) (dto.ImportJob, error)
+	GetImportJobResults(ctx context.Context, id uuid.UUID, filter dto.ImportResultFilter) (dto.ImportJob, []dto.ImportRowResult, error)
+	ListImportJobs(ctx context.Context, status dto.ImportJobStatus) ([]dto.ImportJob, error)
+}
+
+type Config struct {
+	SpoolDir   string
+	BatchSize  int
+	NumWorkers int
+}
+
+// Manager runs import jobs in the background. Uploaded files are kept in the spool
+// directory until their job is finished, so a job interrupted by a failure or a restart
+// continues from the last batch acknowledged by storage-service.
+type Manager struct {
+	cfg            Config
+	storageService StorageService
+	logger         *logging.ZapLogger
+	mu             sync.Mutex
+	pending        []uuid.UUID
+	active         map[uuid.UUID]struct{}
+	wake           chan struct{}
+}
+
+func NewManager(cfg Config, storageService StorageService, logger *logging.ZapLogger) *Manager {
+	return &Manager{
+		cfg:            cfg,
+		storageService: storageService,
+		logger:         logger,
+		active:         make(map[uuid.UUID]struct{}),
+		wake:           make(chan struct{}, 1),
+	}
+}
+
+func (m *Manager) Start(ctx context.Context, format dto.ImportFormat, body io.Reader) (dto.ImportJob, error) {
+	id := uuid.New()
+	if err := m.spool(id, format, body); err != nil {
+		return dto.ImportJob{}, err
+	}
+
+	job, err := m.storageService.CreateImportJob(ctx, id, format)
+	if err != nil {
+		m.removeSpool(ctx, id, format)
+		return dto.ImportJob{}, fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	if err := m.enqueue(id); err != nil {
+		return dto.ImportJob{}, err
+	}
+
+	return job, nil
+}
+
+func (m *Manager) Resume(ctx context.Context, id uuid.UUID) (dto.ImportJob, error) {
+	job, err := m.storageService.GetImportJob(ctx, id)
+	if err != nil {
+		return dto.ImportJob{}, fmt.Errorf("failed to get import job: %w", err)
+	}
+	if job.Status != dto.ImportStatusRunning {
+		return dto.ImportJob{}, ErrJobFinished
+	}
+	if _, err := os.Stat(m.spoolPath(id, job.Format)); err != nil {
+		return dto.ImportJob{}, fmt.Errorf("%w: %w", ErrSpoolFileGone, err)
+	}
+
+	if err := m.enqueue(id); err != nil {
+		return dto.ImportJob{}, err
+	}
+
+	return job, nil
+}
+
+func (m *Manager) Status(
+	ctx context.Context,
+	id uuid.UUID,
+	filter dto.ImportResultFilter,
+) (dto.ImportJob, []dto.ImportRowResult, error) {
+	return m.storageService.GetImportJobResults(ctx, id, filter)
+}
+
+func (m *Manager) Run(ctx context.Context) <-chan error {
+	errCh := make(chan error)
+
+	go func(ctx context.Context) {
+		defer close(errCh)
+
+		if err := m.resumeUnfinished(ctx); err != nil {
+			errCh <- fmt.Errorf("failed to resume unfinished import jobs: %w", err)
+		}
+
+		var wg sync.WaitGroup
+		for range max(m.cfg.NumWorkers, 1) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				m.worker(ctx, errCh)
+			}()
+		}
+		wg.Wait()
+	}(ctx)
+
+	return errCh
+}
+
+func (m *Manager) worker(ctx context.Context, errCh chan<- error) {
+	for {
+		id, ok := m.next()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-m.wake:
+			}
+			continue
+		}
+
+		if err := m.process(ctx, id); err != nil {
+			errCh <- fmt.Errorf("import job %s: %w", id, err)
+		}
+		m.release(id)
+
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (m *Manager) resumeUnfinished(ctx context.Context) error {
+	jobs, err := m.storageService.ListImportJobs(ctx, dto.ImportStatusRunning)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if _, err := os.Stat(m.spoolPath(job.ID, job.Format)); err != nil {
+			// The job was started by another instance or its file was lost, it may still be resumed manually.
+			m.logger.WarnCtx(ctx, fmt.Sprintf("Import job %s has no spooled file, skipping", job.ID))
+			continue
+		}
+		if err := m.enqueue(job.ID); errors.Is(err, ErrJobInProgress) {
+			continue
+		}
+		m.logger.InfoCtx(ctx, fmt.Sprintf("Import job %s resumed", job.ID))
+	}
+
+	return nil
+}
+
+// process imports the rows the storage has not acknowledged yet. A storage failure leaves
+// the job running so it can be resumed, an unreadable file fails the job for good.
+func (m *Manager) process(ctx context.Context, id uuid.UUID) error {
+	job, err := m.storageService.GetImportJob(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get import job: %w", err)
+	}
+	if job.Status != dto.ImportStatusRunning {
+		m.removeSpool(ctx, id, job.Format)
+		return nil
+	}
+
+	file, err := os.Open(m.spoolPath(id, job.Format))
+	if err != nil {
+		return fmt.Errorf("failed to open spooled file: %w", err)
+	}
+	defer file.Close()
+
+	reader, err := NewReader(job.Format, file)
+	if err != nil {
+		return m.fail(ctx, job, err)
+	}
+
+	batch := make([]dto.ImportRow, 0, m.cfg.BatchSize)
+	for {
+		row, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return m.fail(ctx, job, err)
+		}
+		if row.Number <= job.ProcessedRows {
+			continue
+		}
+
+		batch = append(batch, row)
+		if len(batch) < m.cfg.BatchSize {
+			continue
+		}
+		if job, err = m.storageService.ImportBatch(ctx, id, batch); err != nil {
+			return err
+		}
+		batch = batch[:0]
+	}
+
+	if len(batch) > 0 {
+		if job, err = m.storageService.ImportBatch(ctx, id, batch); err != nil {
+			return err
+		}
+	}
+
+	if err := m.storageService.FinishImportJob(ctx, id, dto.ImportStatusCompleted, ""); err != nil {
+		return err
+	}
+	m.logger.InfoCtx(ctx, fmt.Sprintf(
+		"Import job %s completed: %d rows succeeded, %d rows failed",
+		id,
+		job.SucceededRows,
+		job.FailedRows,
+	))
+	m.removeSpool(ctx, id, job.Format)
+
+	return nil
+}
+
+func (m *Manager) fail(ctx context.Context, job dto.ImportJob, cause error) error {
+	m.logger.WarnCtx(ctx, fmt.Sprintf("Import job %s failed", job.ID), zap.Error(cause))
+	if err := m.storageService.FinishImportJob(ctx, job.ID, dto.ImportStatusFailed, cause.Error()); err != nil {
+		return err
+	}
+	m.removeSpool(ctx, job.ID, job.Format)
+	return nil
+}
+
+func (m *Manager) enqueue(id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.active[id]; ok {
+		return ErrJobInProgress
+	}
+	m.active[id] = struct{}{}
+	m.pending = append(m.pending, id)
+	m.notify()
+
+	return nil
+}
+
+func (m *Manager) next() (uuid.UUID, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.pending) == 0 {
+		return uuid.Nil, false
+	}
+	id := m.pending[0]
+	m.pending = m.pending[1:]
+	if len(m.pending) > 0 {
+		m.notify()
+	}
+
+	return id, true
+}
+
+func (m *Manager) notify() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (m *Manager) release(id uuid.UUID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.active, id)
+}
+
+func (m *Manager) spool(id uuid.UUID, format dto.ImportFormat, body io.Reader) error {
+	if err := os.MkdirAll(m.cfg.SpoolDir, 0o750); err != nil {
+		return fmt.Errorf("failed to create spool directory: %w", err)
+	}
+
+	path := m.spoolPath(id, format)
+	file, err := os.CreateTemp(m.cfg.SpoolDir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create spool file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	if _, err := io.Copy(file, body); err != nil {
+		return fmt.Errorf("failed to spool import file: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync spool file: %w", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("failed to move spool file: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Manager) removeSpool(ctx context.Context, id uuid.UUID, format dto.ImportFormat) {
+	err := os.Remove(m.spoolPath(id, format))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		m.logger.WarnCtx(ctx, fmt.Sprintf("Failed to remove spool file of import job %s", id), zap.Error(err))
+	}
+}
+
+func (m *Manager) spoolPath(id uuid.UUID, format dto.ImportFormat) string {
+	return filepath.Join(m.cfg.SpoolDir, fmt.Sprintf("%s.%s", id, format))
+}
@@ -0,0 +1,268 @@
+package bulkimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-invoice-service/api-service/internal/convert"
+	"go-invoice-service/api-service/internal/dto"
+	"go-invoice-service/common/protocol/apiservice/client"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var ErrUnknownFormat = errors.New("unknown import format")
+
+const (
+	columnID         = "id"
+	columnCustomerID = "customer_id"
+	columnAmount     = "amount"
+	columnCurrency   = "currency"
+	columnDueDate    = "due_date"
+	columnCreatedAt  = "created_at"
+	columnUpdatedAt  = "updated_at"
+	columnNotes      = "notes"
+	columnItems      = "items"
+)
+
+var requiredColumns = []string{
+	columnID,
+	columnCustomerID,
+	columnAmount,
+	columnCurrency,
+	columnDueDate,
+	columnItems,
+}
+
+// RowReader returns import rows one by one and io.EOF after the last one.
+// Errors of a single row are reported in ImportRow.Error, a returned error means
+// the rest of the file cannot be read.
+type RowReader interface {
+	Next() (dto.ImportRow, error)
+}
+
+func ParseFormat(format string) (dto.ImportFormat, error) {
+	switch dto.ImportFormat(strings.ToLower(format)) {
+	case dto.ImportFormatCSV:
+		return dto.ImportFormatCSV, nil
+	case dto.ImportFormatJSONL:
+		return dto.ImportFormatJSONL, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+}
+
+func NewReader(format dto.ImportFormat, r io.Reader) (RowReader, error) {
+	switch format {
+	case dto.ImportFormatCSV:
+		return newCSVReader(r)
+	case dto.ImportFormatJSONL:
+		return newJSONLReader(r), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+}
+
+type jsonlReader struct {
+	r      *bufio.Reader
+	number int64
+}
+
+func newJSONLReader(r io.Reader) *jsonlReader {
+	return &jsonlReader{
+		r: bufio.NewReader(r),
+	}
+}
+
+func (j *jsonlReader) Next() (dto.ImportRow, error) {
+	for {
+		line, err := j.r.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return dto.ImportRow{}, fmt.Errorf("failed to read line: %w", err)
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			if errors.Is(err, io.EOF) {
+				return dto.ImportRow{}, io.EOF
+			}
+			continue
+		}
+
+		j.number++
+		row := dto.ImportRow{
+			Number: j.number,
+		}
+		var invoice client.Invoice
+		if err := json.Unmarshal(line, &invoice); err != nil {
+			row.Error = fmt.Sprintf("invalid json: %v", err)
+			return row, nil
+		}
+		return completeRow(row, invoice), nil
+	}
+}
+
+type csvReader struct {
+	r       *csv.Reader
+	columns map[string]int
+	number  int64
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range requiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("csv header misses column %q", name)
+		}
+	}
+
+	return &csvReader{
+		r:       reader,
+		columns: columns,
+	}, nil
+}
+
+func (c *csvReader) Next() (dto.ImportRow, error) {
+	record, err := c.r.Read()
+	if errors.Is(err, io.EOF) {
+		return dto.ImportRow{}, io.EOF
+	}
+
+	c.number++
+	row := dto.ImportRow{
+		Number: c.number,
+	}
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			row.Error = err.Error()
+			return row, nil
+		}
+		return dto.ImportRow{}, fmt.Errorf("failed to read csv record: %w", err)
+	}
+
+	invoice, err := c.invoiceFromRecord(record)
+	if err != nil {
+		row.Error = err.Error()
+		return row, nil
+	}
+	return completeRow(row, invoice), nil
+}
+
+func (c *csvReader) invoiceFromRecord(record []string) (client.Invoice, error) {
+	var invoice client.Invoice
+	var err error
+
+	if invoice.ID, err = uuid.Parse(c.field(record, columnID)); err != nil {
+		return client.Invoice{}, fmt.Errorf("invalid %s: %w", columnID, err)
+	}
+	if invoice.CustomerID, err = uuid.Parse(c.field(record, columnCustomerID)); err != nil {
+		return client.Invoice{}, fmt.Errorf("invalid %s: %w", columnCustomerID, err)
+	}
+	if invoice.Amount, err = decimal.NewFromString(c.field(record, columnAmount)); err != nil {
+		return client.Invoice{}, fmt.Errorf("invalid %s: %w", columnAmount, err)
+	}
+	invoice.Currency = c.field(record, columnCurrency)
+	if invoice.DueDate, err = parseTime(c.field(record, columnDueDate)); err != nil {
+		return client.Invoice{}, fmt.Errorf("invalid %s: %w", columnDueDate, err)
+	}
+	if invoice.CreatedAt, err = parseOptionalTime(c.field(record, columnCreatedAt)); err != nil {
+		return client.Invoice{}, fmt.Errorf("invalid %s: %w", columnCreatedAt, err)
+	}
+	if invoice.UpdatedAt, err = parseOptionalTime(c.field(record, columnUpdatedAt)); err != nil {
+		return client.Invoice{}, fmt.Errorf("invalid %s: %w", columnUpdatedAt, err)
+	}
+	invoice.Notes = c.field(record, columnNotes)
+	if err := json.Unmarshal([]byte(c.field(record, columnItems)), &invoice.Items); err != nil {
+		return client.Invoice{}, fmt.Errorf("invalid %s: %w", columnItems, err)
+	}
+
+	return invoice, nil
+}
+
+func (c *csvReader) field(record []string, column string) string {
+	i, ok := c.columns[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func parseTime(value string) (time.Time, error) {
+	if res, err := time.Parse(time.DateOnly, value); err == nil {
+		return res, nil
+	}
+	res, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected YYYY-MM-DD or RFC 3339 time: %w", err)
+	}
+	return res, nil
+}
+
+func parseOptionalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return parseTime(value)
+}
+
+func completeRow(row dto.ImportRow, invoice client.Invoice) dto.ImportRow {
+	if err := validateInvoice(invoice); err != nil {
+		row.Error = err.Error()
+		return row
+	}
+	row.Invoice = invoiceFromProtocol(invoice)
+	return row
+}
+
+func validateInvoice(invoice client.Invoice) error {
+	switch {
+	case invoice.ID == uuid.Nil:
+		return errors.New("id is required")
+	case invoice.CustomerID == uuid.Nil:
+		return errors.New("customer_id is required")
+	case invoice.Currency == "":
+		return errors.New("currency is required")
+	case invoice.DueDate.IsZero():
+		return errors.New("due_date is required")
+	case len(invoice.Items) == 0:
+		return errors.New("at least one item is required")
+	}
+	for i, item := range invoice.Items {
+		if item.Description == "" {
+			return fmt.Errorf("item %d: description is required", i+1)
+		}
+		if item.Quantity <= 0 {
+			return fmt.Errorf("item %d: quantity must be positive", i+1)
+		}
+	}
+	return nil
+}
+
+// invoiceFromProtocol defaults the timestamps left out of an imported invoice.
+func invoiceFromProtocol(invoice client.Invoice) dto.Invoice {
+	res := convert.InvoiceFromProtocol(invoice)
+	if res.CreatedAt.IsZero() {
+		res.CreatedAt = time.Now().UTC()
+	}
+	if res.UpdatedAt.IsZero() {
+		res.UpdatedAt = res.CreatedAt
+	}
+	return res
+}
@@ -0,0 +1,80 @@
+package convert
+
+import (
+	"github.com/shopspring/decimal"
+	"go-invoice-service/api-service/internal/dto"
+	"go-invoice-service/common/protocol/apiservice/client"
+)
+
+// minorUnitsPerUnit is the number of stored minor units in a protocol currency unit.
+const minorUnitsPerUnit = 1000
+
+func InvoiceFromProtocol(invoice client.Invoice) dto.Invoice {
+	return dto.Invoice{
+		ID:         invoice.ID,
+		CustomerID: invoice.CustomerID,
+		Amount:     CurrencyAmountFromProtocol(invoice.Amount),
+		Currency:   invoice.Currency,
+		DueDate:    invoice.DueDate,
+		CreatedAt:  invoice.CreatedAt,
+		UpdatedAt:  invoice.UpdatedAt,
+		Items:      ItemsFromProtocol(invoice.Items),
+		Notes:      invoice.Notes,
+	}
+}
+
+func ItemsFromProtocol(items []client.Item) []dto.Item {
+	res := make([]dto.Item, len(items))
+	for i, item := range items {
+		res[i] = ItemFromProtocol(item)
+	}
+	return res
+}
+
+func ItemFromProtocol(item client.Item) dto.Item {
+	return dto.Item{
+		Description: item.Description,
+		Quantity:    item.Quantity,
+		UnitPrice:   CurrencyAmountFromProtocol(item.UnitPrice),
+		Total:       CurrencyAmountFromProtocol(item.Total),
+	}
+}
+
+func InvoiceToProtocol(invoice dto.Invoice) client.Invoice {
+	return client.Invoice{
+		ID:         invoice.ID,
+		CustomerID: invoice.CustomerID,
+		Amount:     CurrencyAmountToProtocol(invoice.Amount),
+		Currency:   invoice.Currency,
+		DueDate:    invoice.DueDate,
+		CreatedAt:  invoice.CreatedAt,
+		UpdatedAt:  invoice.UpdatedAt,
+		Items:      ItemsToProtocol(invoice.Items),
+		Notes:      invoice.Notes,
+	}
+}
+
+func ItemsToProtocol(items []dto.Item) []client.Item {
+	res := make([]client.Item, len(items))
+	for i, item := range items {
+		res[i] = ItemToProtocol(item)
+	}
+	return res
+}
+
+func ItemToProtocol(item dto.Item) client.Item {
+	return client.Item{
+		Description: item.Description,
+		Quantity:    item.Quantity,
+		UnitPrice:   CurrencyAmountToProtocol(item.UnitPrice),
+		Total:       CurrencyAmountToProtocol(item.Total),
+	}
+}
+
+func CurrencyAmountFromProtocol(val decimal.Decimal) int64 {
+	return val.Mul(decimal.NewFromInt32(minorUnitsPerUnit)).IntPart()
+}
+
+func CurrencyAmountToProtocol(val int64) decimal.Decimal {
+	return decimal.NewFromInt(val).Div(decimal.NewFromInt32(minorUnitsPerUnit))
+}
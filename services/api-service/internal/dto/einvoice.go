@@ -0,0 +1,24 @@
+package dto
+
+import "github.com/shopspring/decimal"
+
+type Party struct {
+	Name        string
+	VATID       string
+	Street      string
+	City        string
+	PostalCode  string
+	CountryCode string
+}
+
+type TaxCategory struct {
+	Code            string
+	Percent         decimal.Decimal
+	ExemptionReason string
+}
+
+type EInvoiceParams struct {
+	Supplier Party
+	Customer Party
+	Tax      TaxCategory
+}
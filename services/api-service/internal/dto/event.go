@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type InvoiceEventType string
+
+const (
+	InvoiceEventCreated       InvoiceEventType = "Created"
+	InvoiceEventStatusChanged InvoiceEventType = "StatusChanged"
+)
+
+type InvoiceEvent struct {
+	Cursor     string
+	Type       InvoiceEventType
+	InvoiceID  uuid.UUID
+	Status     InvoiceStatus
+	OccurredAt time.Time
+}
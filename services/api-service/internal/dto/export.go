@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV     ExportFormat = "csv"
+	ExportFormatJSONL   ExportFormat = "jsonl"
+	ExportFormatParquet ExportFormat = "parquet"
+)
+
+type ExportDataset string
+
+const (
+	ExportDatasetInvoices ExportDataset = "invoices"
+	ExportDatasetItems    ExportDataset = "items"
+)
+
+// ExportFilter selects invoices created in [From, To). Zero values disable the
+// corresponding condition.
+type ExportFilter struct {
+	From       time.Time
+	To         time.Time
+	Status     InvoiceStatus
+	CustomerID uuid.UUID
+}
+
+type ExportedInvoice struct {
+	Invoice Invoice
+	Status  InvoiceStatus
+}
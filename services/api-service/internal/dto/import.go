@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ImportFormat string
+
+const (
+	ImportFormatCSV   ImportFormat = "csv"
+	ImportFormatJSONL ImportFormat = "jsonl"
+)
+
+type ImportJobStatus string
+
+const (
+	ImportStatusRunning   ImportJobStatus = "Running"
+	ImportStatusCompleted ImportJobStatus = "Completed"
+	ImportStatusFailed    ImportJobStatus = "Failed"
+)
+
+type ImportJob struct {
+	ID            uuid.UUID
+	Format        ImportFormat
+	Status        ImportJobStatus
+	ProcessedRows int64
+	SucceededRows int64
+	FailedRows    int64
+	Error         string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// ImportRow is a single record of an import file. Rows are numbered from 1 in file order,
+// a row with a non-empty Error is reported as failed without reaching the database.
+type ImportRow struct {
+	Number  int64
+	Invoice Invoice
+	Error   string
+}
+
+type ImportRowResult struct {
+	Number    int64
+	InvoiceID uuid.UUID
+	Error     string
+}
+
+// ImportResultFilter selects a page of job results: results of rows numbered after
+// AfterRow, at most Limit of them or the storage default if Limit is zero.
+type ImportResultFilter struct {
+	OnlyFailed bool
+	AfterRow   int64
+	Limit      int32
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type OutboxMessageStatus string
+
+const (
+	OutboxStatusPending   OutboxMessageStatus = "Pending"
+	OutboxStatusParked    OutboxMessageStatus = "Parked"
+	OutboxStatusPublished OutboxMessageStatus = "Published"
+)
+
+type OutboxMessage struct {
+	ID      int64
+	Topic   string
+	Key     string
+	Headers map[string]string
+	Payload []byte
+	// Data is the event data as JSON.
+	Data []byte
+	// AggregateID is uuid.Nil for messages written before aggregates were recorded.
+	AggregateID uuid.UUID
+	Sequence    int64
+	Status      OutboxMessageStatus
+	Attempts    int32
+	LastError   string
+	// FirstFailedAt is zero if no attempt has failed yet.
+	FirstFailedAt time.Time
+	NextSendAt    time.Time
+	CreatedAt     time.Time
+	// PublishedAt is zero unless the message is published.
+	PublishedAt time.Time
+}
+
+type OutboxMessageFilter struct {
+	BeforeID int64
+	Limit    int32
+}
+
+type PublishedOutboxMessageFilter struct {
+	InvoiceID uuid.UUID
+	BeforeID  int64
+	Limit     int32
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type AgingBucket struct {
+	Currency string
+	Bucket   string
+	Invoices int64
+	Amount   int64
+}
+
+type AgingReport struct {
+	AsOf    time.Time
+	Buckets []AgingBucket
+}
+
+// ReportPeriod covers the calendar dates From to To, both inclusive, in Location.
+type ReportPeriod struct {
+	From     time.Time
+	To       time.Time
+	Location *time.Location
+}
+
+type RevenueRow struct {
+	Month      time.Time
+	Currency   string
+	CustomerID *uuid.UUID
+	Invoices   int64
+	Amount     int64
+}
+
+type DSORow struct {
+	Currency    string
+	Receivables int64
+	Sales       int64
+	Days        int32
+	DSO         *float64
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookStatusNil       WebhookDeliveryStatus = ""
+	WebhookStatusPending   WebhookDeliveryStatus = "Pending"
+	WebhookStatusSucceeded WebhookDeliveryStatus = "Succeeded"
+	WebhookStatusDead      WebhookDeliveryStatus = "Dead"
+)
+
+type WebhookEndpoint struct {
+	ID         uuid.UUID
+	URL        string
+	Secret     string
+	EventTypes []string
+	CreatedAt  time.Time
+}
+
+type WebhookDelivery struct {
+	ID            int64
+	EndpointID    uuid.UUID
+	EventType     string
+	Payload       []byte
+	Status        WebhookDeliveryStatus
+	Attempts      int32
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type WebhookAttempt struct {
+	Attempt     int32
+	StatusCode  int32
+	Error       string
+	Response    string
+	Duration    time.Duration
+	AttemptedAt time.Time
+}
+
+type WebhookDeliveryFilter struct {
+	EndpointID uuid.UUID
+	Status     WebhookDeliveryStatus
+	BeforeID   int64
+	Limit      int32
+}
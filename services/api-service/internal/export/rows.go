@@ -0,0 +1,148 @@
+package export
+
+import (
+	"go-invoice-service/api-service/internal/convert"
+	"go-invoice-service/api-service/internal/dto"
+	"go-invoice-service/common/protocol/apiservice/client"
+	"strconv"
+	"time"
+)
+
+var invoiceColumns = []string{
+	"id",
+	"customer_id",
+	"amount",
+	"currency",
+	"due_date",
+	"created_at",
+	"updated_at",
+	"status",
+	"notes",
+	"items_count",
+}
+
+var itemColumns = []string{
+	"invoice_id",
+	"customer_id",
+	"currency",
+	"status",
+	"invoice_created_at",
+	"line",
+	"description",
+	"quantity",
+	"unit_price",
+	"total",
+}
+
+// Amounts are stored as DECIMAL(18, 3), which matches the thousandths kept by the storage.
+type invoiceRow struct {
+	ID         string `parquet:"name=id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	CustomerID string `parquet:"name=customer_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Amount     int64  `parquet:"name=amount, type=INT64, convertedtype=DECIMAL, scale=3, precision=18"`
+	Currency   string `parquet:"name=currency, type=BYTE_ARRAY, convertedtype=UTF8"`
+	DueDate    int32  `parquet:"name=due_date, type=INT32, convertedtype=DATE"`
+	CreatedAt  int64  `parquet:"name=created_at, type=INT64, convertedtype=TIMESTAMP_MILLIS"`
+	UpdatedAt  int64  `parquet:"name=updated_at, type=INT64, convertedtype=TIMESTAMP_MILLIS"`
+	Status     string `parquet:"name=status, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Notes      string `parquet:"name=notes, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ItemsCount int32  `parquet:"name=items_count, type=INT32"`
+}
+
+type itemRow struct {
+	InvoiceID        string `parquet:"name=invoice_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	CustomerID       string `parquet:"name=customer_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Currency         string `parquet:"name=currency, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Status           string `parquet:"name=status, type=BYTE_ARRAY, convertedtype=UTF8"`
+	InvoiceCreatedAt int64  `parquet:"name=invoice_created_at, type=INT64, convertedtype=TIMESTAMP_MILLIS"`
+	Line             int32  `parquet:"name=line, type=INT32"`
+	Description      string `parquet:"name=description, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Quantity         int32  `parquet:"name=quantity, type=INT32"`
+	UnitPrice        int64  `parquet:"name=unit_price, type=INT64, convertedtype=DECIMAL, scale=3, precision=18"`
+	Total            int64  `parquet:"name=total, type=INT64, convertedtype=DECIMAL, scale=3, precision=18"`
+}
+
+func newInvoiceRow(invoice dto.ExportedInvoice) invoiceRow {
+	return invoiceRow{
+		ID:         invoice.Invoice.ID.String(),
+		CustomerID: invoice.Invoice.CustomerID.String(),
+		Amount:     invoice.Invoice.Amount,
+		Currency:   invoice.Invoice.Currency,
+		DueDate:    daysSinceEpoch(invoice.Invoice.DueDate),
+		CreatedAt:  invoice.Invoice.CreatedAt.UnixMilli(),
+		UpdatedAt:  invoice.Invoice.UpdatedAt.UnixMilli(),
+		Status:     string(invoice.Status),
+		Notes:      invoice.Invoice.Notes,
+		ItemsCount: int32(len(invoice.Invoice.Items)),
+	}
+}
+
+func newItemRow(invoice dto.ExportedInvoice, line int32, item dto.Item) itemRow {
+	return itemRow{
+		InvoiceID:        invoice.Invoice.ID.String(),
+		CustomerID:       invoice.Invoice.CustomerID.String(),
+		Currency:         invoice.Invoice.Currency,
+		Status:           string(invoice.Status),
+		InvoiceCreatedAt: invoice.Invoice.CreatedAt.UnixMilli(),
+		Line:             line,
+		Description:      item.Description,
+		Quantity:         item.Quantity,
+		UnitPrice:        item.UnitPrice,
+		Total:            item.Total,
+	}
+}
+
+func invoiceRecord(invoice dto.ExportedInvoice) []string {
+	return []string{
+		invoice.Invoice.ID.String(),
+		invoice.Invoice.CustomerID.String(),
+		convert.CurrencyAmountToProtocol(invoice.Invoice.Amount).String(),
+		invoice.Invoice.Currency,
+		invoice.Invoice.DueDate.UTC().Format(time.DateOnly),
+		invoice.Invoice.CreatedAt.UTC().Format(time.RFC3339),
+		invoice.Invoice.UpdatedAt.UTC().Format(time.RFC3339),
+		string(invoice.Status),
+		invoice.Invoice.Notes,
+		strconv.Itoa(len(invoice.Invoice.Items)),
+	}
+}
+
+func itemRecord(invoice dto.ExportedInvoice, line int32, item dto.Item) []string {
+	return []string{
+		invoice.Invoice.ID.String(),
+		invoice.Invoice.CustomerID.String(),
+		invoice.Invoice.Currency,
+		string(invoice.Status),
+		invoice.Invoice.CreatedAt.UTC().Format(time.RFC3339),
+		strconv.Itoa(int(line)),
+		item.Description,
+		strconv.Itoa(int(item.Quantity)),
+		convert.CurrencyAmountToProtocol(item.UnitPrice).String(),
+		convert.CurrencyAmountToProtocol(item.Total).String(),
+	}
+}
+
+func invoiceToProtocol(invoice dto.ExportedInvoice) client.ExportedInvoice {
+	return client.ExportedInvoice{
+		Invoice: convert.InvoiceToProtocol(invoice.Invoice),
+		Status:  client.InvoiceStatus(invoice.Status),
+	}
+}
+
+func itemToProtocol(invoice dto.ExportedInvoice, line int32, item dto.Item) client.ExportedItem {
+	return client.ExportedItem{
+		InvoiceID:        invoice.Invoice.ID,
+		CustomerID:       invoice.Invoice.CustomerID,
+		Currency:         invoice.Invoice.Currency,
+		Status:           client.InvoiceStatus(invoice.Status),
+		InvoiceCreatedAt: invoice.Invoice.CreatedAt,
+		Line:             line,
+		Description:      item.Description,
+		Quantity:         item.Quantity,
+		UnitPrice:        convert.CurrencyAmountToProtocol(item.UnitPrice),
+		Total:            convert.CurrencyAmountToProtocol(item.Total),
+	}
+}
+
+func daysSinceEpoch(date time.Time) int32 {
+	return int32(date.UTC().Unix() / (24 * 60 * 60))
+}
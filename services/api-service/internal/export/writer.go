@@ -0,0 +1,215 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-invoice-service/api-service/internal/dto"
+	"io"
+	"strings"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+var (
+	ErrUnknownFormat  = errors.New("unknown export format")
+	ErrUnknownDataset = errors.New("unknown export dataset")
+)
+
+// parquetRowGroupSize bounds the rows buffered by the parquet writer before they are flushed.
+const parquetRowGroupSize = 8 * 1024 * 1024
+
+// Writer serializes exported invoices. Flush pushes buffered rows to the underlying writer,
+// Close writes format trailers (the parquet footer) and must be called once all rows are written.
+type Writer interface {
+	Write(invoice dto.ExportedInvoice) error
+	Flush() error
+	Close() error
+}
+
+func ParseFormat(format string) (dto.ExportFormat, error) {
+	switch dto.ExportFormat(strings.ToLower(format)) {
+	case dto.ExportFormatCSV:
+		return dto.ExportFormatCSV, nil
+	case dto.ExportFormatJSONL:
+		return dto.ExportFormatJSONL, nil
+	case dto.ExportFormatParquet:
+		return dto.ExportFormatParquet, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+}
+
+func ParseDataset(dataset string) (dto.ExportDataset, error) {
+	switch dto.ExportDataset(strings.ToLower(dataset)) {
+	case "", dto.ExportDatasetInvoices:
+		return dto.ExportDatasetInvoices, nil
+	case dto.ExportDatasetItems:
+		return dto.ExportDatasetItems, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownDataset, dataset)
+}
+
+func ContentType(format dto.ExportFormat) string {
+	switch format {
+	case dto.ExportFormatCSV:
+		return "text/csv"
+	case dto.ExportFormatJSONL:
+		return "application/jsonl"
+	case dto.ExportFormatParquet:
+		return "application/vnd.apache.parquet"
+	}
+	return "application/octet-stream"
+}
+
+func NewWriter(format dto.ExportFormat, dataset dto.ExportDataset, w io.Writer) (Writer, error) {
+	switch format {
+	case dto.ExportFormatCSV:
+		return newCSVWriter(dataset, w)
+	case dto.ExportFormatJSONL:
+		return newJSONLWriter(dataset, w), nil
+	case dto.ExportFormatParquet:
+		return newParquetWriter(dataset, w)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+}
+
+type csvWriter struct {
+	w       *csv.Writer
+	dataset dto.ExportDataset
+}
+
+func newCSVWriter(dataset dto.ExportDataset, w io.Writer) (*csvWriter, error) {
+	res := &csvWriter{
+		w:       csv.NewWriter(w),
+		dataset: dataset,
+	}
+
+	header := invoiceColumns
+	if dataset == dto.ExportDatasetItems {
+		header = itemColumns
+	}
+	if err := res.w.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	return res, nil
+}
+
+func (c *csvWriter) Write(invoice dto.ExportedInvoice) error {
+	if c.dataset == dto.ExportDatasetItems {
+		for i, item := range invoice.Invoice.Items {
+			if err := c.w.Write(itemRecord(invoice, int32(i+1), item)); err != nil {
+				return fmt.Errorf("failed to write csv record: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := c.w.Write(invoiceRecord(invoice)); err != nil {
+		return fmt.Errorf("failed to write csv record: %w", err)
+	}
+	return nil
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	return c.Flush()
+}
+
+type jsonlWriter struct {
+	buf     *bufio.Writer
+	encoder *json.Encoder
+	dataset dto.ExportDataset
+}
+
+func newJSONLWriter(dataset dto.ExportDataset, w io.Writer) *jsonlWriter {
+	buf := bufio.NewWriter(w)
+	return &jsonlWriter{
+		buf:     buf,
+		encoder: json.NewEncoder(buf),
+		dataset: dataset,
+	}
+}
+
+func (j *jsonlWriter) Write(invoice dto.ExportedInvoice) error {
+	if j.dataset == dto.ExportDatasetItems {
+		for i, item := range invoice.Invoice.Items {
+			if err := j.encoder.Encode(itemToProtocol(invoice, int32(i+1), item)); err != nil {
+				return fmt.Errorf("failed to write json line: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := j.encoder.Encode(invoiceToProtocol(invoice)); err != nil {
+		return fmt.Errorf("failed to write json line: %w", err)
+	}
+	return nil
+}
+
+func (j *jsonlWriter) Flush() error {
+	if err := j.buf.Flush(); err != nil {
+		return fmt.Errorf("failed to flush json lines: %w", err)
+	}
+	return nil
+}
+
+func (j *jsonlWriter) Close() error {
+	return j.Flush()
+}
+
+type parquetWriter struct {
+	w       *writer.ParquetWriter
+	dataset dto.ExportDataset
+}
+
+func newParquetWriter(dataset dto.ExportDataset, w io.Writer) (*parquetWriter, error) {
+	var schema any = new(invoiceRow)
+	if dataset == dto.ExportDatasetItems {
+		schema = new(itemRow)
+	}
+
+	pw, err := writer.NewParquetWriterFromWriter(w, schema, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create parquet writer: %w", err)
+	}
+	pw.RowGroupSize = parquetRowGroupSize
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+
+	return &parquetWriter{
+		w:       pw,
+		dataset: dataset,
+	}, nil
+}
+
+func (p *parquetWriter) Write(invoice dto.ExportedInvoice) error {
+	if p.dataset == dto.ExportDatasetItems {
+		for i, item := range invoice.Invoice.Items {
+			if err := p.w.Write(newItemRow(invoice, int32(i+1), item)); err != nil {
+				return fmt.Errorf("failed to write parquet row: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := p.w.Write(newInvoiceRow(invoice)); err != nil {
+		return fmt.Errorf("failed to write parquet row: %w", err)
+	}
+	return nil
+}
+
+// Flush is a no-op: parquet data is written in whole row groups.
+func (p *parquetWriter) Flush() error {
+	return nil
+}
+
+func (p *parquetWriter) Close() error {
+	if err := p.w.WriteStop(); err != nil {
+		return fmt.Errorf("failed to write parquet footer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"go-invoice-service/api-service/internal/dto"
+	"go-invoice-service/common/protocol/apiservice/client"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func TestWriter_CSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		dataset dto.ExportDataset
+		header  []string
+		rows    int
+	}{
+		{name: "invoices", dataset: dto.ExportDatasetInvoices, header: invoiceColumns, rows: 2},
+		{name: "items", dataset: dto.ExportDatasetItems, header: itemColumns, rows: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeAll(t, dto.ExportFormatCSV, test.dataset, &buf)
+
+			records, err := csv.NewReader(&buf).ReadAll()
+			require.NoError(t, err)
+			require.Len(t, records, test.rows+1)
+			assert.Equal(t, test.header, records[0])
+		})
+	}
+
+	var buf bytes.Buffer
+	writeAll(t, dto.ExportFormatCSV, dto.ExportDatasetInvoices, &buf)
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, "1050", records[1][2])
+	assert.Equal(t, "2025-06-30", records[1][4])
+	assert.Equal(t, "Approved", records[1][7])
+	assert.Equal(t, "2", records[1][9])
+}
+
+func TestWriter_JSONL(t *testing.T) {
+	var buf bytes.Buffer
+	writeAll(t, dto.ExportFormatJSONL, dto.ExportDatasetInvoices, &buf)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var invoice client.ExportedInvoice
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &invoice))
+	assert.Equal(t, client.StatusApproved, invoice.Status)
+	assert.True(t, decimal.NewFromInt(1050).Equal(invoice.Amount))
+	assert.Len(t, invoice.Items, 2)
+}
+
+func TestWriter_Parquet(t *testing.T) {
+	var buf bytes.Buffer
+	writeAll(t, dto.ExportFormatParquet, dto.ExportDatasetItems, &buf)
+
+	file, err := buffer.NewBufferFile(buf.Bytes())
+	require.NoError(t, err)
+	pr, err := reader.NewParquetReader(file, new(itemRow), 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+
+	require.Equal(t, int64(3), pr.GetNumRows())
+	rows := make([]itemRow, 3)
+	require.NoError(t, pr.Read(&rows))
+	assert.Equal(t, "Hosting", rows[1].Description)
+	assert.Equal(t, int64(25_000), rows[1].UnitPrice)
+	assert.Equal(t, int32(2), rows[1].Line)
+}
+
+func writeAll(t *testing.T, format dto.ExportFormat, dataset dto.ExportDataset, buf *bytes.Buffer) {
+	t.Helper()
+
+	writer, err := NewWriter(format, dataset, buf)
+	require.NoError(t, err)
+	for _, invoice := range createInvoices() {
+		require.NoError(t, writer.Write(invoice))
+	}
+	require.NoError(t, writer.Close())
+}
+
+func createInvoices() []dto.ExportedInvoice {
+	created := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	return []dto.ExportedInvoice{
+		{
+			Invoice: dto.Invoice{
+				ID:         uuid.New(),
+				CustomerID: uuid.New(),
+				Amount:     1_050_000,
+				Currency:   "EUR",
+				DueDate:    time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC),
+				CreatedAt:  created,
+				UpdatedAt:  created,
+				Items: []dto.Item{
+					{Description: "Design", Quantity: 1, UnitPrice: 1_000_000, Total: 1_000_000},
+					{Description: "Hosting", Quantity: 2, UnitPrice: 25_000, Total: 50_000},
+				},
+				Notes: "Payment due within 30 days.",
+			},
+			Status: dto.StatusApproved,
+		},
+		{
+			Invoice: dto.Invoice{
+				ID:         uuid.New(),
+				CustomerID: uuid.New(),
+				Amount:     5_500,
+				Currency:   "USD",
+				DueDate:    time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+				CreatedAt:  created.Add(time.Hour),
+				UpdatedAt:  created.Add(time.Hour),
+				Items: []dto.Item{
+					{Description: "Fee", Quantity: 1, UnitPrice: 5_500, Total: 5_500},
+				},
+			},
+			Status: dto.StatusPending,
+		},
+	}
+}
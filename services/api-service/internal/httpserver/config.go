@@ -5,4 +5,6 @@ import "time"
 type Config struct {
 	ServerAddress   string
 	ShutdownTimeout time.Duration
+	// MaxImportFileBytes limits the size of an uploaded import file.
+	MaxImportFileBytes int64
 }
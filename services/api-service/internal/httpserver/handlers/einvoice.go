@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"go-invoice-service/api-service/internal/dto"
+	"go-invoice-service/api-service/internal/ubl"
+	"go-invoice-service/common/pkg/http/utils"
+	"go-invoice-service/common/pkg/logging"
+	"go-invoice-service/common/protocol/apiservice/client"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type EInvoice struct {
+	storageService StorageService
+	logger         *logging.ZapLogger
+}
+
+func NewEInvoice(storageService StorageService, logger *logging.ZapLogger) *EInvoice {
+	return &EInvoice{
+		storageService: storageService,
+		logger:         logger,
+	}
+}
+
+func (h *EInvoice) ExportUBL(w http.ResponseWriter, r *http.Request) {
+	requestJSON, err := utils.DecodeJSON[client.ExportUBLRequest](r.Body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	invoice, _, err := h.storageService.Get(r.Context(), requestJSON.ID)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to get invoice", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	doc := ubl.Export(invoice, eInvoiceParamsFromProtocol(requestJSON))
+	if err := ubl.Validate(doc); err != nil {
+		var validationErr *ubl.ValidationError
+		if errors.As(err, &validationErr) {
+			h.logger.WarnCtx(r.Context(), "UBL invoice violates EN 16931", zap.Error(err))
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		h.logger.ErrorCtx(r.Context(), "Failed to validate UBL invoice", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.xml\"", invoice.ID))
+	if err := ubl.Encode(w, doc); err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to encode UBL invoice", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func eInvoiceParamsFromProtocol(req client.ExportUBLRequest) dto.EInvoiceParams {
+	return dto.EInvoiceParams{
+		Supplier: partyFromProtocol(req.Supplier),
+		Customer: partyFromProtocol(req.Customer),
+		Tax: dto.TaxCategory{
+			Code:            req.Tax.Code,
+			Percent:         req.Tax.Percent,
+			ExemptionReason: req.Tax.ExemptionReason,
+		},
+	}
+}
+
+func partyFromProtocol(party client.Party) dto.Party {
+	return dto.Party{
+		Name:        party.Name,
+		VATID:       party.VATID,
+		Street:      party.Street,
+		City:        party.City,
+		PostalCode:  party.PostalCode,
+		CountryCode: party.CountryCode,
+	}
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-invoice-service/api-service/internal/dto"
+	"go-invoice-service/api-service/internal/services"
+	"go-invoice-service/common/pkg/logging"
+	"go-invoice-service/common/protocol/apiservice/client"
+	"go.uber.org/zap"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	eventsHeartbeatInterval = 15 * time.Second
+	eventsRetryMillis       = 3000
+)
+
+type FeedService interface {
+	WatchInvoices(ctx context.Context, cursor string, fromStart bool) (<-chan dto.InvoiceEvent, <-chan error, error)
+}
+
+type Events struct {
+	feedService FeedService
+	logger      *logging.ZapLogger
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+func NewEvents(feedService FeedService, logger *logging.ZapLogger) *Events {
+	return &Events{
+		feedService: feedService,
+		logger:      logger,
+		done:        make(chan struct{}),
+	}
+}
+
+// Close ends all subscriptions, otherwise they would block the server shutdown.
+func (h *Events) Close() {
+	h.closeOnce.Do(func() {
+		close(h.done)
+	})
+}
+
+// Subscribe streams invoice events as Server-Sent Events. The event id is the feed cursor,
+// so a reconnecting EventSource resumes after the last received event via Last-Event-ID.
+func (h *Events) Subscribe(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	cursor := r.Header.Get("Last-Event-ID")
+	if cursor == "" {
+		cursor = r.URL.Query().Get("cursor")
+	}
+	fromStart := r.URL.Query().Get("from_start") == "true"
+
+	events, errCh, err := h.feedService.WatchInvoices(ctx, cursor, fromStart)
+	if err != nil {
+		h.logger.ErrorCtx(ctx, "Failed to subscribe to invoice events", zap.Error(err))
+		if errors.Is(err, services.ErrInvalidEventCursor) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", eventsRetryMillis); err != nil {
+		return
+	}
+	if err := rc.Flush(); err != nil {
+		h.logger.ErrorCtx(ctx, "Response does not support streaming", zap.Error(err))
+		return
+	}
+
+	heartbeat := time.NewTicker(eventsHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.done:
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				if err := <-errCh; err != nil {
+					h.logger.ErrorCtx(ctx, "Invoice events feed failed", zap.Error(err))
+				}
+				return
+			}
+			if err := writeInvoiceEvent(w, event); err != nil {
+				h.logger.ErrorCtx(ctx, "Failed to write invoice event", zap.Error(err))
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeInvoiceEvent(w http.ResponseWriter, event dto.InvoiceEvent) error {
+	data, err := json.Marshal(client.InvoiceEvent{
+		Cursor:     event.Cursor,
+		Type:       client.InvoiceEventType(event.Type),
+		InvoiceID:  event.InvoiceID,
+		Status:     client.InvoiceStatus(event.Status),
+		OccurredAt: event.OccurredAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.Cursor, event.Type, data)
+	return err
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-invoice-service/api-service/internal/dto"
+	"go-invoice-service/api-service/internal/export"
+	"go-invoice-service/common/pkg/http/utils"
+	"go-invoice-service/common/pkg/logging"
+	"go-invoice-service/common/protocol/apiservice/client"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	exportStatusTrailer   = "X-Export-Status"
+	exportInvoicesTrailer = "X-Export-Invoices"
+)
+
+type ExportService interface {
+	ExportInvoices(ctx context.Context, filter dto.ExportFilter, consume func([]dto.ExportedInvoice) error) error
+}
+
+type Export struct {
+	exportService ExportService
+	logger        *logging.ZapLogger
+}
+
+func NewExport(exportService ExportService, logger *logging.ZapLogger) *Export {
+	return &Export{
+		exportService: exportService,
+		logger:        logger,
+	}
+}
+
+// Export streams the matching invoices as they are read from the storage. Since the status
+// code is sent with the first rows, the outcome is reported in the X-Export-Status trailer.
+func (h *Export) Export(w http.ResponseWriter, r *http.Request) {
+	requestJSON, err := utils.DecodeJSON[client.ExportInvoicesRequest](r.Body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	format, err := export.ParseFormat(requestJSON.Format)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Invalid export format", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	dataset, err := export.ParseDataset(requestJSON.Dataset)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Invalid export dataset", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if requestJSON.Status != "" {
+		if _, err := statusFromProtocol(requestJSON.Status); err != nil {
+			h.logger.ErrorCtx(r.Context(), "Invalid export status filter", zap.Error(err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", export.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(
+		"attachment; filename=\"%s-%s.%s\"",
+		dataset,
+		time.Now().UTC().Format("20060102T150405Z"),
+		format,
+	))
+	w.Header().Set("Trailer", exportStatusTrailer+", "+exportInvoicesTrailer)
+
+	body := &trackingWriter{w: w}
+	writer, err := export.NewWriter(format, dataset, body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to create export writer", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var exported int64
+	err = h.exportService.ExportInvoices(r.Context(), exportFilterFromProtocol(requestJSON), func(invoices []dto.ExportedInvoice) error {
+		for _, invoice := range invoices {
+			if err := writer.Write(invoice); err != nil {
+				return err
+			}
+		}
+		exported += int64(len(invoices))
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = writer.Close()
+	}
+
+	w.Header().Set(exportInvoicesTrailer, strconv.FormatInt(exported, 10))
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to export invoices", zap.Error(err))
+		w.Header().Set(exportStatusTrailer, "failed")
+		if !body.written && !errors.Is(err, context.Canceled) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set(exportStatusTrailer, "completed")
+	h.logger.InfoCtx(r.Context(), fmt.Sprintf("Exported %d invoices", exported))
+}
+
+func exportFilterFromProtocol(req client.ExportInvoicesRequest) dto.ExportFilter {
+	filter := dto.ExportFilter{
+		Status: dto.InvoiceStatus(req.Status),
+	}
+	if req.From != nil {
+		filter.From = *req.From
+	}
+	if req.To != nil {
+		filter.To = *req.To
+	}
+	if req.CustomerID != nil {
+		filter.CustomerID = *req.CustomerID
+	}
+	return filter
+}
+
+func statusFromProtocol(status client.InvoiceStatus) (dto.InvoiceStatus, error) {
+	switch status {
+	case client.StatusPending:
+		return dto.StatusPending, nil
+	case client.StatusApproved:
+		return dto.StatusApproved, nil
+	case client.StatusRejected:
+		return dto.StatusRejected, nil
+	}
+	return "", fmt.Errorf("invalid status: %s", status)
+}
+
+// trackingWriter records whether the response body has been started, after which
+// the status code can no longer be changed.
+type trackingWriter struct {
+	w       io.Writer
+	written bool
+}
+
+func (t *trackingWriter) Write(p []byte) (int, error) {
+	t.written = true
+	return t.w.Write(p) //nolint:wrapcheck // wrapping unnecessary
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"go-invoice-service/api-service/internal/bulkimport"
+	"go-invoice-service/api-service/internal/dto"
+	"go-invoice-service/common/pkg/http/utils"
+	"go-invoice-service/common/pkg/logging"
+	"go-invoice-service/common/protocol/apiservice/client"
+	"go.uber.org/zap"
+	"io"
+	"mime"
+	"net/http"
+)
+
+type ImportManager interface {
+	Start(ctx context.Context, format dto.ImportFormat, body io.Reader) (dto.ImportJob, error)
+	Resume(ctx context.Context, id uuid.UUID) (dto.ImportJob, error)
+	Status(ctx context.Context, id uuid.UUID, filter dto.ImportResultFilter) (dto.ImportJob, []dto.ImportRowResult, error)
+}
+
+type Import struct {
+	importManager ImportManager
+	maxFileBytes  int64
+	logger        *logging.ZapLogger
+}
+
+func NewImport(importManager ImportManager, maxFileBytes int64, logger *logging.ZapLogger) *Import {
+	return &Import{
+		importManager: importManager,
+		maxFileBytes:  maxFileBytes,
+		logger:        logger,
+	}
+}
+
+func (h *Import) Start(w http.ResponseWriter, r *http.Request) {
+	format, err := importFormatFromRequest(r)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to detect import format", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.importManager.Start(r.Context(), format, http.MaxBytesReader(w, r.Body, h.maxFileBytes))
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to start import job", zap.Error(err))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJob(w, r, job)
+}
+
+func (h *Import) Resume(w http.ResponseWriter, r *http.Request) {
+	requestJSON, err := utils.DecodeJSON[client.ResumeImportJobRequest](r.Body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.importManager.Resume(r.Context(), requestJSON.ID)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to resume import job", zap.Error(err))
+		switch {
+		case errors.Is(err, bulkimport.ErrJobFinished), errors.Is(err, bulkimport.ErrJobInProgress):
+			w.WriteHeader(http.StatusConflict)
+		case errors.Is(err, bulkimport.ErrSpoolFileGone):
+			w.WriteHeader(http.StatusGone)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.writeJob(w, r, job)
+}
+
+func (h *Import) Status(w http.ResponseWriter, r *http.Request) {
+	requestJSON, err := utils.DecodeJSON[client.GetImportJobRequest](r.Body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	job, results, err := h.importManager.Status(r.Context(), requestJSON.ID, dto.ImportResultFilter{
+		OnlyFailed: requestJSON.OnlyFailed,
+		AfterRow:   requestJSON.AfterRow,
+		Limit:      requestJSON.Limit,
+	})
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to get import job", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	resp := client.GetImportJobResponse{
+		Job:     importJobToProtocol(job),
+		Results: importRowResultsToProtocol(results),
+	}
+
+	err = utils.EncodeJSON(w, resp)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to encode response", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (h *Import) writeJob(w http.ResponseWriter, r *http.Request, job dto.ImportJob) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := utils.EncodeJSON(w, importJobToProtocol(job)); err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to encode response", zap.Error(err))
+	}
+}
+
+func importFormatFromRequest(r *http.Request) (dto.ImportFormat, error) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return bulkimport.ParseFormat(format)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", bulkimport.ErrUnknownFormat
+	}
+	switch mediaType {
+	case "text/csv":
+		return dto.ImportFormatCSV, nil
+	case "application/jsonl", "application/x-ndjson":
+		return dto.ImportFormatJSONL, nil
+	}
+	return "", bulkimport.ErrUnknownFormat
+}
+
+func importJobToProtocol(job dto.ImportJob) client.ImportJob {
+	return client.ImportJob{
+		ID:            job.ID,
+		Format:        string(job.Format),
+		Status:        client.ImportJobStatus(job.Status),
+		ProcessedRows: job.ProcessedRows,
+		SucceededRows: job.SucceededRows,
+		FailedRows:    job.FailedRows,
+		Error:         job.Error,
+		CreatedAt:     job.CreatedAt,
+		UpdatedAt:     job.UpdatedAt,
+	}
+}
+
+func importRowResultsToProtocol(results []dto.ImportRowResult) []client.ImportRowResult {
+	res := make([]client.ImportRowResult, len(results))
+
+	for i, result := range results {
+		res[i] = client.ImportRowResult{
+			Row:   result.Number,
+			Error: result.Error,
+		}
+		if result.InvoiceID != uuid.Nil {
+			res[i].InvoiceID = &result.InvoiceID
+		}
+	}
+
+	return res
+}
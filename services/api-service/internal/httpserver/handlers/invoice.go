@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"github.com/google/uuid"
-	"github.com/shopspring/decimal"
+	"go-invoice-service/api-service/internal/convert"
 	"go-invoice-service/api-service/internal/dto"
+	"go-invoice-service/api-service/internal/ubl"
 	"go-invoice-service/common/pkg/http/utils"
 	"go-invoice-service/common/pkg/logging"
 	"go-invoice-service/common/protocol/apiservice/client"
 	"go.uber.org/zap"
+	"mime"
 	"net/http"
 )
 
@@ -31,56 +33,37 @@ func NewInvoice(storageService StorageService, logger *logging.ZapLogger) *Invoi
 }
 
 func (h *Invoice) Upload(w http.ResponseWriter, r *http.Request) {
-	requestJSON, err := utils.DecodeJSON[client.UploadInvoiceRequest](r.Body)
+	invoice, err := decodeUploadedInvoice(r)
 	if err != nil {
 		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	err = h.storageService.Upload(r.Context(), invoiceFromProtocol(requestJSON.Invoice))
+	err = h.storageService.Upload(r.Context(), invoice)
 	if err != nil {
 		h.logger.ErrorCtx(r.Context(), "Failed to upload invoice", zap.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
 	}
 }
 
-func invoiceFromProtocol(invoice client.Invoice) dto.Invoice {
-	return dto.Invoice{
-		ID:         invoice.ID,
-		CustomerID: invoice.CustomerID,
-		Amount:     currencyAmountFromProtocol(invoice.Amount),
-		Currency:   invoice.Currency,
-		DueDate:    invoice.DueDate,
-		CreatedAt:  invoice.CreatedAt,
-		UpdatedAt:  invoice.UpdatedAt,
-		Items:      itemsFromProtocol(invoice.Items),
-		Notes:      invoice.Notes,
+func decodeUploadedInvoice(r *http.Request) (dto.Invoice, error) {
+	if isXMLContent(r) {
+		return ubl.Import(r.Body)
 	}
-}
-
-func itemsFromProtocol(items []client.Item) []dto.Item {
-	res := make([]dto.Item, len(items))
-	for i, item := range items {
-		res[i] = itemFromProtocol(item)
+	requestJSON, err := utils.DecodeJSON[client.UploadInvoiceRequest](r.Body)
+	if err != nil {
+		return dto.Invoice{}, err
 	}
-	return res
+	return convert.InvoiceFromProtocol(requestJSON.Invoice), nil
 }
 
-func itemFromProtocol(item client.Item) dto.Item {
-	return dto.Item{
-		Description: item.Description,
-		Quantity:    item.Quantity,
-		UnitPrice:   currencyAmountFromProtocol(item.UnitPrice),
-		Total:       currencyAmountFromProtocol(item.Total),
+func isXMLContent(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return false
 	}
-}
-
-func currencyAmountFromProtocol(val decimal.Decimal) int64 {
-	return val.Mul(decimal.NewFromInt32(1000)).IntPart()
-}
-func currencyAmountToProtocol(val int64) decimal.Decimal {
-	return decimal.NewFromInt(val).Div(decimal.NewFromInt32(1000))
+	return mediaType == "application/xml" || mediaType == "text/xml"
 }
 
 func (h *Invoice) Get(w http.ResponseWriter, r *http.Request) {
@@ -104,7 +87,7 @@ func (h *Invoice) Get(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := client.GetInvoiceResponse{
-		Invoice: *invoiceToProtocol(&invoice),
+		Invoice: convert.InvoiceToProtocol(invoice),
 		Status:  protocolStatus,
 	}
 
@@ -126,36 +109,3 @@ func statusToProtocol(status dto.InvoiceStatus) (client.InvoiceStatus, error) {
 	}
 	return "", fmt.Errorf("invalid status: %s", status)
 }
-
-func invoiceToProtocol(invoice *dto.Invoice) *client.Invoice {
-	return &client.Invoice{
-		ID:         invoice.ID,
-		CustomerID: invoice.CustomerID,
-		Amount:     currencyAmountToProtocol(invoice.Amount),
-		Currency:   invoice.Currency,
-		DueDate:    invoice.DueDate,
-		CreatedAt:  invoice.CreatedAt,
-		UpdatedAt:  invoice.UpdatedAt,
-		Items:      itemsToProtocol(invoice.Items),
-		Notes:      invoice.Notes,
-	}
-}
-
-func itemsToProtocol(items []dto.Item) []client.Item {
-	res := make([]client.Item, len(items))
-
-	for i, item := range items {
-		res[i] = itemToProtocol(item)
-	}
-
-	return res
-}
-
-func itemToProtocol(item dto.Item) client.Item {
-	return client.Item{
-		Description: item.Description,
-		Quantity:    item.Quantity,
-		UnitPrice:   currencyAmountToProtocol(item.UnitPrice),
-		Total:       currencyAmountToProtocol(item.Total),
-	}
-}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"go-invoice-service/api-service/internal/dto"
+	"go-invoice-service/api-service/internal/services"
+	"go-invoice-service/common/pkg/http/utils"
+	"go-invoice-service/common/pkg/logging"
+	"go-invoice-service/common/protocol/apiservice/client"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type OutboxService interface {
+	ListParkedOutboxMessages(ctx context.Context, filter dto.OutboxMessageFilter) ([]dto.OutboxMessage, error)
+	ListPublishedOutboxMessages(ctx context.Context, filter dto.PublishedOutboxMessageFilter) ([]dto.OutboxMessage, error)
+	GetOutboxMessage(ctx context.Context, id int64) (dto.OutboxMessage, error)
+	RequeueOutboxMessage(ctx context.Context, id int64) (dto.OutboxMessage, error)
+	PurgeOutboxMessage(ctx context.Context, id int64) error
+}
+
+type Outbox struct {
+	outboxService OutboxService
+	logger        *logging.ZapLogger
+}
+
+func NewOutbox(outboxService OutboxService, logger *logging.ZapLogger) *Outbox {
+	return &Outbox{
+		outboxService: outboxService,
+		logger:        logger,
+	}
+}
+
+func (h *Outbox) ListParked(w http.ResponseWriter, r *http.Request) {
+	requestJSON, err := utils.DecodeJSON[client.ListParkedOutboxMessagesRequest](r.Body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	messages, err := h.outboxService.ListParkedOutboxMessages(r.Context(), dto.OutboxMessageFilter{
+		BeforeID: requestJSON.BeforeID,
+		Limit:    requestJSON.Limit,
+	})
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to list parked outbox messages", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, r, client.ListParkedOutboxMessagesResponse{
+		Messages: outboxMessagesToProtocol(messages),
+	})
+}
+
+// ListPublished lists the messages published for an invoice, from the newest.
+func (h *Outbox) ListPublished(w http.ResponseWriter, r *http.Request) {
+	requestJSON, err := utils.DecodeJSON[client.ListPublishedOutboxMessagesRequest](r.Body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if requestJSON.InvoiceID == uuid.Nil {
+		h.logger.ErrorCtx(r.Context(), "Invoice id required")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	messages, err := h.outboxService.ListPublishedOutboxMessages(r.Context(), dto.PublishedOutboxMessageFilter{
+		InvoiceID: requestJSON.InvoiceID,
+		BeforeID:  requestJSON.BeforeID,
+		Limit:     requestJSON.Limit,
+	})
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to list published outbox messages", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, r, client.ListPublishedOutboxMessagesResponse{
+		Messages: outboxMessagesToProtocol(messages),
+	})
+}
+
+func (h *Outbox) Get(w http.ResponseWriter, r *http.Request) {
+	requestJSON, err := utils.DecodeJSON[client.GetOutboxMessageRequest](r.Body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	message, err := h.outboxService.GetOutboxMessage(r.Context(), requestJSON.ID)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to get outbox message", zap.Error(err))
+		writeOutboxError(w, err)
+		return
+	}
+
+	h.writeJSON(w, r, outboxMessageToProtocol(message))
+}
+
+func (h *Outbox) Requeue(w http.ResponseWriter, r *http.Request) {
+	requestJSON, err := utils.DecodeJSON[client.RequeueOutboxMessageRequest](r.Body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	message, err := h.outboxService.RequeueOutboxMessage(r.Context(), requestJSON.ID)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to requeue outbox message", zap.Error(err))
+		writeOutboxError(w, err)
+		return
+	}
+
+	h.writeJSON(w, r, outboxMessageToProtocol(message))
+}
+
+func (h *Outbox) Purge(w http.ResponseWriter, r *http.Request) {
+	requestJSON, err := utils.DecodeJSON[client.PurgeOutboxMessageRequest](r.Body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.outboxService.PurgeOutboxMessage(r.Context(), requestJSON.ID); err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to purge outbox message", zap.Error(err))
+		writeOutboxError(w, err)
+	}
+}
+
+func (h *Outbox) writeJSON(w http.ResponseWriter, r *http.Request, resp any) {
+	if err := utils.EncodeJSON(w, resp); err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to encode response", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// writeOutboxError responds with 404 also when requeue or purge target a message
+// that is not parked.
+func writeOutboxError(w http.ResponseWriter, err error) {
+	if errors.Is(err, services.ErrOutboxMessageNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusInternalServerError)
+}
+
+func outboxMessagesToProtocol(messages []dto.OutboxMessage) []client.OutboxMessage {
+	res := make([]client.OutboxMessage, len(messages))
+	for i, message := range messages {
+		res[i] = outboxMessageToProtocol(message)
+	}
+	return res
+}
+
+func outboxMessageToProtocol(message dto.OutboxMessage) client.OutboxMessage {
+	res := client.OutboxMessage{
+		ID:         message.ID,
+		Topic:      message.Topic,
+		Key:        message.Key,
+		Headers:    message.Headers,
+		Payload:    message.Payload,
+		Data:       message.Data,
+		Sequence:   message.Sequence,
+		Status:     client.OutboxMessageStatus(message.Status),
+		Attempts:   message.Attempts,
+		LastError:  message.LastError,
+		NextSendAt: message.NextSendAt,
+		CreatedAt:  message.CreatedAt,
+	}
+	if message.AggregateID != uuid.Nil {
+		res.AggregateID = &message.AggregateID
+	}
+	if !message.FirstFailedAt.IsZero() {
+		res.FirstFailedAt = &message.FirstFailedAt
+	}
+	if !message.PublishedAt.IsZero() {
+		res.PublishedAt = &message.PublishedAt
+	}
+	return res
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"go-invoice-service/api-service/internal/convert"
+	"go-invoice-service/api-service/internal/dto"
+	"go-invoice-service/common/pkg/http/utils"
+	"go-invoice-service/common/pkg/logging"
+	"go-invoice-service/common/protocol/apiservice/client"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+const reportDateLayout = time.DateOnly
+
+var errInvalidReportPeriod = errors.New("invalid report period")
+
+type ReportingService interface {
+	GetAgingReport(ctx context.Context, asOf time.Time, loc *time.Location) (dto.AgingReport, error)
+	GetRevenueReport(ctx context.Context, period dto.ReportPeriod, byCustomer bool) ([]dto.RevenueRow, error)
+	GetDSOReport(ctx context.Context, period dto.ReportPeriod) ([]dto.DSORow, error)
+}
+
+type Reporting struct {
+	reportingService ReportingService
+	logger           *logging.ZapLogger
+}
+
+func NewReporting(reportingService ReportingService, logger *logging.ZapLogger) *Reporting {
+	return &Reporting{
+		reportingService: reportingService,
+		logger:           logger,
+	}
+}
+
+func (h *Reporting) Aging(w http.ResponseWriter, r *http.Request) {
+	requestJSON, err := utils.DecodeJSON[client.AgingReportRequest](r.Body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	loc, err := time.LoadLocation(requestJSON.TimeZone)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Invalid time zone", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var asOf time.Time
+	if requestJSON.AsOf != "" {
+		asOf, err = time.Parse(reportDateLayout, requestJSON.AsOf)
+		if err != nil {
+			h.logger.ErrorCtx(r.Context(), "Invalid as_of date", zap.Error(err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	report, err := h.reportingService.GetAgingReport(r.Context(), asOf, loc)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to get aging report", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	buckets := make([]client.AgingBucket, len(report.Buckets))
+	for i, bucket := range report.Buckets {
+		buckets[i] = client.AgingBucket{
+			Currency: bucket.Currency,
+			Bucket:   bucket.Bucket,
+			Invoices: bucket.Invoices,
+			Amount:   convert.CurrencyAmountToProtocol(bucket.Amount),
+		}
+	}
+
+	h.writeResponse(w, r, client.AgingReportResponse{
+		AsOf:     report.AsOf.Format(reportDateLayout),
+		TimeZone: loc.String(),
+		Buckets:  buckets,
+	})
+}
+
+func (h *Reporting) Revenue(w http.ResponseWriter, r *http.Request) {
+	requestJSON, err := utils.DecodeJSON[client.RevenueReportRequest](r.Body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	period, err := reportPeriodFromProtocol(requestJSON.From, requestJSON.To, requestJSON.TimeZone)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Invalid report period", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	rows, err := h.reportingService.GetRevenueReport(r.Context(), period, requestJSON.ByCustomer)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to get revenue report", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]client.RevenueRow, len(rows))
+	for i, row := range rows {
+		res[i] = client.RevenueRow{
+			Month:      row.Month.Format("2006-01"),
+			Currency:   row.Currency,
+			CustomerID: row.CustomerID,
+			Invoices:   row.Invoices,
+			Amount:     convert.CurrencyAmountToProtocol(row.Amount),
+		}
+	}
+
+	h.writeResponse(w, r, client.RevenueReportResponse{
+		TimeZone: period.Location.String(),
+		Rows:     res,
+	})
+}
+
+func (h *Reporting) DSO(w http.ResponseWriter, r *http.Request) {
+	requestJSON, err := utils.DecodeJSON[client.DSOReportRequest](r.Body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	period, err := reportPeriodFromProtocol(requestJSON.From, requestJSON.To, requestJSON.TimeZone)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Invalid report period", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	rows, err := h.reportingService.GetDSOReport(r.Context(), period)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to get DSO report", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]client.DSORow, len(rows))
+	for i, row := range rows {
+		res[i] = client.DSORow{
+			Currency:    row.Currency,
+			Receivables: convert.CurrencyAmountToProtocol(row.Receivables),
+			Sales:       convert.CurrencyAmountToProtocol(row.Sales),
+			Days:        row.Days,
+		}
+		if row.DSO != nil {
+			dso := decimal.NewFromFloat(*row.DSO).Round(1)
+			res[i].DSO = &dso
+		}
+	}
+
+	h.writeResponse(w, r, client.DSOReportResponse{
+		TimeZone: period.Location.String(),
+		Rows:     res,
+	})
+}
+
+func (h *Reporting) writeResponse(w http.ResponseWriter, r *http.Request, resp any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := utils.EncodeJSON(w, resp); err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to encode response", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func reportPeriodFromProtocol(from string, to string, timeZone string) (dto.ReportPeriod, error) {
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return dto.ReportPeriod{}, fmt.Errorf("%w: invalid time zone: %w", errInvalidReportPeriod, err)
+	}
+
+	fromDate, err := time.Parse(reportDateLayout, from)
+	if err != nil {
+		return dto.ReportPeriod{}, fmt.Errorf("%w: invalid from date: %w", errInvalidReportPeriod, err)
+	}
+
+	toDate, err := time.Parse(reportDateLayout, to)
+	if err != nil {
+		return dto.ReportPeriod{}, fmt.Errorf("%w: invalid to date: %w", errInvalidReportPeriod, err)
+	}
+
+	if toDate.Before(fromDate) {
+		return dto.ReportPeriod{}, fmt.Errorf("%w: 'to' is before 'from'", errInvalidReportPeriod)
+	}
+
+	return dto.ReportPeriod{
+		From:     fromDate,
+		To:       toDate,
+		Location: loc,
+	}, nil
+}
@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go-invoice-service/api-service/internal/dto"
+	"go-invoice-service/api-service/internal/services"
+	"go-invoice-service/common/pkg/http/utils"
+	"go-invoice-service/common/pkg/logging"
+	"go-invoice-service/common/protocol/apiservice/client"
+	"go-invoice-service/common/protocol/kafka"
+	"go.uber.org/zap"
+	"net/http"
+	"net/url"
+	"slices"
+)
+
+type WebhookService interface {
+	CreateWebhookEndpoint(ctx context.Context, endpoint dto.WebhookEndpoint) (dto.WebhookEndpoint, error)
+	ListWebhookEndpoints(ctx context.Context) ([]dto.WebhookEndpoint, error)
+	DeleteWebhookEndpoint(ctx context.Context, id uuid.UUID) error
+	ListWebhookDeliveries(ctx context.Context, filter dto.WebhookDeliveryFilter) ([]dto.WebhookDelivery, error)
+	GetWebhookDelivery(ctx context.Context, id int64) (dto.WebhookDelivery, []dto.WebhookAttempt, error)
+	RedeliverWebhook(ctx context.Context, id int64) (dto.WebhookDelivery, error)
+}
+
+type Webhook struct {
+	webhookService WebhookService
+	logger         *logging.ZapLogger
+}
+
+func NewWebhook(webhookService WebhookService, logger *logging.ZapLogger) *Webhook {
+	return &Webhook{
+		webhookService: webhookService,
+		logger:         logger,
+	}
+}
+
+func (h *Webhook) CreateEndpoint(w http.ResponseWriter, r *http.Request) {
+	requestJSON, err := utils.DecodeJSON[client.CreateWebhookEndpointRequest](r.Body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := validateWebhookEndpoint(requestJSON); err != nil {
+		h.logger.ErrorCtx(r.Context(), "Invalid webhook endpoint", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	endpoint, err := h.webhookService.CreateWebhookEndpoint(r.Context(), dto.WebhookEndpoint{
+		ID:         requestJSON.ID,
+		URL:        requestJSON.URL,
+		Secret:     requestJSON.Secret,
+		EventTypes: requestJSON.EventTypes,
+	})
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to create webhook endpoint", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, r, webhookEndpointToProtocol(endpoint))
+}
+
+func (h *Webhook) ListEndpoints(w http.ResponseWriter, r *http.Request) {
+	endpoints, err := h.webhookService.ListWebhookEndpoints(r.Context())
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to list webhook endpoints", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	resp := client.ListWebhookEndpointsResponse{
+		Endpoints: make([]client.WebhookEndpoint, len(endpoints)),
+	}
+	for i, endpoint := range endpoints {
+		resp.Endpoints[i] = webhookEndpointToProtocol(endpoint)
+	}
+
+	h.writeJSON(w, r, resp)
+}
+
+func (h *Webhook) DeleteEndpoint(w http.ResponseWriter, r *http.Request) {
+	requestJSON, err := utils.DecodeJSON[client.DeleteWebhookEndpointRequest](r.Body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhookEndpoint(r.Context(), requestJSON.ID); err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to delete webhook endpoint", zap.Error(err))
+		writeWebhookError(w, err)
+	}
+}
+
+func (h *Webhook) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	requestJSON, err := utils.DecodeJSON[client.ListWebhookDeliveriesRequest](r.Body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	filter := dto.WebhookDeliveryFilter{
+		Status:   dto.WebhookDeliveryStatus(requestJSON.Status),
+		BeforeID: requestJSON.BeforeID,
+		Limit:    requestJSON.Limit,
+	}
+	if requestJSON.EndpointID != nil {
+		filter.EndpointID = *requestJSON.EndpointID
+	}
+	switch filter.Status {
+	case dto.WebhookStatusNil, dto.WebhookStatusPending, dto.WebhookStatusSucceeded, dto.WebhookStatusDead:
+	default:
+		h.logger.ErrorCtx(r.Context(), fmt.Sprintf("Invalid webhook delivery status: %s", filter.Status))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	deliveries, err := h.webhookService.ListWebhookDeliveries(r.Context(), filter)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to list webhook deliveries", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	resp := client.ListWebhookDeliveriesResponse{
+		Deliveries: make([]client.WebhookDelivery, len(deliveries)),
+	}
+	for i, delivery := range deliveries {
+		resp.Deliveries[i] = webhookDeliveryToProtocol(delivery)
+	}
+
+	h.writeJSON(w, r, resp)
+}
+
+func (h *Webhook) GetDelivery(w http.ResponseWriter, r *http.Request) {
+	requestJSON, err := utils.DecodeJSON[client.GetWebhookDeliveryRequest](r.Body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	delivery, attempts, err := h.webhookService.GetWebhookDelivery(r.Context(), requestJSON.ID)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to get webhook delivery", zap.Error(err))
+		writeWebhookError(w, err)
+		return
+	}
+
+	resp := client.GetWebhookDeliveryResponse{
+		Delivery: webhookDeliveryToProtocol(delivery),
+		Attempts: make([]client.WebhookAttempt, len(attempts)),
+	}
+	for i, attempt := range attempts {
+		resp.Attempts[i] = client.WebhookAttempt{
+			Attempt:     attempt.Attempt,
+			StatusCode:  attempt.StatusCode,
+			Error:       attempt.Error,
+			Response:    attempt.Response,
+			DurationMS:  attempt.Duration.Milliseconds(),
+			AttemptedAt: attempt.AttemptedAt,
+		}
+	}
+
+	h.writeJSON(w, r, resp)
+}
+
+func (h *Webhook) Redeliver(w http.ResponseWriter, r *http.Request) {
+	requestJSON, err := utils.DecodeJSON[client.RedeliverWebhookRequest](r.Body)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to decode request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	delivery, err := h.webhookService.RedeliverWebhook(r.Context(), requestJSON.ID)
+	if err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to redeliver webhook", zap.Error(err))
+		writeWebhookError(w, err)
+		return
+	}
+
+	h.writeJSON(w, r, webhookDeliveryToProtocol(delivery))
+}
+
+func (h *Webhook) writeJSON(w http.ResponseWriter, r *http.Request, resp any) {
+	if err := utils.EncodeJSON(w, resp); err != nil {
+		h.logger.ErrorCtx(r.Context(), "Failed to encode response", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func writeWebhookError(w http.ResponseWriter, err error) {
+	if errors.Is(err, services.ErrWebhookNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusInternalServerError)
+}
+
+func validateWebhookEndpoint(req client.CreateWebhookEndpointRequest) error {
+	if req.ID == uuid.Nil {
+		return errors.New("endpoint id is required")
+	}
+
+	endpointURL, err := url.Parse(req.URL)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint url: %w", err)
+	}
+	if (endpointURL.Scheme != "http" && endpointURL.Scheme != "https") || endpointURL.Host == "" {
+		return fmt.Errorf("endpoint url must be an absolute http(s) url: %s", req.URL)
+	}
+
+	if len(req.EventTypes) == 0 {
+		return errors.New("at least one event type is required")
+	}
+	for _, eventType := range req.EventTypes {
+		known := slices.ContainsFunc(kafka.Topics, func(settings kafka.TopicSettings) bool {
+			return string(settings.Topic) == eventType
+		})
+		if !known {
+			return fmt.Errorf("unknown event type: %s", eventType)
+		}
+	}
+
+	return nil
+}
+
+func webhookEndpointToProtocol(endpoint dto.WebhookEndpoint) client.WebhookEndpoint {
+	return client.WebhookEndpoint{
+		ID:         endpoint.ID,
+		URL:        endpoint.URL,
+		Secret:     endpoint.Secret,
+		EventTypes: endpoint.EventTypes,
+		CreatedAt:  endpoint.CreatedAt,
+	}
+}
+
+func webhookDeliveryToProtocol(delivery dto.WebhookDelivery) client.WebhookDelivery {
+	return client.WebhookDelivery{
+		ID:            delivery.ID,
+		EndpointID:    delivery.EndpointID,
+		EventType:     delivery.EventType,
+		Payload:       delivery.Payload,
+		Status:        client.WebhookDeliveryStatus(delivery.Status),
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt,
+		LastError:     delivery.LastError,
+		CreatedAt:     delivery.CreatedAt,
+		UpdatedAt:     delivery.UpdatedAt,
+	}
+}
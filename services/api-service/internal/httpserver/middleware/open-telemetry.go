@@ -41,6 +41,16 @@ func (r *ResponseWriterWrapper) WriteHeader(statusCode int) {
 	r.inner.WriteHeader(statusCode)
 }
 
+func (r *ResponseWriterWrapper) Flush() {
+	if flusher, ok := r.inner.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *ResponseWriterWrapper) Unwrap() http.ResponseWriter {
+	return r.inner
+}
+
 func (p *OpenTelemetryStats) CreateHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -18,6 +18,35 @@ type MetricsCollector interface {
 
 type StorageService interface {
 	handlers.StorageService
+	ExportService
+	ReportingService
+	FeedService
+	WebhookService
+	OutboxService
+}
+
+type ImportManager interface {
+	handlers.ImportManager
+}
+
+type ExportService interface {
+	handlers.ExportService
+}
+
+type ReportingService interface {
+	handlers.ReportingService
+}
+
+type FeedService interface {
+	handlers.FeedService
+}
+
+type WebhookService interface {
+	handlers.WebhookService
+}
+
+type OutboxService interface {
+	handlers.OutboxService
 }
 
 type Server struct {
@@ -25,7 +54,9 @@ type Server struct {
 	cfg              Config
 	jwtTokenAuth     *jwtauth.JWTAuth
 	storageService   StorageService
+	importManager    ImportManager
 	metricsCollector MetricsCollector
+	eventsHandler    *handlers.Events
 	logger           *logging.ZapLogger
 }
 
@@ -33,6 +64,7 @@ func New(
 	cfg Config,
 	tokenAuth *jwtauth.JWTAuth,
 	storageService StorageService,
+	importManager ImportManager,
 	metricsCollector MetricsCollector,
 	logger *logging.ZapLogger,
 ) *Server {
@@ -41,7 +73,9 @@ func New(
 		cfg:              cfg,
 		jwtTokenAuth:     tokenAuth,
 		storageService:   storageService,
+		importManager:    importManager,
 		metricsCollector: metricsCollector,
+		eventsHandler:    handlers.NewEvents(storageService, logger),
 		logger:           logger,
 	}
 }
@@ -51,6 +85,7 @@ func (s *Server) Run() error {
 		Addr:    s.cfg.ServerAddress,
 		Handler: s.createMux(),
 	}
+	s.srv.RegisterOnShutdown(s.eventsHandler.Close)
 	return s.srv.ListenAndServe()
 }
 
@@ -66,20 +101,48 @@ func (s *Server) createMux() *chi.Mux {
 	// commonMiddleware
 	panicRecover := commonMiddleware.NewPanicRecover(s.logger)
 	loggerContextMiddleware := commonMiddleware.NewLogging()
+	tracingMiddleware := commonMiddleware.NewTracing()
 	requestDecompression := commonMiddleware.NewRequestDecompressor(s.logger)
 	responseCompression := commonMiddleware.NewResponseCompressor(s.logger, gzip.BestSpeed)
 	statsMiddleware := middleware.NewOpenTelemetryStats(s.metricsCollector)
 
 	//handlers
 	invoiceHandler := handlers.NewInvoice(s.storageService, s.logger)
+	eInvoiceHandler := handlers.NewEInvoice(s.storageService, s.logger)
+	importHandler := handlers.NewImport(s.importManager, s.cfg.MaxImportFileBytes, s.logger)
+	exportHandler := handlers.NewExport(s.storageService, s.logger)
+	reportingHandler := handlers.NewReporting(s.storageService, s.logger)
+	webhookHandler := handlers.NewWebhook(s.storageService, s.logger)
+	outboxHandler := handlers.NewOutbox(s.storageService, s.logger)
 
 	invoiceCreateHandler := http.HandlerFunc(invoiceHandler.Upload)
 	invoiceGetHandler := http.HandlerFunc(invoiceHandler.Get)
+	invoiceExportUBLHandler := http.HandlerFunc(eInvoiceHandler.ExportUBL)
+	importStartHandler := http.HandlerFunc(importHandler.Start)
+	importStatusHandler := http.HandlerFunc(importHandler.Status)
+	importResumeHandler := http.HandlerFunc(importHandler.Resume)
+	invoiceExportHandler := http.HandlerFunc(exportHandler.Export)
+	reportAgingHandler := http.HandlerFunc(reportingHandler.Aging)
+	reportRevenueHandler := http.HandlerFunc(reportingHandler.Revenue)
+	reportDSOHandler := http.HandlerFunc(reportingHandler.DSO)
+	invoiceEventsHandler := http.HandlerFunc(s.eventsHandler.Subscribe)
+	webhookCreateEndpointHandler := http.HandlerFunc(webhookHandler.CreateEndpoint)
+	webhookListEndpointsHandler := http.HandlerFunc(webhookHandler.ListEndpoints)
+	webhookDeleteEndpointHandler := http.HandlerFunc(webhookHandler.DeleteEndpoint)
+	webhookListDeliveriesHandler := http.HandlerFunc(webhookHandler.ListDeliveries)
+	webhookGetDeliveryHandler := http.HandlerFunc(webhookHandler.GetDelivery)
+	webhookRedeliverHandler := http.HandlerFunc(webhookHandler.Redeliver)
+	outboxListParkedHandler := http.HandlerFunc(outboxHandler.ListParked)
+	outboxListPublishedHandler := http.HandlerFunc(outboxHandler.ListPublished)
+	outboxGetHandler := http.HandlerFunc(outboxHandler.Get)
+	outboxRequeueHandler := http.HandlerFunc(outboxHandler.Requeue)
+	outboxPurgeHandler := http.HandlerFunc(outboxHandler.Purge)
 
 	// router
 	router.Use(panicRecover.CreateHandler)
 	router.Use(statsMiddleware.CreateHandler)
 	router.Use(loggerContextMiddleware.CreateHandler)
+	router.Use(tracingMiddleware.CreateHandler)
 	router.Route("/api/", func(router chi.Router) {
 		router.With(
 			requestDecompression.CreateHandler,
@@ -87,6 +150,42 @@ func (s *Server) createMux() *chi.Mux {
 		).Route("/invoice/", func(router chi.Router) {
 			router.Post("/create", invoiceCreateHandler.ServeHTTP)
 			router.Post("/get", invoiceGetHandler.ServeHTTP)
+			router.Post("/export/ubl", invoiceExportUBLHandler.ServeHTTP)
+			router.Post("/import", importStartHandler.ServeHTTP)
+			router.Post("/import/status", importStatusHandler.ServeHTTP)
+			router.Post("/import/resume", importResumeHandler.ServeHTTP)
+			router.Post("/export", invoiceExportHandler.ServeHTTP)
+		})
+		// Server-Sent Events are not compressed, so every event reaches the client as soon as it is written.
+		router.Get("/invoice/events", invoiceEventsHandler.ServeHTTP)
+		router.With(
+			requestDecompression.CreateHandler,
+			responseCompression.CreateHandler,
+		).Route("/report/", func(router chi.Router) {
+			router.Post("/aging", reportAgingHandler.ServeHTTP)
+			router.Post("/revenue", reportRevenueHandler.ServeHTTP)
+			router.Post("/dso", reportDSOHandler.ServeHTTP)
+		})
+		router.With(
+			requestDecompression.CreateHandler,
+			responseCompression.CreateHandler,
+		).Route("/webhook/", func(router chi.Router) {
+			router.Post("/endpoints/create", webhookCreateEndpointHandler.ServeHTTP)
+			router.Post("/endpoints/list", webhookListEndpointsHandler.ServeHTTP)
+			router.Post("/endpoints/delete", webhookDeleteEndpointHandler.ServeHTTP)
+			router.Post("/deliveries", webhookListDeliveriesHandler.ServeHTTP)
+			router.Post("/deliveries/get", webhookGetDeliveryHandler.ServeHTTP)
+			router.Post("/deliveries/redeliver", webhookRedeliverHandler.ServeHTTP)
+		})
+		router.With(
+			requestDecompression.CreateHandler,
+			responseCompression.CreateHandler,
+		).Route("/outbox/", func(router chi.Router) {
+			router.Post("/parked", outboxListParkedHandler.ServeHTTP)
+			router.Post("/published", outboxListPublishedHandler.ServeHTTP)
+			router.Post("/get", outboxGetHandler.ServeHTTP)
+			router.Post("/requeue", outboxRequeueHandler.ServeHTTP)
+			router.Post("/purge", outboxPurgeHandler.ServeHTTP)
 		})
 	})
 
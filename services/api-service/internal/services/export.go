@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go-invoice-service/api-service/internal/dto"
+	pb "go-invoice-service/common/protocol/proto/apiservice"
+	"go-invoice-service/common/protocol/proto/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+)
+
+func (s *Storage) ExportInvoices(
+	ctx context.Context,
+	filter dto.ExportFilter,
+	consume func([]dto.ExportedInvoice) error,
+) error {
+	req, err := exportRequestToPB(filter)
+	if err != nil {
+		return err
+	}
+
+	stream, err := s.exportClient.Export(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to start invoices export: %w", err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to receive exported invoices: %w", err)
+		}
+
+		invoices, err := exportedInvoicesFromPB(resp.GetInvoices())
+		if err != nil {
+			return err
+		}
+		if err := consume(invoices); err != nil {
+			return err
+		}
+	}
+}
+
+func exportRequestToPB(filter dto.ExportFilter) (*pb.ExportInvoicesRequest, error) {
+	req := &pb.ExportInvoicesRequest{}
+	if !filter.From.IsZero() {
+		req.From = timestamppb.New(filter.From)
+	}
+	if !filter.To.IsZero() {
+		req.To = timestamppb.New(filter.To)
+	}
+	if filter.Status != "" {
+		status, err := statusToPB(filter.Status)
+		if err != nil {
+			return nil, err
+		}
+		req.Status = &status
+	}
+	if filter.CustomerID != uuid.Nil {
+		req.CustomerId = uuidToPB(filter.CustomerID)
+	}
+	return req, nil
+}
+
+func exportedInvoicesFromPB(invoices []*pb.ExportedInvoice) ([]dto.ExportedInvoice, error) {
+	res := make([]dto.ExportedInvoice, len(invoices))
+
+	for i, invoice := range invoices {
+		converted, err := invoiceFromPB(invoice.GetInvoice())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read invoice from pb: %w", err)
+		}
+		status, err := statusFromPB(invoice.Status)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read invoice status from pb: %w", err)
+		}
+		res[i] = dto.ExportedInvoice{
+			Invoice: *converted,
+			Status:  status,
+		}
+	}
+
+	return res, nil
+}
+
+func statusToPB(status dto.InvoiceStatus) (types.InvoiceStatus, error) {
+	switch status {
+	case dto.StatusPending:
+		return types.InvoiceStatus_Pending, nil
+	case dto.StatusApproved:
+		return types.InvoiceStatus_Approved, nil
+	case dto.StatusRejected:
+		return types.InvoiceStatus_Rejected, nil
+	}
+	return 0, fmt.Errorf("invalid invoice status: %s", status)
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-invoice-service/api-service/internal/dto"
+	pb "go-invoice-service/common/protocol/proto/apiservice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+)
+
+var ErrInvalidEventCursor = errors.New("invalid event cursor")
+
+// WatchInvoices subscribes to the invoice events feed after the cursor. Without a cursor
+// only new events are received, unless fromStart is set. The events channel is closed
+// when the feed ends, the error channel receives the reason if it was not ctx cancellation.
+func (s *Storage) WatchInvoices(
+	ctx context.Context,
+	cursor string,
+	fromStart bool,
+) (<-chan dto.InvoiceEvent, <-chan error, error) {
+	req := &pb.WatchInvoicesRequest{
+		FromStart: &fromStart,
+	}
+	if cursor != "" {
+		req.Cursor = &cursor
+	}
+
+	stream, err := s.feedClient.WatchInvoices(ctx, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to watch invoices: %w", err)
+	}
+	// The storage sends headers once the request is accepted.
+	if _, err := stream.Header(); err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidEventCursor, err)
+		}
+		return nil, nil, fmt.Errorf("failed to watch invoices: %w", err)
+	}
+
+	out := make(chan dto.InvoiceEvent)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(errCh)
+		defer close(out)
+
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				errCh <- fmt.Errorf("failed to receive invoice event: %w", err)
+				return
+			}
+
+			event, err := invoiceEventFromPB(resp)
+			if err != nil {
+				errCh <- err
+				return
+			}
+
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, errCh, nil
+}
+
+func invoiceEventFromPB(event *pb.InvoiceEvent) (dto.InvoiceEvent, error) {
+	var eventType dto.InvoiceEventType
+	switch event.GetType() {
+	case pb.InvoiceEventType_Created:
+		eventType = dto.InvoiceEventCreated
+	case pb.InvoiceEventType_StatusChanged:
+		eventType = dto.InvoiceEventStatusChanged
+	default:
+		return dto.InvoiceEvent{}, fmt.Errorf("invalid invoice event type: %s", event.GetType())
+	}
+
+	invoiceID, err := uuidFromPB(event.GetInvoiceId())
+	if err != nil {
+		return dto.InvoiceEvent{}, err
+	}
+
+	invoiceStatus, err := statusFromPB(event.Status)
+	if err != nil {
+		return dto.InvoiceEvent{}, err
+	}
+
+	return dto.InvoiceEvent{
+		Cursor:     event.GetCursor(),
+		Type:       eventType,
+		InvoiceID:  invoiceID,
+		Status:     invoiceStatus,
+		OccurredAt: event.GetOccurredAt().AsTime(),
+	}, nil
+}
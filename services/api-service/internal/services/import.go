@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"go-invoice-service/api-service/internal/dto"
+	pb "go-invoice-service/common/protocol/proto/apiservice"
+)
+
+func (s *Storage) CreateImportJob(ctx context.Context, id uuid.UUID, format dto.ImportFormat) (dto.ImportJob, error) {
+	formatStr := string(format)
+	req := &pb.CreateImportJobRequest{
+		Id:     uuidToPB(id),
+		Format: &formatStr,
+	}
+	resp, err := s.importClient.CreateJob(ctx, req)
+	if err != nil {
+		return dto.ImportJob{}, fmt.Errorf("failed to create import job: %w", err)
+	}
+	return importJobFromPB(resp)
+}
+
+func (s *Storage) ImportBatch(ctx context.Context, jobID uuid.UUID, rows []dto.ImportRow) (dto.ImportJob, error) {
+	req := &pb.ImportBatchRequest{
+		JobId: uuidToPB(jobID),
+		Rows:  importRowsToPB(rows),
+	}
+	resp, err := s.importClient.ImportBatch(ctx, req)
+	if err != nil {
+		return dto.ImportJob{}, fmt.Errorf("failed to import batch: %w", err)
+	}
+	return importJobFromPB(resp)
+}
+
+func (s *Storage) FinishImportJob(
+	ctx context.Context,
+	id uuid.UUID,
+	status dto.ImportJobStatus,
+	errorMessage string,
+) error {
+	statusPB, err := importStatusToPB(status)
+	if err != nil {
+		return err
+	}
+	req := &pb.FinishImportJobRequest{
+		Id:     uuidToPB(id),
+		Status: &statusPB,
+		Error:  &errorMessage,
+	}
+	_, err = s.importClient.FinishJob(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to finish import job: %w", err)
+	}
+	return nil
+}
+
+// GetImportJob returns the job without its results.
+func (s *Storage) GetImportJob(ctx context.Context, id uuid.UUID) (dto.ImportJob, error) {
+	withoutResults := true
+	req := &pb.GetImportJobRequest{
+		Id:             uuidToPB(id),
+		WithoutResults: &withoutResults,
+	}
+	resp, err := s.importClient.GetJob(ctx, req)
+	if err != nil {
+		return dto.ImportJob{}, fmt.Errorf("failed to get import job: %w", err)
+	}
+	return importJobFromPB(resp.GetJob())
+}
+
+func (s *Storage) GetImportJobResults(
+	ctx context.Context,
+	id uuid.UUID,
+	filter dto.ImportResultFilter,
+) (dto.ImportJob, []dto.ImportRowResult, error) {
+	req := &pb.GetImportJobRequest{
+		Id:         uuidToPB(id),
+		OnlyFailed: &filter.OnlyFailed,
+		AfterRow:   &filter.AfterRow,
+		Limit:      &filter.Limit,
+	}
+	resp, err := s.importClient.GetJob(ctx, req)
+	if err != nil {
+		return dto.ImportJob{}, nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+	job, err := importJobFromPB(resp.GetJob())
+	if err != nil {
+		return dto.ImportJob{}, nil, err
+	}
+	results, err := importRowResultsFromPB(resp.GetResults())
+	if err != nil {
+		return dto.ImportJob{}, nil, err
+	}
+	return job, results, nil
+}
+
+func (s *Storage) ListImportJobs(ctx context.Context, status dto.ImportJobStatus) ([]dto.ImportJob, error) {
+	statusPB, err := importStatusToPB(status)
+	if err != nil {
+		return nil, err
+	}
+	req := &pb.ListImportJobsRequest{
+		Status: &statusPB,
+	}
+	resp, err := s.importClient.ListJobs(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list import jobs: %w", err)
+	}
+	res := make([]dto.ImportJob, len(resp.GetJobs()))
+	for i, job := range resp.GetJobs() {
+		res[i], err = importJobFromPB(job)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func importRowsToPB(rows []dto.ImportRow) []*pb.ImportRow {
+	res := make([]*pb.ImportRow, len(rows))
+
+	for i, row := range rows {
+		res[i] = &pb.ImportRow{
+			Number: &row.Number,
+			Error:  &row.Error,
+		}
+		if row.Error == "" {
+			res[i].Invoice = invoiceToPB(row.Invoice)
+		}
+	}
+
+	return res
+}
+
+func importJobFromPB(job *pb.ImportJob) (dto.ImportJob, error) {
+	id, err := uuidFromPB(job.GetId())
+	if err != nil {
+		return dto.ImportJob{}, err
+	}
+	status, err := importStatusFromPB(job.GetStatus())
+	if err != nil {
+		return dto.ImportJob{}, err
+	}
+	return dto.ImportJob{
+		ID:            id,
+		Format:        dto.ImportFormat(job.GetFormat()),
+		Status:        status,
+		ProcessedRows: job.GetProcessedRows(),
+		SucceededRows: job.GetSucceededRows(),
+		FailedRows:    job.GetFailedRows(),
+		Error:         job.GetError(),
+		CreatedAt:     job.GetCreatedAt().AsTime(),
+		UpdatedAt:     job.GetUpdatedAt().AsTime(),
+	}, nil
+}
+
+func importRowResultsFromPB(results []*pb.ImportRowResult) ([]dto.ImportRowResult, error) {
+	res := make([]dto.ImportRowResult, len(results))
+
+	for i, result := range results {
+		res[i] = dto.ImportRowResult{
+			Number: result.GetNumber(),
+			Error:  result.GetError(),
+		}
+		if result.GetInvoiceId() == nil {
+			continue
+		}
+		invoiceID, err := uuidFromPB(result.GetInvoiceId())
+		if err != nil {
+			return nil, err
+		}
+		res[i].InvoiceID = invoiceID
+	}
+
+	return res, nil
+}
+
+func importStatusToPB(status dto.ImportJobStatus) (pb.ImportJobStatus, error) {
+	switch status {
+	case dto.ImportStatusRunning:
+		return pb.ImportJobStatus_Running, nil
+	case dto.ImportStatusCompleted:
+		return pb.ImportJobStatus_Completed, nil
+	case dto.ImportStatusFailed:
+		return pb.ImportJobStatus_Failed, nil
+	}
+	return 0, fmt.Errorf("invalid import job status: %s", status)
+}
+
+func importStatusFromPB(status pb.ImportJobStatus) (dto.ImportJobStatus, error) {
+	switch status {
+	case pb.ImportJobStatus_Running:
+		return dto.ImportStatusRunning, nil
+	case pb.ImportJobStatus_Completed:
+		return dto.ImportStatusCompleted, nil
+	case pb.ImportJobStatus_Failed:
+		return dto.ImportStatusFailed, nil
+	}
+	return "", fmt.Errorf("invalid import job status: %s", status)
+}
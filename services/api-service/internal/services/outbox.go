@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go-invoice-service/api-service/internal/dto"
+	pb "go-invoice-service/common/protocol/proto/apiservice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrOutboxMessageNotFound = errors.New("outbox message not found")
+
+func (s *Storage) ListParkedOutboxMessages(
+	ctx context.Context,
+	filter dto.OutboxMessageFilter,
+) ([]dto.OutboxMessage, error) {
+	resp, err := s.outboxClient.ListParked(ctx, &pb.ListParkedOutboxMessagesRequest{
+		BeforeId: &filter.BeforeID,
+		Limit:    &filter.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list parked outbox messages: %w", err)
+	}
+
+	return outboxMessagesFromPB(resp.GetMessages())
+}
+
+func (s *Storage) ListPublishedOutboxMessages(
+	ctx context.Context,
+	filter dto.PublishedOutboxMessageFilter,
+) ([]dto.OutboxMessage, error) {
+	resp, err := s.outboxClient.ListPublished(ctx, &pb.ListPublishedOutboxMessagesRequest{
+		AggregateId: uuidToPB(filter.InvoiceID),
+		BeforeId:    &filter.BeforeID,
+		Limit:       &filter.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list published outbox messages: %w", err)
+	}
+
+	return outboxMessagesFromPB(resp.GetMessages())
+}
+
+func (s *Storage) GetOutboxMessage(ctx context.Context, id int64) (dto.OutboxMessage, error) {
+	resp, err := s.outboxClient.Get(ctx, &pb.GetOutboxMessageRequest{
+		Id: &id,
+	})
+	if err != nil {
+		return dto.OutboxMessage{}, outboxError("failed to get outbox message", err)
+	}
+	return outboxMessageFromPB(resp)
+}
+
+func (s *Storage) RequeueOutboxMessage(ctx context.Context, id int64) (dto.OutboxMessage, error) {
+	resp, err := s.outboxClient.Requeue(ctx, &pb.RequeueOutboxMessageRequest{
+		Id: &id,
+	})
+	if err != nil {
+		return dto.OutboxMessage{}, outboxError("failed to requeue outbox message", err)
+	}
+	s.logger.InfoCtx(ctx, fmt.Sprintf("Outbox message %d requeued", id))
+	return outboxMessageFromPB(resp)
+}
+
+func (s *Storage) PurgeOutboxMessage(ctx context.Context, id int64) error {
+	_, err := s.outboxClient.Purge(ctx, &pb.PurgeOutboxMessageRequest{
+		Id: &id,
+	})
+	if err != nil {
+		return outboxError("failed to purge outbox message", err)
+	}
+	s.logger.InfoCtx(ctx, fmt.Sprintf("Outbox message %d purged", id))
+	return nil
+}
+
+func outboxError(msg string, err error) error {
+	if status.Code(err) == codes.NotFound {
+		return fmt.Errorf("%s: %w: %w", msg, ErrOutboxMessageNotFound, err)
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+func outboxMessagesFromPB(messages []*pb.OutboxMessage) ([]dto.OutboxMessage, error) {
+	res := make([]dto.OutboxMessage, len(messages))
+	for i, message := range messages {
+		var err error
+		res[i], err = outboxMessageFromPB(message)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func outboxMessageFromPB(message *pb.OutboxMessage) (dto.OutboxMessage, error) {
+	var aggregateID uuid.UUID
+	if message.GetAggregateId() != nil {
+		var err error
+		aggregateID, err = uuidFromPB(message.GetAggregateId())
+		if err != nil {
+			return dto.OutboxMessage{}, fmt.Errorf("invalid aggregate id: %w", err)
+		}
+	}
+	messageStatus, err := outboxStatusFromPB(message.GetStatus())
+	if err != nil {
+		return dto.OutboxMessage{}, err
+	}
+
+	res := dto.OutboxMessage{
+		ID:          message.GetId(),
+		Topic:       message.GetTopic(),
+		Key:         message.GetKey(),
+		Headers:     message.GetHeaders(),
+		Payload:     message.GetPayload(),
+		Data:        message.GetData(),
+		AggregateID: aggregateID,
+		Sequence:    message.GetSequence(),
+		Status:      messageStatus,
+		Attempts:    message.GetAttempts(),
+		LastError:   message.GetLastError(),
+		NextSendAt:  message.GetNextSendAt().AsTime(),
+		CreatedAt:   message.GetCreatedAt().AsTime(),
+	}
+	if message.GetFirstFailedAt() != nil {
+		res.FirstFailedAt = message.GetFirstFailedAt().AsTime()
+	}
+	if message.GetPublishedAt() != nil {
+		res.PublishedAt = message.GetPublishedAt().AsTime()
+	}
+	return res, nil
+}
+
+func outboxStatusFromPB(status pb.OutboxMessageStatus) (dto.OutboxMessageStatus, error) {
+	switch status {
+	case pb.OutboxMessageStatus_OutboxPending:
+		return dto.OutboxStatusPending, nil
+	case pb.OutboxMessageStatus_OutboxParked:
+		return dto.OutboxStatusParked, nil
+	case pb.OutboxMessageStatus_OutboxPublished:
+		return dto.OutboxStatusPublished, nil
+	}
+	return "", fmt.Errorf("invalid outbox message status: %s", status)
+}
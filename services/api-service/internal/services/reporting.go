@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"go-invoice-service/api-service/internal/dto"
+	pb "go-invoice-service/common/protocol/proto/apiservice"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+const reportDateLayout = time.DateOnly
+
+func (s *Storage) GetAgingReport(ctx context.Context, asOf time.Time, loc *time.Location) (dto.AgingReport, error) {
+	req := &pb.AgingRequest{
+		TimeZone: proto.String(loc.String()),
+	}
+	if !asOf.IsZero() {
+		req.AsOf = proto.String(asOf.Format(reportDateLayout))
+	}
+
+	resp, err := s.reportingClient.GetAging(ctx, req)
+	if err != nil {
+		return dto.AgingReport{}, fmt.Errorf("failed to get aging report: %w", err)
+	}
+
+	reportDate, err := time.Parse(reportDateLayout, resp.GetAsOf())
+	if err != nil {
+		return dto.AgingReport{}, fmt.Errorf("invalid aging report date: %w", err)
+	}
+
+	buckets := make([]dto.AgingBucket, len(resp.GetBuckets()))
+	for i, bucket := range resp.GetBuckets() {
+		buckets[i] = dto.AgingBucket{
+			Currency: bucket.GetCurrency(),
+			Bucket:   bucket.GetBucket(),
+			Invoices: bucket.GetInvoices(),
+			Amount:   bucket.GetAmount(),
+		}
+	}
+
+	return dto.AgingReport{
+		AsOf:    reportDate,
+		Buckets: buckets,
+	}, nil
+}
+
+func (s *Storage) GetRevenueReport(ctx context.Context, period dto.ReportPeriod, byCustomer bool) ([]dto.RevenueRow, error) {
+	resp, err := s.reportingClient.GetRevenue(ctx, &pb.RevenueRequest{
+		From:       proto.String(period.From.Format(reportDateLayout)),
+		To:         proto.String(period.To.Format(reportDateLayout)),
+		TimeZone:   proto.String(period.Location.String()),
+		ByCustomer: &byCustomer,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revenue report: %w", err)
+	}
+
+	rows := make([]dto.RevenueRow, len(resp.GetRows()))
+	for i, row := range resp.GetRows() {
+		month, err := time.Parse("2006-01", row.GetMonth())
+		if err != nil {
+			return nil, fmt.Errorf("invalid revenue report month: %w", err)
+		}
+		rows[i] = dto.RevenueRow{
+			Month:    month,
+			Currency: row.GetCurrency(),
+			Invoices: row.GetInvoices(),
+			Amount:   row.GetAmount(),
+		}
+		if row.CustomerId != nil {
+			customerID, err := uuidFromPB(row.GetCustomerId())
+			if err != nil {
+				return nil, err
+			}
+			rows[i].CustomerID = &customerID
+		}
+	}
+
+	return rows, nil
+}
+
+func (s *Storage) GetDSOReport(ctx context.Context, period dto.ReportPeriod) ([]dto.DSORow, error) {
+	resp, err := s.reportingClient.GetDSO(ctx, &pb.DSORequest{
+		From:     proto.String(period.From.Format(reportDateLayout)),
+		To:       proto.String(period.To.Format(reportDateLayout)),
+		TimeZone: proto.String(period.Location.String()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dso report: %w", err)
+	}
+
+	rows := make([]dto.DSORow, len(resp.GetRows()))
+	for i, row := range resp.GetRows() {
+		rows[i] = dto.DSORow{
+			Currency:    row.GetCurrency(),
+			Receivables: row.GetReceivables(),
+			Sales:       row.GetSales(),
+			Days:        row.GetDays(),
+			DSO:         row.Dso,
+		}
+	}
+
+	return rows, nil
+}
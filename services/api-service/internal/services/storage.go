@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	"go-invoice-service/api-service/internal/dto"
 	"go-invoice-service/common/pkg/logging"
+	"go-invoice-service/common/pkg/tracing"
 	pb "go-invoice-service/common/protocol/proto/apiservice"
 	"go-invoice-service/common/protocol/proto/types"
 	"google.golang.org/grpc"
@@ -19,22 +20,45 @@ type StorageConfig struct {
 }
 
 type Storage struct {
-	conn          *grpc.ClientConn
-	storageClient pb.InvoiceStorageClient
-	logger        *logging.ZapLogger
+	conn            *grpc.ClientConn
+	storageClient   pb.InvoiceStorageClient
+	importClient    pb.InvoiceImportClient
+	exportClient    pb.InvoiceExportClient
+	reportingClient pb.InvoiceReportingClient
+	feedClient      pb.InvoiceFeedClient
+	webhookClient   pb.WebhookStorageClient
+	outboxClient    pb.OutboxAdminClient
+	logger          *logging.ZapLogger
 }
 
 func NewStorage(cfg StorageConfig, logger *logging.ZapLogger) (*Storage, error) {
 	options := grpc.WithTransportCredentials(insecure.NewCredentials())
-	conn, err := grpc.NewClient(cfg.ServerAddress, options)
+	conn, err := grpc.NewClient(
+		cfg.ServerAddress,
+		options,
+		grpc.WithChainUnaryInterceptor(tracing.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(tracing.StreamClientInterceptor()),
+	)
 	if err != nil {
 		return nil, err
 	}
 	storageClient := pb.NewInvoiceStorageClient(conn)
+	importClient := pb.NewInvoiceImportClient(conn)
+	exportClient := pb.NewInvoiceExportClient(conn)
+	reportingClient := pb.NewInvoiceReportingClient(conn)
+	feedClient := pb.NewInvoiceFeedClient(conn)
+	webhookClient := pb.NewWebhookStorageClient(conn)
+	outboxClient := pb.NewOutboxAdminClient(conn)
 	return &Storage{
-		conn:          conn,
-		storageClient: storageClient,
-		logger:        logger,
+		conn:            conn,
+		storageClient:   storageClient,
+		importClient:    importClient,
+		exportClient:    exportClient,
+		reportingClient: reportingClient,
+		feedClient:      feedClient,
+		webhookClient:   webhookClient,
+		outboxClient:    outboxClient,
+		logger:          logger,
 	}, nil
 }
 
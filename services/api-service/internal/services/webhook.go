@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go-invoice-service/api-service/internal/dto"
+	pb "go-invoice-service/common/protocol/proto/apiservice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+func (s *Storage) CreateWebhookEndpoint(ctx context.Context, endpoint dto.WebhookEndpoint) (dto.WebhookEndpoint, error) {
+	req := &pb.CreateWebhookEndpointRequest{
+		Id:         uuidToPB(endpoint.ID),
+		Url:        &endpoint.URL,
+		EventTypes: endpoint.EventTypes,
+	}
+	if endpoint.Secret != "" {
+		req.Secret = &endpoint.Secret
+	}
+
+	resp, err := s.webhookClient.CreateEndpoint(ctx, req)
+	if err != nil {
+		return dto.WebhookEndpoint{}, fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+	s.logger.InfoCtx(ctx, fmt.Sprintf("Webhook endpoint %s created successfully", endpoint.ID))
+	return webhookEndpointFromPB(resp)
+}
+
+func (s *Storage) ListWebhookEndpoints(ctx context.Context) ([]dto.WebhookEndpoint, error) {
+	resp, err := s.webhookClient.ListEndpoints(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+
+	res := make([]dto.WebhookEndpoint, len(resp.GetEndpoints()))
+	for i, endpoint := range resp.GetEndpoints() {
+		res[i], err = webhookEndpointFromPB(endpoint)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (s *Storage) DeleteWebhookEndpoint(ctx context.Context, id uuid.UUID) error {
+	_, err := s.webhookClient.DeleteEndpoint(ctx, &pb.DeleteWebhookEndpointRequest{
+		Id: uuidToPB(id),
+	})
+	if err != nil {
+		return webhookError("failed to delete webhook endpoint", err)
+	}
+	s.logger.InfoCtx(ctx, fmt.Sprintf("Webhook endpoint %s deleted successfully", id))
+	return nil
+}
+
+func (s *Storage) ListWebhookDeliveries(
+	ctx context.Context,
+	filter dto.WebhookDeliveryFilter,
+) ([]dto.WebhookDelivery, error) {
+	req := &pb.ListWebhookDeliveriesRequest{
+		BeforeId: &filter.BeforeID,
+		Limit:    &filter.Limit,
+	}
+	if filter.EndpointID != uuid.Nil {
+		req.EndpointId = uuidToPB(filter.EndpointID)
+	}
+	if filter.Status != dto.WebhookStatusNil {
+		deliveryStatus, err := webhookStatusToPB(filter.Status)
+		if err != nil {
+			return nil, err
+		}
+		req.Status = &deliveryStatus
+	}
+
+	resp, err := s.webhookClient.ListDeliveries(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	res := make([]dto.WebhookDelivery, len(resp.GetDeliveries()))
+	for i, delivery := range resp.GetDeliveries() {
+		res[i], err = webhookDeliveryFromPB(delivery)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (s *Storage) GetWebhookDelivery(ctx context.Context, id int64) (dto.WebhookDelivery, []dto.WebhookAttempt, error) {
+	resp, err := s.webhookClient.GetDelivery(ctx, &pb.GetWebhookDeliveryRequest{
+		Id: &id,
+	})
+	if err != nil {
+		return dto.WebhookDelivery{}, nil, webhookError("failed to get webhook delivery", err)
+	}
+
+	delivery, err := webhookDeliveryFromPB(resp.GetDelivery())
+	if err != nil {
+		return dto.WebhookDelivery{}, nil, err
+	}
+
+	attempts := make([]dto.WebhookAttempt, len(resp.GetAttempts()))
+	for i, attempt := range resp.GetAttempts() {
+		attempts[i] = dto.WebhookAttempt{
+			Attempt:     attempt.GetAttempt(),
+			StatusCode:  attempt.GetStatusCode(),
+			Error:       attempt.GetError(),
+			Response:    attempt.GetResponse(),
+			Duration:    attempt.GetDuration().AsDuration(),
+			AttemptedAt: attempt.GetAttemptedAt().AsTime(),
+		}
+	}
+
+	return delivery, attempts, nil
+}
+
+func (s *Storage) RedeliverWebhook(ctx context.Context, id int64) (dto.WebhookDelivery, error) {
+	resp, err := s.webhookClient.Redeliver(ctx, &pb.RedeliverWebhookRequest{
+		Id: &id,
+	})
+	if err != nil {
+		return dto.WebhookDelivery{}, webhookError("failed to redeliver webhook", err)
+	}
+	s.logger.InfoCtx(ctx, fmt.Sprintf("Webhook delivery %d scheduled for redelivery", id))
+	return webhookDeliveryFromPB(resp)
+}
+
+func webhookError(msg string, err error) error {
+	if status.Code(err) == codes.NotFound {
+		return fmt.Errorf("%s: %w: %w", msg, ErrWebhookNotFound, err)
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+func webhookEndpointFromPB(endpoint *pb.WebhookEndpoint) (dto.WebhookEndpoint, error) {
+	id, err := uuidFromPB(endpoint.GetId())
+	if err != nil {
+		return dto.WebhookEndpoint{}, fmt.Errorf("invalid webhook endpoint id: %w", err)
+	}
+	return dto.WebhookEndpoint{
+		ID:         id,
+		URL:        endpoint.GetUrl(),
+		Secret:     endpoint.GetSecret(),
+		EventTypes: endpoint.GetEventTypes(),
+		CreatedAt:  endpoint.GetCreatedAt().AsTime(),
+	}, nil
+}
+
+func webhookDeliveryFromPB(delivery *pb.WebhookDelivery) (dto.WebhookDelivery, error) {
+	endpointID, err := uuidFromPB(delivery.GetEndpointId())
+	if err != nil {
+		return dto.WebhookDelivery{}, fmt.Errorf("invalid webhook endpoint id: %w", err)
+	}
+	deliveryStatus, err := webhookStatusFromPB(delivery.GetStatus())
+	if err != nil {
+		return dto.WebhookDelivery{}, err
+	}
+	return dto.WebhookDelivery{
+		ID:            delivery.GetId(),
+		EndpointID:    endpointID,
+		EventType:     delivery.GetEventType(),
+		Payload:       delivery.GetPayload(),
+		Status:        deliveryStatus,
+		Attempts:      delivery.GetAttempts(),
+		NextAttemptAt: delivery.GetNextAttemptAt().AsTime(),
+		LastError:     delivery.GetLastError(),
+		CreatedAt:     delivery.GetCreatedAt().AsTime(),
+		UpdatedAt:     delivery.GetUpdatedAt().AsTime(),
+	}, nil
+}
+
+func webhookStatusToPB(status dto.WebhookDeliveryStatus) (pb.WebhookDeliveryStatus, error) {
+	switch status {
+	case dto.WebhookStatusPending:
+		return pb.WebhookDeliveryStatus_Pending, nil
+	case dto.WebhookStatusSucceeded:
+		return pb.WebhookDeliveryStatus_Succeeded, nil
+	case dto.WebhookStatusDead:
+		return pb.WebhookDeliveryStatus_Dead, nil
+	}
+	return 0, fmt.Errorf("invalid webhook delivery status: %s", status)
+}
+
+func webhookStatusFromPB(status pb.WebhookDeliveryStatus) (dto.WebhookDeliveryStatus, error) {
+	switch status {
+	case pb.WebhookDeliveryStatus_Pending:
+		return dto.WebhookStatusPending, nil
+	case pb.WebhookDeliveryStatus_Succeeded:
+		return dto.WebhookStatusSucceeded, nil
+	case pb.WebhookDeliveryStatus_Dead:
+		return dto.WebhookStatusDead, nil
+	}
+	return "", fmt.Errorf("invalid webhook delivery status: %s", status)
+}
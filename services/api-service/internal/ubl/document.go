@@ -0,0 +1,127 @@
+package ubl
+
+import "encoding/xml"
+
+const (
+	NamespaceInvoice = "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+	NamespaceCAC     = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	NamespaceCBC     = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+
+	CustomizationEN16931 = "urn:cen.eu:en16931:2017"
+	TypeCodeCommercial   = "380"
+	UnitCodeOne          = "C62"
+	TaxSchemeVAT         = "VAT"
+)
+
+// Invoice is a UBL 2.1 Invoice restricted to the EN 16931 core elements we produce.
+// Element names carry the cac/cbc prefixes literally, see Decode for the reading side.
+type Invoice struct {
+	XMLName  xml.Name `xml:"Invoice"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	XmlnsCAC string   `xml:"xmlns:cac,attr"`
+	XmlnsCBC string   `xml:"xmlns:cbc,attr"`
+
+	CustomizationID         string        `xml:"cbc:CustomizationID"`
+	ID                      string        `xml:"cbc:ID"`
+	IssueDate               string        `xml:"cbc:IssueDate"`
+	DueDate                 string        `xml:"cbc:DueDate,omitempty"`
+	InvoiceTypeCode         string        `xml:"cbc:InvoiceTypeCode"`
+	Note                    string        `xml:"cbc:Note,omitempty"`
+	DocumentCurrencyCode    string        `xml:"cbc:DocumentCurrencyCode"`
+	AccountingSupplierParty PartyWrapper  `xml:"cac:AccountingSupplierParty"`
+	AccountingCustomerParty PartyWrapper  `xml:"cac:AccountingCustomerParty"`
+	TaxTotal                []TaxTotal    `xml:"cac:TaxTotal"`
+	LegalMonetaryTotal      MonetaryTotal `xml:"cac:LegalMonetaryTotal"`
+	InvoiceLines            []InvoiceLine `xml:"cac:InvoiceLine"`
+}
+
+type PartyWrapper struct {
+	Party Party `xml:"cac:Party"`
+}
+
+type Party struct {
+	PartyIdentification *PartyIdentification `xml:"cac:PartyIdentification,omitempty"`
+	PostalAddress       PostalAddress        `xml:"cac:PostalAddress"`
+	PartyTaxScheme      *PartyTaxScheme      `xml:"cac:PartyTaxScheme,omitempty"`
+	PartyLegalEntity    PartyLegalEntity     `xml:"cac:PartyLegalEntity"`
+}
+
+type PartyIdentification struct {
+	ID string `xml:"cbc:ID"`
+}
+
+type PostalAddress struct {
+	StreetName string  `xml:"cbc:StreetName,omitempty"`
+	CityName   string  `xml:"cbc:CityName,omitempty"`
+	PostalZone string  `xml:"cbc:PostalZone,omitempty"`
+	Country    Country `xml:"cac:Country"`
+}
+
+type Country struct {
+	IdentificationCode string `xml:"cbc:IdentificationCode"`
+}
+
+type PartyTaxScheme struct {
+	CompanyID string    `xml:"cbc:CompanyID"`
+	TaxScheme TaxScheme `xml:"cac:TaxScheme"`
+}
+
+type TaxScheme struct {
+	ID string `xml:"cbc:ID"`
+}
+
+type PartyLegalEntity struct {
+	RegistrationName string `xml:"cbc:RegistrationName"`
+}
+
+type Amount struct {
+	Value      string `xml:",chardata"`
+	CurrencyID string `xml:"currencyID,attr"`
+}
+
+type Quantity struct {
+	Value    string `xml:",chardata"`
+	UnitCode string `xml:"unitCode,attr"`
+}
+
+type TaxTotal struct {
+	TaxAmount    Amount        `xml:"cbc:TaxAmount"`
+	TaxSubtotals []TaxSubtotal `xml:"cac:TaxSubtotal"`
+}
+
+type TaxSubtotal struct {
+	TaxableAmount Amount      `xml:"cbc:TaxableAmount"`
+	TaxAmount     Amount      `xml:"cbc:TaxAmount"`
+	TaxCategory   TaxCategory `xml:"cac:TaxCategory"`
+}
+
+type TaxCategory struct {
+	ID                 string    `xml:"cbc:ID"`
+	Percent            string    `xml:"cbc:Percent,omitempty"`
+	TaxExemptionReason string    `xml:"cbc:TaxExemptionReason,omitempty"`
+	TaxScheme          TaxScheme `xml:"cac:TaxScheme"`
+}
+
+type MonetaryTotal struct {
+	LineExtensionAmount Amount `xml:"cbc:LineExtensionAmount"`
+	TaxExclusiveAmount  Amount `xml:"cbc:TaxExclusiveAmount"`
+	TaxInclusiveAmount  Amount `xml:"cbc:TaxInclusiveAmount"`
+	PayableAmount       Amount `xml:"cbc:PayableAmount"`
+}
+
+type InvoiceLine struct {
+	ID                  string   `xml:"cbc:ID"`
+	InvoicedQuantity    Quantity `xml:"cbc:InvoicedQuantity"`
+	LineExtensionAmount Amount   `xml:"cbc:LineExtensionAmount"`
+	Item                Item     `xml:"cac:Item"`
+	Price               Price    `xml:"cac:Price"`
+}
+
+type Item struct {
+	Name                  string      `xml:"cbc:Name"`
+	ClassifiedTaxCategory TaxCategory `xml:"cac:ClassifiedTaxCategory"`
+}
+
+type Price struct {
+	PriceAmount Amount `xml:"cbc:PriceAmount"`
+}
@@ -0,0 +1,153 @@
+package ubl
+
+import (
+	"encoding/xml"
+	"fmt"
+	"go-invoice-service/api-service/internal/dto"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	dateLayout     = time.DateOnly
+	amountDecimals = 2
+	minorUnitExp   = -3
+)
+
+const taxCategoryOutOfScope = "O"
+
+func Export(invoice dto.Invoice, params dto.EInvoiceParams) *Invoice {
+	currency := invoice.Currency
+	taxCategory := taxCategoryToUBL(params.Tax)
+
+	lines := make([]InvoiceLine, len(invoice.Items))
+	lineExtension := decimal.Zero
+	for i, item := range invoice.Items {
+		lineAmount := amountFromMinor(item.Total)
+		lineExtension = lineExtension.Add(lineAmount)
+		lines[i] = InvoiceLine{
+			ID: strconv.Itoa(i + 1),
+			InvoicedQuantity: Quantity{
+				Value:    strconv.Itoa(int(item.Quantity)),
+				UnitCode: UnitCodeOne,
+			},
+			LineExtensionAmount: newAmount(lineAmount, currency),
+			Item: Item{
+				Name:                  item.Description,
+				ClassifiedTaxCategory: taxCategory,
+			},
+			Price: Price{
+				PriceAmount: newAmount(amountFromMinor(item.UnitPrice), currency),
+			},
+		}
+	}
+
+	taxAmount := lineExtension.Mul(params.Tax.Percent).Div(decimal.NewFromInt(100)).Round(amountDecimals)
+	taxInclusive := lineExtension.Add(taxAmount)
+
+	return &Invoice{
+		Xmlns:                NamespaceInvoice,
+		XmlnsCAC:             NamespaceCAC,
+		XmlnsCBC:             NamespaceCBC,
+		CustomizationID:      CustomizationEN16931,
+		ID:                   invoice.ID.String(),
+		IssueDate:            invoice.CreatedAt.UTC().Format(dateLayout),
+		DueDate:              invoice.DueDate.UTC().Format(dateLayout),
+		InvoiceTypeCode:      TypeCodeCommercial,
+		Note:                 invoice.Notes,
+		DocumentCurrencyCode: currency,
+		AccountingSupplierParty: PartyWrapper{
+			Party: partyToUBL(params.Supplier, ""),
+		},
+		AccountingCustomerParty: PartyWrapper{
+			Party: partyToUBL(params.Customer, invoice.CustomerID.String()),
+		},
+		TaxTotal: []TaxTotal{
+			{
+				TaxAmount: newAmount(taxAmount, currency),
+				TaxSubtotals: []TaxSubtotal{
+					{
+						TaxableAmount: newAmount(lineExtension, currency),
+						TaxAmount:     newAmount(taxAmount, currency),
+						TaxCategory:   taxCategory,
+					},
+				},
+			},
+		},
+		LegalMonetaryTotal: MonetaryTotal{
+			LineExtensionAmount: newAmount(lineExtension, currency),
+			TaxExclusiveAmount:  newAmount(lineExtension, currency),
+			TaxInclusiveAmount:  newAmount(taxInclusive, currency),
+			PayableAmount:       newAmount(taxInclusive, currency),
+		},
+		InvoiceLines: lines,
+	}
+}
+
+func Encode(w io.Writer, doc *Invoice) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("error writing xml header: %w", err)
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("xml encode error: %w", err)
+	}
+	return nil
+}
+
+func partyToUBL(party dto.Party, id string) Party {
+	res := Party{
+		PostalAddress: PostalAddress{
+			StreetName: party.Street,
+			CityName:   party.City,
+			PostalZone: party.PostalCode,
+			Country: Country{
+				IdentificationCode: party.CountryCode,
+			},
+		},
+		PartyLegalEntity: PartyLegalEntity{
+			RegistrationName: party.Name,
+		},
+	}
+	if id != "" {
+		res.PartyIdentification = &PartyIdentification{ID: id}
+	}
+	if party.VATID != "" {
+		res.PartyTaxScheme = &PartyTaxScheme{
+			CompanyID: party.VATID,
+			TaxScheme: TaxScheme{ID: TaxSchemeVAT},
+		}
+	}
+	return res
+}
+
+func taxCategoryToUBL(tax dto.TaxCategory) TaxCategory {
+	res := TaxCategory{
+		ID:                 tax.Code,
+		TaxExemptionReason: tax.ExemptionReason,
+		TaxScheme:          TaxScheme{ID: TaxSchemeVAT},
+	}
+	if tax.Code != taxCategoryOutOfScope {
+		res.Percent = tax.Percent.String()
+	}
+	return res
+}
+
+func newAmount(val decimal.Decimal, currency string) Amount {
+	return Amount{
+		Value:      val.StringFixed(amountDecimals),
+		CurrencyID: currency,
+	}
+}
+
+func amountFromMinor(val int64) decimal.Decimal {
+	return decimal.New(val, minorUnitExp).Round(amountDecimals)
+}
+
+func amountToMinor(val decimal.Decimal) int64 {
+	return val.Shift(-minorUnitExp).IntPart()
+}
@@ -0,0 +1,166 @@
+package ubl
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"go-invoice-service/api-service/internal/dto"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var ErrFractionalQuantity = errors.New("fractional quantities are not supported")
+
+func Import(r io.Reader) (dto.Invoice, error) {
+	doc, err := Decode(r)
+	if err != nil {
+		return dto.Invoice{}, err
+	}
+	if err := Validate(doc); err != nil {
+		return dto.Invoice{}, err
+	}
+	return ToInvoice(doc)
+}
+
+// Decode reads a UBL invoice regardless of the prefixes the producer bound to the
+// cac/cbc namespaces, by rewriting element names into the prefixed form used by Invoice.
+func Decode(r io.Reader) (*Invoice, error) {
+	var doc Invoice
+	decoder := xml.NewTokenDecoder(&prefixingReader{inner: xml.NewDecoder(r)})
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("xml decode error: %w", err)
+	}
+	return &doc, nil
+}
+
+func ToInvoice(doc *Invoice) (dto.Invoice, error) {
+	issueDate, err := time.Parse(dateLayout, doc.IssueDate)
+	if err != nil {
+		return dto.Invoice{}, fmt.Errorf("invalid issue date: %w", err)
+	}
+	var dueDate time.Time
+	if doc.DueDate != "" {
+		dueDate, err = time.Parse(dateLayout, doc.DueDate)
+		if err != nil {
+			return dto.Invoice{}, fmt.Errorf("invalid due date: %w", err)
+		}
+	}
+	amount, err := decimal.NewFromString(doc.LegalMonetaryTotal.LineExtensionAmount.Value)
+	if err != nil {
+		return dto.Invoice{}, fmt.Errorf("invalid line extension amount: %w", err)
+	}
+	items, err := itemsFromUBL(doc.InvoiceLines)
+	if err != nil {
+		return dto.Invoice{}, err
+	}
+
+	return dto.Invoice{
+		ID:         idFromUBL(doc.ID, "invoice"),
+		CustomerID: customerIDFromUBL(doc.AccountingCustomerParty.Party),
+		Amount:     amountToMinor(amount),
+		Currency:   doc.DocumentCurrencyCode,
+		DueDate:    dueDate,
+		CreatedAt:  issueDate,
+		UpdatedAt:  issueDate,
+		Items:      items,
+		Notes:      doc.Note,
+	}, nil
+}
+
+func itemsFromUBL(lines []InvoiceLine) ([]dto.Item, error) {
+	res := make([]dto.Item, len(lines))
+	for i, line := range lines {
+		item, err := itemFromUBL(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid line %s: %w", line.ID, err)
+		}
+		res[i] = item
+	}
+	return res, nil
+}
+
+func itemFromUBL(line InvoiceLine) (dto.Item, error) {
+	quantity, err := decimal.NewFromString(line.InvoicedQuantity.Value)
+	if err != nil {
+		return dto.Item{}, fmt.Errorf("invalid quantity: %w", err)
+	}
+	if !quantity.IsInteger() {
+		return dto.Item{}, ErrFractionalQuantity
+	}
+	price, err := decimal.NewFromString(line.Price.PriceAmount.Value)
+	if err != nil {
+		return dto.Item{}, fmt.Errorf("invalid price: %w", err)
+	}
+	total, err := decimal.NewFromString(line.LineExtensionAmount.Value)
+	if err != nil {
+		return dto.Item{}, fmt.Errorf("invalid line amount: %w", err)
+	}
+	return dto.Item{
+		Description: line.Item.Name,
+		Quantity:    int32(quantity.IntPart()),
+		UnitPrice:   amountToMinor(price),
+		Total:       amountToMinor(total),
+	}, nil
+}
+
+func customerIDFromUBL(party Party) uuid.UUID {
+	switch {
+	case party.PartyIdentification != nil && party.PartyIdentification.ID != "":
+		return idFromUBL(party.PartyIdentification.ID, "customer")
+	case party.PartyTaxScheme != nil && party.PartyTaxScheme.CompanyID != "":
+		return idFromUBL(party.PartyTaxScheme.CompanyID, "customer")
+	}
+	return idFromUBL(party.PartyLegalEntity.RegistrationName, "customer")
+}
+
+// idFromUBL keeps UUID identifiers as is and derives a stable name-based UUID otherwise,
+// so importing the same document twice maps onto the same invoice.
+func idFromUBL(id string, kind string) uuid.UUID {
+	if res, err := uuid.Parse(id); err == nil {
+		return res
+	}
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("urn:ubl:"+kind+":"+id))
+}
+
+type prefixingReader struct {
+	inner *xml.Decoder
+}
+
+var namespacePrefixes = map[string]string{
+	NamespaceCAC: "cac:",
+	NamespaceCBC: "cbc:",
+}
+
+func (p *prefixingReader) Token() (xml.Token, error) {
+	tok, err := p.inner.Token()
+	if err != nil {
+		return nil, err //nolint:wrapcheck // io.EOF must be passed as is
+	}
+	switch t := tok.(type) {
+	case xml.StartElement:
+		t.Name = prefixName(t.Name)
+		attrs := make([]xml.Attr, 0, len(t.Attr))
+		for _, attr := range t.Attr {
+			if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+				continue
+			}
+			attrs = append(attrs, attr)
+		}
+		t.Attr = attrs
+		return t, nil
+	case xml.EndElement:
+		t.Name = prefixName(t.Name)
+		return t, nil
+	}
+	return tok, nil
+}
+
+func prefixName(name xml.Name) xml.Name {
+	if prefix, ok := namespacePrefixes[name.Space]; ok {
+		return xml.Name{Local: prefix + name.Local}
+	}
+	return xml.Name{Local: name.Local}
+}
@@ -0,0 +1,175 @@
+package ubl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type RuleViolation struct {
+	Rule    string
+	Message string
+}
+
+type ValidationError struct {
+	Violations []RuleViolation
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = fmt.Sprintf("[%s] %s", v.Rule, v.Message)
+	}
+	return "EN 16931 validation failed: " + strings.Join(parts, "; ")
+}
+
+// Validate checks the document against the EN 16931 core business rules that apply to
+// the subset of UBL we support (no allowances, charges or prepaid amounts).
+func Validate(doc *Invoice) error {
+	v := &validator{}
+
+	v.require(doc.CustomizationID != "", "BR-01", "specification identifier is missing")
+	v.require(doc.ID != "", "BR-02", "invoice number is missing")
+	v.date(doc.IssueDate, "BR-03", "issue date")
+	if doc.DueDate != "" {
+		v.date(doc.DueDate, "BR-03", "due date")
+	}
+	v.require(doc.InvoiceTypeCode != "", "BR-04", "invoice type code is missing")
+	v.require(doc.DocumentCurrencyCode != "", "BR-05", "invoice currency code is missing")
+
+	seller := doc.AccountingSupplierParty.Party
+	buyer := doc.AccountingCustomerParty.Party
+	v.require(seller.PartyLegalEntity.RegistrationName != "", "BR-06", "seller name is missing")
+	v.require(buyer.PartyLegalEntity.RegistrationName != "", "BR-07", "buyer name is missing")
+	v.require(seller.PostalAddress.CityName != "", "BR-08", "seller postal address is missing")
+	v.require(seller.PostalAddress.Country.IdentificationCode != "", "BR-09", "seller country code is missing")
+	v.require(buyer.PostalAddress.CityName != "", "BR-10", "buyer postal address is missing")
+	v.require(buyer.PostalAddress.Country.IdentificationCode != "", "BR-11", "buyer country code is missing")
+
+	totals := doc.LegalMonetaryTotal
+	lineExtension := v.amount(totals.LineExtensionAmount, "BR-12", "sum of invoice line net amount")
+	taxExclusive := v.amount(totals.TaxExclusiveAmount, "BR-13", "invoice total amount without VAT")
+	taxInclusive := v.amount(totals.TaxInclusiveAmount, "BR-14", "invoice total amount with VAT")
+	payable := v.amount(totals.PayableAmount, "BR-15", "amount due for payment")
+
+	v.require(len(doc.InvoiceLines) > 0, "BR-16", "invoice has no lines")
+
+	linesSum := decimal.Zero
+	categorySums := make(map[string]decimal.Decimal)
+	hasStandardRated := false
+	for _, line := range doc.InvoiceLines {
+		v.require(line.ID != "", "BR-21", "invoice line identifier is missing")
+		_, err := decimal.NewFromString(line.InvoicedQuantity.Value)
+		v.require(err == nil, "BR-22", fmt.Sprintf("line %s: invoiced quantity is missing or invalid", line.ID))
+		lineAmount := v.amount(line.LineExtensionAmount, "BR-24", fmt.Sprintf("line %s net amount", line.ID))
+		v.require(line.Item.Name != "", "BR-25", fmt.Sprintf("line %s: item name is missing", line.ID))
+		price := v.amount(line.Price.PriceAmount, "BR-26", fmt.Sprintf("line %s item net price", line.ID))
+		v.require(!price.IsNegative(), "BR-27", fmt.Sprintf("line %s: item net price is negative", line.ID))
+
+		category := line.Item.ClassifiedTaxCategory
+		v.taxCategory(category, fmt.Sprintf("line %s", line.ID))
+		if category.ID == "S" {
+			hasStandardRated = true
+		}
+
+		linesSum = linesSum.Add(lineAmount)
+		key := categoryKey(category)
+		categorySums[key] = categorySums[key].Add(lineAmount)
+	}
+
+	v.require(linesSum.Equal(lineExtension), "BR-CO-10",
+		fmt.Sprintf("sum of line net amounts %s differs from %s", linesSum, lineExtension))
+	v.require(taxExclusive.Equal(lineExtension), "BR-CO-13",
+		fmt.Sprintf("total without VAT %s differs from sum of lines %s", taxExclusive, lineExtension))
+
+	taxTotal := decimal.Zero
+	v.require(len(doc.TaxTotal) > 0 && len(doc.TaxTotal[0].TaxSubtotals) > 0, "BR-CO-18", "VAT breakdown is missing")
+	if len(doc.TaxTotal) > 0 {
+		taxTotal = v.amount(doc.TaxTotal[0].TaxAmount, "BR-CO-14", "invoice total VAT amount")
+		subtotalsSum := decimal.Zero
+		for _, subtotal := range doc.TaxTotal[0].TaxSubtotals {
+			taxable := v.amount(subtotal.TaxableAmount, "BR-45", "VAT category taxable amount")
+			tax := v.amount(subtotal.TaxAmount, "BR-46", "VAT category tax amount")
+			subtotalsSum = subtotalsSum.Add(tax)
+
+			category := subtotal.TaxCategory
+			v.taxCategory(category, "VAT breakdown")
+			key := categoryKey(category)
+			v.require(taxable.Equal(categorySums[key]), "BR-"+category.ID+"-08",
+				fmt.Sprintf("taxable amount %s for category %s differs from sum of lines %s", taxable, key, categorySums[key]))
+			if percent, err := decimal.NewFromString(category.Percent); err == nil {
+				expected := taxable.Mul(percent).Div(decimal.NewFromInt(100)).Round(amountDecimals)
+				v.require(tax.Equal(expected), "BR-"+category.ID+"-09",
+					fmt.Sprintf("tax amount %s for category %s, expected %s", tax, key, expected))
+			}
+		}
+		v.require(taxTotal.Equal(subtotalsSum), "BR-CO-14",
+			fmt.Sprintf("total VAT amount %s differs from sum of breakdown %s", taxTotal, subtotalsSum))
+	}
+
+	v.require(taxInclusive.Equal(taxExclusive.Add(taxTotal)), "BR-CO-15",
+		fmt.Sprintf("total with VAT %s differs from %s + %s", taxInclusive, taxExclusive, taxTotal))
+	v.require(payable.Equal(taxInclusive), "BR-CO-16",
+		fmt.Sprintf("amount due %s differs from total with VAT %s", payable, taxInclusive))
+
+	if hasStandardRated {
+		v.require(seller.PartyTaxScheme != nil && seller.PartyTaxScheme.CompanyID != "", "BR-S-02",
+			"seller VAT identifier is required for standard rated lines")
+	}
+
+	if len(v.violations) > 0 {
+		return &ValidationError{Violations: v.violations}
+	}
+	return nil
+}
+
+type validator struct {
+	violations []RuleViolation
+}
+
+func (v *validator) require(ok bool, rule, message string) {
+	if !ok {
+		v.violations = append(v.violations, RuleViolation{Rule: rule, Message: message})
+	}
+}
+
+func (v *validator) date(val string, rule, name string) {
+	_, err := time.Parse(dateLayout, val)
+	v.require(err == nil, rule, fmt.Sprintf("%s '%s' is missing or not in YYYY-MM-DD format", name, val))
+}
+
+func (v *validator) amount(a Amount, rule, name string) decimal.Decimal {
+	val, err := decimal.NewFromString(a.Value)
+	if err != nil {
+		v.require(false, rule, fmt.Sprintf("%s is missing or invalid", name))
+		return decimal.Zero
+	}
+	v.require(val.Equal(val.Round(amountDecimals)), "BR-DEC", fmt.Sprintf("%s has more than two decimals", name))
+	return val
+}
+
+func (v *validator) taxCategory(category TaxCategory, where string) {
+	v.require(category.ID != "", "BR-CO-04", fmt.Sprintf("%s: VAT category code is missing", where))
+	if category.ID == "S" {
+		percent, err := decimal.NewFromString(category.Percent)
+		v.require(err == nil && percent.IsPositive(), "BR-S-05",
+			fmt.Sprintf("%s: standard rated VAT percent must be greater than zero", where))
+	}
+	if category.ID == "E" {
+		v.require(category.TaxExemptionReason != "", "BR-E-10",
+			fmt.Sprintf("%s: exemption reason is missing", where))
+	}
+}
+
+func categoryKey(category TaxCategory) string {
+	if category.Percent == "" {
+		return category.ID
+	}
+	percent, err := decimal.NewFromString(category.Percent)
+	if err != nil {
+		return category.ID + "/" + category.Percent
+	}
+	return category.ID + "/" + percent.String()
+}
@@ -0,0 +1,210 @@
+package ubl
+
+import (
+	"bytes"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-invoice-service/api-service/internal/dto"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	invoice := createInvoiceDTO()
+
+	doc := Export(invoice, createParams())
+	require.NoError(t, Validate(doc))
+
+	assert.Equal(t, "1050.00", doc.LegalMonetaryTotal.LineExtensionAmount.Value)
+	assert.Equal(t, "210.00", doc.TaxTotal[0].TaxAmount.Value)
+	assert.Equal(t, "1260.00", doc.LegalMonetaryTotal.PayableAmount.Value)
+
+	var buf bytes.Buffer
+	require.NoError(t, Encode(&buf, doc))
+
+	imported, err := Import(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, invoice, imported)
+}
+
+func TestDecode_ForeignPrefixes(t *testing.T) {
+	invoice := createInvoiceDTO()
+
+	var buf bytes.Buffer
+	require.NoError(t, Encode(&buf, Export(invoice, createParams())))
+
+	xmlDoc := buf.String()
+	xmlDoc = strings.ReplaceAll(xmlDoc, "cbc:", "b:")
+	xmlDoc = strings.ReplaceAll(xmlDoc, "xmlns:cbc=", "xmlns:b=")
+	xmlDoc = strings.ReplaceAll(xmlDoc, "<Invoice xmlns=", "<ubl:Invoice xmlns:ubl=")
+	xmlDoc = strings.ReplaceAll(xmlDoc, "</Invoice>", "</ubl:Invoice>")
+
+	imported, err := Import(strings.NewReader(xmlDoc))
+	require.NoError(t, err)
+	assert.Equal(t, invoice, imported)
+}
+
+func TestImport_DerivesStableIDs(t *testing.T) {
+	doc := Export(createInvoiceDTO(), createParams())
+	doc.ID = "INV-2025-0001"
+	doc.AccountingCustomerParty.Party.PartyIdentification = nil
+
+	first, err := ToInvoice(doc)
+	require.NoError(t, err)
+	second, err := ToInvoice(doc)
+	require.NoError(t, err)
+
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, first.CustomerID, second.CustomerID)
+	assert.NotEqual(t, uuid.Nil, first.ID)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Invoice)
+		rules  []string
+	}{
+		{
+			name:   "valid",
+			modify: func(*Invoice) {},
+		},
+		{
+			name: "missing_seller_name",
+			modify: func(doc *Invoice) {
+				doc.AccountingSupplierParty.Party.PartyLegalEntity.RegistrationName = ""
+			},
+			rules: []string{"BR-06"},
+		},
+		{
+			name: "missing_lines",
+			modify: func(doc *Invoice) {
+				doc.InvoiceLines = nil
+			},
+			rules: []string{"BR-16", "BR-CO-10", "BR-S-08"},
+		},
+		{
+			name: "line_sum_mismatch",
+			modify: func(doc *Invoice) {
+				doc.InvoiceLines[0].LineExtensionAmount.Value = "999.00"
+			},
+			rules: []string{"BR-CO-10", "BR-S-08"},
+		},
+		{
+			name: "payable_mismatch",
+			modify: func(doc *Invoice) {
+				doc.LegalMonetaryTotal.PayableAmount.Value = "1.00"
+			},
+			rules: []string{"BR-CO-16"},
+		},
+		{
+			name: "too_many_decimals",
+			modify: func(doc *Invoice) {
+				doc.InvoiceLines[0].Price.PriceAmount.Value = "1000.001"
+			},
+			rules: []string{"BR-DEC"},
+		},
+		{
+			name: "standard_rate_without_seller_vat_id",
+			modify: func(doc *Invoice) {
+				doc.AccountingSupplierParty.Party.PartyTaxScheme = nil
+			},
+			rules: []string{"BR-S-02"},
+		},
+		{
+			name: "exempt_without_reason",
+			modify: func(doc *Invoice) {
+				for i := range doc.InvoiceLines {
+					doc.InvoiceLines[i].Item.ClassifiedTaxCategory = TaxCategory{ID: "E", Percent: "0"}
+				}
+				subtotal := &doc.TaxTotal[0].TaxSubtotals[0]
+				subtotal.TaxCategory = TaxCategory{ID: "E", Percent: "0"}
+				subtotal.TaxAmount.Value = "0.00"
+				doc.TaxTotal[0].TaxAmount.Value = "0.00"
+				doc.LegalMonetaryTotal.TaxInclusiveAmount.Value = "1050.00"
+				doc.LegalMonetaryTotal.PayableAmount.Value = "1050.00"
+			},
+			rules: []string{"BR-E-10"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc := Export(createInvoiceDTO(), createParams())
+			test.modify(doc)
+
+			err := Validate(doc)
+			if len(test.rules) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			violated := make(map[string]bool)
+			for _, v := range validationErr.Violations {
+				violated[v.Rule] = true
+			}
+			for _, rule := range test.rules {
+				assert.True(t, violated[rule], "expected violation of %s, got %v", rule, validationErr.Violations)
+			}
+		})
+	}
+}
+
+func createInvoiceDTO() dto.Invoice {
+	issued := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	return dto.Invoice{
+		ID:         uuid.New(),
+		CustomerID: uuid.New(),
+		Amount:     1_050_000,
+		Currency:   "EUR",
+		DueDate:    time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC),
+		CreatedAt:  issued,
+		UpdatedAt:  issued,
+		Items: []dto.Item{
+			{
+				Description: "Website Design",
+				Quantity:    1,
+				UnitPrice:   1_000_000,
+				Total:       1_000_000,
+			},
+			{
+				Description: "Hosting (1 month)",
+				Quantity:    2,
+				UnitPrice:   25_000,
+				Total:       50_000,
+			},
+		},
+		Notes: "Payment due within 30 days.",
+	}
+}
+
+func createParams() dto.EInvoiceParams {
+	return dto.EInvoiceParams{
+		Supplier: dto.Party{
+			Name:        "Seller GmbH",
+			VATID:       "DE123456789",
+			Street:      "Hauptstrasse 1",
+			City:        "Berlin",
+			PostalCode:  "10115",
+			CountryCode: "DE",
+		},
+		Customer: dto.Party{
+			Name:        "Buyer SAS",
+			VATID:       "FR12345678901",
+			Street:      "Rue de Rivoli 10",
+			City:        "Paris",
+			PostalCode:  "75001",
+			CountryCode: "FR",
+		},
+		Tax: dto.TaxCategory{
+			Code:    "S",
+			Percent: decimal.NewFromInt(20),
+		},
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"github.com/google/uuid"
+	"go-invoice-service/common/pkg/breaker"
 	"go-invoice-service/common/pkg/flagtypes"
 	"go-invoice-service/common/pkg/meterutils"
 	"message-sheduler-service/internal/controllers"
@@ -16,74 +18,261 @@ import (
 const (
 	kafkaAddressFlag         = "kafka-address"
 	kafkaAddressEnv          = "KAFKA_ADDRESS"
+	kafkaTransactionalFlag   = "kafka-transactional"
+	kafkaTransactionalEnv    = "KAFKA_TRANSACTIONAL"
+	kafkaControlTopicFlag    = "kafka-control-topic"
+	kafkaControlTopicEnv     = "KAFKA_CONTROL_TOPIC"
+	kafkaTxIntervalFlag      = "kafka-transaction-interval"
+	kafkaTxIntervalEnv       = "KAFKA_TRANSACTION_INTERVAL_MS"
+	kafkaPublishedTTLFlag    = "kafka-published-ttl"
+	kafkaPublishedTTLEnv     = "KAFKA_PUBLISHED_TTL_MS"
 	storageAddressFlag       = "storage-address"
 	storageAddressEnv        = "STORAGE_ADDRESS"
 	workersCountFlag         = "workers-count"
 	workersCountEnv          = "WORKERS_COUNT"
+	instanceIDFlag           = "instance-id"
+	instanceIDEnv            = "INSTANCE_ID"
 	retryIntervalFlag        = "retry-interval"
 	retryIntervalEnv         = "RETRY_INTERVAL_MS"
 	dispatchIntervalFlag     = "dispatch-interval"
 	dispatchIntervalEnv      = "DISPATCH_INTERVAL_MS"
+	idleDispatchIntervalFlag = "idle-dispatch-interval"
+	idleDispatchIntervalEnv  = "IDLE_DISPATCH_INTERVAL_MS"
+	ackBatchSizeFlag         = "ack-batch-size"
+	ackBatchSizeEnv          = "ACK_BATCH_SIZE"
+	ackIntervalFlag          = "ack-interval"
+	ackIntervalEnv           = "ACK_INTERVAL_MS"
+	drainTimeoutFlag         = "drain-timeout"
+	drainTimeoutEnv          = "DRAIN_TIMEOUT_MS"
+	outboxMaxAttemptsFlag    = "outbox-max-attempts"
+	outboxMaxAttemptsEnv     = "OUTBOX_MAX_ATTEMPTS"
+	outboxBackoffBaseFlag    = "outbox-backoff-base"
+	outboxBackoffBaseEnv     = "OUTBOX_BACKOFF_BASE_MS"
+	outboxBackoffMaxFlag     = "outbox-backoff-max"
+	outboxBackoffMaxEnv      = "OUTBOX_BACKOFF_MAX_MS"
+	outboxMinBatchSizeFlag   = "outbox-min-batch-size"
+	outboxMinBatchSizeEnv    = "OUTBOX_MIN_BATCH_SIZE"
+	outboxMaxBatchSizeFlag   = "outbox-max-batch-size"
+	outboxMaxBatchSizeEnv    = "OUTBOX_MAX_BATCH_SIZE"
+	minDispatchIntervalFlag  = "min-dispatch-interval"
+	minDispatchIntervalEnv   = "MIN_DISPATCH_INTERVAL_MS"
+	outboxTargetLatencyFlag  = "outbox-target-latency"
+	outboxTargetLatencyEnv   = "OUTBOX_TARGET_LATENCY_MS"
+	breakerFailuresFlag      = "circuit-breaker-failure-threshold"
+	breakerFailuresEnv       = "CIRCUIT_BREAKER_FAILURE_THRESHOLD"
+	breakerOpenTimeoutFlag   = "circuit-breaker-open-timeout"
+	breakerOpenTimeoutEnv    = "CIRCUIT_BREAKER_OPEN_TIMEOUT_MS"
+	breakerSuccessesFlag     = "circuit-breaker-success-threshold"
+	breakerSuccessesEnv      = "CIRCUIT_BREAKER_SUCCESS_THRESHOLD"
 	prometheusPortFlag       = "prometheus-port"
 	prometheusPortEnv        = "PROMETHEUS_PORT"
 	otelCollectorAddressFlag = "otel-collector-address"
 	otelCollectorAddressEnv  = "OTEL_COLLECTOR_ADDRESS"
+
+	webhookWorkersCountFlag     = "webhook-workers-count"
+	webhookWorkersCountEnv      = "WEBHOOK_WORKERS_COUNT"
+	webhookDispatchIntervalFlag = "webhook-dispatch-interval"
+	webhookDispatchIntervalEnv  = "WEBHOOK_DISPATCH_INTERVAL_MS"
+	webhookTimeoutFlag          = "webhook-timeout"
+	webhookTimeoutEnv           = "WEBHOOK_TIMEOUT_MS"
+	webhookLeaseFlag            = "webhook-lease"
+	webhookLeaseEnv             = "WEBHOOK_LEASE_MS"
+	webhookMaxAttemptsFlag      = "webhook-max-attempts"
+	webhookMaxAttemptsEnv       = "WEBHOOK_MAX_ATTEMPTS"
+	webhookBackoffBaseFlag      = "webhook-backoff-base"
+	webhookBackoffBaseEnv       = "WEBHOOK_BACKOFF_BASE_MS"
+	webhookBackoffMaxFlag       = "webhook-backoff-max"
+	webhookBackoffMaxEnv        = "WEBHOOK_BACKOFF_MAX_MS"
 )
 
 const (
 	defaultKafkaAddress         = "localhost:9092"
+	defaultKafkaControlTopic    = "outbox_control"
+	defaultKafkaTxInterval      = 100 * time.Millisecond
+	defaultKafkaPublishedTTL    = 1 * time.Hour
 	defaultStorageAddress       = "localhost:5000"
 	defaultWorkersCount         = 3
 	defaultRetryInterval        = 30 * time.Second
-	defaultDispatchInterval     = 1 * time.Second
+	defaultDispatchInterval     = 100 * time.Millisecond
+	defaultIdleDispatchInterval = 10 * time.Second
+	defaultAckBatchSize         = 100
+	defaultAckInterval          = 100 * time.Millisecond
+	defaultOutboxMaxAttempts    = 10
+	defaultOutboxBackoffBase    = 1 * time.Second
+	defaultOutboxBackoffMax     = 5 * time.Minute
 	defaultShutdownTimeout      = 5 * time.Second
+	defaultDrainTimeout         = 10 * time.Second
+	defaultOutboxMinBatchSize   = 10
+	defaultOutboxMaxBatchSize   = 500
+	defaultMinDispatchInterval  = 10 * time.Millisecond
+	defaultOutboxTargetLatency  = 1 * time.Second
+	defaultBreakerFailures      = 5
+	defaultBreakerOpenTimeout   = 5 * time.Second
+	defaultBreakerSuccesses     = 1
 	defaultPrometheusPort       = 9090
 	defaultOtelCollectorAddress = "localhost:4318"
+
+	defaultWebhookWorkersCount     = 2
+	defaultWebhookDispatchInterval = 1 * time.Second
+	defaultWebhookTimeout          = 10 * time.Second
+	defaultWebhookLease            = 1 * time.Minute
+	defaultWebhookMaxAttempts      = 10
+	defaultWebhookBackoffBase      = 30 * time.Second
+	defaultWebhookBackoffMax       = 1 * time.Hour
 )
 
 var defaultRetryAttempts = []time.Duration{time.Second, 3 * time.Second, 5 * time.Second}
 
 type Config struct {
-	KafkaProducerConfig    services.KafkaProducerConfig
-	StorageConfig          services.StorageConfig
-	OutboxDispatcherConfig controllers.OutboxDispatcherConfig
-	PrometheusConfig       meterutils.PrometheusConfig
-	OpenTelemetryConfig    meterutils.OpenTelemetryConfig
+	KafkaProducerConfig     services.KafkaProducerConfig
+	StorageConfig           services.StorageConfig
+	OutboxDispatcherConfig  controllers.OutboxDispatcherConfig
+	WebhookSenderConfig     services.WebhookSenderConfig
+	WebhookDispatcherConfig controllers.WebhookDispatcherConfig
+	BreakerConfig           breaker.Config
+	PrometheusConfig        meterutils.PrometheusConfig
+	OpenTelemetryConfig     meterutils.OpenTelemetryConfig
 }
 
 func Load() (*Config, error) {
 
 	kafkaAddress := defaultKafkaAddress
+	kafkaTransactional := false
+	kafkaControlTopic := defaultKafkaControlTopic
+	kafkaTxInterval := defaultKafkaTxInterval
+	kafkaPublishedTTL := defaultKafkaPublishedTTL
 	storageAddress := defaultStorageAddress
 	workersCount := defaultWorkersCount
+	instanceID := uuid.NewString()
+	// A random instance ID changes on every restart, it must be set in transactional mode.
+	instanceIDSet := false
 	retryInterval := defaultRetryInterval
 	dispatchInterval := defaultDispatchInterval
+	idleDispatchInterval := defaultIdleDispatchInterval
+	ackBatchSize := defaultAckBatchSize
+	ackInterval := defaultAckInterval
+	drainTimeout := defaultDrainTimeout
+	outboxMaxAttempts := defaultOutboxMaxAttempts
+	outboxBackoffBase := defaultOutboxBackoffBase
+	outboxBackoffMax := defaultOutboxBackoffMax
+	outboxMinBatchSize := defaultOutboxMinBatchSize
+	outboxMaxBatchSize := defaultOutboxMaxBatchSize
+	minDispatchInterval := defaultMinDispatchInterval
+	outboxTargetLatency := defaultOutboxTargetLatency
+	breakerFailures := defaultBreakerFailures
+	breakerOpenTimeout := defaultBreakerOpenTimeout
+	breakerSuccesses := defaultBreakerSuccesses
 	prometheusPort := defaultPrometheusPort
 	otelCollectorAddress := defaultOtelCollectorAddress
+	webhookWorkersCount := defaultWebhookWorkersCount
+	webhookDispatchInterval := defaultWebhookDispatchInterval
+	webhookTimeout := defaultWebhookTimeout
+	webhookLease := defaultWebhookLease
+	webhookMaxAttempts := defaultWebhookMaxAttempts
+	webhookBackoffBase := defaultWebhookBackoffBase
+	webhookBackoffMax := defaultWebhookBackoffMax
 
 	// Flags Definition.
 
 	kafkaAddressFlagVal := flagtypes.NewString()
 	flag.Var(kafkaAddressFlagVal, kafkaAddressFlag, "Kafka bootstrap server address")
 
+	kafkaTransactionalFlagVal := flagtypes.NewBool()
+	flag.Var(kafkaTransactionalFlagVal, kafkaTransactionalFlag, "Publish outbox messages in Kafka transactions")
+
+	kafkaControlTopicFlagVal := flagtypes.NewString()
+	flag.Var(kafkaControlTopicFlagVal, kafkaControlTopicFlag, "Kafka topic of last published outbox sequences")
+
+	kafkaTxIntervalFlagVal := flagtypes.NewInt()
+	flag.Var(kafkaTxIntervalFlagVal, kafkaTxIntervalFlag, "Kafka transaction commit interval (ms)")
+
+	kafkaPublishedTTLFlagVal := flagtypes.NewInt()
+	flag.Var(kafkaPublishedTTLFlagVal, kafkaPublishedTTLFlag, "How long published outbox sequences are remembered (ms)")
+
 	storageAddressFlagVal := flagtypes.NewString()
 	flag.Var(storageAddressFlagVal, storageAddressFlag, "Storage server address")
 
 	workersCountFlagVal := flagtypes.NewInt()
 	flag.Var(workersCountFlagVal, workersCountFlag, "Workers count")
 
+	instanceIDFlagVal := flagtypes.NewString()
+	flag.Var(instanceIDFlagVal, instanceIDFlag, "Instance ID, outbox messages are leased to it")
+
 	retryIntervalFlagVal := flagtypes.NewInt()
 	flag.Var(retryIntervalFlagVal, retryIntervalFlag, "Retry interval (ms)")
 
 	dispatchIntervalFlagVal := flagtypes.NewInt()
 	flag.Var(dispatchIntervalFlagVal, dispatchIntervalFlag, "Dispatch interval (ms)")
 
+	idleDispatchIntervalFlagVal := flagtypes.NewInt()
+	flag.Var(idleDispatchIntervalFlagVal, idleDispatchIntervalFlag, "Dispatch interval while outbox is idle (ms)")
+
+	ackBatchSizeFlagVal := flagtypes.NewInt()
+	flag.Var(ackBatchSizeFlagVal, ackBatchSizeFlag, "Delivered messages removed from outbox at once")
+
+	ackIntervalFlagVal := flagtypes.NewInt()
+	flag.Var(ackIntervalFlagVal, ackIntervalFlag, "Max delay of removing delivered messages from outbox (ms)")
+
+	drainTimeoutFlagVal := flagtypes.NewInt()
+	flag.Var(drainTimeoutFlagVal, drainTimeoutFlag, "Max duration of releasing and delivering in-flight messages on shutdown (ms)")
+
+	outboxMaxAttemptsFlagVal := flagtypes.NewInt()
+	flag.Var(outboxMaxAttemptsFlagVal, outboxMaxAttemptsFlag, "Outbox message publish attempts before parking")
+
+	outboxBackoffBaseFlagVal := flagtypes.NewInt()
+	flag.Var(outboxBackoffBaseFlagVal, outboxBackoffBaseFlag, "Outbox message first retry delay (ms)")
+
+	outboxBackoffMaxFlagVal := flagtypes.NewInt()
+	flag.Var(outboxBackoffMaxFlagVal, outboxBackoffMaxFlag, "Outbox message max retry delay (ms)")
+
+	outboxMinBatchSizeFlagVal := flagtypes.NewInt()
+	flag.Var(outboxMinBatchSizeFlagVal, outboxMinBatchSizeFlag, "Min outbox messages fetched at once")
+
+	outboxMaxBatchSizeFlagVal := flagtypes.NewInt()
+	flag.Var(outboxMaxBatchSizeFlagVal, outboxMaxBatchSizeFlag, "Max outbox messages fetched at once")
+
+	minDispatchIntervalFlagVal := flagtypes.NewInt()
+	flag.Var(minDispatchIntervalFlagVal, minDispatchIntervalFlag, "Dispatch interval while outbox backlog is large (ms)")
+
+	outboxTargetLatencyFlagVal := flagtypes.NewInt()
+	flag.Var(outboxTargetLatencyFlagVal, outboxTargetLatencyFlag, "Delivery latency above which outbox batches shrink (ms)")
+
+	breakerFailuresFlagVal := flagtypes.NewInt()
+	flag.Var(breakerFailuresFlagVal, breakerFailuresFlag, "Consecutive storage or Kafka failures opening a circuit breaker")
+
+	breakerOpenTimeoutFlagVal := flagtypes.NewInt()
+	flag.Var(breakerOpenTimeoutFlagVal, breakerOpenTimeoutFlag, "Duration of an open circuit breaker rejecting calls (ms)")
+
+	breakerSuccessesFlagVal := flagtypes.NewInt()
+	flag.Var(breakerSuccessesFlagVal, breakerSuccessesFlag, "Successful trial calls closing a half-open circuit breaker")
+
 	prometheusPortFlagVal := flagtypes.NewInt()
 	flag.Var(prometheusPortFlagVal, prometheusPortFlag, "Prometheus port")
 
 	otelCollectorAddressFlagVal := flagtypes.NewString()
 	flag.Var(otelCollectorAddressFlagVal, otelCollectorAddressFlag, "OpenTelemetry Collector address")
 
+	webhookWorkersCountFlagVal := flagtypes.NewInt()
+	flag.Var(webhookWorkersCountFlagVal, webhookWorkersCountFlag, "Webhook workers count")
+
+	webhookDispatchIntervalFlagVal := flagtypes.NewInt()
+	flag.Var(webhookDispatchIntervalFlagVal, webhookDispatchIntervalFlag, "Webhook dispatch interval (ms)")
+
+	webhookTimeoutFlagVal := flagtypes.NewInt()
+	flag.Var(webhookTimeoutFlagVal, webhookTimeoutFlag, "Webhook request timeout (ms)")
+
+	webhookLeaseFlagVal := flagtypes.NewInt()
+	flag.Var(webhookLeaseFlagVal, webhookLeaseFlag, "Webhook delivery lease (ms)")
+
+	webhookMaxAttemptsFlagVal := flagtypes.NewInt()
+	flag.Var(webhookMaxAttemptsFlagVal, webhookMaxAttemptsFlag, "Webhook delivery attempts before dead-lettering")
+
+	webhookBackoffBaseFlagVal := flagtypes.NewInt()
+	flag.Var(webhookBackoffBaseFlagVal, webhookBackoffBaseFlag, "Webhook first retry delay (ms)")
+
+	webhookBackoffMaxFlagVal := flagtypes.NewInt()
+	flag.Var(webhookBackoffMaxFlagVal, webhookBackoffMaxFlag, "Webhook max retry delay (ms)")
+
 	flag.Parse()
 
 	// Flags Parse.
@@ -92,6 +281,22 @@ func Load() (*Config, error) {
 		kafkaAddress = val
 	}
 
+	if val, ok := kafkaTransactionalFlagVal.Value(); ok {
+		kafkaTransactional = val
+	}
+
+	if val, ok := kafkaControlTopicFlagVal.Value(); ok {
+		kafkaControlTopic = val
+	}
+
+	if val, ok := kafkaTxIntervalFlagVal.Value(); ok {
+		kafkaTxInterval = time.Duration(val) * time.Millisecond
+	}
+
+	if val, ok := kafkaPublishedTTLFlagVal.Value(); ok {
+		kafkaPublishedTTL = time.Duration(val) * time.Millisecond
+	}
+
 	if val, ok := storageAddressFlagVal.Value(); ok {
 		storageAddress = val
 	}
@@ -100,6 +305,11 @@ func Load() (*Config, error) {
 		workersCount = val
 	}
 
+	if val, ok := instanceIDFlagVal.Value(); ok {
+		instanceID = val
+		instanceIDSet = true
+	}
+
 	if val, ok := retryIntervalFlagVal.Value(); ok {
 		retryInterval = time.Duration(val) * time.Millisecond
 	}
@@ -108,6 +318,62 @@ func Load() (*Config, error) {
 		dispatchInterval = time.Duration(val) * time.Millisecond
 	}
 
+	if val, ok := idleDispatchIntervalFlagVal.Value(); ok {
+		idleDispatchInterval = time.Duration(val) * time.Millisecond
+	}
+
+	if val, ok := ackBatchSizeFlagVal.Value(); ok {
+		ackBatchSize = val
+	}
+
+	if val, ok := ackIntervalFlagVal.Value(); ok {
+		ackInterval = time.Duration(val) * time.Millisecond
+	}
+
+	if val, ok := drainTimeoutFlagVal.Value(); ok {
+		drainTimeout = time.Duration(val) * time.Millisecond
+	}
+
+	if val, ok := outboxMaxAttemptsFlagVal.Value(); ok {
+		outboxMaxAttempts = val
+	}
+
+	if val, ok := outboxBackoffBaseFlagVal.Value(); ok {
+		outboxBackoffBase = time.Duration(val) * time.Millisecond
+	}
+
+	if val, ok := outboxBackoffMaxFlagVal.Value(); ok {
+		outboxBackoffMax = time.Duration(val) * time.Millisecond
+	}
+
+	if val, ok := outboxMinBatchSizeFlagVal.Value(); ok {
+		outboxMinBatchSize = val
+	}
+
+	if val, ok := outboxMaxBatchSizeFlagVal.Value(); ok {
+		outboxMaxBatchSize = val
+	}
+
+	if val, ok := minDispatchIntervalFlagVal.Value(); ok {
+		minDispatchInterval = time.Duration(val) * time.Millisecond
+	}
+
+	if val, ok := outboxTargetLatencyFlagVal.Value(); ok {
+		outboxTargetLatency = time.Duration(val) * time.Millisecond
+	}
+
+	if val, ok := breakerFailuresFlagVal.Value(); ok {
+		breakerFailures = val
+	}
+
+	if val, ok := breakerOpenTimeoutFlagVal.Value(); ok {
+		breakerOpenTimeout = time.Duration(val) * time.Millisecond
+	}
+
+	if val, ok := breakerSuccessesFlagVal.Value(); ok {
+		breakerSuccesses = val
+	}
+
 	if val, ok := prometheusPortFlagVal.Value(); ok {
 		prometheusPort = val
 	}
@@ -116,12 +382,68 @@ func Load() (*Config, error) {
 		otelCollectorAddress = valStr
 	}
 
+	if val, ok := webhookWorkersCountFlagVal.Value(); ok {
+		webhookWorkersCount = val
+	}
+
+	if val, ok := webhookDispatchIntervalFlagVal.Value(); ok {
+		webhookDispatchInterval = time.Duration(val) * time.Millisecond
+	}
+
+	if val, ok := webhookTimeoutFlagVal.Value(); ok {
+		webhookTimeout = time.Duration(val) * time.Millisecond
+	}
+
+	if val, ok := webhookLeaseFlagVal.Value(); ok {
+		webhookLease = time.Duration(val) * time.Millisecond
+	}
+
+	if val, ok := webhookMaxAttemptsFlagVal.Value(); ok {
+		webhookMaxAttempts = val
+	}
+
+	if val, ok := webhookBackoffBaseFlagVal.Value(); ok {
+		webhookBackoffBase = time.Duration(val) * time.Millisecond
+	}
+
+	if val, ok := webhookBackoffMaxFlagVal.Value(); ok {
+		webhookBackoffMax = time.Duration(val) * time.Millisecond
+	}
+
 	// Environment Variables.
 
 	if valStr, ok := os.LookupEnv(kafkaAddressEnv); ok {
 		kafkaAddress = valStr
 	}
 
+	if valStr, ok := os.LookupEnv(kafkaTransactionalEnv); ok {
+		val, err := strconv.ParseBool(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, kafkaTransactionalEnv)
+		}
+		kafkaTransactional = val
+	}
+
+	if valStr, ok := os.LookupEnv(kafkaControlTopicEnv); ok {
+		kafkaControlTopic = valStr
+	}
+
+	if valStr, ok := os.LookupEnv(kafkaTxIntervalEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, kafkaTxIntervalEnv)
+		}
+		kafkaTxInterval = time.Duration(val) * time.Millisecond
+	}
+
+	if valStr, ok := os.LookupEnv(kafkaPublishedTTLEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, kafkaPublishedTTLEnv)
+		}
+		kafkaPublishedTTL = time.Duration(val) * time.Millisecond
+	}
+
 	if valStr, ok := os.LookupEnv(storageAddressEnv); ok {
 		storageAddress = valStr
 	}
@@ -134,6 +456,11 @@ func Load() (*Config, error) {
 		workersCount = val
 	}
 
+	if valStr, ok := os.LookupEnv(instanceIDEnv); ok {
+		instanceID = valStr
+		instanceIDSet = true
+	}
+
 	if valStr, ok := os.LookupEnv(retryIntervalEnv); ok {
 		val, err := strconv.Atoi(valStr)
 		if err != nil {
@@ -150,6 +477,118 @@ func Load() (*Config, error) {
 		dispatchInterval = time.Duration(val) * time.Millisecond
 	}
 
+	if valStr, ok := os.LookupEnv(idleDispatchIntervalEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, idleDispatchIntervalEnv)
+		}
+		idleDispatchInterval = time.Duration(val) * time.Millisecond
+	}
+
+	if valStr, ok := os.LookupEnv(ackBatchSizeEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, ackBatchSizeEnv)
+		}
+		ackBatchSize = val
+	}
+
+	if valStr, ok := os.LookupEnv(ackIntervalEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, ackIntervalEnv)
+		}
+		ackInterval = time.Duration(val) * time.Millisecond
+	}
+
+	if valStr, ok := os.LookupEnv(drainTimeoutEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, drainTimeoutEnv)
+		}
+		drainTimeout = time.Duration(val) * time.Millisecond
+	}
+
+	if valStr, ok := os.LookupEnv(outboxMaxAttemptsEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, outboxMaxAttemptsEnv)
+		}
+		outboxMaxAttempts = val
+	}
+
+	if valStr, ok := os.LookupEnv(outboxBackoffBaseEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, outboxBackoffBaseEnv)
+		}
+		outboxBackoffBase = time.Duration(val) * time.Millisecond
+	}
+
+	if valStr, ok := os.LookupEnv(outboxBackoffMaxEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, outboxBackoffMaxEnv)
+		}
+		outboxBackoffMax = time.Duration(val) * time.Millisecond
+	}
+
+	if valStr, ok := os.LookupEnv(outboxMinBatchSizeEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, outboxMinBatchSizeEnv)
+		}
+		outboxMinBatchSize = val
+	}
+
+	if valStr, ok := os.LookupEnv(outboxMaxBatchSizeEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, outboxMaxBatchSizeEnv)
+		}
+		outboxMaxBatchSize = val
+	}
+
+	if valStr, ok := os.LookupEnv(minDispatchIntervalEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, minDispatchIntervalEnv)
+		}
+		minDispatchInterval = time.Duration(val) * time.Millisecond
+	}
+
+	if valStr, ok := os.LookupEnv(outboxTargetLatencyEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, outboxTargetLatencyEnv)
+		}
+		outboxTargetLatency = time.Duration(val) * time.Millisecond
+	}
+
+	if valStr, ok := os.LookupEnv(breakerFailuresEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, breakerFailuresEnv)
+		}
+		breakerFailures = val
+	}
+
+	if valStr, ok := os.LookupEnv(breakerOpenTimeoutEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, breakerOpenTimeoutEnv)
+		}
+		breakerOpenTimeout = time.Duration(val) * time.Millisecond
+	}
+
+	if valStr, ok := os.LookupEnv(breakerSuccessesEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, breakerSuccessesEnv)
+		}
+		breakerSuccesses = val
+	}
+
 	if valStr, ok := os.LookupEnv(prometheusPortEnv); ok {
 		val, err := strconv.Atoi(valStr)
 		if err != nil {
@@ -158,35 +597,214 @@ func Load() (*Config, error) {
 		prometheusPort = val
 	}
 
+	if valStr, ok := os.LookupEnv(webhookWorkersCountEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, webhookWorkersCountEnv)
+		}
+		webhookWorkersCount = val
+	}
+
+	if valStr, ok := os.LookupEnv(webhookDispatchIntervalEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, webhookDispatchIntervalEnv)
+		}
+		webhookDispatchInterval = time.Duration(val) * time.Millisecond
+	}
+
+	if valStr, ok := os.LookupEnv(webhookTimeoutEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, webhookTimeoutEnv)
+		}
+		webhookTimeout = time.Duration(val) * time.Millisecond
+	}
+
+	if valStr, ok := os.LookupEnv(webhookLeaseEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, webhookLeaseEnv)
+		}
+		webhookLease = time.Duration(val) * time.Millisecond
+	}
+
+	if valStr, ok := os.LookupEnv(webhookMaxAttemptsEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, webhookMaxAttemptsEnv)
+		}
+		webhookMaxAttempts = val
+	}
+
+	if valStr, ok := os.LookupEnv(webhookBackoffBaseEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, webhookBackoffBaseEnv)
+		}
+		webhookBackoffBase = time.Duration(val) * time.Millisecond
+	}
+
+	if valStr, ok := os.LookupEnv(webhookBackoffMaxEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, webhookBackoffMaxEnv)
+		}
+		webhookBackoffMax = time.Duration(val) * time.Millisecond
+	}
+
 	// Validation.
 
+	if kafkaTransactional && kafkaControlTopic == "" {
+		return &Config{}, errors.New("kafka control topic must not be empty in transactional mode")
+	}
+
+	if kafkaTxInterval <= time.Duration(0) {
+		return &Config{}, errors.New("kafka transaction interval must be greater than zero")
+	}
+
 	if workersCount < 1 {
 		return &Config{}, errors.New("workers count must be greater than one")
 	}
 
+	if instanceID == "" {
+		return &Config{}, errors.New("instance id must not be empty")
+	}
+
+	if kafkaTransactional && !instanceIDSet {
+		return &Config{}, errors.New("instance id must be set in transactional mode, it is the stable kafka transactional id")
+	}
+
 	if retryInterval < time.Duration(0) {
 		return &Config{}, errors.New("retry internal must be greater than zero")
 	}
 
+	if kafkaPublishedTTL <= retryInterval {
+		return &Config{}, errors.New("kafka published ttl must be greater than retry interval")
+	}
+
 	if dispatchInterval < time.Duration(0) {
 		return &Config{}, errors.New("dispatch internal must be greater than zero")
 	}
 
+	if idleDispatchInterval < dispatchInterval {
+		return &Config{}, errors.New("idle dispatch interval must not be less than dispatch interval")
+	}
+
+	if minDispatchInterval <= time.Duration(0) || minDispatchInterval > dispatchInterval {
+		return &Config{}, errors.New("min dispatch interval must be greater than zero and not greater than dispatch interval")
+	}
+
+	if outboxMinBatchSize < 1 || outboxMaxBatchSize < outboxMinBatchSize {
+		return &Config{}, errors.New("outbox min batch size must be greater than zero and not greater than max batch size")
+	}
+
+	if outboxTargetLatency <= time.Duration(0) {
+		return &Config{}, errors.New("outbox target latency must be greater than zero")
+	}
+
+	if ackBatchSize < 1 {
+		return &Config{}, errors.New("ack batch size must be greater than zero")
+	}
+
+	if ackInterval <= time.Duration(0) {
+		return &Config{}, errors.New("ack interval must be greater than zero")
+	}
+
+	if drainTimeout <= time.Duration(0) {
+		return &Config{}, errors.New("drain timeout must be greater than zero")
+	}
+
+	if outboxMaxAttempts < 1 {
+		return &Config{}, errors.New("outbox max attempts must be greater than zero")
+	}
+
+	if outboxBackoffBase <= time.Duration(0) || outboxBackoffMax < outboxBackoffBase {
+		return &Config{}, errors.New("outbox backoff base must be greater than zero and not exceed backoff max")
+	}
+
+	if breakerFailures < 1 || breakerSuccesses < 1 {
+		return &Config{}, errors.New("circuit breaker failure and success thresholds must be greater than zero")
+	}
+
+	if breakerOpenTimeout <= time.Duration(0) {
+		return &Config{}, errors.New("circuit breaker open timeout must be greater than zero")
+	}
+
 	if prometheusPort < 0 || prometheusPort > 65535 {
 		return &Config{}, errors.New("prometheus port must be between 0 and 65535")
 	}
 
+	if webhookWorkersCount < 1 {
+		return &Config{}, errors.New("webhook workers count must be greater than zero")
+	}
+
+	if webhookDispatchInterval < time.Duration(0) {
+		return &Config{}, errors.New("webhook dispatch interval must be greater than zero")
+	}
+
+	if webhookTimeout <= time.Duration(0) {
+		return &Config{}, errors.New("webhook timeout must be greater than zero")
+	}
+
+	if webhookLease <= webhookTimeout {
+		return &Config{}, errors.New("webhook lease must be greater than webhook timeout")
+	}
+
+	if webhookMaxAttempts < 1 {
+		return &Config{}, errors.New("webhook max attempts must be greater than zero")
+	}
+
+	if webhookBackoffBase <= time.Duration(0) || webhookBackoffMax < webhookBackoffBase {
+		return &Config{}, errors.New("webhook backoff base must be greater than zero and not exceed backoff max")
+	}
+
 	return &Config{
 		KafkaProducerConfig: services.KafkaProducerConfig{
-			ServerAddress: kafkaAddress,
+			ServerAddress:       kafkaAddress,
+			Transactional:       kafkaTransactional,
+			TransactionalID:     instanceID,
+			ControlTopic:        kafkaControlTopic,
+			TransactionInterval: kafkaTxInterval,
+			PublishedTTL:        kafkaPublishedTTL,
 		},
 		StorageConfig: services.StorageConfig{
 			ServerAddress: storageAddress,
 		},
 		OutboxDispatcherConfig: controllers.OutboxDispatcherConfig{
-			DispatchInterval: dispatchInterval,
-			RetryIn:          retryInterval,
-			NumWorkers:       int32(workersCount),
+			MinBatchSize:         int32(outboxMinBatchSize),
+			MaxBatchSize:         int32(outboxMaxBatchSize),
+			DispatchInterval:     dispatchInterval,
+			MinDispatchInterval:  minDispatchInterval,
+			TargetLatency:        outboxTargetLatency,
+			IdleDispatchInterval: idleDispatchInterval,
+			RetryIn:              retryInterval,
+			NumWorkers:           int32(workersCount),
+			LeaseOwner:           instanceID,
+			AckBatchSize:         int32(ackBatchSize),
+			AckInterval:          ackInterval,
+			DrainTimeout:         drainTimeout,
+			ShutdownTimeout:      defaultShutdownTimeout,
+			MaxAttempts:          int32(outboxMaxAttempts),
+			BackoffBase:          outboxBackoffBase,
+			BackoffMax:           outboxBackoffMax,
+		},
+		WebhookSenderConfig: services.WebhookSenderConfig{
+			Timeout: webhookTimeout,
+		},
+		WebhookDispatcherConfig: controllers.WebhookDispatcherConfig{
+			NumWorkers:       int32(webhookWorkersCount),
+			DispatchInterval: webhookDispatchInterval,
+			LeaseOwner:       instanceID,
+			LeaseFor:         webhookLease,
+			MaxAttempts:      int32(webhookMaxAttempts),
+			BackoffBase:      webhookBackoffBase,
+			BackoffMax:       webhookBackoffMax,
+		},
+		BreakerConfig: breaker.Config{
+			FailureThreshold: breakerFailures,
+			OpenTimeout:      breakerOpenTimeout,
+			SuccessThreshold: breakerSuccesses,
 		},
 		PrometheusConfig: meterutils.PrometheusConfig{
 			PortToListen:    uint16(prometheusPort),
@@ -0,0 +1,216 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/google/uuid"
+	"go-invoice-service/common/pkg/flagtypes"
+	"go-invoice-service/common/protocol/kafka"
+	"message-sheduler-service/internal/controllers"
+	"message-sheduler-service/internal/dto"
+	"message-sheduler-service/internal/services"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	replayTopicFlag     = "topic"
+	replayAggregateFlag = "aggregate"
+	replayTenantFlag    = "tenant"
+	replayFromFlag      = "from"
+	replayToFlag        = "to"
+	replayAfterIDFlag   = "after-id"
+	replayRateFlag      = "rate"
+	replayRateEnv       = "REPLAY_RATE"
+	replayBatchSizeFlag = "batch-size"
+	replayBatchSizeEnv  = "REPLAY_BATCH_SIZE"
+)
+
+const (
+	defaultReplayRate      = 100
+	defaultReplayBatchSize = 100
+	maxReplayBatchSize     = 1000
+)
+
+type ReplayConfig struct {
+	StorageConfig  services.StorageConfig
+	ReplayerConfig controllers.ReplayerConfig
+	Filter         dto.ReplayFilter
+	AfterID        int64
+}
+
+// LoadReplay loads the config of the replay command from its arguments and the
+// environment.
+func LoadReplay(args []string) (*ReplayConfig, error) {
+
+	storageAddress := defaultStorageAddress
+	topic := ""
+	aggregateID := uuid.Nil
+	tenant := ""
+	var from, to time.Time
+	afterID := 0
+	rate := defaultReplayRate
+	batchSize := defaultReplayBatchSize
+
+	// Flags Definition.
+
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+
+	storageAddressFlagVal := flagtypes.NewString()
+	flags.Var(storageAddressFlagVal, storageAddressFlag, "Storage server address")
+
+	topicFlagVal := flagtypes.NewString()
+	flags.Var(topicFlagVal, replayTopicFlag, "Topic of replayed events, all topics if empty")
+
+	aggregateFlagVal := flagtypes.NewString()
+	flags.Var(aggregateFlagVal, replayAggregateFlag, "Invoice ID of replayed events, all invoices if empty")
+
+	tenantFlagVal := flagtypes.NewString()
+	flags.Var(tenantFlagVal, replayTenantFlag, "Tenant of replayed events, not supported until events carry a tenant")
+
+	fromFlagVal := flagtypes.NewString()
+	flags.Var(fromFlagVal, replayFromFlag, "Replay events created at or after, RFC 3339 time or date (UTC)")
+
+	toFlagVal := flagtypes.NewString()
+	flags.Var(toFlagVal, replayToFlag, "Replay events created before, RFC 3339 time or date (UTC)")
+
+	afterIDFlagVal := flagtypes.NewInt()
+	flags.Var(afterIDFlagVal, replayAfterIDFlag, "Replay events after the outbox message ID, to continue a stopped replay")
+
+	rateFlagVal := flagtypes.NewInt()
+	flags.Var(rateFlagVal, replayRateFlag, "Max replayed events per second")
+
+	batchSizeFlagVal := flagtypes.NewInt()
+	flags.Var(batchSizeFlagVal, replayBatchSizeFlag, "Events replayed at once")
+
+	if err := flags.Parse(args); err != nil {
+		return &ReplayConfig{}, err
+	}
+
+	// Flags Parse.
+
+	if val, ok := storageAddressFlagVal.Value(); ok {
+		storageAddress = val
+	}
+
+	if val, ok := topicFlagVal.Value(); ok {
+		topic = val
+	}
+
+	if val, ok := aggregateFlagVal.Value(); ok {
+		id, err := uuid.Parse(val)
+		if err != nil {
+			return &ReplayConfig{}, fmt.Errorf("%w: '%s' flag parsing failed", err, replayAggregateFlag)
+		}
+		aggregateID = id
+	}
+
+	if val, ok := tenantFlagVal.Value(); ok {
+		tenant = val
+	}
+
+	if val, ok := fromFlagVal.Value(); ok {
+		t, err := parseReplayTime(val)
+		if err != nil {
+			return &ReplayConfig{}, fmt.Errorf("%w: '%s' flag parsing failed", err, replayFromFlag)
+		}
+		from = t
+	}
+
+	if val, ok := toFlagVal.Value(); ok {
+		t, err := parseReplayTime(val)
+		if err != nil {
+			return &ReplayConfig{}, fmt.Errorf("%w: '%s' flag parsing failed", err, replayToFlag)
+		}
+		to = t
+	}
+
+	if val, ok := afterIDFlagVal.Value(); ok {
+		afterID = val
+	}
+
+	if val, ok := rateFlagVal.Value(); ok {
+		rate = val
+	}
+
+	if val, ok := batchSizeFlagVal.Value(); ok {
+		batchSize = val
+	}
+
+	// Environment Variables.
+
+	if valStr, ok := os.LookupEnv(storageAddressEnv); ok {
+		storageAddress = valStr
+	}
+
+	if valStr, ok := os.LookupEnv(replayRateEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &ReplayConfig{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, replayRateEnv)
+		}
+		rate = val
+	}
+
+	if valStr, ok := os.LookupEnv(replayBatchSizeEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &ReplayConfig{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, replayBatchSizeEnv)
+		}
+		batchSize = val
+	}
+
+	// Validation.
+
+	if topic != "" && !slices.ContainsFunc(kafka.Events, func(e kafka.Event) bool { return e.EventType() == kafka.Topic(topic) }) {
+		return &ReplayConfig{}, fmt.Errorf("unknown event topic '%s'", topic)
+	}
+
+	// Invoices have no tenant, so the tenant of event envelopes is never set and a
+	// filter by it would replay nothing.
+	if tenant != "" {
+		return &ReplayConfig{}, errors.New("replay by tenant is not supported, events carry no tenant")
+	}
+
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return &ReplayConfig{}, errors.New("replay from must be before replay to")
+	}
+
+	if afterID < 0 {
+		return &ReplayConfig{}, errors.New("replay after id must not be negative")
+	}
+
+	if rate < 1 {
+		return &ReplayConfig{}, errors.New("replay rate must be greater than zero")
+	}
+
+	if batchSize < 1 || batchSize > maxReplayBatchSize {
+		return &ReplayConfig{}, fmt.Errorf("replay batch size must be between 1 and %d", maxReplayBatchSize)
+	}
+
+	return &ReplayConfig{
+		StorageConfig: services.StorageConfig{
+			ServerAddress: storageAddress,
+		},
+		ReplayerConfig: controllers.ReplayerConfig{
+			Rate:      rate,
+			BatchSize: int32(batchSize),
+		},
+		Filter: dto.ReplayFilter{
+			Topic:       topic,
+			AggregateID: aggregateID,
+			From:        from,
+			To:          to,
+		},
+		AfterID: int64(afterID),
+	}, nil
+}
+
+func parseReplayTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"go-invoice-service/common/pkg/breaker"
 	"go-invoice-service/common/pkg/logging"
 	"go-invoice-service/common/pkg/meterutils"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
 	"log"
 	"message-sheduler-service/cmd/config"
 	"message-sheduler-service/internal/controllers"
@@ -56,13 +58,19 @@ func main() {
 	)
 	defer cancelCtx()
 
-	kafkaProducer, err := services.NewKafkaProducer(cfg.KafkaProducerConfig, metricsCollector)
+	kafkaBreaker := breaker.New("kafka", cfg.BreakerConfig, metricsCollector, logger)
+	kafkaProducer, err := services.NewKafkaProducer(cfg.KafkaProducerConfig, metricsCollector, kafkaBreaker)
 	if err != nil {
 		logger.ErrorCtx(rootCtx, "Failed to create kafka producer", zap.Error(err))
 	}
 	defer kafkaProducer.Close()
 
-	storageService, err := services.NewStorage(cfg.StorageConfig)
+	storageBreaker := breaker.New("storage", cfg.BreakerConfig, metricsCollector, logger)
+	storageService, err := services.NewStorage(
+		cfg.StorageConfig,
+		grpc.WithChainUnaryInterceptor(breaker.UnaryClientInterceptor(storageBreaker)),
+		grpc.WithChainStreamInterceptor(breaker.StreamClientInterceptor(storageBreaker)),
+	)
 	if err != nil {
 		logger.ErrorCtx(rootCtx, "Failed to create storage service", zap.Error(err))
 	}
@@ -72,10 +80,19 @@ func main() {
 		cfg.OutboxDispatcherConfig,
 		storageService,
 		kafkaProducer,
+		metricsCollector,
 		logger,
 	)
 
-	if err := run(rootCtx, cfg, outboxDispatcher, logger); err != nil {
+	webhookDispatcher := controllers.NewWebhookDispatcher(
+		cfg.WebhookDispatcherConfig,
+		storageService,
+		services.NewWebhookSender(cfg.WebhookSenderConfig),
+		metricsCollector,
+		logger,
+	)
+
+	if err := run(rootCtx, cfg, kafkaProducer, outboxDispatcher, webhookDispatcher, logger); err != nil {
 		logger.ErrorCtx(rootCtx, "Service shutdown with error", zap.Error(err))
 	} else {
 		logger.InfoCtx(rootCtx, "Service shutdown gracefully")
@@ -85,25 +102,51 @@ func main() {
 func run(
 	rootCtx context.Context,
 	cfg *config.Config,
+	kafkaProducer *services.KafkaProducer,
 	outboxDispatcher *controllers.OutboxDispatcher,
+	webhookDispatcher *controllers.WebhookDispatcher,
 	logger *logging.ZapLogger,
 ) error {
-	err := setup.EnsureKafkaTopics(rootCtx, cfg.KafkaProducerConfig.ServerAddress, logger)
+	var controlTopic string
+	if cfg.KafkaProducerConfig.Transactional {
+		controlTopic = cfg.KafkaProducerConfig.ControlTopic
+	}
+	err := setup.EnsureKafkaTopics(rootCtx, cfg.KafkaProducerConfig.ServerAddress, controlTopic, logger)
 	if err != nil {
 		return fmt.Errorf("failed to setup kafka topics: %w", err)
 	}
 
+	if err = kafkaProducer.Init(rootCtx); err != nil {
+		return fmt.Errorf("failed to init kafka producer: %w", err)
+	}
+
 	g, ctx := errgroup.WithContext(rootCtx)
 
 	g.Go(func() error {
 		defer logger.InfoCtx(ctx, "Outbox Dispatching Finished")
 		errCh := outboxDispatcher.Run(ctx)
 		for err := range errCh {
+			if errors.Is(err, breaker.ErrOpen) {
+				// The breaker logs when it opens and closes.
+				continue
+			}
 			logger.ErrorCtx(ctx, "Outbox Dispatching Error", zap.Error(err))
 		}
 		return nil
 	})
 
+	g.Go(func() error {
+		defer logger.InfoCtx(ctx, "Webhook Dispatching Finished")
+		errCh := webhookDispatcher.Run(ctx)
+		for err := range errCh {
+			if errors.Is(err, breaker.ErrOpen) {
+				continue
+			}
+			logger.ErrorCtx(ctx, "Webhook Dispatching Error", zap.Error(err))
+		}
+		return nil
+	})
+
 	promServer := meterutils.NewPrometheusServer(cfg.PrometheusConfig)
 
 	g.Go(func() error {
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"go-invoice-service/common/pkg/logging"
+	"go.uber.org/zap/zapcore"
+	"log"
+	"message-sheduler-service/cmd/config"
+	"message-sheduler-service/internal/controllers"
+	"message-sheduler-service/internal/services"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Re-publishes events kept in the outbox or its archive, e.g.
+//
+//	replay -topic invoice_approved -from 2025-01-01 -to 2025-02-01
+func main() {
+	cfg, err := config.LoadReplay(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancelCtx := signal.NotifyContext(
+		context.Background(),
+		syscall.SIGINT,
+		syscall.SIGTERM,
+	)
+	defer cancelCtx()
+
+	if err := replay(ctx, cfg); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func replay(ctx context.Context, cfg *config.ReplayConfig) error {
+	logger, err := logging.NewZapLogger(zapcore.InfoLevel)
+	if err != nil {
+		return err
+	}
+
+	storageService, err := services.NewStorage(cfg.StorageConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create storage service: %w", err)
+	}
+	defer storageService.Close()
+
+	replayer := controllers.NewReplayer(cfg.ReplayerConfig, storageService, logger)
+	total, err := replayer.Run(ctx, cfg.Filter, cfg.AfterID)
+	if err != nil {
+		return fmt.Errorf("replay stopped after %d events: %w", total, err)
+	}
+	logger.InfoCtx(ctx, fmt.Sprintf("Replay finished, %d events replayed", total))
+
+	return nil
+}
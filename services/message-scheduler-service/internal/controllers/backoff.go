@@ -0,0 +1,16 @@
+package controllers
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// backoff doubles the delay after every failed attempt up to maxDelay and adds
+// up to 20% of jitter, so failed messages do not retry in lockstep.
+func backoff(attempt int32, base time.Duration, maxDelay time.Duration) time.Duration {
+	delay := maxDelay
+	if shift := attempt - 1; shift < 32 && base<<shift > 0 && base<<shift < maxDelay {
+		delay = base << shift
+	}
+	return delay + time.Duration(rand.Int64N(int64(delay)/5+1))
+}
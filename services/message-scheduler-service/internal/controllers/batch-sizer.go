@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"sync"
+	"time"
+)
+
+// latencySmoothing is the weight of a new delivery latency in the moving average.
+const latencySmoothing = 5
+
+// batchSizer adapts the number of outbox messages fetched at once and the interval
+// between fetches. While the backlog is larger than a batch and sends keep up, the
+// batch grows and fetches get more frequent. When deliveries fail or their latency
+// exceeds the target, the batch is halved and the interval doubled.
+type batchSizer struct {
+	cfg OutboxDispatcherConfig
+
+	mu       sync.Mutex
+	size     int32
+	interval time.Duration
+	backlog  int64
+	// latency is the moving average of delivery latency.
+	latency time.Duration
+	// observed and failed count deliveries since the last adjustment.
+	observed int
+	failed   int
+}
+
+func newBatchSizer(cfg OutboxDispatcherConfig) *batchSizer {
+	return &batchSizer{
+		cfg:      cfg,
+		size:     cfg.MinBatchSize,
+		interval: cfg.DispatchInterval,
+	}
+}
+
+// pace adjusts the batch and returns how many messages to fetch while buffLen
+// fetched messages wait for sending, and the interval before the next fetch.
+func (s *batchSizer) pace(buffLen int32) (int32, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case s.failed > 0 || (s.observed > 0 && s.latency > s.cfg.TargetLatency):
+		s.size = max(s.cfg.MinBatchSize, s.size/2)
+		s.interval = min(s.cfg.IdleDispatchInterval, s.interval*2)
+	case s.backlog > int64(s.size) && buffLen < s.size/2 && s.latency <= s.cfg.TargetLatency/2:
+		s.size = min(s.cfg.MaxBatchSize, s.size+s.size/4+1)
+		s.interval = max(s.cfg.MinDispatchInterval, s.interval/2)
+	case s.interval > s.cfg.DispatchInterval:
+		// Kafka has recovered, but the backlog is small.
+		s.interval = max(s.cfg.DispatchInterval, s.interval/2)
+	}
+	s.observed = 0
+	s.failed = 0
+
+	return max(0, s.size-buffLen), s.interval
+}
+
+func (s *batchSizer) observeBacklog(backlog int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.backlog = backlog
+}
+
+func (s *batchSizer) observeDelivery(latency time.Duration, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if failed {
+		s.failed++
+		return
+	}
+	if s.observed == 0 && s.latency == 0 {
+		s.latency = latency
+	} else {
+		s.latency += (latency - s.latency) / latencySmoothing
+	}
+	s.observed++
+}
+
+func (s *batchSizer) batchSize() int32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.size
+}
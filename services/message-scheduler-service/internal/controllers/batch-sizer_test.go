@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBatchSizer_Pace(t *testing.T) {
+	cfg := OutboxDispatcherConfig{
+		MinBatchSize:         10,
+		MaxBatchSize:         100,
+		DispatchInterval:     100 * time.Millisecond,
+		MinDispatchInterval:  10 * time.Millisecond,
+		IdleDispatchInterval: time.Second,
+		TargetLatency:        time.Second,
+	}
+
+	tests := []struct {
+		name         string
+		size         int32
+		interval     time.Duration
+		backlog      int64
+		latencies    []time.Duration
+		failures     int
+		buffLen      int32
+		wantLimit    int32
+		wantSize     int32
+		wantInterval time.Duration
+	}{
+		{
+			name:         "grows_on_large_backlog",
+			size:         40,
+			interval:     100 * time.Millisecond,
+			backlog:      1000,
+			latencies:    []time.Duration{10 * time.Millisecond},
+			wantLimit:    51,
+			wantSize:     51,
+			wantInterval: 50 * time.Millisecond,
+		},
+		{
+			name:         "grows_up_to_max",
+			size:         90,
+			interval:     15 * time.Millisecond,
+			backlog:      1000,
+			buffLen:      20,
+			wantLimit:    80,
+			wantSize:     100,
+			wantInterval: 10 * time.Millisecond,
+		},
+		{
+			name:         "holds_while_sends_lag",
+			size:         40,
+			interval:     100 * time.Millisecond,
+			backlog:      1000,
+			buffLen:      30,
+			wantLimit:    10,
+			wantSize:     40,
+			wantInterval: 100 * time.Millisecond,
+		},
+		{
+			name:         "holds_on_small_backlog",
+			size:         40,
+			interval:     100 * time.Millisecond,
+			backlog:      5,
+			wantLimit:    40,
+			wantSize:     40,
+			wantInterval: 100 * time.Millisecond,
+		},
+		{
+			name:         "shrinks_on_failures",
+			size:         40,
+			interval:     100 * time.Millisecond,
+			backlog:      1000,
+			failures:     1,
+			wantLimit:    20,
+			wantSize:     20,
+			wantInterval: 200 * time.Millisecond,
+		},
+		{
+			name:         "shrinks_on_latency",
+			size:         40,
+			interval:     100 * time.Millisecond,
+			backlog:      1000,
+			latencies:    []time.Duration{2 * time.Second},
+			wantLimit:    20,
+			wantSize:     20,
+			wantInterval: 200 * time.Millisecond,
+		},
+		{
+			name:         "shrinks_down_to_min",
+			size:         12,
+			interval:     800 * time.Millisecond,
+			failures:     3,
+			wantLimit:    10,
+			wantSize:     10,
+			wantInterval: time.Second,
+		},
+		{
+			name:         "interval_recovers",
+			size:         10,
+			interval:     800 * time.Millisecond,
+			latencies:    []time.Duration{10 * time.Millisecond},
+			wantLimit:    10,
+			wantSize:     10,
+			wantInterval: 400 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newBatchSizer(cfg)
+			s.size = tt.size
+			s.interval = tt.interval
+			s.observeBacklog(tt.backlog)
+			for _, latency := range tt.latencies {
+				s.observeDelivery(latency, false)
+			}
+			for range tt.failures {
+				s.observeDelivery(0, true)
+			}
+
+			limit, interval := s.pace(tt.buffLen)
+			assert.Equal(t, tt.wantLimit, limit)
+			assert.Equal(t, tt.wantSize, s.batchSize())
+			assert.Equal(t, tt.wantInterval, interval)
+		})
+	}
+}
+
+func TestBatchSizer_ObserveDelivery(t *testing.T) {
+	s := newBatchSizer(OutboxDispatcherConfig{})
+
+	s.observeDelivery(100*time.Millisecond, false)
+	assert.Equal(t, 100*time.Millisecond, s.latency)
+
+	s.observeDelivery(600*time.Millisecond, false)
+	assert.Equal(t, 200*time.Millisecond, s.latency)
+
+	s.observeDelivery(0, true)
+	assert.Equal(t, 200*time.Millisecond, s.latency)
+	assert.Equal(t, 2, s.observed)
+	assert.Equal(t, 1, s.failed)
+}
@@ -2,104 +2,518 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/google/uuid"
+	"go-invoice-service/common/pkg/breaker"
 	"go-invoice-service/common/pkg/chutils"
 	"go-invoice-service/common/pkg/logging"
+	"hash/fnv"
 	"message-sheduler-service/internal/dto"
+	"sync"
 	"time"
 )
 
 type StorageService interface {
-	GetOutboxMessages(ctx context.Context, maxCount int32, retryIn time.Duration) ([]dto.OutboxMessage, error)
-	DeleteOutboxMessage(ctx context.Context, id int64) error
+	GetOutboxMessages(ctx context.Context, leaseOwner string, maxCount int32, retryIn time.Duration) (dto.OutboxBatch, error)
+	DeleteOutboxMessages(ctx context.Context, leaseOwner string, ids []int64) ([]int64, error)
+	FailOutboxMessage(ctx context.Context, leaseOwner string, failure dto.OutboxFailure) (bool, error)
+	ReleaseOutboxMessages(ctx context.Context, leaseOwner string, ids []int64) (int64, error)
+	WatchOutbox(ctx context.Context) (<-chan struct{}, <-chan error, error)
 }
 
 type KafkaProducer interface {
-	SendMessage(ctx context.Context, topic string, payload []byte) error
+	SendMessage(ctx context.Context, msg dto.OutboxMessage) error
+	Deliveries() <-chan dto.DeliveryReport
+	// Flush waits until the messages sent so far are delivered or ctx is done, and
+	// returns the number of messages still not delivered.
+	Flush(ctx context.Context) int
+	// Ready reports whether messages are accepted. SendMessage fails with
+	// breaker.ErrOpen while they are not.
+	Ready() bool
 }
 
+type OutboxMetrics interface {
+	IncOutboxTotalExpiredLeases(ctx context.Context, topic string)
+	IncOutboxTotalLostLeases(ctx context.Context, count int64)
+	IncOutboxTotalFailedAttempts(ctx context.Context, topic string, outcome string)
+	SetOutboxBatchSize(ctx context.Context, size int64)
+	SetOutboxBacklog(ctx context.Context, backlog int64)
+}
+
+const (
+	outboxOutcomeRetry  = "retry"
+	outboxOutcomeParked = "parked"
+)
+
 type OutboxDispatcher struct {
 	cfg            OutboxDispatcherConfig
 	storageService StorageService
 	kafkaProducer  KafkaProducer
+	metrics        OutboxMetrics
 	logger         *logging.ZapLogger
+	batchSizer     *batchSizer
+
+	mu sync.Mutex
+	// inFlight holds send times of messages whose deliveries are not handled yet.
+	inFlight map[int64]time.Time
+	// unsent messages were fetched but not sent because of shutdown.
+	unsent []int64
 }
 
 type OutboxDispatcherConfig struct {
-	NumWorkers       int32
-	RetryIn          time.Duration
-	DispatchInterval time.Duration
+	NumWorkers int32
+	// LeaseOwner identifies this instance, messages are leased to it for RetryIn.
+	LeaseOwner string
+	RetryIn    time.Duration
+	// Messages are fetched in batches of MinBatchSize to MaxBatchSize every
+	// MinDispatchInterval to IdleDispatchInterval, starting at DispatchInterval.
+	// Batches grow while the backlog is large and shrink when delivery latency
+	// exceeds TargetLatency or deliveries fail.
+	MinBatchSize        int32
+	MaxBatchSize        int32
+	DispatchInterval    time.Duration
+	MinDispatchInterval time.Duration
+	TargetLatency       time.Duration
+	// IdleDispatchInterval is the polling interval when the outbox is empty and no
+	// signal comes from storage.
+	IdleDispatchInterval time.Duration
+	AckBatchSize         int32
+	AckInterval          time.Duration
+	// DrainTimeout limits the drain on shutdown, while fetched messages that were
+	// not sent are released and deliveries of sent messages are awaited.
+	DrainTimeout time.Duration
+	// ShutdownTimeout limits the final acknowledgement of delivered messages.
+	ShutdownTimeout time.Duration
+	// Messages failed to publish are retried with exponential backoff and parked
+	// after MaxAttempts.
+	MaxAttempts int32
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
 }
 
 func NewOutboxDispatcher(
 	cfg OutboxDispatcherConfig,
 	storageService StorageService,
 	kafkaProducer KafkaProducer,
+	metrics OutboxMetrics,
 	logger *logging.ZapLogger,
 ) *OutboxDispatcher {
 	return &OutboxDispatcher{
 		cfg:            cfg,
 		storageService: storageService,
 		kafkaProducer:  kafkaProducer,
+		metrics:        metrics,
 		logger:         logger,
+		batchSizer:     newBatchSizer(cfg),
+		inFlight:       make(map[int64]time.Time),
 	}
 }
 
+// Run dispatches messages until ctx is done and then drains: fetching stops,
+// fetched messages that were not sent are released back to storage, the producer
+// is flushed and deliveries of sent messages are acknowledged, within DrainTimeout.
+// Messages left unfinished are reported. The returned channel is closed once the
+// drain is over.
 func (d *OutboxDispatcher) Run(ctx context.Context) <-chan error {
-	errChs := make([]<-chan error, d.cfg.NumWorkers+1)
+	errChs := make([]<-chan error, d.cfg.NumWorkers+4)
+
+	// drainCtx is cancelled DrainTimeout after ctx, storage calls made after
+	// shutdown started use it.
+	drainCtx, cancelDrain := context.WithCancel(context.WithoutCancel(ctx))
+	stopDrainTimer := context.AfterFunc(ctx, func() {
+		time.AfterFunc(d.cfg.DrainTimeout, cancelDrain)
+	})
+
+	wake, watchErr := d.outboxWatcher(ctx)
+	errChs[d.cfg.NumWorkers+2] = watchErr
 
-	const overhead int32 = 1 // making buffer length > numWorkers to prevent workers idling while waiting db response
-	genOut, genErr := d.messagesGenerator(ctx, d.cfg.NumWorkers*(overhead+1), d.cfg.RetryIn)
+	genOut, genErr := d.messagesGenerator(ctx, d.cfg.RetryIn, wake)
 	errChs[0] = genErr
 
+	senders := &sync.WaitGroup{}
+	queues := d.messagesRouter(ctx, genOut)
 	for i := range d.cfg.NumWorkers {
-		errChs[i+1] = d.messagesSender(ctx, genOut)
+		senders.Add(1)
+		errChs[i+1] = d.messagesSender(ctx, queues[i], senders)
 	}
 
+	stopAck := make(chan struct{})
+	errChs[d.cfg.NumWorkers+1] = d.messagesAcknowledger(drainCtx, stopAck, func() {
+		stopDrainTimer()
+		cancelDrain()
+	})
+	errChs[d.cfg.NumWorkers+3] = d.drain(ctx, drainCtx, senders, stopAck)
+
 	return chutils.FanIn(errChs...)
 }
 
+// drain waits for the senders to stop once ctx is done, releases the leases of the
+// messages they did not send and flushes the producer. stopAck is closed then, so
+// the acknowledger finishes as soon as no delivery is pending.
+func (d *OutboxDispatcher) drain(
+	ctx context.Context,
+	drainCtx context.Context,
+	senders *sync.WaitGroup,
+	stopAck chan<- struct{},
+) <-chan error {
+	errCh := make(chan error)
+
+	go func() {
+		defer close(errCh)
+		defer close(stopAck)
+
+		<-ctx.Done()
+		senders.Wait()
+
+		d.mu.Lock()
+		unsent := d.unsent
+		d.mu.Unlock()
+		if len(unsent) > 0 {
+			released, err := d.storageService.ReleaseOutboxMessages(drainCtx, d.cfg.LeaseOwner, unsent)
+			if err != nil {
+				errCh <- fmt.Errorf("failed to release outbox messages: %w", err)
+			} else {
+				d.mu.Lock()
+				d.unsent = nil
+				d.mu.Unlock()
+				d.logger.InfoCtx(drainCtx, fmt.Sprintf("%d unsent messages released", released))
+			}
+		}
+
+		if pending := d.kafkaProducer.Flush(drainCtx); pending > 0 {
+			d.logger.WarnCtx(drainCtx, fmt.Sprintf("%d messages were not delivered before drain timeout", pending))
+		}
+	}()
+
+	return errCh
+}
+
+func (d *OutboxDispatcher) track(id int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.inFlight[id] = time.Now()
+}
+
+// untrack returns the time the message was sent at.
+func (d *OutboxDispatcher) untrack(id int64) (time.Time, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	sentAt, ok := d.inFlight[id]
+	delete(d.inFlight, id)
+	return sentAt, ok
+}
+
+func (d *OutboxDispatcher) addUnsent(id int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.unsent = append(d.unsent, id)
+}
+
+// unfinished returns the numbers of messages still waiting for delivery and of
+// messages whose leases were not released.
+func (d *OutboxDispatcher) unfinished() (int, int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.inFlight), len(d.unsent)
+}
+
+// messagesRouter hands all messages of an aggregate to the same sender, so they
+// are produced one by one in the order they were read, while other aggregates are
+// produced in parallel. Storage returns message N+1 of an aggregate only after
+// message N is deleted, so a sender never gets them out of order.
+func (d *OutboxDispatcher) messagesRouter(ctx context.Context, in <-chan dto.OutboxMessage) []chan dto.OutboxMessage {
+	queues := make([]chan dto.OutboxMessage, d.cfg.NumWorkers)
+	for i := range queues {
+		queues[i] = make(chan dto.OutboxMessage, 1)
+	}
+
+	go func(ctx context.Context) {
+		defer func() {
+			for _, queue := range queues {
+				close(queue)
+			}
+		}()
+
+		for msg := range in {
+			select {
+			case <-ctx.Done():
+				// The generator stops on shutdown, the messages it already
+				// fetched are released by the drain.
+				d.addUnsent(msg.ID)
+			case queues[queueIndex(msg, len(queues))] <- msg:
+			}
+		}
+	}(ctx)
+
+	return queues
+}
+
+func queueIndex(msg dto.OutboxMessage, queuesCount int) int {
+	if msg.AggregateID == uuid.Nil {
+		return int(msg.ID % int64(queuesCount))
+	}
+	h := fnv.New32a()
+	_, _ = h.Write(msg.AggregateID[:])
+	return int(h.Sum32() % uint32(queuesCount))
+}
+
+// outboxWatcher passes outbox signals of storage to the returned channel and
+// resubscribes every IdleDispatchInterval while the watch stream is broken.
+// Meanwhile the outbox is still polled every IdleDispatchInterval.
+func (d *OutboxDispatcher) outboxWatcher(ctx context.Context) (<-chan struct{}, <-chan error) {
+	wake := make(chan struct{}, 1)
+	errCh := make(chan error)
+
+	go func(ctx context.Context) {
+		defer close(errCh)
+
+		for ctx.Err() == nil {
+			signals, watchErr, err := d.storageService.WatchOutbox(ctx)
+			if err != nil {
+				errCh <- err
+			} else {
+				for range signals {
+					select {
+					case wake <- struct{}{}:
+					default:
+					}
+				}
+				if err := <-watchErr; err != nil {
+					errCh <- err
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(d.cfg.IdleDispatchInterval):
+			}
+		}
+	}(ctx)
+
+	return wake, errCh
+}
+
+// messagesGenerator fetches messages in batches paced by batchSizer. Fetched
+// messages wait in a buffer of MaxBatchSize, a batch is fetched only when fewer
+// than the current batch size are waiting. Nothing is fetched while the producer
+// is not ready.
 func (d *OutboxDispatcher) messagesGenerator(
 	ctx context.Context,
-	buffCap int32,
 	retryIn time.Duration,
+	wake <-chan struct{},
 ) (<-chan dto.OutboxMessage, <-chan error) {
-	return chutils.Generator[dto.OutboxMessage](
+	return chutils.PacedGenerator[dto.OutboxMessage](
 		ctx,
-		buffCap,
-		d.cfg.DispatchInterval,
-		func(ctx context.Context, buffLen int32) ([]dto.OutboxMessage, error) {
-			return d.storageService.GetOutboxMessages(ctx, buffCap-buffLen, retryIn)
+		d.cfg.MaxBatchSize,
+		d.cfg.IdleDispatchInterval,
+		wake,
+		func(buffLen int32) (int32, time.Duration) {
+			if !d.kafkaProducer.Ready() {
+				return 0, d.cfg.DispatchInterval
+			}
+			return d.batchSizer.pace(buffLen)
+		},
+		func(ctx context.Context, limit int32) ([]dto.OutboxMessage, error) {
+			d.metrics.SetOutboxBatchSize(ctx, int64(d.batchSizer.batchSize()))
+			batch, err := d.storageService.GetOutboxMessages(ctx, d.cfg.LeaseOwner, limit, retryIn)
+			if err != nil {
+				return nil, err
+			}
+			d.batchSizer.observeBacklog(batch.Backlog)
+			d.metrics.SetOutboxBacklog(ctx, batch.Backlog)
+			messages := batch.Messages
+			for _, msg := range messages {
+				if msg.LeaseExpired {
+					d.metrics.IncOutboxTotalExpiredLeases(ctx, msg.Topic)
+					d.logger.WarnCtx(ctx, fmt.Sprintf("lease of message %v expired, sending it again", msg.ID))
+				}
+			}
+			return messages, nil
 		},
 	)
 }
 
-func (d *OutboxDispatcher) messagesSender(ctx context.Context, in <-chan dto.OutboxMessage) <-chan error {
+// messagesSender only enqueues messages to the producer, their deliveries are
+// handled by messagesAcknowledger.
+func (d *OutboxDispatcher) messagesSender(
+	ctx context.Context,
+	in <-chan dto.OutboxMessage,
+	wg *sync.WaitGroup,
+) <-chan error {
 	errCh := make(chan error)
 
 	go func(ctx context.Context) {
 		defer close(errCh)
+		defer wg.Done()
 
 		for msg := range in {
 			if ctx.Err() != nil {
-				return
+				d.addUnsent(msg.ID)
+				continue
 			}
 
-			err := d.kafkaProducer.SendMessage(ctx, msg.Topic, msg.Payload)
+			d.track(msg.ID)
+			err := d.kafkaProducer.SendMessage(ctx, msg)
+			if errors.Is(err, breaker.ErrOpen) {
+				// The message was not produced, so no attempt is recorded. It is
+				// fetched again once the producer is ready.
+				d.untrack(msg.ID)
+				if _, err := d.storageService.ReleaseOutboxMessages(ctx, d.cfg.LeaseOwner, []int64{msg.ID}); err != nil {
+					errCh <- fmt.Errorf("failed to release outbox message %v: %w", msg.ID, err)
+				}
+				continue
+			}
 			if err != nil {
+				d.untrack(msg.ID)
+				d.batchSizer.observeDelivery(0, true)
 				errCh <- fmt.Errorf("failed to send message to kafka: %w", err)
+				err = d.failMessage(ctx, dto.DeliveryReport{
+					MessageID: msg.ID,
+					Topic:     msg.Topic,
+					Attempts:  msg.Attempts,
+					Err:       err,
+				})
+				if err != nil {
+					errCh <- err
+				}
 				continue
 			}
-			d.logger.InfoCtx(ctx, fmt.Sprintf("message %v sent to topic %s", msg.ID, msg.Topic))
+			d.logger.InfoCtx(ctx, fmt.Sprintf(
+				"message %v (aggregate %s, sequence %d) queued for topic %s",
+				msg.ID, msg.AggregateID, msg.Sequence, msg.Topic,
+			))
+		}
+	}(ctx)
+
+	return errCh
+}
+
+// messagesAcknowledger removes delivered messages from the outbox in batches of
+// AckBatchSize, or every AckInterval if fewer were delivered. Failures of messages
+// that were not delivered are recorded one by one. After stop is closed it returns
+// once no delivery is pending or ctx is done, and calls done.
+func (d *OutboxDispatcher) messagesAcknowledger(ctx context.Context, stop <-chan struct{}, done func()) <-chan error {
+	errCh := make(chan error)
+
+	go func(ctx context.Context) {
+		defer close(errCh)
+		defer done()
 
-			err = d.storageService.DeleteOutboxMessage(ctx, msg.ID)
+		ticker := time.NewTicker(d.cfg.AckInterval)
+		defer ticker.Stop()
+
+		ids := make([]int64, 0, d.cfg.AckBatchSize)
+		flush := func(ctx context.Context) {
+			if len(ids) == 0 {
+				return
+			}
+			rejected, err := d.storageService.DeleteOutboxMessages(ctx, d.cfg.LeaseOwner, ids)
 			if err != nil {
-				errCh <- fmt.Errorf("failed to delete outbox message: %w", err)
-				continue
+				errCh <- fmt.Errorf("failed to delete outbox messages: %w", err)
+			} else {
+				d.logger.InfoCtx(ctx, fmt.Sprintf("%d messages removed from outbox", len(ids)-len(rejected)))
+			}
+			if len(rejected) > 0 {
+				// Another instance took the messages over after their leases expired
+				// and sends them again.
+				d.metrics.IncOutboxTotalLostLeases(ctx, int64(len(rejected)))
+				d.logger.WarnCtx(ctx, fmt.Sprintf("leases of messages %v were lost", rejected))
+			}
+			ids = ids[:0]
+		}
+
+		deliveries := d.kafkaProducer.Deliveries()
+		draining := false
+	loop:
+		for {
+			if draining {
+				if inFlight, _ := d.unfinished(); inFlight == 0 {
+					break
+				}
+			}
+			select {
+			case <-stop:
+				draining = true
+				stop = nil
+			case <-ctx.Done():
+				break loop
+			case report, ok := <-deliveries:
+				if !ok {
+					break loop
+				}
+				if sentAt, ok := d.untrack(report.MessageID); ok {
+					d.batchSizer.observeDelivery(time.Since(sentAt), report.Err != nil)
+				}
+				if report.Err != nil {
+					errCh <- fmt.Errorf("message %v was not delivered: %w", report.MessageID, report.Err)
+					if err := d.failMessage(ctx, report); err != nil {
+						errCh <- err
+					}
+					continue
+				}
+				d.logger.InfoCtx(ctx, fmt.Sprintf("message %v delivered to topic %s", report.MessageID, report.Topic))
+				ids = append(ids, report.MessageID)
+				if int32(len(ids)) >= d.cfg.AckBatchSize {
+					flush(ctx)
+				}
+			case <-ticker.C:
+				flush(ctx)
 			}
-			d.logger.InfoCtx(ctx, fmt.Sprintf("message %v removed from outbox", msg.ID))
 		}
+
+		// Messages already delivered are acknowledged even after the drain
+		// timeout, so they are not sent again.
+		flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), d.cfg.ShutdownTimeout)
+		defer cancel()
+		flush(flushCtx)
+
+		if !draining && ctx.Err() == nil {
+			return
+		}
+		inFlight, unreleased := d.unfinished()
+		if inFlight == 0 && unreleased == 0 {
+			d.logger.InfoCtx(flushCtx, "outbox dispatcher drained")
+			return
+		}
+		d.logger.WarnCtx(flushCtx, fmt.Sprintf(
+			"outbox dispatcher stopped with %d messages in flight and %d unreleased, they are sent again once their leases expire",
+			inFlight, unreleased,
+		))
 	}(ctx)
 
 	return errCh
 }
+
+// failMessage records a failed publish attempt. The message is sent again after a
+// backoff, or parked once it has failed MaxAttempts times. Either way the next
+// messages of its aggregate are held back until it is delivered or purged.
+func (d *OutboxDispatcher) failMessage(ctx context.Context, report dto.DeliveryReport) error {
+	failure := dto.OutboxFailure{
+		MessageID: report.MessageID,
+		Error:     report.Err.Error(),
+	}
+	outcome := outboxOutcomeRetry
+	attempt := report.Attempts + 1
+	if attempt >= d.cfg.MaxAttempts {
+		failure.Park = true
+		outcome = outboxOutcomeParked
+	} else {
+		failure.NextSendAt = time.Now().Add(backoff(attempt, d.cfg.BackoffBase, d.cfg.BackoffMax))
+	}
+	d.metrics.IncOutboxTotalFailedAttempts(ctx, report.Topic, outcome)
+
+	recorded, err := d.storageService.FailOutboxMessage(ctx, d.cfg.LeaseOwner, failure)
+	if err != nil {
+		return fmt.Errorf("failed to record failure of message %v: %w", report.MessageID, err)
+	}
+	if !recorded {
+		d.metrics.IncOutboxTotalLostLeases(ctx, 1)
+		d.logger.WarnCtx(ctx, fmt.Sprintf("lease of message %v was lost", report.MessageID))
+		return nil
+	}
+	if failure.Park {
+		d.logger.WarnCtx(ctx, fmt.Sprintf("message %v parked after %d attempts", report.MessageID, attempt))
+	}
+	return nil
+}
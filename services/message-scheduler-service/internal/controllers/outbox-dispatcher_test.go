@@ -0,0 +1,605 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-invoice-service/common/pkg/breaker"
+	"go-invoice-service/common/pkg/logging"
+	"message-sheduler-service/internal/dto"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// outboxStorage returns the first pending message of every aggregate and signals
+// watchers on deletes, like the storage service does.
+type outboxStorage struct {
+	mu       sync.Mutex
+	messages []dto.OutboxMessage
+	leased   map[int64]bool
+	// takenOver messages are leased to another owner.
+	takenOver map[int64]bool
+	retryAt   map[int64]time.Time
+	parked    map[int64]bool
+	released  []int64
+	maxBatch  int
+	signals   chan struct{}
+}
+
+func (s *outboxStorage) GetOutboxMessages(
+	_ context.Context,
+	_ string,
+	maxCount int32,
+	_ time.Duration,
+) (dto.OutboxBatch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res []dto.OutboxMessage
+	seen := map[uuid.UUID]bool{}
+	for _, msg := range s.messages {
+		if int32(len(res)) == maxCount {
+			break
+		}
+		if seen[msg.AggregateID] {
+			continue
+		}
+		seen[msg.AggregateID] = true
+		if s.parked[msg.ID] || time.Now().Before(s.retryAt[msg.ID]) {
+			continue
+		}
+		if !s.leased[msg.ID] {
+			s.leased[msg.ID] = true
+			res = append(res, msg)
+		}
+	}
+	return dto.OutboxBatch{Messages: res}, nil
+}
+
+func (s *outboxStorage) DeleteOutboxMessages(_ context.Context, _ string, ids []int64) ([]int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.maxBatch = max(s.maxBatch, len(ids))
+	var rejected []int64
+	s.messages = slices.DeleteFunc(s.messages, func(msg dto.OutboxMessage) bool {
+		if !slices.Contains(ids, msg.ID) {
+			return false
+		}
+		if s.takenOver[msg.ID] {
+			rejected = append(rejected, msg.ID)
+			return false
+		}
+		return true
+	})
+	s.signal()
+	return rejected, nil
+}
+
+func (s *outboxStorage) FailOutboxMessage(_ context.Context, _ string, failure dto.OutboxFailure) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.takenOver[failure.MessageID] {
+		return false, nil
+	}
+	i := slices.IndexFunc(s.messages, func(msg dto.OutboxMessage) bool {
+		return msg.ID == failure.MessageID
+	})
+	if i < 0 {
+		return false, nil
+	}
+	s.messages[i].Attempts++
+	delete(s.leased, failure.MessageID)
+	if failure.Park {
+		s.parked[failure.MessageID] = true
+	} else {
+		s.retryAt[failure.MessageID] = failure.NextSendAt
+	}
+	return true, nil
+}
+
+func (s *outboxStorage) ReleaseOutboxMessages(_ context.Context, _ string, ids []int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var released int64
+	for _, id := range ids {
+		if s.leased[id] && !s.takenOver[id] {
+			delete(s.leased, id)
+			s.released = append(s.released, id)
+			released++
+		}
+	}
+	return released, nil
+}
+
+func (s *outboxStorage) WatchOutbox(ctx context.Context) (<-chan struct{}, <-chan error, error) {
+	s.signal()
+
+	out := make(chan struct{})
+	errCh := make(chan error)
+	go func() {
+		defer close(errCh)
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.signals:
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- struct{}{}:
+			}
+		}
+	}()
+	return out, errCh, nil
+}
+
+func (s *outboxStorage) signal() {
+	select {
+	case s.signals <- struct{}{}:
+	default:
+	}
+}
+
+func (s *outboxStorage) empty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.messages) == 0
+}
+
+type outboxMetrics struct {
+	mu             sync.Mutex
+	expiredLeases  int64
+	lostLeases     int64
+	failedAttempts map[string]int64
+}
+
+func (m *outboxMetrics) IncOutboxTotalExpiredLeases(_ context.Context, _ string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expiredLeases++
+}
+
+func (m *outboxMetrics) IncOutboxTotalLostLeases(_ context.Context, count int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lostLeases += count
+}
+
+func (m *outboxMetrics) SetOutboxBatchSize(_ context.Context, _ int64) {}
+
+func (m *outboxMetrics) SetOutboxBacklog(_ context.Context, _ int64) {}
+
+func (m *outboxMetrics) IncOutboxTotalFailedAttempts(_ context.Context, _ string, outcome string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failedAttempts == nil {
+		m.failedAttempts = map[string]int64{}
+	}
+	m.failedAttempts[outcome]++
+}
+
+// kafkaProducer reports deliveries asynchronously, like the real producer does.
+type kafkaProducer struct {
+	mu          sync.Mutex
+	sent        map[uuid.UUID][]int64
+	inFlight    int
+	maxInFlight int
+	deliveries  chan dto.DeliveryReport
+	// failing messages are never delivered.
+	failing map[int64]bool
+	// sendDelay slows down enqueueing, so messages pile up in the dispatcher.
+	sendDelay time.Duration
+	// rejecting sends fail as if the circuit breaker was open.
+	rejecting int
+}
+
+func (p *kafkaProducer) SendMessage(_ context.Context, msg dto.OutboxMessage) error {
+	time.Sleep(p.sendDelay)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.rejecting > 0 {
+		p.rejecting--
+		return breaker.ErrOpen
+	}
+	p.inFlight++
+	p.maxInFlight = max(p.maxInFlight, p.inFlight)
+	p.sent[msg.AggregateID] = append(p.sent[msg.AggregateID], msg.Sequence)
+
+	go func() {
+		time.Sleep(time.Millisecond)
+
+		p.mu.Lock()
+		p.inFlight--
+		p.mu.Unlock()
+		report := dto.DeliveryReport{MessageID: msg.ID, Topic: msg.Topic, Attempts: msg.Attempts}
+		if p.failing[msg.ID] {
+			report.Err = errors.New("message timed out")
+		}
+		p.deliveries <- report
+	}()
+	return nil
+}
+
+func (p *kafkaProducer) Deliveries() <-chan dto.DeliveryReport {
+	return p.deliveries
+}
+
+func (p *kafkaProducer) Ready() bool {
+	return true
+}
+
+func (p *kafkaProducer) Flush(ctx context.Context) int {
+	for {
+		p.mu.Lock()
+		inFlight := p.inFlight
+		p.mu.Unlock()
+		if inFlight == 0 || ctx.Err() != nil {
+			return inFlight
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestOutboxDispatcher_Run(t *testing.T) {
+	const aggregatesCount = 8
+	const messagesPerAggregate = 5
+
+	aggregates := make([]uuid.UUID, aggregatesCount)
+	for i := range aggregates {
+		aggregates[i] = uuid.New()
+	}
+
+	storage := &outboxStorage{
+		leased:  map[int64]bool{},
+		retryAt: map[int64]time.Time{},
+		parked:  map[int64]bool{},
+		signals: make(chan struct{}, 1),
+	}
+	id := int64(0)
+	for sequence := range int64(messagesPerAggregate) {
+		for _, aggregateID := range aggregates {
+			id++
+			storage.messages = append(storage.messages, dto.OutboxMessage{
+				ID:          id,
+				Topic:       "new_invoice",
+				AggregateID: aggregateID,
+				Sequence:    sequence + 1,
+			})
+		}
+	}
+	producer := &kafkaProducer{
+		sent:       map[uuid.UUID][]int64{},
+		deliveries: make(chan dto.DeliveryReport, aggregatesCount*messagesPerAggregate),
+	}
+
+	dispatcher := NewOutboxDispatcher(
+		OutboxDispatcherConfig{
+			NumWorkers:          4,
+			RetryIn:             time.Minute,
+			DispatchInterval:    time.Millisecond,
+			MinDispatchInterval: time.Millisecond,
+			MinBatchSize:        8,
+			MaxBatchSize:        8,
+			TargetLatency:       time.Second,
+			// Messages are fetched on signals only.
+			IdleDispatchInterval: time.Hour,
+			AckBatchSize:         aggregatesCount,
+			AckInterval:          5 * time.Millisecond,
+			DrainTimeout:         time.Second,
+			ShutdownTimeout:      time.Second,
+		},
+		storage,
+		producer,
+		&outboxMetrics{},
+		logging.NewNopLogger(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := dispatcher.Run(ctx)
+	go func() {
+		for err := range errCh {
+			assert.NoError(t, err)
+		}
+	}()
+
+	require.Eventually(t, storage.empty, 5*time.Second, time.Millisecond)
+	cancel()
+
+	producer.mu.Lock()
+	defer producer.mu.Unlock()
+	for _, aggregateID := range aggregates {
+		assert.Equal(t, []int64{1, 2, 3, 4, 5}, producer.sent[aggregateID])
+	}
+	assert.Greater(t, producer.maxInFlight, 1)
+
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+	assert.Greater(t, storage.maxBatch, 1)
+}
+
+func TestOutboxDispatcher_Run_LostLease(t *testing.T) {
+	storage := &outboxStorage{
+		messages: []dto.OutboxMessage{
+			{ID: 1, Topic: "new_invoice", AggregateID: uuid.New(), Sequence: 1},
+			{ID: 2, Topic: "new_invoice", AggregateID: uuid.New(), Sequence: 1, LeaseExpired: true},
+		},
+		leased:    map[int64]bool{},
+		takenOver: map[int64]bool{1: true},
+		retryAt:   map[int64]time.Time{},
+		parked:    map[int64]bool{},
+		signals:   make(chan struct{}, 1),
+	}
+	producer := &kafkaProducer{
+		sent:       map[uuid.UUID][]int64{},
+		deliveries: make(chan dto.DeliveryReport, 2),
+	}
+	metrics := &outboxMetrics{}
+
+	dispatcher := NewOutboxDispatcher(
+		OutboxDispatcherConfig{
+			NumWorkers:           2,
+			LeaseOwner:           "scheduler-1",
+			RetryIn:              time.Minute,
+			DispatchInterval:     time.Millisecond,
+			MinDispatchInterval:  time.Millisecond,
+			MinBatchSize:         4,
+			MaxBatchSize:         4,
+			TargetLatency:        time.Second,
+			IdleDispatchInterval: time.Hour,
+			AckBatchSize:         2,
+			AckInterval:          5 * time.Millisecond,
+			DrainTimeout:         time.Second,
+			ShutdownTimeout:      time.Second,
+		},
+		storage,
+		producer,
+		metrics,
+		logging.NewNopLogger(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errCh := dispatcher.Run(ctx)
+	go func() {
+		for err := range errCh {
+			assert.NoError(t, err)
+		}
+	}()
+
+	// Message 2 is deleted, message 1 stays for its new owner.
+	require.Eventually(t, func() bool {
+		storage.mu.Lock()
+		defer storage.mu.Unlock()
+		return len(storage.messages) == 1 && storage.messages[0].ID == 1
+	}, 5*time.Second, time.Millisecond)
+	require.Eventually(t, func() bool {
+		metrics.mu.Lock()
+		defer metrics.mu.Unlock()
+		return metrics.lostLeases == 1
+	}, 5*time.Second, time.Millisecond)
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	assert.Equal(t, int64(1), metrics.expiredLeases)
+}
+
+func TestOutboxDispatcher_Run_Parked(t *testing.T) {
+	failingAggregate := uuid.New()
+	otherAggregate := uuid.New()
+	storage := &outboxStorage{
+		messages: []dto.OutboxMessage{
+			{ID: 1, Topic: "new_invoice", AggregateID: failingAggregate, Sequence: 1},
+			{ID: 2, Topic: "new_invoice", AggregateID: otherAggregate, Sequence: 1},
+			{ID: 3, Topic: "invoice_approved", AggregateID: failingAggregate, Sequence: 2},
+		},
+		leased:  map[int64]bool{},
+		retryAt: map[int64]time.Time{},
+		parked:  map[int64]bool{},
+		signals: make(chan struct{}, 1),
+	}
+	producer := &kafkaProducer{
+		sent:       map[uuid.UUID][]int64{},
+		deliveries: make(chan dto.DeliveryReport, 3),
+		failing:    map[int64]bool{1: true},
+	}
+	metrics := &outboxMetrics{}
+
+	dispatcher := NewOutboxDispatcher(
+		OutboxDispatcherConfig{
+			NumWorkers:          2,
+			LeaseOwner:          "scheduler-1",
+			RetryIn:             time.Minute,
+			DispatchInterval:    time.Millisecond,
+			MinDispatchInterval: time.Millisecond,
+			MinBatchSize:        4,
+			MaxBatchSize:        4,
+			TargetLatency:       time.Second,
+			// Retries are not signalled, they are found by polling.
+			IdleDispatchInterval: 5 * time.Millisecond,
+			AckBatchSize:         2,
+			AckInterval:          5 * time.Millisecond,
+			DrainTimeout:         time.Second,
+			ShutdownTimeout:      time.Second,
+			MaxAttempts:          3,
+			BackoffBase:          time.Millisecond,
+			BackoffMax:           2 * time.Millisecond,
+		},
+		storage,
+		producer,
+		metrics,
+		logging.NewNopLogger(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errCh := dispatcher.Run(ctx)
+	go func() {
+		// Failed deliveries are reported as errors.
+		for range errCh {
+		}
+	}()
+
+	require.Eventually(t, func() bool {
+		storage.mu.Lock()
+		defer storage.mu.Unlock()
+		return storage.parked[1]
+	}, 5*time.Second, time.Millisecond)
+
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+	// The parked message holds back the next message of its aggregate only.
+	require.Len(t, storage.messages, 2)
+	assert.Equal(t, int64(1), storage.messages[0].ID)
+	assert.Equal(t, int32(3), storage.messages[0].Attempts)
+	assert.Equal(t, int64(3), storage.messages[1].ID)
+
+	producer.mu.Lock()
+	defer producer.mu.Unlock()
+	assert.Equal(t, []int64{1, 1, 1}, producer.sent[failingAggregate])
+	assert.Equal(t, []int64{1}, producer.sent[otherAggregate])
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	assert.Equal(t, map[string]int64{outboxOutcomeRetry: 2, outboxOutcomeParked: 1}, metrics.failedAttempts)
+}
+
+func TestOutboxDispatcher_Run_Drain(t *testing.T) {
+	const messagesCount = 20
+
+	storage := &outboxStorage{
+		leased:  map[int64]bool{},
+		retryAt: map[int64]time.Time{},
+		parked:  map[int64]bool{},
+		signals: make(chan struct{}, 1),
+	}
+	for id := range int64(messagesCount) {
+		storage.messages = append(storage.messages, dto.OutboxMessage{
+			ID:          id + 1,
+			Topic:       "new_invoice",
+			AggregateID: uuid.New(),
+			Sequence:    1,
+		})
+	}
+	producer := &kafkaProducer{
+		sent:       map[uuid.UUID][]int64{},
+		deliveries: make(chan dto.DeliveryReport, messagesCount),
+		sendDelay:  20 * time.Millisecond,
+	}
+
+	dispatcher := NewOutboxDispatcher(
+		OutboxDispatcherConfig{
+			NumWorkers:           2,
+			RetryIn:              time.Minute,
+			DispatchInterval:     time.Millisecond,
+			MinDispatchInterval:  time.Millisecond,
+			MinBatchSize:         4,
+			MaxBatchSize:         4,
+			TargetLatency:        time.Second,
+			IdleDispatchInterval: time.Hour,
+			AckBatchSize:         messagesCount,
+			AckInterval:          time.Hour,
+			DrainTimeout:         time.Second,
+			ShutdownTimeout:      time.Second,
+		},
+		storage,
+		producer,
+		&outboxMetrics{},
+		logging.NewNopLogger(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := dispatcher.Run(ctx)
+	time.Sleep(30 * time.Millisecond)
+	cancel()
+
+	for err := range errCh {
+		assert.NoError(t, err)
+	}
+
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+	producer.mu.Lock()
+	defer producer.mu.Unlock()
+
+	// Sent messages are acknowledged, fetched but unsent messages are released.
+	assert.NotEmpty(t, storage.released)
+	assert.Len(t, storage.messages, messagesCount-len(producer.sent))
+	for _, msg := range storage.messages {
+		assert.False(t, storage.leased[msg.ID], "message %v is still leased", msg.ID)
+	}
+}
+
+func TestOutboxDispatcher_Run_BreakerOpen(t *testing.T) {
+	storage := &outboxStorage{
+		messages: []dto.OutboxMessage{
+			{ID: 1, Topic: "new_invoice", AggregateID: uuid.New(), Sequence: 1},
+			{ID: 2, Topic: "new_invoice", AggregateID: uuid.New(), Sequence: 1},
+		},
+		leased:  map[int64]bool{},
+		retryAt: map[int64]time.Time{},
+		parked:  map[int64]bool{},
+		signals: make(chan struct{}, 1),
+	}
+	producer := &kafkaProducer{
+		sent:       map[uuid.UUID][]int64{},
+		deliveries: make(chan dto.DeliveryReport, 2),
+		rejecting:  2,
+	}
+	metrics := &outboxMetrics{}
+
+	dispatcher := NewOutboxDispatcher(
+		OutboxDispatcherConfig{
+			NumWorkers:          2,
+			LeaseOwner:          "scheduler-1",
+			RetryIn:             time.Minute,
+			DispatchInterval:    time.Millisecond,
+			MinDispatchInterval: time.Millisecond,
+			MinBatchSize:        4,
+			MaxBatchSize:        4,
+			TargetLatency:       time.Second,
+			// Released messages are not signalled, they are found by polling.
+			IdleDispatchInterval: 5 * time.Millisecond,
+			AckBatchSize:         2,
+			AckInterval:          5 * time.Millisecond,
+			DrainTimeout:         time.Second,
+			ShutdownTimeout:      time.Second,
+			MaxAttempts:          3,
+			BackoffBase:          time.Millisecond,
+			BackoffMax:           2 * time.Millisecond,
+		},
+		storage,
+		producer,
+		metrics,
+		logging.NewNopLogger(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errCh := dispatcher.Run(ctx)
+	go func() {
+		for err := range errCh {
+			assert.NoError(t, err)
+		}
+	}()
+
+	require.Eventually(t, storage.empty, 5*time.Second, time.Millisecond)
+
+	// Rejected messages were released and sent again without recording attempts.
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+	assert.Len(t, storage.released, 2)
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	assert.Empty(t, metrics.failedAttempts)
+}
@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"go-invoice-service/common/pkg/logging"
+	"message-sheduler-service/internal/dto"
+	"time"
+)
+
+type ReplayStorage interface {
+	ReplayOutboxMessages(
+		ctx context.Context,
+		filter dto.ReplayFilter,
+		afterID int64,
+		maxCount int32,
+	) (dto.ReplayBatch, error)
+}
+
+type ReplayerConfig struct {
+	// Rate is the max number of messages replayed per second. Replays are sent in
+	// the order they are scheduled, so live messages wait at most for one batch.
+	Rate      int
+	BatchSize int32
+}
+
+// Replayer schedules copies of published outbox messages, which are then sent by
+// the outbox dispatcher like any other message.
+type Replayer struct {
+	cfg     ReplayerConfig
+	storage ReplayStorage
+	logger  *logging.ZapLogger
+}
+
+func NewReplayer(cfg ReplayerConfig, storage ReplayStorage, logger *logging.ZapLogger) *Replayer {
+	return &Replayer{
+		cfg:     cfg,
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+// Run replays the messages matching filter with IDs greater than afterID in batches
+// of BatchSize, at most Rate messages a second, and returns the number of replayed
+// messages. A stopped replay is continued from the last logged id.
+func (r *Replayer) Run(ctx context.Context, filter dto.ReplayFilter, afterID int64) (int64, error) {
+	interval := time.Second * time.Duration(r.cfg.BatchSize) / time.Duration(r.cfg.Rate)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var total int64
+	for {
+		batch, err := r.storage.ReplayOutboxMessages(ctx, filter, afterID, r.cfg.BatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += int64(batch.Count)
+		if batch.Count > 0 {
+			afterID = batch.LastID
+			r.logger.InfoCtx(ctx, fmt.Sprintf("Replayed %d outbox messages, last id %d", total, afterID))
+		}
+		if batch.Count < r.cfg.BatchSize {
+			return total, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return total, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
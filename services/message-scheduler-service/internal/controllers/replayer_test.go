@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-invoice-service/common/pkg/logging"
+	"message-sheduler-service/internal/dto"
+	"testing"
+	"time"
+)
+
+// replayStorage replays messages with IDs 1 to count.
+type replayStorage struct {
+	count    int64
+	failAt   int64
+	afterIDs []int64
+	filters  []dto.ReplayFilter
+}
+
+func (s *replayStorage) ReplayOutboxMessages(
+	_ context.Context,
+	filter dto.ReplayFilter,
+	afterID int64,
+	maxCount int32,
+) (dto.ReplayBatch, error) {
+	s.afterIDs = append(s.afterIDs, afterID)
+	s.filters = append(s.filters, filter)
+	if s.failAt > 0 && afterID >= s.failAt {
+		return dto.ReplayBatch{}, errors.New("storage unavailable")
+	}
+
+	lastID := min(afterID+int64(maxCount), s.count)
+	if lastID <= afterID {
+		return dto.ReplayBatch{}, nil
+	}
+	return dto.ReplayBatch{Count: int32(lastID - afterID), LastID: lastID}, nil
+}
+
+func TestReplayer_Run(t *testing.T) {
+	filter := dto.ReplayFilter{
+		Topic:       "invoice_approved",
+		AggregateID: uuid.New(),
+		From:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name     string
+		storage  *replayStorage
+		afterID  int64
+		total    int64
+		afterIDs []int64
+		wantErr  bool
+	}{
+		{
+			name:     "partial_last_batch",
+			storage:  &replayStorage{count: 7},
+			total:    7,
+			afterIDs: []int64{0, 3, 6},
+		},
+		{
+			name:     "full_last_batch",
+			storage:  &replayStorage{count: 6},
+			total:    6,
+			afterIDs: []int64{0, 3, 6},
+		},
+		{
+			name:     "nothing_to_replay",
+			storage:  &replayStorage{},
+			afterIDs: []int64{0},
+		},
+		{
+			name:     "continued",
+			storage:  &replayStorage{count: 7},
+			afterID:  3,
+			total:    4,
+			afterIDs: []int64{3, 6},
+		},
+		{
+			name:     "storage_error",
+			storage:  &replayStorage{count: 7, failAt: 3},
+			total:    3,
+			afterIDs: []int64{0, 3},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replayer := NewReplayer(
+				ReplayerConfig{Rate: 3000, BatchSize: 3},
+				tt.storage,
+				logging.NewNopLogger(),
+			)
+
+			total, err := replayer.Run(context.Background(), filter, tt.afterID)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.total, total)
+			assert.Equal(t, tt.afterIDs, tt.storage.afterIDs)
+			for _, f := range tt.storage.filters {
+				assert.Equal(t, filter, f)
+			}
+		})
+	}
+}
+
+func TestReplayer_Run_RateLimited(t *testing.T) {
+	storage := &replayStorage{count: 10}
+	replayer := NewReplayer(ReplayerConfig{Rate: 100, BatchSize: 2}, storage, logging.NewNopLogger())
+
+	start := time.Now()
+	total, err := replayer.Run(context.Background(), dto.ReplayFilter{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), total)
+	// 6 batches, the 5 waits between them take 20ms each.
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestReplayer_Run_Canceled(t *testing.T) {
+	storage := &replayStorage{count: 10}
+	replayer := NewReplayer(ReplayerConfig{Rate: 1, BatchSize: 2}, storage, logging.NewNopLogger())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	total, err := replayer.Run(ctx, dto.ReplayFilter{}, 0)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int64(2), total)
+}
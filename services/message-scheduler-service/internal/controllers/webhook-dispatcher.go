@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"go-invoice-service/common/pkg/chutils"
+	"go-invoice-service/common/pkg/logging"
+	"message-sheduler-service/internal/dto"
+	"time"
+)
+
+type WebhookStorageService interface {
+	GetWebhookDeliveries(ctx context.Context, leaseOwner string, maxCount int32, leaseFor time.Duration) ([]dto.WebhookDelivery, error)
+	CompleteWebhookAttempt(ctx context.Context, leaseOwner string, result dto.WebhookAttemptResult) (bool, error)
+}
+
+type WebhookSender interface {
+	Send(ctx context.Context, delivery dto.WebhookDelivery) (dto.WebhookResponse, error)
+}
+
+type WebhookMetrics interface {
+	IncWebhookTotalAttempts(ctx context.Context, eventType string, outcome string)
+}
+
+type WebhookDispatcher struct {
+	cfg            WebhookDispatcherConfig
+	storageService WebhookStorageService
+	sender         WebhookSender
+	metrics        WebhookMetrics
+	logger         *logging.ZapLogger
+}
+
+type WebhookDispatcherConfig struct {
+	NumWorkers       int32
+	DispatchInterval time.Duration
+	// LeaseOwner identifies this instance, deliveries are leased to it for LeaseFor.
+	LeaseOwner string
+	// LeaseFor must be longer than a delivery takes, otherwise it may be sent twice.
+	LeaseFor    time.Duration
+	MaxAttempts int32
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+}
+
+func NewWebhookDispatcher(
+	cfg WebhookDispatcherConfig,
+	storageService WebhookStorageService,
+	sender WebhookSender,
+	metrics WebhookMetrics,
+	logger *logging.ZapLogger,
+) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		cfg:            cfg,
+		storageService: storageService,
+		sender:         sender,
+		metrics:        metrics,
+		logger:         logger,
+	}
+}
+
+func (d *WebhookDispatcher) Run(ctx context.Context) <-chan error {
+	errChs := make([]<-chan error, d.cfg.NumWorkers+1)
+
+	genOut, genErr := chutils.Generator[dto.WebhookDelivery](
+		ctx,
+		d.cfg.NumWorkers,
+		d.cfg.DispatchInterval,
+		d.cfg.DispatchInterval,
+		nil,
+		func(ctx context.Context, buffLen int32) ([]dto.WebhookDelivery, error) {
+			return d.storageService.GetWebhookDeliveries(ctx, d.cfg.LeaseOwner, d.cfg.NumWorkers-buffLen, d.cfg.LeaseFor)
+		},
+	)
+	errChs[0] = genErr
+
+	for i := range d.cfg.NumWorkers {
+		errChs[i+1] = d.deliveriesSender(ctx, genOut)
+	}
+
+	return chutils.FanIn(errChs...)
+}
+
+func (d *WebhookDispatcher) deliveriesSender(ctx context.Context, in <-chan dto.WebhookDelivery) <-chan error {
+	errCh := make(chan error)
+
+	go func(ctx context.Context) {
+		defer close(errCh)
+
+		for delivery := range in {
+			if ctx.Err() != nil {
+				return
+			}
+
+			start := time.Now()
+			resp, err := d.sender.Send(ctx, delivery)
+			result := d.attemptResult(delivery, resp, err, time.Since(start))
+			d.metrics.IncWebhookTotalAttempts(ctx, delivery.EventType, string(result.Outcome))
+
+			recorded, err := d.storageService.CompleteWebhookAttempt(ctx, d.cfg.LeaseOwner, result)
+			if err != nil {
+				errCh <- fmt.Errorf("failed to complete webhook delivery %d: %w", delivery.ID, err)
+				continue
+			}
+			if !recorded {
+				d.logger.WarnCtx(ctx, fmt.Sprintf("lease of webhook delivery %v was lost", delivery.ID))
+				continue
+			}
+			d.logger.InfoCtx(ctx, fmt.Sprintf("webhook delivery %v attempt finished: %s", delivery.ID, result.Outcome))
+		}
+	}(ctx)
+
+	return errCh
+}
+
+func (d *WebhookDispatcher) attemptResult(
+	delivery dto.WebhookDelivery,
+	resp dto.WebhookResponse,
+	sendErr error,
+	duration time.Duration,
+) dto.WebhookAttemptResult {
+	result := dto.WebhookAttemptResult{
+		DeliveryID: delivery.ID,
+		Response:   resp,
+		Duration:   duration,
+	}
+
+	switch {
+	case sendErr != nil:
+		result.Error = sendErr.Error()
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		result.Error = fmt.Sprintf("unexpected status code %d", resp.StatusCode)
+	default:
+		result.Outcome = dto.WebhookOutcomeSucceeded
+		return result
+	}
+
+	attempt := delivery.Attempts + 1
+	if attempt >= d.cfg.MaxAttempts {
+		result.Outcome = dto.WebhookOutcomeDead
+		return result
+	}
+
+	result.Outcome = dto.WebhookOutcomeRetry
+	result.NextAttemptAt = time.Now().Add(backoff(attempt, d.cfg.BackoffBase, d.cfg.BackoffMax))
+	return result
+}
@@ -1,7 +1,49 @@
 package dto
 
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
 type OutboxMessage struct {
-	ID      int64
-	Topic   string
-	Payload []byte
+	ID    int64
+	Topic string
+	// AggregateID is uuid.Nil for messages written before aggregates were recorded.
+	AggregateID uuid.UUID
+	Sequence    int64
+	// LeaseExpired is set when the message was leased before and not deleted in time,
+	// so it may have been published already.
+	LeaseExpired bool
+	// LeasedAt is when the message was requested from the outbox.
+	LeasedAt time.Time
+	// Attempts is the number of failed publish attempts.
+	Attempts int32
+	Key      string
+	Headers  map[string]string
+	Payload  []byte
+}
+
+// OutboxBatch holds messages leased for sending. Backlog is the number of messages
+// due for sending left in the outbox.
+type OutboxBatch struct {
+	Messages []OutboxMessage
+	Backlog  int64
+}
+
+// DeliveryReport is the outcome of producing an outbox message to Kafka.
+type DeliveryReport struct {
+	MessageID int64
+	Topic     string
+	// Attempts is the number of failed publish attempts before this one.
+	Attempts int32
+	Err      error
+}
+
+// OutboxFailure records a failed publish attempt. A parked message is not sent
+// again until it is requeued, otherwise it is retried at NextSendAt.
+type OutboxFailure struct {
+	MessageID  int64
+	Error      string
+	Park       bool
+	NextSendAt time.Time
 }
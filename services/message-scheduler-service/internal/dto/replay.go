@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ReplayFilter selects published outbox messages to replay by their topic, invoice
+// and creation time in [From, To). Zero fields match all messages.
+type ReplayFilter struct {
+	Topic       string
+	AggregateID uuid.UUID
+	From        time.Time
+	To          time.Time
+}
+
+type ReplayBatch struct {
+	Count int32
+	// LastID is the ID of the last replayed message, the next batch starts after it.
+	LastID int64
+}
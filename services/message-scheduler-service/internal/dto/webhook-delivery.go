@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+type WebhookDelivery struct {
+	ID        int64
+	EventType string
+	Payload   []byte
+	Attempts  int32
+	URL       string
+	Secret    string
+}
+
+type WebhookResponse struct {
+	StatusCode int32
+	Body       string
+}
+
+type WebhookAttemptOutcome string
+
+const (
+	WebhookOutcomeSucceeded WebhookAttemptOutcome = "succeeded"
+	WebhookOutcomeRetry     WebhookAttemptOutcome = "retry"
+	WebhookOutcomeDead      WebhookAttemptOutcome = "dead"
+)
+
+type WebhookAttemptResult struct {
+	DeliveryID    int64
+	Outcome       WebhookAttemptOutcome
+	Response      WebhookResponse
+	Error         string
+	Duration      time.Duration
+	NextAttemptAt time.Time
+}
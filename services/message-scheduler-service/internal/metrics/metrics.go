@@ -10,6 +10,14 @@ import (
 type MetricsCollector struct {
 	kafkaTotalProduceMessages metric.Int64Counter
 	kafkaTotalProducedBytes   metric.Int64Counter
+	kafkaTotalSkippedMessages metric.Int64Counter
+	webhookTotalAttempts      metric.Int64Counter
+	outboxTotalExpiredLeases  metric.Int64Counter
+	outboxTotalLostLeases     metric.Int64Counter
+	outboxTotalFailedAttempts metric.Int64Counter
+	outboxBatchSize           metric.Int64Gauge
+	outboxBacklog             metric.Int64Gauge
+	circuitBreakerState       metric.Int64Gauge
 }
 
 func MustInitCustomMetric() *MetricsCollector {
@@ -34,6 +42,71 @@ func MustInitCustomMetric() *MetricsCollector {
 		),
 	)
 
+	m.kafkaTotalSkippedMessages = must(
+		meter.Int64Counter(
+			"kafka_total_skipped_messages",
+			metric.WithDescription("Total outbox messages not produced because they were published already"),
+		),
+	)
+
+	// Webhooks.
+	meter = metricProvider.Meter("webhooks")
+
+	m.webhookTotalAttempts = must(
+		meter.Int64Counter(
+			"webhook_total_attempts",
+			metric.WithDescription("Total webhook delivery attempts"),
+		),
+	)
+
+	// Outbox.
+	meter = metricProvider.Meter("outbox")
+
+	m.outboxTotalExpiredLeases = must(
+		meter.Int64Counter(
+			"outbox_total_expired_leases",
+			metric.WithDescription("Total outbox messages fetched again after their lease expired"),
+		),
+	)
+
+	m.outboxTotalLostLeases = must(
+		meter.Int64Counter(
+			"outbox_total_lost_leases",
+			metric.WithDescription("Total delivered outbox messages not deleted because they were leased to another owner"),
+		),
+	)
+
+	m.outboxTotalFailedAttempts = must(
+		meter.Int64Counter(
+			"outbox_total_failed_attempts",
+			metric.WithDescription("Total failed outbox publish attempts"),
+		),
+	)
+
+	m.outboxBatchSize = must(
+		meter.Int64Gauge(
+			"outbox_batch_size",
+			metric.WithDescription("Current number of outbox messages fetched at once"),
+		),
+	)
+
+	m.outboxBacklog = must(
+		meter.Int64Gauge(
+			"outbox_backlog",
+			metric.WithDescription("Outbox messages due for sending and not fetched yet"),
+		),
+	)
+
+	// Circuit breakers.
+	meter = metricProvider.Meter("breakers")
+
+	m.circuitBreakerState = must(
+		meter.Int64Gauge(
+			"circuit_breaker_state",
+			metric.WithDescription("Circuit breaker state: 0 closed, 1 half-open, 2 open"),
+		),
+	)
+
 	return m
 }
 
@@ -57,3 +130,52 @@ func (m *MetricsCollector) IncKafkaTotalProducedBytes(ctx context.Context, topic
 	)
 	m.kafkaTotalProducedBytes.Add(ctx, bytesCount, metric.WithAttributeSet(attrSet))
 }
+
+func (m *MetricsCollector) IncKafkaTotalSkippedMessages(ctx context.Context, topic string) {
+	attrSet := attribute.NewSet(
+		attribute.KeyValue{Key: "topic", Value: attribute.StringValue(topic)},
+	)
+	m.kafkaTotalSkippedMessages.Add(ctx, 1, metric.WithAttributeSet(attrSet))
+}
+
+func (m *MetricsCollector) IncWebhookTotalAttempts(ctx context.Context, eventType string, outcome string) {
+	attrSet := attribute.NewSet(
+		attribute.KeyValue{Key: "event_type", Value: attribute.StringValue(eventType)},
+		attribute.KeyValue{Key: "outcome", Value: attribute.StringValue(outcome)},
+	)
+	m.webhookTotalAttempts.Add(ctx, 1, metric.WithAttributeSet(attrSet))
+}
+
+func (m *MetricsCollector) IncOutboxTotalExpiredLeases(ctx context.Context, topic string) {
+	attrSet := attribute.NewSet(
+		attribute.KeyValue{Key: "topic", Value: attribute.StringValue(topic)},
+	)
+	m.outboxTotalExpiredLeases.Add(ctx, 1, metric.WithAttributeSet(attrSet))
+}
+
+func (m *MetricsCollector) IncOutboxTotalLostLeases(ctx context.Context, count int64) {
+	m.outboxTotalLostLeases.Add(ctx, count)
+}
+
+func (m *MetricsCollector) IncOutboxTotalFailedAttempts(ctx context.Context, topic string, outcome string) {
+	attrSet := attribute.NewSet(
+		attribute.KeyValue{Key: "topic", Value: attribute.StringValue(topic)},
+		attribute.KeyValue{Key: "outcome", Value: attribute.StringValue(outcome)},
+	)
+	m.outboxTotalFailedAttempts.Add(ctx, 1, metric.WithAttributeSet(attrSet))
+}
+
+func (m *MetricsCollector) SetOutboxBatchSize(ctx context.Context, size int64) {
+	m.outboxBatchSize.Record(ctx, size)
+}
+
+func (m *MetricsCollector) SetOutboxBacklog(ctx context.Context, backlog int64) {
+	m.outboxBacklog.Record(ctx, backlog)
+}
+
+func (m *MetricsCollector) SetCircuitBreakerState(ctx context.Context, name string, state int64) {
+	attrSet := attribute.NewSet(
+		attribute.KeyValue{Key: "name", Value: attribute.StringValue(name)},
+	)
+	m.circuitBreakerState.Record(ctx, state, metric.WithAttributeSet(attrSet))
+}
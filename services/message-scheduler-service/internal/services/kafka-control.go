@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/google/uuid"
+	"strconv"
+	"time"
+)
+
+const (
+	// controlPollTimeout is how long loading the control topic waits for a single event.
+	controlPollTimeout     = 100 * time.Millisecond
+	controlMetadataTimeout = 5 * time.Second
+)
+
+// controlMessage records the last published sequence of the aggregate. The control
+// topic is compacted, so only the latest record of every aggregate is kept.
+func controlMessage(topic string, aggregateID uuid.UUID, sequence int64) *kafka.Message {
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Key:            []byte(aggregateID.String()),
+		Value:          []byte(strconv.FormatInt(sequence, 10)),
+	}
+}
+
+// controlPosition is the offset of the next control record to read in every partition.
+type controlPosition map[int32]kafka.Offset
+
+// readPublishedSequences reads the control topic from position, or from the beginning
+// of partitions missing in it, up to its end. It returns the last published sequence of
+// every aggregate read and the position to continue from. Only records of committed
+// transactions are read.
+func readPublishedSequences(
+	ctx context.Context,
+	cfg KafkaProducerConfig,
+	position controlPosition,
+) (map[uuid.UUID]int64, controlPosition, error) {
+	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":    cfg.ServerAddress,
+		"group.id":             cfg.TransactionalID + "-control",
+		"enable.auto.commit":   false,
+		"enable.partition.eof": true,
+		"isolation.level":      "read_committed",
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer consumer.Close()
+
+	topic := cfg.ControlTopic
+	metadata, err := consumer.GetMetadata(&topic, false, int(controlMetadataTimeout.Milliseconds()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get metadata: %w", err)
+	}
+	topicMetadata, ok := metadata.Topics[topic]
+	if !ok || len(topicMetadata.Partitions) == 0 {
+		return nil, nil, errors.New("topic not found")
+	}
+
+	next := make(controlPosition, len(topicMetadata.Partitions))
+	partitions := make([]kafka.TopicPartition, len(topicMetadata.Partitions))
+	for i, partition := range topicMetadata.Partitions {
+		offset, ok := position[partition.ID]
+		if !ok {
+			offset = kafka.OffsetBeginning
+		}
+		next[partition.ID] = offset
+		partitions[i] = kafka.TopicPartition{Topic: &topic, Partition: partition.ID, Offset: offset}
+	}
+	if err = consumer.Assign(partitions); err != nil {
+		return nil, nil, fmt.Errorf("failed to assign partitions: %w", err)
+	}
+
+	published := make(map[uuid.UUID]int64)
+	remaining := len(partitions)
+	for remaining > 0 {
+		if err = ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		switch e := consumer.Poll(int(controlPollTimeout.Milliseconds())).(type) {
+		case *kafka.Message:
+			// The end offset of a partition may be past records of open transactions,
+			// so reading continues after the last record read instead.
+			next[e.TopicPartition.Partition] = e.TopicPartition.Offset + 1
+			aggregateID, err := uuid.ParseBytes(e.Key)
+			if err != nil {
+				continue
+			}
+			if e.Value == nil {
+				delete(published, aggregateID)
+				continue
+			}
+			sequence, err := strconv.ParseInt(string(e.Value), 10, 64)
+			if err != nil {
+				continue
+			}
+			published[aggregateID] = sequence
+		case kafka.PartitionEOF:
+			remaining--
+		case kafka.Error:
+			if e.IsFatal() {
+				return nil, nil, e
+			}
+		}
+	}
+
+	return published, next, nil
+}
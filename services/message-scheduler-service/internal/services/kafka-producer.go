@@ -2,66 +2,531 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/google/uuid"
+	"go-invoice-service/common/pkg/breaker"
+	"maps"
+	"message-sheduler-service/internal/dto"
+	"slices"
+	"sync"
+	"time"
 )
 
 type KafkaMetrics interface {
 	IncKafkaTotalProduceMessages(ctx context.Context, topic string)
 	IncKafkaTotalProducedBytes(ctx context.Context, topic string, bytesCount int64)
+	IncKafkaTotalSkippedMessages(ctx context.Context, topic string)
 }
 
 type KafkaProducerConfig struct {
 	ServerAddress string
+	// Transactional enables the idempotent producer and publishes messages in Kafka
+	// transactions, together with the last published sequence of every aggregate.
+	Transactional bool
+	// TransactionalID must stay the same across restarts, so a restarted producer
+	// fences off its previous incarnation.
+	TransactionalID     string
+	ControlTopic        string
+	TransactionInterval time.Duration
+	// PublishedTTL is how long the last published sequence of an aggregate is remembered
+	// after it was published or read. It must exceed the time a published message may
+	// stay in the outbox, otherwise the message is produced again.
+	PublishedTTL time.Duration
 }
 
+const (
+	// deliveriesBuffer is the number of delivery reports kept until the dispatcher reads them.
+	deliveriesBuffer = 1024
+	// transactionTimeout limits initializing, committing and aborting a transaction.
+	transactionTimeout = 30 * time.Second
+)
+
+// KafkaProducer produces messages without waiting for them to be delivered.
+// Delivery reports of all messages are read by a single handler and published
+// on the Deliveries channel. Failed deliveries open the circuit breaker, then
+// messages are rejected with breaker.ErrOpen without being produced.
+//
+// In transactional mode messages are reported delivered only once the transaction
+// they were produced in is committed, and messages that the control topic records
+// as published already are reported delivered without being produced again. The
+// control topic is read again before a message with an expired lease is sent, as
+// another instance may have published it.
 type KafkaProducer struct {
-	producer *kafka.Producer
-	metrics  KafkaMetrics
+	cfg        KafkaProducerConfig
+	producer   *kafka.Producer
+	metrics    KafkaMetrics
+	breaker    *breaker.Breaker
+	deliveries chan dto.DeliveryReport
+	closed     chan struct{}
+	done       chan struct{}
+
+	// Transactional mode only.
+
+	// txMu orders transaction operations, messages are not produced while a
+	// transaction is committed.
+	txMu          sync.Mutex
+	inTransaction bool
+	// abort is set when the open transaction can't be committed consistently.
+	abort     bool
+	pending   []pendingMessage
+	committed chan struct{}
+
+	mu        sync.Mutex
+	published map[uuid.UUID]publishedSequence
+
+	// controlMu orders reads of the control topic. controlReadAt is when the last
+	// read started, the next one continues from controlPosition.
+	controlMu       sync.Mutex
+	controlPosition controlPosition
+	controlReadAt   time.Time
+}
+
+// delivery is the report of a produced message, generation is the circuit breaker
+// generation the message was allowed in.
+type delivery struct {
+	report     dto.DeliveryReport
+	generation uint64
+}
+
+type pendingMessage struct {
+	delivery
+	aggregateID uuid.UUID
+	sequence    int64
 }
 
-func NewKafkaProducer(cfg KafkaProducerConfig, metrics KafkaMetrics) (*KafkaProducer, error) {
-	producer, err := kafka.NewProducer(&kafka.ConfigMap{
+// publishedSequence is the last published sequence of an aggregate, seenAt is when it
+// was last published or read from the control topic.
+type publishedSequence struct {
+	sequence int64
+	seenAt   time.Time
+}
+
+func NewKafkaProducer(cfg KafkaProducerConfig, metrics KafkaMetrics, cb *breaker.Breaker) (*KafkaProducer, error) {
+	configMap := &kafka.ConfigMap{
 		"bootstrap.servers": cfg.ServerAddress,
-	})
+		// Reports are built from opaque, so keys and values are not copied back.
+		"go.delivery.report.fields": "none",
+	}
+	if cfg.Transactional {
+		_ = configMap.SetKey("enable.idempotence", true)
+		_ = configMap.SetKey("transactional.id", cfg.TransactionalID)
+		// Messages are reported when their transaction is committed.
+		_ = configMap.SetKey("go.delivery.reports", false)
+	}
+
+	producer, err := kafka.NewProducer(configMap)
 	if err != nil {
 		return nil, err
 	}
 
-	return &KafkaProducer{
-		producer: producer,
-		metrics:  metrics,
-	}, nil
+	p := &KafkaProducer{
+		cfg:        cfg,
+		producer:   producer,
+		metrics:    metrics,
+		breaker:    cb,
+		deliveries: make(chan dto.DeliveryReport, deliveriesBuffer),
+		closed:     make(chan struct{}),
+		done:       make(chan struct{}),
+		published:  make(map[uuid.UUID]publishedSequence),
+		committed:  make(chan struct{}),
+	}
+	go p.handleDeliveries()
+	if cfg.Transactional {
+		go p.commitTransactions()
+	} else {
+		close(p.committed)
+	}
+
+	return p, nil
+}
+
+// Init prepares transactions and loads the last published sequences from the control
+// topic, which must exist by then. It does nothing unless the producer is transactional.
+func (p *KafkaProducer) Init(ctx context.Context) error {
+	if !p.cfg.Transactional {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, transactionTimeout)
+	defer cancel()
+
+	// A transaction left open by the previous incarnation is aborted here.
+	if err := p.producer.InitTransactions(ctx); err != nil {
+		return fmt.Errorf("failed to init transactions: %w", err)
+	}
+
+	if err := p.readControl(ctx, time.Now()); err != nil {
+		return fmt.Errorf("failed to load control topic %s: %w", p.cfg.ControlTopic, err)
+	}
+
+	return nil
+}
+
+// readControl reads the control topic records written since the last read, unless
+// that read started after since.
+func (p *KafkaProducer) readControl(ctx context.Context, since time.Time) error {
+	p.controlMu.Lock()
+	defer p.controlMu.Unlock()
+
+	if p.controlReadAt.After(since) {
+		return nil
+	}
+
+	startedAt := time.Now()
+	published, position, err := readPublishedSequences(ctx, p.cfg, p.controlPosition)
+	if err != nil {
+		return err
+	}
+	p.controlPosition = position
+	p.controlReadAt = startedAt
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for aggregateID, sequence := range published {
+		p.markPublished(aggregateID, sequence, startedAt)
+	}
+
+	return nil
+}
+
+// markPublished must be called with p.mu held.
+func (p *KafkaProducer) markPublished(aggregateID uuid.UUID, sequence int64, at time.Time) {
+	if cur := p.published[aggregateID]; cur.sequence > sequence {
+		sequence = cur.sequence
+	}
+	p.published[aggregateID] = publishedSequence{sequence: sequence, seenAt: at}
+}
+
+func (p *KafkaProducer) isPublished(outboxMsg dto.OutboxMessage) bool {
+	if outboxMsg.AggregateID == uuid.Nil {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return outboxMsg.Sequence <= p.published[outboxMsg.AggregateID].sequence
+}
+
+// prunePublished forgets sequences not published or read for PublishedTTL.
+func (p *KafkaProducer) prunePublished(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	maps.DeleteFunc(p.published, func(_ uuid.UUID, seq publishedSequence) bool {
+		return now.Sub(seq.seenAt) >= p.cfg.PublishedTTL
+	})
 }
 
+// Close stops the producer. Reports of messages delivered after Close are dropped,
+// and an open transaction is aborted, so its messages are sent again after restart.
 func (p *KafkaProducer) Close() {
+	close(p.closed)
+	<-p.committed
+	if p.cfg.Transactional {
+		p.txMu.Lock()
+		if p.inTransaction {
+			ctx, cancel := context.WithTimeout(context.Background(), transactionTimeout)
+			_ = p.producer.AbortTransaction(ctx)
+			cancel()
+		}
+		p.txMu.Unlock()
+	}
 	p.producer.Close()
+	<-p.done
 }
 
-func (p *KafkaProducer) SendMessage(ctx context.Context, topic string, payload []byte) error {
-	msg := &kafka.Message{
-		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
-		Value:          payload,
+// Flush waits until the messages sent so far are delivered or ctx is done, and
+// returns the number of messages still not delivered. In transactional mode the
+// open transaction is committed right away.
+func (p *KafkaProducer) Flush(ctx context.Context) int {
+	if p.cfg.Transactional {
+		for _, msg := range p.finishTransaction() {
+			p.report(msg.delivery)
+		}
+		return 0
 	}
 
-	deliveryChan := make(chan kafka.Event)
-	err := p.producer.Produce(msg, deliveryChan)
+	const flushStepMs = 100
+	for {
+		pending := p.producer.Flush(flushStepMs)
+		if pending == 0 || ctx.Err() != nil {
+			return pending
+		}
+	}
+}
+
+// Ready reports whether messages are accepted, they are not while the circuit
+// breaker is open.
+func (p *KafkaProducer) Ready() bool {
+	return p.breaker.Ready()
+}
+
+// Deliveries returns the channel of delivery reports. It is closed after Close.
+func (p *KafkaProducer) Deliveries() <-chan dto.DeliveryReport {
+	return p.deliveries
+}
+
+// SendMessage enqueues the outbox message for production, its delivery is reported
+// on the Deliveries channel. Messages with a key go to the partition of the key,
+// so they are consumed in the order they were sent.
+func (p *KafkaProducer) SendMessage(ctx context.Context, outboxMsg dto.OutboxMessage) error {
+	generation, err := p.breaker.Allow()
+	if err != nil {
+		return fmt.Errorf("failed to send message to Kafka server: %w", err)
+	}
+	if p.cfg.Transactional {
+		return p.sendTransactional(ctx, outboxMsg, generation)
+	}
+
+	err = p.producer.Produce(p.kafkaMessage(outboxMsg, generation), nil)
 	if err != nil {
+		p.breaker.Failure(generation)
 		return fmt.Errorf("failed to send message to Kafka server: %w", err)
 	}
-	p.metrics.IncKafkaTotalProduceMessages(ctx, topic)
-	p.metrics.IncKafkaTotalProducedBytes(ctx, topic, int64(len(payload)))
+	p.incProduced(ctx, outboxMsg)
+
+	return nil
+}
+
+func (p *KafkaProducer) sendTransactional(ctx context.Context, outboxMsg dto.OutboxMessage, generation uint64) error {
+	d := delivery{
+		report: dto.DeliveryReport{
+			MessageID: outboxMsg.ID,
+			Topic:     outboxMsg.Topic,
+			Attempts:  outboxMsg.Attempts,
+		},
+		generation: generation,
+	}
+
+	if outboxMsg.LeaseExpired && outboxMsg.AggregateID != uuid.Nil {
+		// The previous lease owner may have published the message.
+		readCtx, cancel := context.WithTimeout(ctx, transactionTimeout)
+		err := p.readControl(readCtx, outboxMsg.LeasedAt)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				p.breaker.Release(generation)
+			} else {
+				p.breaker.Failure(generation)
+			}
+			return fmt.Errorf("failed to read control topic %s: %w", p.cfg.ControlTopic, err)
+		}
+	}
+
+	if p.isPublished(outboxMsg) {
+		// The message was published, but not deleted from the outbox. Nothing is
+		// sent, so the breaker records no outcome.
+		p.metrics.IncKafkaTotalSkippedMessages(ctx, outboxMsg.Topic)
+		p.breaker.Release(generation)
+		p.deliver(d.report)
+		return nil
+	}
+
+	p.txMu.Lock()
+	defer p.txMu.Unlock()
+
+	if !p.inTransaction {
+		if err := p.producer.BeginTransaction(); err != nil {
+			p.breaker.Failure(generation)
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		p.inTransaction = true
+	}
+
+	err := p.producer.Produce(p.kafkaMessage(outboxMsg, generation), nil)
+	if err != nil {
+		p.breaker.Failure(generation)
+		return fmt.Errorf("failed to send message to Kafka server: %w", err)
+	}
+	p.incProduced(ctx, outboxMsg)
+
+	if outboxMsg.AggregateID != uuid.Nil {
+		err = p.producer.Produce(controlMessage(p.cfg.ControlTopic, outboxMsg.AggregateID, outboxMsg.Sequence), nil)
+		if err != nil {
+			// The message is in the transaction already, without its sequence.
+			p.abort = true
+			d.report.Err = fmt.Errorf("failed to send control message to Kafka server: %w", err)
+		}
+	}
+
+	p.pending = append(p.pending, pendingMessage{
+		delivery:    d,
+		aggregateID: outboxMsg.AggregateID,
+		sequence:    outboxMsg.Sequence,
+	})
+
+	return nil
+}
+
+// commitTransactions commits the open transaction every TransactionInterval and
+// reports its messages. Messages of a failed transaction are reported with the error.
+func (p *KafkaProducer) commitTransactions() {
+	defer close(p.committed)
+
+	ticker := time.NewTicker(p.cfg.TransactionInterval)
+	defer ticker.Stop()
+	pruneTicker := time.NewTicker(p.cfg.PublishedTTL)
+	defer pruneTicker.Stop()
 
 	for {
 		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case e := <-deliveryChan:
-			m := e.(*kafka.Message)
-			if m.TopicPartition.Error != nil {
-				return fmt.Errorf("failed to send message to Kafka server: %w", m.TopicPartition.Error)
+		case <-p.closed:
+			return
+		case now := <-pruneTicker.C:
+			p.prunePublished(now)
+			continue
+		case <-ticker.C:
+		}
+
+		for _, msg := range p.finishTransaction() {
+			p.report(msg.delivery)
+		}
+	}
+}
+
+// finishTransaction commits the open transaction, or aborts it, and returns its messages.
+// Only txMu is held during the commit, so messages published already are skipped meanwhile.
+func (p *KafkaProducer) finishTransaction() []pendingMessage {
+	p.txMu.Lock()
+	defer p.txMu.Unlock()
+
+	if !p.inTransaction {
+		return nil
+	}
+	pending, abort := p.pending, p.abort
+	p.pending = nil
+	p.abort = false
+	p.inTransaction = false
+
+	ctx, cancel := context.WithTimeout(context.Background(), transactionTimeout)
+	defer cancel()
+
+	var err error
+	if abort {
+		err = errors.New("transaction aborted")
+		if abortErr := p.producer.AbortTransaction(ctx); abortErr != nil {
+			err = fmt.Errorf("failed to abort transaction: %w", abortErr)
+		}
+	} else {
+		err = p.commit(ctx)
+	}
+
+	if err != nil {
+		for i := range pending {
+			if pending[i].report.Err == nil {
+				pending[i].report.Err = err
 			}
+		}
+		return pending
+	}
+
+	now := time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, msg := range pending {
+		if msg.aggregateID != uuid.Nil {
+			p.markPublished(msg.aggregateID, msg.sequence, now)
+		}
+	}
+
+	return pending
+}
+
+func (p *KafkaProducer) commit(ctx context.Context) error {
+	for {
+		err := p.producer.CommitTransaction(ctx)
+		if err == nil {
 			return nil
 		}
+
+		var kafkaErr kafka.Error
+		if !errors.As(err, &kafkaErr) {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		if kafkaErr.IsRetriable() && ctx.Err() == nil {
+			continue
+		}
+		if kafkaErr.TxnRequiresAbort() {
+			if abortErr := p.producer.AbortTransaction(ctx); abortErr != nil {
+				return fmt.Errorf("failed to abort transaction: %w", abortErr)
+			}
+		}
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+}
+
+// report publishes the delivery report and records the outcome in the circuit breaker.
+func (p *KafkaProducer) report(d delivery) {
+	if d.report.Err != nil {
+		p.breaker.Failure(d.generation)
+	} else {
+		p.breaker.Success(d.generation)
+	}
+	p.deliver(d.report)
+}
+
+func (p *KafkaProducer) deliver(report dto.DeliveryReport) {
+	select {
+	case <-p.closed:
+	case p.deliveries <- report:
+	}
+}
+
+func (p *KafkaProducer) kafkaMessage(outboxMsg dto.OutboxMessage, generation uint64) *kafka.Message {
+	topic := outboxMsg.Topic
+	msg := &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Value:          outboxMsg.Payload,
+		Headers:        kafkaHeaders(outboxMsg.Headers),
+		Opaque: delivery{
+			report: dto.DeliveryReport{
+				MessageID: outboxMsg.ID,
+				Topic:     topic,
+				Attempts:  outboxMsg.Attempts,
+			},
+			generation: generation,
+		},
+	}
+	if outboxMsg.Key != "" {
+		msg.Key = []byte(outboxMsg.Key)
+	}
+	return msg
+}
+
+func (p *KafkaProducer) incProduced(ctx context.Context, outboxMsg dto.OutboxMessage) {
+	p.metrics.IncKafkaTotalProduceMessages(ctx, outboxMsg.Topic)
+	p.metrics.IncKafkaTotalProducedBytes(ctx, outboxMsg.Topic, int64(len(outboxMsg.Payload)))
+}
+
+func (p *KafkaProducer) handleDeliveries() {
+	defer close(p.done)
+	defer close(p.deliveries)
+
+	for e := range p.producer.Events() {
+		m, ok := e.(*kafka.Message)
+		if !ok {
+			continue
+		}
+		d, ok := m.Opaque.(delivery)
+		if !ok {
+			continue
+		}
+		if m.TopicPartition.Error != nil {
+			d.report.Err = fmt.Errorf("failed to send message to Kafka server: %w", m.TopicPartition.Error)
+		}
+
+		p.report(d)
+	}
+}
+
+func kafkaHeaders(headers map[string]string) []kafka.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+	keys := slices.Sorted(maps.Keys(headers))
+	res := make([]kafka.Header, len(keys))
+	for i, k := range keys {
+		res[i] = kafka.Header{Key: k, Value: []byte(headers[k])}
 	}
+	return res
 }
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-invoice-service/common/pkg/breaker"
+	"go-invoice-service/common/pkg/logging"
+	"message-sheduler-service/internal/dto"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testTopic        = "new_invoice"
+	testControlTopic = "outbox_control"
+)
+
+type kafkaMetrics struct {
+	mu       sync.Mutex
+	produced int
+	skipped  int
+}
+
+func (m *kafkaMetrics) IncKafkaTotalProduceMessages(_ context.Context, _ string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.produced++
+}
+
+func (m *kafkaMetrics) IncKafkaTotalProducedBytes(_ context.Context, _ string, _ int64) {}
+
+func (m *kafkaMetrics) IncKafkaTotalSkippedMessages(_ context.Context, _ string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.skipped++
+}
+
+func (m *kafkaMetrics) SetCircuitBreakerState(_ context.Context, _ string, _ int64) {}
+
+// newMockCluster starts an in-process Kafka cluster with the outbox and control topics.
+func newMockCluster(t *testing.T) *kafka.MockCluster {
+	cluster, err := kafka.NewMockCluster(3)
+	require.NoError(t, err)
+	t.Cleanup(cluster.Close)
+	require.NoError(t, cluster.CreateTopic(testTopic, 1, 3))
+	require.NoError(t, cluster.CreateTopic(testControlTopic, 1, 3))
+	return cluster
+}
+
+func newTransactionalProducer(
+	t *testing.T,
+	cluster *kafka.MockCluster,
+	txInterval time.Duration,
+	metrics *kafkaMetrics,
+) (*KafkaProducer, KafkaProducerConfig) {
+	return newInstanceProducer(t, cluster, "scheduler-test", txInterval, metrics)
+}
+
+func newInstanceProducer(
+	t *testing.T,
+	cluster *kafka.MockCluster,
+	instanceID string,
+	txInterval time.Duration,
+	metrics *kafkaMetrics,
+) (*KafkaProducer, KafkaProducerConfig) {
+	cfg := KafkaProducerConfig{
+		ServerAddress:       cluster.BootstrapServers(),
+		Transactional:       true,
+		TransactionalID:     instanceID,
+		ControlTopic:        testControlTopic,
+		TransactionInterval: txInterval,
+		PublishedTTL:        time.Hour,
+	}
+	cb := breaker.New(
+		"kafka",
+		breaker.Config{FailureThreshold: 5, OpenTimeout: 10 * time.Millisecond, SuccessThreshold: 1},
+		metrics,
+		logging.NewNopLogger(),
+	)
+	producer, err := NewKafkaProducer(cfg, metrics, cb)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	require.NoError(t, producer.Init(ctx))
+
+	return producer, cfg
+}
+
+func receiveReports(t *testing.T, producer *KafkaProducer, count int) []dto.DeliveryReport {
+	reports := make([]dto.DeliveryReport, 0, count)
+	timeout := time.After(30 * time.Second)
+	for len(reports) < count {
+		select {
+		case report := <-producer.Deliveries():
+			reports = append(reports, report)
+		case <-timeout:
+			require.FailNow(t, "delivery reports not received", "got %d of %d", len(reports), count)
+		}
+	}
+	return reports
+}
+
+func loadPublished(t *testing.T, cfg KafkaProducerConfig) map[uuid.UUID]int64 {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	published, _, err := readPublishedSequences(ctx, cfg, nil)
+	require.NoError(t, err)
+	return published
+}
+
+func TestKafkaProducer_Transactional(t *testing.T) {
+	cluster := newMockCluster(t)
+	aggregateA := uuid.New()
+	aggregateB := uuid.New()
+
+	metrics := &kafkaMetrics{}
+	producer, cfg := newTransactionalProducer(t, cluster, 10*time.Millisecond, metrics)
+
+	messages := []dto.OutboxMessage{
+		{ID: 1, Topic: testTopic, AggregateID: aggregateA, Sequence: 1, Payload: []byte("a1")},
+		{ID: 2, Topic: testTopic, AggregateID: aggregateB, Sequence: 1, Payload: []byte("b1")},
+		{ID: 3, Topic: testTopic, AggregateID: aggregateA, Sequence: 2, Payload: []byte("a2")},
+		// Messages without an aggregate are not recorded in the control topic.
+		{ID: 4, Topic: testTopic, Payload: []byte("x")},
+	}
+	for _, msg := range messages {
+		require.NoError(t, producer.SendMessage(context.Background(), msg))
+	}
+	for _, report := range receiveReports(t, producer, len(messages)) {
+		assert.NoError(t, report.Err, "message %d", report.MessageID)
+	}
+	producer.Close()
+
+	assert.Equal(t, map[uuid.UUID]int64{aggregateA: 2, aggregateB: 1}, loadPublished(t, cfg))
+	assert.Equal(t, len(messages), metrics.produced)
+
+	// A restarted instance skips messages recorded as published.
+	metrics = &kafkaMetrics{}
+	producer, _ = newTransactionalProducer(t, cluster, 10*time.Millisecond, metrics)
+	defer producer.Close()
+
+	require.NoError(t, producer.SendMessage(context.Background(), messages[2]))
+	require.NoError(t, producer.SendMessage(context.Background(), dto.OutboxMessage{
+		ID: 5, Topic: testTopic, AggregateID: aggregateA, Sequence: 3, Payload: []byte("a3"),
+	}))
+	reports := receiveReports(t, producer, 2)
+	assert.Equal(t, int64(3), reports[0].MessageID)
+	assert.Equal(t, int64(5), reports[1].MessageID)
+	for _, report := range reports {
+		assert.NoError(t, report.Err)
+	}
+	assert.Equal(t, 1, metrics.skipped)
+	assert.Equal(t, 1, metrics.produced)
+
+	assert.Equal(t, map[uuid.UUID]int64{aggregateA: 3, aggregateB: 1}, loadPublished(t, cfg))
+}
+
+func TestKafkaProducer_Transactional_FlushCommits(t *testing.T) {
+	cluster := newMockCluster(t)
+	aggregateID := uuid.New()
+
+	// Transactions are committed only by Flush.
+	producer, cfg := newTransactionalProducer(t, cluster, time.Hour, &kafkaMetrics{})
+	defer producer.Close()
+
+	require.NoError(t, producer.SendMessage(context.Background(), dto.OutboxMessage{
+		ID: 1, Topic: testTopic, AggregateID: aggregateID, Sequence: 1,
+	}))
+	assert.Equal(t, 0, producer.Flush(context.Background()))
+
+	reports := receiveReports(t, producer, 1)
+	assert.NoError(t, reports[0].Err)
+	assert.Equal(t, map[uuid.UUID]int64{aggregateID: 1}, loadPublished(t, cfg))
+}
+
+func TestKafkaProducer_Transactional_CloseAborts(t *testing.T) {
+	cluster := newMockCluster(t)
+
+	producer, cfg := newTransactionalProducer(t, cluster, time.Hour, &kafkaMetrics{})
+	require.NoError(t, producer.SendMessage(context.Background(), dto.OutboxMessage{
+		ID: 1, Topic: testTopic, AggregateID: uuid.New(), Sequence: 1,
+	}))
+	producer.Close()
+
+	// The open transaction was aborted, so its sequence is not read back.
+	assert.Empty(t, loadPublished(t, cfg))
+}
+
+func TestKafkaProducer_Transactional_LeaseTakeover(t *testing.T) {
+	cluster := newMockCluster(t)
+	aggregateID := uuid.New()
+
+	// Both instances start before the message is published by the first one.
+	first, _ := newInstanceProducer(t, cluster, "scheduler-a", 10*time.Millisecond, &kafkaMetrics{})
+	defer first.Close()
+	metrics := &kafkaMetrics{}
+	second, _ := newInstanceProducer(t, cluster, "scheduler-b", 10*time.Millisecond, metrics)
+	defer second.Close()
+
+	msg := dto.OutboxMessage{ID: 1, Topic: testTopic, AggregateID: aggregateID, Sequence: 1}
+	require.NoError(t, first.SendMessage(context.Background(), msg))
+	require.NoError(t, receiveReports(t, first, 1)[0].Err)
+
+	// The message was not deleted, so the second instance leases it after the lease
+	// of the first one expires.
+	msg.LeaseExpired = true
+	msg.LeasedAt = time.Now()
+	require.NoError(t, second.SendMessage(context.Background(), msg))
+	reports := receiveReports(t, second, 1)
+	assert.Equal(t, int64(1), reports[0].MessageID)
+	assert.NoError(t, reports[0].Err)
+	assert.Equal(t, 1, metrics.skipped)
+	assert.Equal(t, 0, metrics.produced)
+
+	// A read started after the message was leased is not repeated.
+	readAt := second.controlReadAt
+	require.NoError(t, second.SendMessage(context.Background(), msg))
+	receiveReports(t, second, 1)
+	assert.Equal(t, readAt, second.controlReadAt)
+	assert.Equal(t, 2, metrics.skipped)
+}
+
+func TestKafkaProducer_Transactional_SkipKeepsBreakerState(t *testing.T) {
+	cluster := newMockCluster(t)
+	aggregateID := uuid.New()
+
+	producer, _ := newTransactionalProducer(t, cluster, 10*time.Millisecond, &kafkaMetrics{})
+	defer producer.Close()
+
+	msg := dto.OutboxMessage{ID: 1, Topic: testTopic, AggregateID: aggregateID, Sequence: 1}
+	require.NoError(t, producer.SendMessage(context.Background(), msg))
+	require.NoError(t, receiveReports(t, producer, 1)[0].Err)
+
+	for range 5 {
+		generation, err := producer.breaker.Allow()
+		require.NoError(t, err)
+		producer.breaker.Failure(generation)
+	}
+	require.Equal(t, breaker.StateOpen, producer.breaker.State())
+	require.Eventually(t, producer.Ready, time.Second, time.Millisecond)
+
+	// The skipped message is the trial call, it neither closes nor opens the breaker
+	// and gives the trial back.
+	require.NoError(t, producer.SendMessage(context.Background(), msg))
+	receiveReports(t, producer, 1)
+	assert.Equal(t, breaker.StateHalfOpen, producer.breaker.State())
+	_, err := producer.breaker.Allow()
+	assert.NoError(t, err)
+}
+
+func TestKafkaProducer_PrunePublished(t *testing.T) {
+	cluster := newMockCluster(t)
+	aggregateA := uuid.New()
+	aggregateB := uuid.New()
+
+	producer, cfg := newTransactionalProducer(t, cluster, 10*time.Millisecond, &kafkaMetrics{})
+	defer producer.Close()
+
+	now := time.Now()
+	producer.mu.Lock()
+	producer.markPublished(aggregateA, 2, now.Add(-cfg.PublishedTTL))
+	producer.markPublished(aggregateB, 1, now.Add(-cfg.PublishedTTL/2))
+	producer.mu.Unlock()
+
+	producer.prunePublished(now)
+
+	producer.mu.Lock()
+	defer producer.mu.Unlock()
+	assert.Equal(t, map[uuid.UUID]publishedSequence{
+		aggregateB: {sequence: 1, seenAt: now.Add(-cfg.PublishedTTL / 2)},
+	}, producer.published)
+}
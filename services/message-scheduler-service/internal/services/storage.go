@@ -2,10 +2,17 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/google/uuid"
 	pb "go-invoice-service/common/protocol/proto/messagescheduler"
+	"go-invoice-service/common/protocol/proto/types"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
 	"message-sheduler-service/internal/dto"
 	"time"
 
@@ -17,20 +24,23 @@ type StorageConfig struct {
 }
 
 type Storage struct {
-	conn                *grpc.ClientConn
-	outboxStorageClient pb.OutboxStorageClient
+	conn                 *grpc.ClientConn
+	outboxStorageClient  pb.OutboxStorageClient
+	webhookStorageClient pb.WebhookDeliveryStorageClient
 }
 
-func NewStorage(cfg StorageConfig) (*Storage, error) {
-	options := grpc.WithTransportCredentials(insecure.NewCredentials())
-	conn, err := grpc.NewClient(cfg.ServerAddress, options)
+func NewStorage(cfg StorageConfig, options ...grpc.DialOption) (*Storage, error) {
+	options = append(options, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(cfg.ServerAddress, options...)
 	if err != nil {
 		return nil, err
 	}
 	outboxStorageClient := pb.NewOutboxStorageClient(conn)
+	webhookStorageClient := pb.NewWebhookDeliveryStorageClient(conn)
 	return &Storage{
-		conn:                conn,
-		outboxStorageClient: outboxStorageClient,
+		conn:                 conn,
+		outboxStorageClient:  outboxStorageClient,
+		webhookStorageClient: webhookStorageClient,
 	}, nil
 }
 
@@ -44,38 +54,222 @@ func (s *Storage) Close() error {
 
 func (s *Storage) GetOutboxMessages(
 	ctx context.Context,
+	leaseOwner string,
 	maxCount int32,
 	retryIn time.Duration,
-) ([]dto.OutboxMessage, error) {
+) (dto.OutboxBatch, error) {
 	req := &pb.GetMessagesRequest{
-		MaxCount: &maxCount,
-		RetryAfter: &durationpb.Duration{
-			Seconds: int64(retryIn.Seconds()),
-		},
+		MaxCount:   &maxCount,
+		RetryAfter: durationpb.New(retryIn),
+		LeaseOwner: &leaseOwner,
 	}
+	leasedAt := time.Now()
 	resp, err := s.outboxStorageClient.Get(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get outbox messages: %w", err)
+		return dto.OutboxBatch{}, fmt.Errorf("failed to get outbox messages: %w", err)
 	}
 	msgsCount := len(resp.OutboxMessages)
 	res := make([]dto.OutboxMessage, msgsCount)
 	for i, msg := range resp.OutboxMessages {
+		var aggregateID uuid.UUID
+		if msg.GetAggregateId() != nil {
+			aggregateID, err = uuid.FromBytes(msg.GetAggregateId().GetValue())
+			if err != nil {
+				return dto.OutboxBatch{}, fmt.Errorf("invalid aggregate id of outbox message %d: %w", msg.GetId(), err)
+			}
+		}
 		res[i] = dto.OutboxMessage{
-			ID:      msg.GetId(),
-			Topic:   msg.GetTopic(),
-			Payload: msg.GetPayload(),
+			ID:           msg.GetId(),
+			Topic:        msg.GetTopic(),
+			AggregateID:  aggregateID,
+			Sequence:     msg.GetSequence(),
+			LeaseExpired: msg.GetLeaseExpired(),
+			LeasedAt:     leasedAt,
+			Attempts:     msg.GetAttempts(),
+			Key:          msg.GetKey(),
+			Headers:      msg.GetHeaders(),
+			Payload:      msg.GetPayload(),
+		}
+	}
+	return dto.OutboxBatch{Messages: res, Backlog: resp.GetBacklog()}, nil
+}
+
+// DeleteOutboxMessages deletes messages leased to leaseOwner and returns IDs of
+// messages not deleted because they are leased to another owner now.
+func (s *Storage) DeleteOutboxMessages(ctx context.Context, leaseOwner string, ids []int64) ([]int64, error) {
+	req := &pb.DeleteBatchRequest{
+		Ids:        ids,
+		LeaseOwner: &leaseOwner,
+	}
+	resp, err := s.outboxStorageClient.DeleteBatch(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete outbox messages: %w", err)
+	}
+	return resp.GetRejectedIds(), nil
+}
+
+// FailOutboxMessage records a failed publish attempt of a message leased to leaseOwner.
+// It returns false if the message is leased to another owner now.
+func (s *Storage) FailOutboxMessage(ctx context.Context, leaseOwner string, failure dto.OutboxFailure) (bool, error) {
+	req := &pb.FailMessageRequest{
+		Id:         &failure.MessageID,
+		LeaseOwner: &leaseOwner,
+		Error:      &failure.Error,
+		Park:       &failure.Park,
+		NextSendAt: timestamppb.New(failure.NextSendAt),
+	}
+	_, err := s.outboxStorageClient.Fail(ctx, req)
+	if status.Code(err) == codes.FailedPrecondition {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to fail outbox message: %w", err)
+	}
+	return true, nil
+}
+
+// ReleaseOutboxMessages gives up the leases of messages leased to leaseOwner, so
+// they are sent again without waiting for the leases to expire. It returns the
+// number of released messages.
+func (s *Storage) ReleaseOutboxMessages(ctx context.Context, leaseOwner string, ids []int64) (int64, error) {
+	req := &pb.ReleaseMessagesRequest{
+		Ids:        ids,
+		LeaseOwner: &leaseOwner,
+	}
+	resp, err := s.outboxStorageClient.Release(ctx, req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to release outbox messages: %w", err)
+	}
+	return resp.GetReleasedCount(), nil
+}
+
+// WatchOutbox subscribes to outbox signals. The signals channel is closed when the
+// stream ends, the error channel receives the reason if it was not ctx cancellation.
+func (s *Storage) WatchOutbox(ctx context.Context) (<-chan struct{}, <-chan error, error) {
+	stream, err := s.outboxStorageClient.WatchOutbox(ctx, &pb.WatchOutboxRequest{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to watch outbox: %w", err)
+	}
+
+	out := make(chan struct{}, 1)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(errCh)
+		defer close(out)
+
+		for {
+			_, err := stream.Recv()
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				errCh <- fmt.Errorf("failed to receive outbox signal: %w", err)
+				return
+			}
+
+			// A signal not read yet already covers this one.
+			select {
+			case out <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	return out, errCh, nil
+}
+
+// ReplayOutboxMessages schedules copies of at most maxCount published messages
+// matching filter with IDs greater than afterID.
+func (s *Storage) ReplayOutboxMessages(
+	ctx context.Context,
+	filter dto.ReplayFilter,
+	afterID int64,
+	maxCount int32,
+) (dto.ReplayBatch, error) {
+	req := &pb.ReplayMessagesRequest{
+		AfterId:  &afterID,
+		MaxCount: &maxCount,
+		Topic:    &filter.Topic,
+	}
+	if filter.AggregateID != uuid.Nil {
+		req.AggregateId = &types.UUID{Value: filter.AggregateID[:]}
+	}
+	if !filter.From.IsZero() {
+		req.CreatedFrom = timestamppb.New(filter.From)
+	}
+	if !filter.To.IsZero() {
+		req.CreatedTo = timestamppb.New(filter.To)
+	}
+	resp, err := s.outboxStorageClient.Replay(ctx, req)
+	if err != nil {
+		return dto.ReplayBatch{}, fmt.Errorf("failed to replay outbox messages: %w", err)
+	}
+	return dto.ReplayBatch{
+		Count:  resp.GetCount(),
+		LastID: resp.GetLastId(),
+	}, nil
+}
+
+func (s *Storage) GetWebhookDeliveries(
+	ctx context.Context,
+	leaseOwner string,
+	maxCount int32,
+	leaseFor time.Duration,
+) ([]dto.WebhookDelivery, error) {
+	req := &pb.GetWebhookDeliveriesRequest{
+		MaxCount:   &maxCount,
+		LeaseFor:   durationpb.New(leaseFor),
+		LeaseOwner: &leaseOwner,
+	}
+	resp, err := s.webhookStorageClient.GetDeliveries(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+	res := make([]dto.WebhookDelivery, len(resp.GetDeliveries()))
+	for i, delivery := range resp.GetDeliveries() {
+		res[i] = dto.WebhookDelivery{
+			ID:        delivery.GetId(),
+			EventType: delivery.GetEventType(),
+			Payload:   delivery.GetPayload(),
+			Attempts:  delivery.GetAttempts(),
+			URL:       delivery.GetUrl(),
+			Secret:    delivery.GetSecret(),
 		}
 	}
 	return res, nil
 }
 
-func (s *Storage) DeleteOutboxMessage(ctx context.Context, id int64) error {
-	req := &pb.DeleteMessageRequest{
-		Id: &id,
+// CompleteWebhookAttempt records an attempt of a delivery leased to leaseOwner.
+// It returns false if the delivery is leased to another owner now.
+func (s *Storage) CompleteWebhookAttempt(ctx context.Context, leaseOwner string, result dto.WebhookAttemptResult) (bool, error) {
+	var outcome pb.WebhookAttemptOutcome
+	switch result.Outcome {
+	case dto.WebhookOutcomeSucceeded:
+		outcome = pb.WebhookAttemptOutcome_Succeeded
+	case dto.WebhookOutcomeRetry:
+		outcome = pb.WebhookAttemptOutcome_Retry
+	case dto.WebhookOutcomeDead:
+		outcome = pb.WebhookAttemptOutcome_Dead
+	default:
+		return false, fmt.Errorf("unknown webhook attempt outcome: %s", result.Outcome)
+	}
+	req := &pb.CompleteWebhookAttemptRequest{
+		DeliveryId:    &result.DeliveryID,
+		Outcome:       &outcome,
+		StatusCode:    &result.Response.StatusCode,
+		Error:         &result.Error,
+		Response:      &result.Response.Body,
+		Duration:      durationpb.New(result.Duration),
+		NextAttemptAt: timestamppb.New(result.NextAttemptAt),
+		LeaseOwner:    &leaseOwner,
+	}
+	_, err := s.webhookStorageClient.CompleteAttempt(ctx, req)
+	if status.Code(err) == codes.FailedPrecondition {
+		return false, nil
 	}
-	_, err := s.outboxStorageClient.Delete(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to delete outbox message: %w", err)
+		return false, fmt.Errorf("failed to complete webhook attempt: %w", err)
 	}
-	return nil
+	return true, nil
 }
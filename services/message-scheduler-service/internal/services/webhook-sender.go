@@ -0,0 +1,109 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"message-sheduler-service/internal/dto"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	WebhookIDHeader        = "X-Webhook-Id"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// maxWebhookResponseBytes limits the part of the response body kept in the delivery log.
+const maxWebhookResponseBytes = 1024
+
+type WebhookSenderConfig struct {
+	Timeout time.Duration
+}
+
+type WebhookSender struct {
+	client *http.Client
+}
+
+func NewWebhookSender(cfg WebhookSenderConfig) *WebhookSender {
+	return &WebhookSender{
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+			// A redirected delivery is reported as failed, the endpoint has to be updated.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+type webhookBody struct {
+	ID    int64           `json:"id"`
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// Send posts the delivery to its endpoint. An error is returned only when no response was
+// received, the caller decides whether the status code means success.
+func (s *WebhookSender) Send(ctx context.Context, delivery dto.WebhookDelivery) (dto.WebhookResponse, error) {
+	body, err := json.Marshal(webhookBody{
+		ID:    delivery.ID,
+		Event: delivery.EventType,
+		Data:  delivery.Payload,
+	})
+	if err != nil {
+		return dto.WebhookResponse{}, fmt.Errorf("failed to marshal webhook body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return dto.WebhookResponse{}, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookIDHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(delivery.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return dto.WebhookResponse{}, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseBytes))
+	if err != nil {
+		return dto.WebhookResponse{}, fmt.Errorf("failed to read webhook response: %w", err)
+	}
+
+	return dto.WebhookResponse{
+		StatusCode: int32(resp.StatusCode),
+		Body:       sanitizeWebhookResponse(respBody),
+	}, nil
+}
+
+// SignWebhook returns the signature header value: HMAC-SHA256 of "<timestamp>.<body>"
+// keyed with the endpoint secret.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// sanitizeWebhookResponse makes the response body storable as text.
+func sanitizeWebhookResponse(body []byte) string {
+	return strings.ReplaceAll(strings.ToValidUTF8(string(body), "�"), "\x00", "")
+}
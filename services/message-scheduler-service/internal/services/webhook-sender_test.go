@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"message-sheduler-service/internal/dto"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestWebhookSenderSend(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		respBody   string
+		wantBody   string
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusNoContent,
+		},
+		{
+			name:       "failure body is sanitized",
+			statusCode: http.StatusInternalServerError,
+			respBody:   "oops\x00\xff",
+			wantBody:   "oops�",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotBody []byte
+			var gotHeader http.Header
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotBody, _ = io.ReadAll(r.Body)
+				gotHeader = r.Header.Clone()
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.respBody))
+			}))
+			defer srv.Close()
+
+			sender := NewWebhookSender(WebhookSenderConfig{Timeout: time.Second})
+			resp, err := sender.Send(context.Background(), dto.WebhookDelivery{
+				ID:        42,
+				EventType: "invoice_approved",
+				Payload:   []byte(`{"invoiceId":"a"}`),
+				URL:       srv.URL,
+				Secret:    "secret",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, int32(tt.statusCode), resp.StatusCode)
+			assert.Equal(t, tt.wantBody, resp.Body)
+
+			timestamp, err := strconv.ParseInt(gotHeader.Get(WebhookTimestampHeader), 10, 64)
+			require.NoError(t, err)
+			assert.Equal(t, SignWebhook("secret", timestamp, gotBody), gotHeader.Get(WebhookSignatureHeader))
+			assert.Equal(t, "42", gotHeader.Get(WebhookIDHeader))
+			assert.Equal(t, "invoice_approved", gotHeader.Get(WebhookEventHeader))
+
+			var body webhookBody
+			require.NoError(t, json.Unmarshal(gotBody, &body))
+			assert.Equal(t, int64(42), body.ID)
+			assert.JSONEq(t, `{"invoiceId":"a"}`, string(body.Data))
+		})
+	}
+}
@@ -6,22 +6,46 @@ import (
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"go-invoice-service/common/pkg/logging"
 	common "go-invoice-service/common/protocol/kafka"
+	"slices"
 )
 
-func EnsureKafkaTopics(ctx context.Context, address string, logger *logging.ZapLogger) error {
-	for _, t := range common.Topics {
+// controlTopicReplicationFactor matches the replication factor of the event topics.
+const controlTopicReplicationFactor = 3
+
+// EnsureKafkaTopics creates the event topics and, unless controlTopic is empty,
+// the compacted control topic of the transactional producer.
+func EnsureKafkaTopics(ctx context.Context, address string, controlTopic string, logger *logging.ZapLogger) error {
+	for _, t := range slices.Concat(common.Topics, common.DeadLetterTopics) {
 		err := ensureTopic(
 			ctx,
 			address,
 			t.Topic,
 			t.PartitionsCount,
 			t.ReplicationFactor,
+			nil,
 		)
 		if err != nil {
 			return fmt.Errorf("ensure topic %s failed: %w", t.Topic, err)
 		}
 		logger.InfoCtx(ctx, fmt.Sprintf("Topic %s ensured", t.Topic))
 	}
+
+	if controlTopic == "" {
+		return nil
+	}
+	err := ensureTopic(
+		ctx,
+		address,
+		common.Topic(controlTopic),
+		1,
+		controlTopicReplicationFactor,
+		map[string]string{"cleanup.policy": "compact"},
+	)
+	if err != nil {
+		return fmt.Errorf("ensure topic %s failed: %w", controlTopic, err)
+	}
+	logger.InfoCtx(ctx, fmt.Sprintf("Topic %s ensured", controlTopic))
+
 	return nil
 }
 
@@ -31,6 +55,7 @@ func ensureTopic(
 	topic common.Topic,
 	partitionsCount int,
 	replicationFactor int,
+	config map[string]string,
 ) error {
 	admin, err := kafka.NewAdminClient(&kafka.ConfigMap{"bootstrap.servers": address})
 	if err != nil {
@@ -44,6 +69,7 @@ func ensureTopic(
 			Topic:             string(topic),
 			NumPartitions:     partitionsCount,
 			ReplicationFactor: replicationFactor,
+			Config:            config,
 		}},
 		kafka.SetAdminOperationTimeout(5e9),
 	)
@@ -0,0 +1,373 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go-invoice-service/common/pkg/flagtypes"
+	"go-invoice-service/common/pkg/meterutils"
+	"net/mail"
+	"notifications-service/internal/kafka"
+	notificationsMail "notifications-service/internal/mail"
+	"notifications-service/internal/services"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	MailSenderSMTP = "smtp"
+	MailSenderFile = "file"
+)
+
+const (
+	kafkaAddressFlag         = "kafka-address"
+	kafkaAddressEnv          = "KAFKA_ADDRESS"
+	storageAddressFlag       = "storage-address"
+	storageAddressEnv        = "STORAGE_ADDRESS"
+	kafkaPollTimeoutMsFlag   = "kafka-poll-timeout-ms"
+	kafkaPollTimeoutMsEnv    = "KAFKA_POLL_TIMEOUT_MS"
+	prometheusPortFlag       = "prometheus-port"
+	prometheusPortEnv        = "PROMETHEUS_PORT"
+	otelCollectorAddressFlag = "otel-collector-address"
+	otelCollectorAddressEnv  = "OTEL_COLLECTOR_ADDRESS"
+	mailSenderFlag           = "mail-sender"
+	mailSenderEnv            = "MAIL_SENDER"
+	mailFromFlag             = "mail-from"
+	mailFromEnv              = "MAIL_FROM"
+	mailDirFlag              = "mail-dir"
+	mailDirEnv               = "MAIL_DIR"
+	smtpAddressFlag          = "smtp-address"
+	smtpAddressEnv           = "SMTP_ADDRESS"
+	smtpUsernameFlag         = "smtp-username"
+	smtpUsernameEnv          = "SMTP_USERNAME"
+	smtpPasswordEnv          = "SMTP_PASSWORD"
+	recipientsFileFlag       = "recipients-file"
+	recipientsFileEnv        = "RECIPIENTS_FILE"
+	defaultLocaleFlag        = "default-locale"
+	defaultLocaleEnv         = "DEFAULT_LOCALE"
+	notificationLeaseFlag    = "notification-lease"
+	notificationLeaseEnv     = "NOTIFICATION_LEASE_MS"
+	maxAttemptsFlag          = "message-max-attempts"
+	maxAttemptsEnv           = "MESSAGE_MAX_ATTEMPTS"
+	retryBackoffMsFlag       = "message-retry-backoff-ms"
+	retryBackoffMsEnv        = "MESSAGE_RETRY_BACKOFF_MS"
+)
+
+const (
+	defaultKafkaAddress         = "localhost:9092"
+	defaultStorageAddress       = "localhost:5000"
+	defaultKafkaPollTimeoutMs   = 100
+	defaultShutdownTimeout      = 5 * time.Second
+	defaultPrometheusPort       = 9090
+	defaultOtelCollectorAddress = "localhost:4318"
+	defaultMailSender           = MailSenderFile
+	defaultMailFrom             = "Invoice Service <noreply@localhost>"
+	defaultMailDir              = "./mail"
+	defaultSMTPAddress          = "localhost:25"
+	defaultRecipientsFile       = "./recipients.json"
+	defaultLocale               = "en"
+	defaultNotificationLease    = 1 * time.Minute
+	defaultMaxAttempts          = 3
+	defaultRetryBackoffMs       = 1000
+)
+
+var defaultRetryAttempts = []time.Duration{
+	1 * time.Second,
+	2 * time.Second,
+	4 * time.Second,
+	8 * time.Second,
+}
+
+type Config struct {
+	KafkaConsumerConfig   kafka.ConsumerConfig
+	KafkaDispatcherConfig services.MessagesDispatcherConfig
+	PrometheusConfig      meterutils.PrometheusConfig
+	OpenTelemetryConfig   meterutils.OpenTelemetryConfig
+	StorageAddress        string
+	MailSender            string
+	MailDir               string
+	SMTPConfig            notificationsMail.SMTPConfig
+	RecipientsFile        string
+	DefaultLocale         string
+}
+
+func Load() (*Config, error) {
+
+	kafkaAddress := defaultKafkaAddress
+	storageAddress := defaultStorageAddress
+	kafkaPollTimeoutMs := defaultKafkaPollTimeoutMs
+	prometheusPort := defaultPrometheusPort
+	otelCollectorAddress := defaultOtelCollectorAddress
+	mailSender := defaultMailSender
+	mailFrom := defaultMailFrom
+	mailDir := defaultMailDir
+	smtpAddress := defaultSMTPAddress
+	smtpUsername := ""
+	smtpPassword := ""
+	recipientsFile := defaultRecipientsFile
+	locale := defaultLocale
+	notificationLease := defaultNotificationLease
+	maxAttempts := defaultMaxAttempts
+	retryBackoffMs := defaultRetryBackoffMs
+
+	// Flags Definition.
+
+	kafkaAddressFlagVal := flagtypes.NewString()
+	flag.Var(kafkaAddressFlagVal, kafkaAddressFlag, "Kafka bootstrap server address")
+
+	storageAddressFlagVal := flagtypes.NewString()
+	flag.Var(storageAddressFlagVal, storageAddressFlag, "Storage server address")
+
+	kafkaPollTimeoutMsFlagVal := flagtypes.NewInt()
+	flag.Var(kafkaPollTimeoutMsFlagVal, kafkaPollTimeoutMsFlag, "Kafka poll timeout (ms)")
+
+	prometheusPortFlagVal := flagtypes.NewInt()
+	flag.Var(prometheusPortFlagVal, prometheusPortFlag, "Prometheus port")
+
+	otelCollectorAddressFlagVal := flagtypes.NewString()
+	flag.Var(otelCollectorAddressFlagVal, otelCollectorAddressFlag, "OpenTelemetry Collector address")
+
+	mailSenderFlagVal := flagtypes.NewString()
+	flag.Var(mailSenderFlagVal, mailSenderFlag, "Mail sender (smtp or file)")
+
+	mailFromFlagVal := flagtypes.NewString()
+	flag.Var(mailFromFlagVal, mailFromFlag, "Mail From address")
+
+	mailDirFlagVal := flagtypes.NewString()
+	flag.Var(mailDirFlagVal, mailDirFlag, "Directory to write emails to with the file sender")
+
+	smtpAddressFlagVal := flagtypes.NewString()
+	flag.Var(smtpAddressFlagVal, smtpAddressFlag, "SMTP server address")
+
+	smtpUsernameFlagVal := flagtypes.NewString()
+	flag.Var(smtpUsernameFlagVal, smtpUsernameFlag, "SMTP username")
+
+	recipientsFileFlagVal := flagtypes.NewString()
+	flag.Var(recipientsFileFlagVal, recipientsFileFlag, "Customer recipients JSON file")
+
+	defaultLocaleFlagVal := flagtypes.NewString()
+	flag.Var(defaultLocaleFlagVal, defaultLocaleFlag, "Locale used when a recipient has no templates in its own")
+
+	notificationLeaseFlagVal := flagtypes.NewInt()
+	flag.Var(notificationLeaseFlagVal, notificationLeaseFlag, "Notification sending lease (ms)")
+
+	maxAttemptsFlagVal := flagtypes.NewInt()
+	flag.Var(maxAttemptsFlagVal, maxAttemptsFlag, "Message handling attempts before polling it again")
+
+	retryBackoffMsFlagVal := flagtypes.NewInt()
+	flag.Var(retryBackoffMsFlagVal, retryBackoffMsFlag, "Message handling retry initial backoff (ms)")
+
+	flag.Parse()
+
+	// Flags Parse.
+
+	if val, ok := kafkaAddressFlagVal.Value(); ok {
+		kafkaAddress = val
+	}
+
+	if val, ok := storageAddressFlagVal.Value(); ok {
+		storageAddress = val
+	}
+
+	if val, ok := kafkaPollTimeoutMsFlagVal.Value(); ok {
+		kafkaPollTimeoutMs = val
+	}
+
+	if val, ok := prometheusPortFlagVal.Value(); ok {
+		prometheusPort = val
+	}
+
+	if val, ok := otelCollectorAddressFlagVal.Value(); ok {
+		otelCollectorAddress = val
+	}
+
+	if val, ok := mailSenderFlagVal.Value(); ok {
+		mailSender = val
+	}
+
+	if val, ok := mailFromFlagVal.Value(); ok {
+		mailFrom = val
+	}
+
+	if val, ok := mailDirFlagVal.Value(); ok {
+		mailDir = val
+	}
+
+	if val, ok := smtpAddressFlagVal.Value(); ok {
+		smtpAddress = val
+	}
+
+	if val, ok := smtpUsernameFlagVal.Value(); ok {
+		smtpUsername = val
+	}
+
+	if val, ok := recipientsFileFlagVal.Value(); ok {
+		recipientsFile = val
+	}
+
+	if val, ok := defaultLocaleFlagVal.Value(); ok {
+		locale = val
+	}
+
+	if val, ok := notificationLeaseFlagVal.Value(); ok {
+		notificationLease = time.Duration(val) * time.Millisecond
+	}
+
+	if val, ok := maxAttemptsFlagVal.Value(); ok {
+		maxAttempts = val
+	}
+
+	if val, ok := retryBackoffMsFlagVal.Value(); ok {
+		retryBackoffMs = val
+	}
+
+	// Environment Variables.
+
+	if valStr, ok := os.LookupEnv(kafkaAddressEnv); ok {
+		kafkaAddress = valStr
+	}
+
+	if valStr, ok := os.LookupEnv(storageAddressEnv); ok {
+		storageAddress = valStr
+	}
+
+	if valStr, ok := os.LookupEnv(kafkaPollTimeoutMsEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, kafkaPollTimeoutMsEnv)
+		}
+		kafkaPollTimeoutMs = val
+	}
+
+	if valStr, ok := os.LookupEnv(prometheusPortEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, prometheusPortEnv)
+		}
+		prometheusPort = val
+	}
+
+	if valStr, ok := os.LookupEnv(otelCollectorAddressEnv); ok {
+		otelCollectorAddress = valStr
+	}
+
+	if valStr, ok := os.LookupEnv(mailSenderEnv); ok {
+		mailSender = valStr
+	}
+
+	if valStr, ok := os.LookupEnv(mailFromEnv); ok {
+		mailFrom = valStr
+	}
+
+	if valStr, ok := os.LookupEnv(mailDirEnv); ok {
+		mailDir = valStr
+	}
+
+	if valStr, ok := os.LookupEnv(smtpAddressEnv); ok {
+		smtpAddress = valStr
+	}
+
+	if valStr, ok := os.LookupEnv(smtpUsernameEnv); ok {
+		smtpUsername = valStr
+	}
+
+	// The password is read only from the environment, so it does not show up in the process list.
+	if valStr, ok := os.LookupEnv(smtpPasswordEnv); ok {
+		smtpPassword = valStr
+	}
+
+	if valStr, ok := os.LookupEnv(recipientsFileEnv); ok {
+		recipientsFile = valStr
+	}
+
+	if valStr, ok := os.LookupEnv(defaultLocaleEnv); ok {
+		locale = valStr
+	}
+
+	if valStr, ok := os.LookupEnv(notificationLeaseEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, notificationLeaseEnv)
+		}
+		notificationLease = time.Duration(val) * time.Millisecond
+	}
+
+	if valStr, ok := os.LookupEnv(maxAttemptsEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, maxAttemptsEnv)
+		}
+		maxAttempts = val
+	}
+
+	if valStr, ok := os.LookupEnv(retryBackoffMsEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, retryBackoffMsEnv)
+		}
+		retryBackoffMs = val
+	}
+
+	// Validation.
+
+	if kafkaPollTimeoutMs < 1 {
+		return &Config{}, errors.New("kafka poll timeout must be greater than one")
+	}
+
+	if prometheusPort < 0 || prometheusPort > 65535 {
+		return &Config{}, errors.New("prometheus port must be between 0 and 65535")
+	}
+
+	if mailSender != MailSenderSMTP && mailSender != MailSenderFile {
+		return &Config{}, fmt.Errorf("mail sender must be '%s' or '%s'", MailSenderSMTP, MailSenderFile)
+	}
+
+	if _, err := mail.ParseAddress(mailFrom); err != nil {
+		return &Config{}, fmt.Errorf("invalid mail from address: %w", err)
+	}
+
+	if notificationLease <= time.Duration(0) {
+		return &Config{}, errors.New("notification lease must be greater than zero")
+	}
+
+	if maxAttempts < 1 {
+		return &Config{}, errors.New("message max attempts must be at least one")
+	}
+
+	if retryBackoffMs < 0 {
+		return &Config{}, errors.New("message retry backoff must not be negative")
+	}
+
+	return &Config{
+		KafkaConsumerConfig: kafka.ConsumerConfig{
+			ServerAddress: kafkaAddress,
+			RetryAttempts: defaultRetryAttempts,
+		},
+		KafkaDispatcherConfig: services.MessagesDispatcherConfig{
+			PollTimeoutMs: kafkaPollTimeoutMs,
+			From:          mailFrom,
+			LeaseFor:      notificationLease,
+			MaxAttempts:   maxAttempts,
+			RetryBackoff:  time.Duration(retryBackoffMs) * time.Millisecond,
+		},
+		PrometheusConfig: meterutils.PrometheusConfig{
+			PortToListen:    uint16(prometheusPort),
+			ShutdownTimeout: defaultShutdownTimeout,
+		},
+		OpenTelemetryConfig: meterutils.OpenTelemetryConfig{
+			ServiceName:      "notifications-service",
+			CollectorAddress: otelCollectorAddress,
+		},
+		StorageAddress: storageAddress,
+		MailSender:     mailSender,
+		MailDir:        mailDir,
+		SMTPConfig: notificationsMail.SMTPConfig{
+			ServerAddress: smtpAddress,
+			Username:      smtpUsername,
+			Password:      smtpPassword,
+		},
+		RecipientsFile: recipientsFile,
+		DefaultLocale:  locale,
+	}, nil
+}
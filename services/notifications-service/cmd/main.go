@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-invoice-service/common/pkg/logging"
+	"go-invoice-service/common/pkg/meterutils"
+	kafkaProtocol "go-invoice-service/common/protocol/kafka"
+	storagepb "go-invoice-service/common/protocol/proto/notifications"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"log"
+	"net/http"
+	"notifications-service/cmd/config"
+	"notifications-service/internal/kafka"
+	"notifications-service/internal/mail"
+	"notifications-service/internal/metrics"
+	"notifications-service/internal/services"
+	"notifications-service/internal/templates"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	mp, err := meterutils.SetupMeterProvider(cfg.OpenTelemetryConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer mp.Shutdown(context.Background())
+
+	metricsCollector := metrics.MustInitCustomMetric()
+
+	cfgJSON, err := json.MarshalIndent(cfg, "", "   ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Config:", string(cfgJSON))
+
+	logger, err := logging.NewZapLogger(zapcore.DebugLevel)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rootCtx, cancelCtx := signal.NotifyContext(
+		context.Background(),
+		syscall.SIGHUP,
+		syscall.SIGINT,
+		syscall.SIGTERM,
+		syscall.SIGQUIT,
+		syscall.SIGABRT,
+	)
+	defer cancelCtx()
+
+	recipients, err := services.LoadRecipients(cfg.RecipientsFile)
+	if err != nil {
+		logger.FatalCtx(rootCtx, "Failed to load recipients", zap.Error(err))
+	}
+
+	renderer, err := templates.NewRenderer(cfg.DefaultLocale)
+	if err != nil {
+		logger.FatalCtx(rootCtx, "Failed to load templates", zap.Error(err))
+	}
+
+	sender, err := newMailSender(cfg)
+	if err != nil {
+		logger.FatalCtx(rootCtx, "Failed to create mail sender", zap.Error(err))
+	}
+
+	kafkaConsumer, err := kafka.NewKafkaConsumer(
+		cfg.KafkaConsumerConfig,
+		metricsCollector,
+		"notifications-service",
+		[]string{
+			string(kafkaProtocol.TopicInvoiceApproved),
+			string(kafkaProtocol.TopicInvoiceRejected),
+		},
+		logger,
+	)
+	if err != nil {
+		logger.FatalCtx(rootCtx, "Failed to create consumer", zap.Error(err))
+	}
+	defer kafkaConsumer.Close()
+
+	options := grpc.WithTransportCredentials(insecure.NewCredentials())
+	storageServiceConnection, err := grpc.NewClient(cfg.StorageAddress, options)
+	if err != nil {
+		logger.FatalCtx(rootCtx, "Failed to connect to storage service", zap.Error(err))
+	}
+	defer storageServiceConnection.Close()
+
+	notificationStorageClient := storagepb.NewNotificationStorageClient(storageServiceConnection)
+	storageService := services.NewNotificationStorage(notificationStorageClient)
+
+	messagesDispatcher := services.NewMessagesDispatcher(
+		cfg.KafkaDispatcherConfig,
+		storageService,
+		recipients,
+		renderer,
+		sender,
+		kafkaConsumer,
+		metricsCollector,
+		logger,
+	)
+
+	if err := run(rootCtx, cfg, messagesDispatcher, logger); err != nil {
+		logger.ErrorCtx(rootCtx, "Service shutdown with error", zap.Error(err))
+	} else {
+		logger.InfoCtx(rootCtx, "Service shutdown gracefully")
+	}
+}
+
+func newMailSender(cfg *config.Config) (services.MailSender, error) {
+	if cfg.MailSender == config.MailSenderSMTP {
+		return mail.NewSMTPSender(cfg.SMTPConfig)
+	}
+	return mail.NewFileSender(cfg.MailDir)
+}
+
+func run(
+	rootCtx context.Context,
+	cfg *config.Config,
+	messagesDispatcher *services.MessagesDispatcher,
+	logger *logging.ZapLogger,
+) error {
+	g, ctx := errgroup.WithContext(rootCtx)
+
+	g.Go(func() error {
+		defer logger.InfoCtx(ctx, "Kafka Dispatching Finished")
+		errCh := messagesDispatcher.Run(ctx)
+		for err := range errCh {
+			logger.ErrorCtx(ctx, "Kafka Dispatching Error", zap.Error(err))
+		}
+		return nil
+	})
+
+	promServer := meterutils.NewPrometheusServer(cfg.PrometheusConfig)
+
+	g.Go(func() error {
+		defer logger.InfoCtx(ctx, "Prometheus HTTP server stopped")
+		if err := promServer.Run(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("HTTP server error: %w", err)
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		defer logger.InfoCtx(ctx, "Prometheus HTTP server shutdown")
+		<-ctx.Done()
+		if err := promServer.Shutdown(ctx); err != nil {
+			return fmt.Errorf("shutdown HTTP server error: %w", err)
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return fmt.Errorf("goroutine error occured: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Message struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Value     []byte
+}
+
+// PartitionOffset holds the next offset to consume from a partition, i.e. the
+// last processed offset plus one, as Kafka expects it to be committed.
+type PartitionOffset struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+}
+
+func (m Message) NextOffset() PartitionOffset {
+	return PartitionOffset{
+		Topic:     m.Topic,
+		Partition: m.Partition,
+		Offset:    m.Offset + 1,
+	}
+}
+
+type Invoice struct {
+	ID         uuid.UUID
+	CustomerID uuid.UUID
+	Amount     int64
+	Currency   string
+	DueDate    time.Time
+	CreatedAt  time.Time
+	Notes      string
+}
+
+type InvoiceStatus string
+
+const (
+	NilInvoiceStatus      InvoiceStatus = ""
+	PendingInvoiceStatus  InvoiceStatus = "Pending"
+	ApprovedInvoiceStatus InvoiceStatus = "Approved"
+	RejectedInvoiceStatus InvoiceStatus = "Rejected"
+)
+
+type Recipient struct {
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Locale string `json:"locale"`
+}
+
+type NotificationKey struct {
+	InvoiceID uuid.UUID
+	EventType string
+}
+
+type ClaimResult string
+
+const (
+	ClaimResultClaimed     ClaimResult = "Claimed"
+	ClaimResultAlreadySent ClaimResult = "AlreadySent"
+	ClaimResultInProgress  ClaimResult = "InProgress"
+)
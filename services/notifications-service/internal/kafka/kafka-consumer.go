@@ -0,0 +1,146 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go-invoice-service/common/pkg/logging"
+	"go-invoice-service/common/pkg/timeutils"
+	"go.uber.org/zap"
+	"notifications-service/internal/dto"
+	"time"
+)
+
+var (
+	ErrPartitionEOF = errors.New("PartitionEOF has been reached")
+	ErrNoMessage    = errors.New("no messages polled")
+)
+
+type ConsumerMetrics interface {
+	IncKafkaTotalConsumedMessages(ctx context.Context, topic, consumerGroupID string)
+}
+
+type ConsumerConfig struct {
+	ServerAddress string
+	RetryAttempts []time.Duration
+}
+
+type KafkaConsumer struct {
+	cfg      ConsumerConfig
+	metrics  ConsumerMetrics
+	consumer *kafka.Consumer
+	groupID  string
+	logger   *logging.ZapLogger
+}
+
+func NewKafkaConsumer(
+	cfg ConsumerConfig,
+	metrics ConsumerMetrics,
+	groupID string,
+	topics []string,
+	logger *logging.ZapLogger,
+) (*KafkaConsumer, error) {
+	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":  cfg.ServerAddress,
+		"group.id":           groupID,
+		"enable.auto.commit": false,
+		"auto.offset.reset":  "earliest",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka consumer %w", err)
+	}
+	err = consumer.SubscribeTopics(topics, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to kafka topics: %w", err)
+	}
+	return &KafkaConsumer{
+		cfg:      cfg,
+		metrics:  metrics,
+		consumer: consumer,
+		groupID:  groupID,
+		logger:   logger,
+	}, nil
+}
+
+func (c *KafkaConsumer) Close() error {
+	return c.consumer.Close()
+}
+
+func (c *KafkaConsumer) PeekNext(pollTimeoutMs int) (dto.Message, error) {
+	ev := c.consumer.Poll(pollTimeoutMs)
+	switch e := ev.(type) {
+	case *kafka.Message:
+		var topic string
+		if e.TopicPartition.Topic != nil {
+			topic = *e.TopicPartition.Topic
+		}
+		return dto.Message{
+			Topic:     topic,
+			Partition: e.TopicPartition.Partition,
+			Offset:    int64(e.TopicPartition.Offset),
+			Value:     e.Value,
+		}, nil
+
+	case kafka.PartitionEOF:
+		return dto.Message{}, ErrPartitionEOF
+
+	case kafka.Error:
+		return dto.Message{}, e
+
+	case nil:
+		return dto.Message{}, ErrNoMessage
+	}
+
+	return dto.Message{}, errors.New("unknown kafka event")
+}
+
+func (c *KafkaConsumer) CommitOffsets(ctx context.Context, offsets []dto.PartitionOffset) error {
+	tps := make([]kafka.TopicPartition, len(offsets))
+	for i, o := range offsets {
+		tps[i] = kafka.TopicPartition{
+			Topic:     &o.Topic,
+			Partition: o.Partition,
+			Offset:    kafka.Offset(o.Offset),
+		}
+	}
+	err := timeutils.Retry(
+		ctx,
+		c.cfg.RetryAttempts,
+		func(ctx context.Context) error {
+			c.logger.InfoCtx(ctx, "commiting kafka offsets")
+			_, err := c.consumer.CommitOffsets(tps)
+			return err
+		},
+		func(ctx context.Context, err error) bool {
+			c.logger.ErrorCtx(ctx, "kafka commit offsets fail", zap.Error(err))
+			return true
+		},
+		true,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to commit offsets %w", err)
+	}
+	c.logger.InfoCtx(ctx, "kafka offsets commited")
+	for _, o := range offsets {
+		c.metrics.IncKafkaTotalConsumedMessages(ctx, o.Topic, c.groupID)
+	}
+	return nil
+}
+
+// Rewind seeks the partition of msg back to it, so the next poll returns the message again.
+func (c *KafkaConsumer) Rewind(msg dto.Message) error {
+	err := c.consumer.Seek(kafka.TopicPartition{
+		Topic:     &msg.Topic,
+		Partition: msg.Partition,
+		Offset:    kafka.Offset(msg.Offset),
+	}, 0)
+	if err != nil {
+		return fmt.Errorf("failed to seek back to message: %w", err)
+	}
+	return nil
+}
+
+func (c *KafkaConsumer) ErrIsNoMessage(err error) bool {
+	return errors.Is(err, ErrNoMessage)
+}
@@ -0,0 +1,39 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileSender writes every message to its own .eml file instead of sending it.
+type FileSender struct {
+	dir string
+}
+
+func NewFileSender(dir string) (*FileSender, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	return &FileSender{
+		dir: dir,
+	}, nil
+}
+
+func (s *FileSender) Send(_ context.Context, msg Message) error {
+	now := time.Now()
+	data, err := Format(msg, now)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%d-%s.eml", now.UnixNano(), strings.NewReplacer("/", "_", "@", "_").Replace(msg.ID))
+	if err := os.WriteFile(filepath.Join(s.dir, name), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write email file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package mail
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// MemorySender keeps sent messages in memory, it is meant for tests.
+type MemorySender struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemorySender() *MemorySender {
+	return &MemorySender{}
+}
+
+func (s *MemorySender) Send(_ context.Context, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+func (s *MemorySender) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.messages)
+}
@@ -0,0 +1,46 @@
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"time"
+)
+
+type Message struct {
+	// ID is used as the Message-ID header, so receivers can drop duplicates too.
+	ID      string
+	From    string
+	To      mail.Address
+	Subject string
+	Body    string
+}
+
+// Format renders the message as an RFC 5322 plain text email.
+func Format(msg Message, date time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", msg.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	if msg.ID != "" {
+		fmt.Fprintf(&buf, "Message-ID: <%s>\r\n", msg.ID)
+	}
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	buf.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(msg.Body)); err != nil {
+		return nil, fmt.Errorf("failed to encode message body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode message body: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,51 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+type SMTPConfig struct {
+	ServerAddress string
+	Username      string
+	Password      string `json:"-"`
+}
+
+type SMTPSender struct {
+	cfg  SMTPConfig
+	auth smtp.Auth
+}
+
+func NewSMTPSender(cfg SMTPConfig) (*SMTPSender, error) {
+	host, _, err := net.SplitHostPort(cfg.ServerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid smtp server address: %w", err)
+	}
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, host)
+	}
+
+	return &SMTPSender{
+		cfg:  cfg,
+		auth: auth,
+	}, nil
+}
+
+func (s *SMTPSender) Send(_ context.Context, msg Message) error {
+	data, err := Format(msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	err = smtp.SendMail(s.cfg.ServerAddress, s.auth, msg.From, []string{msg.To.Address}, data)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
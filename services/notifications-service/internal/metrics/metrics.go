@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type MetricsCollector struct {
+	kafkaTotalConsumedMessages metric.Int64Counter
+	totalSentNotifications     metric.Int64Counter
+}
+
+func MustInitCustomMetric() *MetricsCollector {
+	metricProvider := otel.GetMeterProvider()
+
+	m := &MetricsCollector{}
+
+	// Kafka.
+	kafkaMeter := metricProvider.Meter("kafka")
+
+	m.kafkaTotalConsumedMessages = must(
+		kafkaMeter.Int64Counter(
+			"kafka_total_consumed_messages",
+			metric.WithDescription("Total Kafka consumed messages"),
+		),
+	)
+
+	// Notifications.
+	notificationsMeter := metricProvider.Meter("notifications")
+
+	m.totalSentNotifications = must(
+		notificationsMeter.Int64Counter(
+			"total_sent_notifications",
+			metric.WithDescription("Total sent notifications"),
+		),
+	)
+
+	return m
+}
+
+func must[TMetric any](res TMetric, err error) TMetric {
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func (m *MetricsCollector) IncKafkaTotalConsumedMessages(ctx context.Context, topic, consumerGroupID string) {
+	attrSet := attribute.NewSet(
+		attribute.KeyValue{Key: "topic", Value: attribute.StringValue(topic)},
+		attribute.KeyValue{Key: "consumer-group-id", Value: attribute.StringValue(consumerGroupID)},
+	)
+	m.kafkaTotalConsumedMessages.Add(ctx, 1, metric.WithAttributeSet(attrSet))
+}
+
+func (m *MetricsCollector) IncTotalSentNotifications(ctx context.Context, eventType string, locale string) {
+	attrSet := attribute.NewSet(
+		attribute.KeyValue{Key: "event_type", Value: attribute.StringValue(eventType)},
+		attribute.KeyValue{Key: "locale", Value: attribute.StringValue(locale)},
+	)
+	m.totalSentNotifications.Add(ctx, 1, metric.WithAttributeSet(attrSet))
+}
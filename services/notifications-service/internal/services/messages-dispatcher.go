@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go-invoice-service/common/pkg/logging"
+	"go-invoice-service/common/pkg/timeutils"
+	protocol "go-invoice-service/common/protocol/kafka"
+	"go.uber.org/zap"
+	netmail "net/mail"
+	"notifications-service/internal/dto"
+	"notifications-service/internal/mail"
+	"notifications-service/internal/templates"
+	"time"
+)
+
+var (
+	ErrNotificationInProgress = errors.New("notification is being sent by another consumer")
+	ErrInvalidMessage         = errors.New("invalid message")
+)
+
+type MessagesDispatcherConfig struct {
+	PollTimeoutMs int
+	From          string
+	// LeaseFor is how long a claimed notification is reserved for sending. If the consumer
+	// crashes before marking it sent, the notification is sent again after the lease.
+	LeaseFor time.Duration
+	// MaxAttempts limits handling of a message in place, doubling the delay from RetryBackoff.
+	// A message still failing is polled again, it is not committed.
+	MaxAttempts  int
+	RetryBackoff time.Duration
+}
+
+type NotificationStorage interface {
+	GetInvoice(ctx context.Context, id uuid.UUID) (*dto.Invoice, dto.InvoiceStatus, error)
+	Claim(ctx context.Context, key dto.NotificationKey, recipient string, leaseFor time.Duration) (dto.ClaimResult, error)
+	MarkSent(ctx context.Context, key dto.NotificationKey) error
+	Release(ctx context.Context, key dto.NotificationKey) error
+}
+
+type RecipientDirectory interface {
+	Get(customerID uuid.UUID) (dto.Recipient, bool)
+}
+
+type MessageRenderer interface {
+	Render(locale string, eventType string, data templates.Data) (string, string, string, error)
+}
+
+type MailSender interface {
+	Send(ctx context.Context, msg mail.Message) error
+}
+
+type MessageConsumer interface {
+	PeekNext(pollTimeoutMs int) (dto.Message, error)
+	CommitOffsets(ctx context.Context, offsets []dto.PartitionOffset) error
+	Rewind(msg dto.Message) error
+	ErrIsNoMessage(error) bool
+}
+
+type NotificationsMetrics interface {
+	IncTotalSentNotifications(ctx context.Context, eventType string, locale string)
+}
+
+type MessagesDispatcher struct {
+	cfg                 MessagesDispatcherConfig
+	notificationStorage NotificationStorage
+	recipients          RecipientDirectory
+	renderer            MessageRenderer
+	sender              MailSender
+	messageConsumer     MessageConsumer
+	metrics             NotificationsMetrics
+	logger              *logging.ZapLogger
+}
+
+func NewMessagesDispatcher(
+	cfg MessagesDispatcherConfig,
+	notificationStorage NotificationStorage,
+	recipients RecipientDirectory,
+	renderer MessageRenderer,
+	sender MailSender,
+	messageConsumer MessageConsumer,
+	metrics NotificationsMetrics,
+	logger *logging.ZapLogger,
+) *MessagesDispatcher {
+	return &MessagesDispatcher{
+		cfg:                 cfg,
+		notificationStorage: notificationStorage,
+		recipients:          recipients,
+		renderer:            renderer,
+		sender:              sender,
+		messageConsumer:     messageConsumer,
+		metrics:             metrics,
+		logger:              logger,
+	}
+}
+
+func (d *MessagesDispatcher) Run(ctx context.Context) <-chan error {
+	errCh := make(chan error)
+
+	go func(ctx context.Context) {
+		defer close(errCh)
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+
+			err := d.tick(ctx)
+
+			if err != nil && !d.messageConsumer.ErrIsNoMessage(err) {
+				errCh <- err
+			}
+		}
+	}(ctx)
+
+	return errCh
+}
+
+func (d *MessagesDispatcher) tick(ctx context.Context) error {
+	msg, err := d.messageConsumer.PeekNext(d.cfg.PollTimeoutMs)
+	if err != nil {
+		return err
+	}
+
+	err = d.handleWithRetry(ctx, msg)
+	if errors.Is(err, ErrInvalidMessage) {
+		d.logger.ErrorCtx(ctx, "invalid message skipped",
+			zap.String("topic", msg.Topic),
+			zap.Int32("partition", msg.Partition),
+			zap.Int64("offset", msg.Offset),
+			zap.Error(err),
+		)
+	} else if err != nil {
+		// The offset is not committed past a failed message: it is polled again.
+		if rewindErr := d.messageConsumer.Rewind(msg); rewindErr != nil {
+			return errors.Join(err, rewindErr)
+		}
+		return err
+	}
+
+	return d.messageConsumer.CommitOffsets(ctx, []dto.PartitionOffset{msg.NextOffset()})
+}
+
+func (d *MessagesDispatcher) handleWithRetry(ctx context.Context, msg dto.Message) error {
+	backoff := d.cfg.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := d.HandleMessage(ctx, msg)
+		if err == nil || attempt >= d.cfg.MaxAttempts || errors.Is(err, ErrInvalidMessage) {
+			return err
+		}
+		d.logger.WarnCtx(ctx, "message handling failed, retrying",
+			zap.String("topic", msg.Topic),
+			zap.Int32("partition", msg.Partition),
+			zap.Int64("offset", msg.Offset),
+			zap.Int("attempt", attempt),
+			zap.Error(err),
+		)
+		if err := timeutils.SleepCtx(ctx, backoff); err != nil {
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// HandleMessage emails the customer about an approved or rejected invoice. The notification
+// is claimed in the storage before sending, so a redelivered message is not emailed twice.
+func (d *MessagesDispatcher) HandleMessage(ctx context.Context, msg dto.Message) error {
+	var invoiceID uuid.UUID
+	var snapshot *protocol.InvoiceSnapshot
+	switch protocol.Topic(msg.Topic) {
+	case protocol.TopicInvoiceApproved:
+		approved, err := protocol.DecodeEnvelope[protocol.ApprovedInvoice](msg.Value)
+		if err != nil {
+			return fmt.Errorf("%w: failed to decode approved invoice: %w", ErrInvalidMessage, err)
+		}
+		invoiceID, snapshot = approved.Data.ID, approved.Data.Invoice
+	case protocol.TopicInvoiceRejected:
+		rejected, err := protocol.DecodeEnvelope[protocol.RejectedInvoice](msg.Value)
+		if err != nil {
+			return fmt.Errorf("%w: failed to decode rejected invoice: %w", ErrInvalidMessage, err)
+		}
+		invoiceID, snapshot = rejected.Data.ID, rejected.Data.Invoice
+	default:
+		d.logger.InfoCtx(ctx, "unexpected topic skipped", zap.String("topic", msg.Topic))
+		return nil
+	}
+
+	// Events carrying a snapshot need no call to the storage.
+	invoice := invoiceFromSnapshot(invoiceID, snapshot)
+	if invoice == nil {
+		var err error
+		invoice, _, err = d.notificationStorage.GetInvoice(ctx, invoiceID)
+		if err != nil {
+			return fmt.Errorf("failed to get invoice: %w", err)
+		}
+	}
+
+	recipient, ok := d.recipients.Get(invoice.CustomerID)
+	if !ok {
+		d.logger.InfoCtx(ctx, "customer has no recipient, notification skipped",
+			zap.String("id", invoice.ID.String()),
+			zap.String("customer_id", invoice.CustomerID.String()),
+		)
+		return nil
+	}
+
+	key := dto.NotificationKey{
+		InvoiceID: invoice.ID,
+		EventType: msg.Topic,
+	}
+
+	claim, err := d.notificationStorage.Claim(ctx, key, recipient.Email, d.cfg.LeaseFor)
+	if err != nil {
+		return fmt.Errorf("failed to claim notification: %w", err)
+	}
+	switch claim {
+	case dto.ClaimResultAlreadySent:
+		d.logger.InfoCtx(ctx, "duplicated notification skipped", zap.String("id", invoice.ID.String()))
+		return nil
+	case dto.ClaimResultInProgress:
+		return fmt.Errorf("%w: invoice %s", ErrNotificationInProgress, invoice.ID)
+	}
+
+	locale, err := d.send(ctx, key, recipient, invoice)
+	if err != nil {
+		if releaseErr := d.notificationStorage.Release(ctx, key); releaseErr != nil {
+			return errors.Join(err, releaseErr)
+		}
+		return err
+	}
+
+	// The email is sent already: if marking fails, it is sent again once the lease expires.
+	if err := d.notificationStorage.MarkSent(ctx, key); err != nil {
+		return fmt.Errorf("failed to mark notification sent: %w", err)
+	}
+	d.metrics.IncTotalSentNotifications(ctx, key.EventType, locale)
+	d.logger.InfoCtx(ctx, "notification sent",
+		zap.String("id", invoice.ID.String()),
+		zap.String("event_type", key.EventType),
+	)
+
+	return nil
+}
+
+func (d *MessagesDispatcher) send(
+	ctx context.Context,
+	key dto.NotificationKey,
+	recipient dto.Recipient,
+	invoice *dto.Invoice,
+) (string, error) {
+	subject, body, locale, err := d.renderer.Render(recipient.Locale, key.EventType, templates.Data{
+		Recipient: recipient,
+		Invoice:   *invoice,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render notification: %w", err)
+	}
+
+	err = d.sender.Send(ctx, mail.Message{
+		ID:   fmt.Sprintf("%s.%s@notifications-service", key.InvoiceID, key.EventType),
+		From: d.cfg.From,
+		To: netmail.Address{
+			Name:    recipient.Name,
+			Address: recipient.Email,
+		},
+		Subject: subject,
+		Body:    body,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to send notification: %w", err)
+	}
+
+	return locale, nil
+}
+
+func invoiceFromSnapshot(id uuid.UUID, snapshot *protocol.InvoiceSnapshot) *dto.Invoice {
+	if snapshot == nil {
+		return nil
+	}
+	return &dto.Invoice{
+		ID:         id,
+		CustomerID: snapshot.CustomerID,
+		Amount:     snapshot.Amount,
+		Currency:   snapshot.Currency,
+		DueDate:    snapshot.DueDate,
+		CreatedAt:  snapshot.CreatedAt,
+		Notes:      snapshot.Notes,
+	}
+}
@@ -0,0 +1,388 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-invoice-service/common/pkg/logging"
+	protocol "go-invoice-service/common/protocol/kafka"
+	"go.uber.org/mock/gomock"
+	"notifications-service/internal/dto"
+	"notifications-service/internal/mail"
+	mock_services "notifications-service/internal/services/mocks"
+	"notifications-service/internal/templates"
+	"testing"
+	"time"
+)
+
+func TestMessagesDispatcher_HandleMessage(t *testing.T) {
+	invoiceID := uuid.New()
+	customerID := uuid.New()
+
+	tests := []struct {
+		name         string
+		topic        protocol.Topic
+		messageBody  []byte
+		snapshot     bool
+		hasRecipient bool
+		claimResult  dto.ClaimResult
+		claimError   error
+		markError    error
+		wantSent     int
+		wantRelease  bool
+		resultCheck  func(*testing.T, error)
+	}{
+		{
+			name:         "success_approved",
+			topic:        protocol.TopicInvoiceApproved,
+			messageBody:  messageFromId(invoiceID),
+			hasRecipient: true,
+			claimResult:  dto.ClaimResultClaimed,
+			wantSent:     1,
+			resultCheck: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:         "success_rejected",
+			topic:        protocol.TopicInvoiceRejected,
+			messageBody:  messageFromId(invoiceID),
+			hasRecipient: true,
+			claimResult:  dto.ClaimResultClaimed,
+			wantSent:     1,
+			resultCheck: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:         "success_approved_snapshot",
+			topic:        protocol.TopicInvoiceApproved,
+			messageBody:  snapshotMessage(protocol.ApprovedInvoice{ID: invoiceID, Invoice: createSnapshot(customerID)}),
+			snapshot:     true,
+			hasRecipient: true,
+			claimResult:  dto.ClaimResultClaimed,
+			wantSent:     1,
+			resultCheck: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:         "success_rejected_snapshot",
+			topic:        protocol.TopicInvoiceRejected,
+			messageBody:  snapshotMessage(protocol.RejectedInvoice{ID: invoiceID, Invoice: createSnapshot(customerID)}),
+			snapshot:     true,
+			hasRecipient: true,
+			claimResult:  dto.ClaimResultClaimed,
+			wantSent:     1,
+			resultCheck: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:         "already_sent_skipped",
+			topic:        protocol.TopicInvoiceApproved,
+			messageBody:  messageFromId(invoiceID),
+			hasRecipient: true,
+			claimResult:  dto.ClaimResultAlreadySent,
+			resultCheck: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:         "in_progress_error",
+			topic:        protocol.TopicInvoiceApproved,
+			messageBody:  messageFromId(invoiceID),
+			hasRecipient: true,
+			claimResult:  dto.ClaimResultInProgress,
+			resultCheck: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrNotificationInProgress)
+			},
+		},
+		{
+			name:        "no_recipient_skipped",
+			topic:       protocol.TopicInvoiceApproved,
+			messageBody: messageFromId(invoiceID),
+			resultCheck: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:         "claim_error",
+			topic:        protocol.TopicInvoiceApproved,
+			messageBody:  messageFromId(invoiceID),
+			hasRecipient: true,
+			claimError:   errors.New("test storage error"),
+			resultCheck: func(t *testing.T, err error) {
+				require.Error(t, err)
+			},
+		},
+		{
+			name:         "mark_sent_error",
+			topic:        protocol.TopicInvoiceApproved,
+			messageBody:  messageFromId(invoiceID),
+			hasRecipient: true,
+			claimResult:  dto.ClaimResultClaimed,
+			markError:    errors.New("test storage error"),
+			wantSent:     1,
+			resultCheck: func(t *testing.T, err error) {
+				require.Error(t, err)
+			},
+		},
+		{
+			name:        "invalid_message_error",
+			topic:       protocol.TopicInvoiceApproved,
+			messageBody: []byte(`}invalid JSON {`),
+			resultCheck: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidMessage)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+
+			cfg := MessagesDispatcherConfig{
+				PollTimeoutMs: 100,
+				From:          "Invoice Service <noreply@example.com>",
+				LeaseFor:      time.Minute,
+			}
+
+			storage := mock_services.NewMockNotificationStorage(ctl)
+			messagesConsumer := mock_services.NewMockMessageConsumer(ctl)
+			metrics := mock_services.NewMockNotificationsMetrics(ctl)
+			sender := mail.NewMemorySender()
+			logger := logging.NewNopLogger()
+
+			renderer, err := templates.NewRenderer("en")
+			require.NoError(t, err)
+
+			recipients := NewRecipients(nil)
+			if test.hasRecipient {
+				recipients = NewRecipients(map[uuid.UUID]dto.Recipient{
+					customerID: {Name: "Jane", Email: "jane@example.com", Locale: "de-AT"},
+				})
+			}
+
+			if json.Valid(test.messageBody) && !test.snapshot {
+				storage.EXPECT().
+					GetInvoice(gomock.Any(), invoiceID).
+					Return(createInvoiceDTO(invoiceID, customerID), dto.ApprovedInvoiceStatus, nil).
+					Times(1)
+			}
+
+			if test.hasRecipient {
+				key := dto.NotificationKey{InvoiceID: invoiceID, EventType: string(test.topic)}
+				storage.EXPECT().
+					Claim(gomock.Any(), key, "jane@example.com", cfg.LeaseFor).
+					Return(test.claimResult, test.claimError).
+					Times(1)
+
+				if test.claimError == nil && test.claimResult == dto.ClaimResultClaimed {
+					storage.EXPECT().
+						MarkSent(gomock.Any(), key).
+						Return(test.markError).
+						Times(1)
+				}
+				if test.markError == nil && test.wantSent > 0 {
+					metrics.EXPECT().
+						IncTotalSentNotifications(gomock.Any(), string(test.topic), "de").
+						Times(1)
+				}
+			}
+
+			messagesDispatcher := NewMessagesDispatcher(
+				cfg,
+				storage,
+				recipients,
+				renderer,
+				sender,
+				messagesConsumer,
+				metrics,
+				logger,
+			)
+
+			err = messagesDispatcher.HandleMessage(context.Background(), dto.Message{
+				Topic: string(test.topic),
+				Value: test.messageBody,
+			})
+			test.resultCheck(t, err)
+
+			messages := sender.Messages()
+			require.Len(t, messages, test.wantSent)
+			for _, msg := range messages {
+				assert.Equal(t, "jane@example.com", msg.To.Address)
+				assert.Contains(t, msg.Subject, invoiceID.String())
+				assert.Contains(t, msg.Body, "Hallo Jane")
+			}
+		})
+	}
+}
+
+// flakySender fails the first failures sends.
+type flakySender struct {
+	*mail.MemorySender
+	failures int
+}
+
+func (s *flakySender) Send(ctx context.Context, msg mail.Message) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("test smtp error")
+	}
+	return s.MemorySender.Send(ctx, msg)
+}
+
+func TestMessagesDispatcher_Tick(t *testing.T) {
+	invoiceID := uuid.New()
+	customerID := uuid.New()
+	topic := string(protocol.TopicInvoiceApproved)
+	key := dto.NotificationKey{InvoiceID: invoiceID, EventType: topic}
+
+	tests := []struct {
+		name         string
+		messageBody  []byte
+		sendFailures int
+		wantAttempts int
+		wantSent     int
+		wantCommit   bool
+		wantErr      bool
+	}{
+		{
+			name:         "send_failure_retried",
+			messageBody:  snapshotMessage(protocol.ApprovedInvoice{ID: invoiceID, Invoice: createSnapshot(customerID)}),
+			sendFailures: 2,
+			wantAttempts: 3,
+			wantSent:     1,
+			wantCommit:   true,
+		},
+		{
+			name:         "send_failures_exhausted_rewound",
+			messageBody:  snapshotMessage(protocol.ApprovedInvoice{ID: invoiceID, Invoice: createSnapshot(customerID)}),
+			sendFailures: 3,
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+		{
+			name:        "invalid_message_committed",
+			messageBody: []byte(`}invalid JSON {`),
+			wantCommit:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+
+			cfg := MessagesDispatcherConfig{
+				PollTimeoutMs: 100,
+				From:          "Invoice Service <noreply@example.com>",
+				LeaseFor:      time.Minute,
+				MaxAttempts:   3,
+				RetryBackoff:  time.Millisecond,
+			}
+
+			storage := mock_services.NewMockNotificationStorage(ctl)
+			messagesConsumer := mock_services.NewMockMessageConsumer(ctl)
+			metrics := mock_services.NewMockNotificationsMetrics(ctl)
+			sender := &flakySender{MemorySender: mail.NewMemorySender(), failures: test.sendFailures}
+
+			renderer, err := templates.NewRenderer("en")
+			require.NoError(t, err)
+			recipients := NewRecipients(map[uuid.UUID]dto.Recipient{
+				customerID: {Name: "Jane", Email: "jane@example.com", Locale: "de-AT"},
+			})
+
+			msg := dto.Message{Topic: topic, Partition: 3, Offset: 7, Value: test.messageBody}
+			messagesConsumer.EXPECT().PeekNext(cfg.PollTimeoutMs).Return(msg, nil)
+
+			if test.wantAttempts > 0 {
+				storage.EXPECT().
+					Claim(gomock.Any(), key, "jane@example.com", cfg.LeaseFor).
+					Return(dto.ClaimResultClaimed, nil).
+					Times(test.wantAttempts)
+				storage.EXPECT().
+					Release(gomock.Any(), key).
+					Return(nil).
+					Times(min(test.sendFailures, test.wantAttempts))
+			}
+			if test.wantSent > 0 {
+				storage.EXPECT().MarkSent(gomock.Any(), key).Return(nil)
+				metrics.EXPECT().IncTotalSentNotifications(gomock.Any(), topic, "de")
+			}
+			if test.wantCommit {
+				messagesConsumer.EXPECT().
+					CommitOffsets(gomock.Any(), []dto.PartitionOffset{{Topic: topic, Partition: 3, Offset: 8}}).
+					Return(nil)
+			} else {
+				messagesConsumer.EXPECT().Rewind(msg).Return(nil)
+			}
+
+			messagesDispatcher := NewMessagesDispatcher(
+				cfg,
+				storage,
+				recipients,
+				renderer,
+				sender,
+				messagesConsumer,
+				metrics,
+				logging.NewNopLogger(),
+			)
+
+			err = messagesDispatcher.tick(context.Background())
+			if test.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Len(t, sender.Messages(), test.wantSent)
+		})
+	}
+}
+
+func messageFromId(id uuid.UUID) []byte {
+	val := protocol.ApprovedInvoice{
+		ID: id,
+	}
+	res, err := json.Marshal(val)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func snapshotMessage[T protocol.Event](data T) []byte {
+	res, err := json.Marshal(protocol.NewEnvelope(data, time.Now(), "correlation-id"))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func createSnapshot(customerID uuid.UUID) *protocol.InvoiceSnapshot {
+	return &protocol.InvoiceSnapshot{
+		CustomerID: customerID,
+		Amount:     1234500,
+		Currency:   "EUR",
+		DueDate:    time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC),
+		Status:     string(dto.ApprovedInvoiceStatus),
+		CreatedAt:  time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func createInvoiceDTO(id uuid.UUID, customerID uuid.UUID) *dto.Invoice {
+	return &dto.Invoice{
+		ID:         id,
+		CustomerID: customerID,
+		Amount:     1234500,
+		Currency:   "EUR",
+		DueDate:    time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:  time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
@@ -0,0 +1,340 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/services/messages-dispatcher.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/services/messages-dispatcher.go -destination=./internal/services/mocks/mock_messages-dispatcher.go
+//
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	dto "notifications-service/internal/dto"
+	mail "notifications-service/internal/mail"
+	templates "notifications-service/internal/templates"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotificationStorage is a mock of NotificationStorage interface.
+type MockNotificationStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationStorageMockRecorder is the mock recorder for MockNotificationStorage.
+type MockNotificationStorageMockRecorder struct {
+	mock *MockNotificationStorage
+}
+
+// NewMockNotificationStorage creates a new mock instance.
+func NewMockNotificationStorage(ctrl *gomock.Controller) *MockNotificationStorage {
+	mock := &MockNotificationStorage{ctrl: ctrl}
+	mock.recorder = &MockNotificationStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationStorage) EXPECT() *MockNotificationStorageMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockNotificationStorage) Claim(ctx context.Context, key dto.NotificationKey, recipient string, leaseFor time.Duration) (dto.ClaimResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, key, recipient, leaseFor)
+	ret0, _ := ret[0].(dto.ClaimResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockNotificationStorageMockRecorder) Claim(ctx, key, recipient, leaseFor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockNotificationStorage)(nil).Claim), ctx, key, recipient, leaseFor)
+}
+
+// GetInvoice mocks base method.
+func (m *MockNotificationStorage) GetInvoice(ctx context.Context, id uuid.UUID) (*dto.Invoice, dto.InvoiceStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoice", ctx, id)
+	ret0, _ := ret[0].(*dto.Invoice)
+	ret1, _ := ret[1].(dto.InvoiceStatus)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetInvoice indicates an expected call of GetInvoice.
+func (mr *MockNotificationStorageMockRecorder) GetInvoice(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockNotificationStorage)(nil).GetInvoice), ctx, id)
+}
+
+// MarkSent mocks base method.
+func (m *MockNotificationStorage) MarkSent(ctx context.Context, key dto.NotificationKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockNotificationStorageMockRecorder) MarkSent(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockNotificationStorage)(nil).MarkSent), ctx, key)
+}
+
+// Release mocks base method.
+func (m *MockNotificationStorage) Release(ctx context.Context, key dto.NotificationKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockNotificationStorageMockRecorder) Release(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockNotificationStorage)(nil).Release), ctx, key)
+}
+
+// MockRecipientDirectory is a mock of RecipientDirectory interface.
+type MockRecipientDirectory struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecipientDirectoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRecipientDirectoryMockRecorder is the mock recorder for MockRecipientDirectory.
+type MockRecipientDirectoryMockRecorder struct {
+	mock *MockRecipientDirectory
+}
+
+// NewMockRecipientDirectory creates a new mock instance.
+func NewMockRecipientDirectory(ctrl *gomock.Controller) *MockRecipientDirectory {
+	mock := &MockRecipientDirectory{ctrl: ctrl}
+	mock.recorder = &MockRecipientDirectoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecipientDirectory) EXPECT() *MockRecipientDirectoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockRecipientDirectory) Get(customerID uuid.UUID) (dto.Recipient, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", customerID)
+	ret0, _ := ret[0].(dto.Recipient)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRecipientDirectoryMockRecorder) Get(customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRecipientDirectory)(nil).Get), customerID)
+}
+
+// MockMessageRenderer is a mock of MessageRenderer interface.
+type MockMessageRenderer struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageRendererMockRecorder
+	isgomock struct{}
+}
+
+// MockMessageRendererMockRecorder is the mock recorder for MockMessageRenderer.
+type MockMessageRendererMockRecorder struct {
+	mock *MockMessageRenderer
+}
+
+// NewMockMessageRenderer creates a new mock instance.
+func NewMockMessageRenderer(ctrl *gomock.Controller) *MockMessageRenderer {
+	mock := &MockMessageRenderer{ctrl: ctrl}
+	mock.recorder = &MockMessageRendererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageRenderer) EXPECT() *MockMessageRendererMockRecorder {
+	return m.recorder
+}
+
+// Render mocks base method.
+func (m *MockMessageRenderer) Render(locale, eventType string, data templates.Data) (string, string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Render", locale, eventType, data)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// Render indicates an expected call of Render.
+func (mr *MockMessageRendererMockRecorder) Render(locale, eventType, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockMessageRenderer)(nil).Render), locale, eventType, data)
+}
+
+// MockMailSender is a mock of MailSender interface.
+type MockMailSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockMailSenderMockRecorder is the mock recorder for MockMailSender.
+type MockMailSenderMockRecorder struct {
+	mock *MockMailSender
+}
+
+// NewMockMailSender creates a new mock instance.
+func NewMockMailSender(ctrl *gomock.Controller) *MockMailSender {
+	mock := &MockMailSender{ctrl: ctrl}
+	mock.recorder = &MockMailSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMailSender) EXPECT() *MockMailSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockMailSender) Send(ctx context.Context, msg mail.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockMailSenderMockRecorder) Send(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMailSender)(nil).Send), ctx, msg)
+}
+
+// MockMessageConsumer is a mock of MessageConsumer interface.
+type MockMessageConsumer struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageConsumerMockRecorder
+	isgomock struct{}
+}
+
+// MockMessageConsumerMockRecorder is the mock recorder for MockMessageConsumer.
+type MockMessageConsumerMockRecorder struct {
+	mock *MockMessageConsumer
+}
+
+// NewMockMessageConsumer creates a new mock instance.
+func NewMockMessageConsumer(ctrl *gomock.Controller) *MockMessageConsumer {
+	mock := &MockMessageConsumer{ctrl: ctrl}
+	mock.recorder = &MockMessageConsumerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageConsumer) EXPECT() *MockMessageConsumerMockRecorder {
+	return m.recorder
+}
+
+// CommitOffsets mocks base method.
+func (m *MockMessageConsumer) CommitOffsets(ctx context.Context, offsets []dto.PartitionOffset) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitOffsets", ctx, offsets)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitOffsets indicates an expected call of CommitOffsets.
+func (mr *MockMessageConsumerMockRecorder) CommitOffsets(ctx, offsets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitOffsets", reflect.TypeOf((*MockMessageConsumer)(nil).CommitOffsets), ctx, offsets)
+}
+
+// ErrIsNoMessage mocks base method.
+func (m *MockMessageConsumer) ErrIsNoMessage(arg0 error) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ErrIsNoMessage", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ErrIsNoMessage indicates an expected call of ErrIsNoMessage.
+func (mr *MockMessageConsumerMockRecorder) ErrIsNoMessage(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ErrIsNoMessage", reflect.TypeOf((*MockMessageConsumer)(nil).ErrIsNoMessage), arg0)
+}
+
+// PeekNext mocks base method.
+func (m *MockMessageConsumer) PeekNext(pollTimeoutMs int) (dto.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeekNext", pollTimeoutMs)
+	ret0, _ := ret[0].(dto.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PeekNext indicates an expected call of PeekNext.
+func (mr *MockMessageConsumerMockRecorder) PeekNext(pollTimeoutMs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeekNext", reflect.TypeOf((*MockMessageConsumer)(nil).PeekNext), pollTimeoutMs)
+}
+
+// Rewind mocks base method.
+func (m *MockMessageConsumer) Rewind(msg dto.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rewind", msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rewind indicates an expected call of Rewind.
+func (mr *MockMessageConsumerMockRecorder) Rewind(msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rewind", reflect.TypeOf((*MockMessageConsumer)(nil).Rewind), msg)
+}
+
+// MockNotificationsMetrics is a mock of NotificationsMetrics interface.
+type MockNotificationsMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationsMetricsMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationsMetricsMockRecorder is the mock recorder for MockNotificationsMetrics.
+type MockNotificationsMetricsMockRecorder struct {
+	mock *MockNotificationsMetrics
+}
+
+// NewMockNotificationsMetrics creates a new mock instance.
+func NewMockNotificationsMetrics(ctrl *gomock.Controller) *MockNotificationsMetrics {
+	mock := &MockNotificationsMetrics{ctrl: ctrl}
+	mock.recorder = &MockNotificationsMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationsMetrics) EXPECT() *MockNotificationsMetricsMockRecorder {
+	return m.recorder
+}
+
+// IncTotalSentNotifications mocks base method.
+func (m *MockNotificationsMetrics) IncTotalSentNotifications(ctx context.Context, eventType, locale string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncTotalSentNotifications", ctx, eventType, locale)
+}
+
+// IncTotalSentNotifications indicates an expected call of IncTotalSentNotifications.
+func (mr *MockNotificationsMetricsMockRecorder) IncTotalSentNotifications(ctx, eventType, locale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncTotalSentNotifications", reflect.TypeOf((*MockNotificationsMetrics)(nil).IncTotalSentNotifications), ctx, eventType, locale)
+}
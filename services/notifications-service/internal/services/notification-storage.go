@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	pb "go-invoice-service/common/protocol/proto/notifications"
+	"go-invoice-service/common/protocol/proto/types"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"notifications-service/internal/dto"
+	"time"
+)
+
+type NotificationStorageClient interface {
+	pb.NotificationStorageClient
+}
+
+type NotificationStorageService struct {
+	client NotificationStorageClient
+}
+
+func NewNotificationStorage(client NotificationStorageClient) *NotificationStorageService {
+	return &NotificationStorageService{
+		client: client,
+	}
+}
+
+func (s *NotificationStorageService) GetInvoice(ctx context.Context, id uuid.UUID) (*dto.Invoice, dto.InvoiceStatus, error) {
+	resp, err := s.client.GetInvoice(ctx, &pb.GetInvoiceRequest{
+		Id: uuidToProto(id),
+	})
+	if err != nil {
+		return nil, dto.NilInvoiceStatus, fmt.Errorf("failed to get invoice: %w", err)
+	}
+	invoice, err := invoiceFromProto(resp.GetInvoice())
+	if err != nil {
+		return nil, dto.NilInvoiceStatus, fmt.Errorf("failed to retrieve invoice: %w", err)
+	}
+	invoiceStatus, err := invoiceStatusFromProto(resp.GetStatus())
+	if err != nil {
+		return nil, dto.NilInvoiceStatus, fmt.Errorf("failed to retrieve invoice status: %w", err)
+	}
+	return invoice, invoiceStatus, nil
+}
+
+func (s *NotificationStorageService) Claim(
+	ctx context.Context,
+	key dto.NotificationKey,
+	recipient string,
+	leaseFor time.Duration,
+) (dto.ClaimResult, error) {
+	resp, err := s.client.ClaimNotification(ctx, &pb.ClaimNotificationRequest{
+		Key:       notificationKeyToProto(key),
+		Recipient: &recipient,
+		LeaseFor:  durationpb.New(leaseFor),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to claim notification: %w", err)
+	}
+
+	switch resp.GetResult() {
+	case pb.NotificationClaimResult_Claimed:
+		return dto.ClaimResultClaimed, nil
+	case pb.NotificationClaimResult_AlreadySent:
+		return dto.ClaimResultAlreadySent, nil
+	case pb.NotificationClaimResult_InProgress:
+		return dto.ClaimResultInProgress, nil
+	}
+	return "", fmt.Errorf("invalid notification claim result %s", resp.GetResult().String())
+}
+
+func (s *NotificationStorageService) MarkSent(ctx context.Context, key dto.NotificationKey) error {
+	_, err := s.client.MarkNotificationSent(ctx, notificationKeyToProto(key))
+	if err != nil {
+		return fmt.Errorf("failed to mark notification sent: %w", err)
+	}
+	return nil
+}
+
+func (s *NotificationStorageService) Release(ctx context.Context, key dto.NotificationKey) error {
+	_, err := s.client.ReleaseNotification(ctx, notificationKeyToProto(key))
+	if err != nil {
+		return fmt.Errorf("failed to release notification: %w", err)
+	}
+	return nil
+}
+
+func notificationKeyToProto(key dto.NotificationKey) *pb.NotificationKey {
+	return &pb.NotificationKey{
+		InvoiceId: uuidToProto(key.InvoiceID),
+		EventType: &key.EventType,
+	}
+}
+
+func invoiceStatusFromProto(status types.InvoiceStatus) (dto.InvoiceStatus, error) {
+	switch status {
+	case types.InvoiceStatus_Pending:
+		return dto.PendingInvoiceStatus, nil
+	case types.InvoiceStatus_Approved:
+		return dto.ApprovedInvoiceStatus, nil
+	case types.InvoiceStatus_Rejected:
+		return dto.RejectedInvoiceStatus, nil
+	}
+	return dto.NilInvoiceStatus, fmt.Errorf("invalid invoice status %s", status.String())
+}
+
+func invoiceFromProto(invoice *types.Invoice) (*dto.Invoice, error) {
+	id, err := uuidFromProto(invoice.GetId())
+	if err != nil {
+		return nil, err
+	}
+	customerID, err := uuidFromProto(invoice.GetCustomerId())
+	if err != nil {
+		return nil, err
+	}
+	return &dto.Invoice{
+		ID:         id,
+		CustomerID: customerID,
+		Amount:     invoice.GetAmount(),
+		Currency:   invoice.GetCurrency(),
+		DueDate:    invoice.GetDueDate().AsTime(),
+		CreatedAt:  invoice.GetCreatedAt().AsTime(),
+		Notes:      invoice.GetNotes(),
+	}, nil
+}
+
+func uuidFromProto(id *types.UUID) (uuid.UUID, error) {
+	res, err := uuid.FromBytes(id.GetValue())
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid uuid: %w", err)
+	}
+	return res, nil
+}
+
+func uuidToProto(id uuid.UUID) *types.UUID {
+	return &types.UUID{
+		Value: id[:],
+	}
+}
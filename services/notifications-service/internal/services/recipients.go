@@ -0,0 +1,47 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"net/mail"
+	"notifications-service/internal/dto"
+	"os"
+)
+
+// Recipients is a customer contacts directory loaded from a JSON file that maps
+// customer ids to recipients, since invoices store only the customer id.
+type Recipients struct {
+	recipients map[uuid.UUID]dto.Recipient
+}
+
+func NewRecipients(recipients map[uuid.UUID]dto.Recipient) *Recipients {
+	return &Recipients{
+		recipients: recipients,
+	}
+}
+
+func LoadRecipients(fileName string) (*Recipients, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recipients file: %w", err)
+	}
+
+	var recipients map[uuid.UUID]dto.Recipient
+	if err := json.Unmarshal(data, &recipients); err != nil {
+		return nil, fmt.Errorf("failed to decode recipients file: %w", err)
+	}
+
+	for customerID, recipient := range recipients {
+		if _, err := mail.ParseAddress(recipient.Email); err != nil {
+			return nil, fmt.Errorf("invalid email of customer %s: %w", customerID, err)
+		}
+	}
+
+	return NewRecipients(recipients), nil
+}
+
+func (r *Recipients) Get(customerID uuid.UUID) (dto.Recipient, bool) {
+	recipient, ok := r.recipients[customerID]
+	return recipient, ok
+}
@@ -0,0 +1,126 @@
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"notifications-service/internal/dto"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+//go:embed files
+var files embed.FS
+
+var ErrUnknownEventType = errors.New("no template for event type")
+
+type Data struct {
+	Recipient dto.Recipient
+	Invoice   dto.Invoice
+}
+
+// Renderer renders notification emails from files/<locale>/<event type>.tmpl. Every
+// template defines a "subject" and a "body".
+type Renderer struct {
+	defaultLocale string
+	// templates by locale and event type.
+	templates map[string]map[string]*template.Template
+}
+
+func NewRenderer(defaultLocale string) (*Renderer, error) {
+	res := &Renderer{
+		defaultLocale: normalizeLocale(defaultLocale),
+		templates:     make(map[string]map[string]*template.Template),
+	}
+
+	paths, err := fs.Glob(files, "files/*/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	for _, p := range paths {
+		locale := path.Base(path.Dir(p))
+		eventType := strings.TrimSuffix(path.Base(p), ".tmpl")
+
+		tmpl, err := template.New(path.Base(p)).
+			Funcs(template.FuncMap{"amount": formatAmount}).
+			Option("missingkey=error").
+			ParseFS(files, p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", p, err)
+		}
+		for _, name := range []string{"subject", "body"} {
+			if tmpl.Lookup(name) == nil {
+				return nil, fmt.Errorf("template %s does not define %q", p, name)
+			}
+		}
+
+		if res.templates[locale] == nil {
+			res.templates[locale] = make(map[string]*template.Template)
+		}
+		res.templates[locale][eventType] = tmpl
+	}
+
+	if res.templates[res.defaultLocale] == nil {
+		return nil, fmt.Errorf("no templates for default locale %s", defaultLocale)
+	}
+
+	return res, nil
+}
+
+// Render returns the subject and the body in the requested locale, falling back to its
+// language ("de-AT" to "de") and then to the default locale. The locale used is returned.
+func (r *Renderer) Render(locale string, eventType string, data Data) (string, string, string, error) {
+	tmpl, usedLocale := r.lookup(locale, eventType)
+	if tmpl == nil {
+		return "", "", "", fmt.Errorf("%w: %s", ErrUnknownEventType, eventType)
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return "", "", "", fmt.Errorf("failed to render subject: %w", err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return "", "", "", fmt.Errorf("failed to render body: %w", err)
+	}
+
+	return strings.TrimSpace(subject.String()), strings.TrimLeft(body.String(), "\n"), usedLocale, nil
+}
+
+func (r *Renderer) lookup(locale string, eventType string) (*template.Template, string) {
+	locale = normalizeLocale(locale)
+	candidates := []string{locale}
+	if language, _, ok := strings.Cut(locale, "-"); ok {
+		candidates = append(candidates, language)
+	}
+	candidates = append(candidates, r.defaultLocale)
+
+	for _, candidate := range candidates {
+		if tmpl := r.templates[candidate][eventType]; tmpl != nil {
+			return tmpl, candidate
+		}
+	}
+	return nil, ""
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+// formatAmount formats thousandths of a currency unit with at least two decimals.
+func formatAmount(amount int64, decimalSeparator string) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	fraction := fmt.Sprintf("%03d", amount%1000)
+	fraction = strings.TrimSuffix(fraction, "0")
+
+	return sign + strconv.FormatInt(amount/1000, 10) + decimalSeparator + fraction
+}
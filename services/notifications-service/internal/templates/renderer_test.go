@@ -0,0 +1,95 @@
+package templates
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"notifications-service/internal/dto"
+	"testing"
+	"time"
+)
+
+func TestRenderer_Render(t *testing.T) {
+	renderer, err := NewRenderer("en")
+	require.NoError(t, err)
+
+	data := Data{
+		Recipient: dto.Recipient{Name: "Jane"},
+		Invoice: dto.Invoice{
+			ID:       uuid.MustParse("53150a25-02f1-540a-99e7-48e267fd6d13"),
+			Amount:   1234567,
+			Currency: "EUR",
+			DueDate:  time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		locale      string
+		eventType   string
+		wantLocale  string
+		wantSubject string
+		wantBody    string
+		wantErr     error
+	}{
+		{
+			name:        "exact_locale",
+			locale:      "de",
+			eventType:   "invoice_approved",
+			wantLocale:  "de",
+			wantSubject: "Rechnung 53150a25-02f1-540a-99e7-48e267fd6d13 wurde freigegeben",
+			wantBody:    "über 1234,567 EUR wurde freigegeben.\nSie ist am 01.07.2025 fällig.",
+		},
+		{
+			name:        "language_fallback",
+			locale:      "de_AT",
+			eventType:   "invoice_approved",
+			wantLocale:  "de",
+			wantSubject: "Rechnung 53150a25-02f1-540a-99e7-48e267fd6d13 wurde freigegeben",
+		},
+		{
+			name:        "default_fallback",
+			locale:      "fr",
+			eventType:   "invoice_approved",
+			wantLocale:  "en",
+			wantSubject: "Invoice 53150a25-02f1-540a-99e7-48e267fd6d13 has been approved",
+			wantBody:    "for 1234.567 EUR has been approved.\nIt is due on July 1, 2025.",
+		},
+		{
+			name:      "unknown_event_type",
+			locale:    "en",
+			eventType: "new_invoice",
+			wantErr:   ErrUnknownEventType,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			subject, body, locale, err := renderer.Render(test.locale, test.eventType, data)
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.wantLocale, locale)
+			assert.Equal(t, test.wantSubject, subject)
+			assert.Contains(t, body, test.wantBody)
+		})
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount int64
+		want   string
+	}{
+		{amount: 1234500, want: "1234.50"},
+		{amount: 1234567, want: "1234.567"},
+		{amount: 1000, want: "1.00"},
+		{amount: -50, want: "-0.05"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, formatAmount(test.amount, "."))
+	}
+}
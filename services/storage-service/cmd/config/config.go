@@ -5,10 +5,15 @@ import (
 	"flag"
 	"fmt"
 	"go-invoice-service/common/pkg/flagtypes"
+	"go-invoice-service/common/protocol/kafka"
+	"math"
 	"os"
+	"slices"
 	"storage-service/internal/data/postgres"
 	"storage-service/internal/grpc"
+	"storage-service/internal/services"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -17,10 +22,37 @@ const (
 	postgresConnectionStringEnv  = "POSTGRES_CONNECTION_STRING"
 	grpcPortFlag                 = "grpc-port"
 	grpcPortEnv                  = "GRPC_PORT"
+	feedPollIntervalFlag         = "feed-poll-interval"
+	feedPollIntervalEnv          = "FEED_POLL_INTERVAL_MS"
+	feedBatchSizeFlag            = "feed-batch-size"
+	feedBatchSizeEnv             = "FEED_BATCH_SIZE"
+	outboxPayloadFormatFlag      = "outbox-payload-format"
+	outboxPayloadFormatEnv       = "OUTBOX_PAYLOAD_FORMAT"
+	schemaRegistryFileFlag       = "schema-registry-file"
+	schemaRegistryFileEnv        = "SCHEMA_REGISTRY_FILE"
+	eventDetailsFlag             = "event-details"
+	eventDetailsEnv              = "EVENT_DETAILS"
+	inboxTTLFlag                 = "inbox-ttl"
+	inboxTTLEnv                  = "INBOX_TTL_MS"
+	inboxCleanupIntervalFlag     = "inbox-cleanup-interval"
+	inboxCleanupIntervalEnv      = "INBOX_CLEANUP_INTERVAL_MS"
+	outboxKeepPublishedFlag      = "outbox-keep-published"
+	outboxKeepPublishedEnv       = "OUTBOX_KEEP_PUBLISHED"
+	outboxRetentionDaysFlag      = "outbox-retention-days"
+	outboxRetentionDaysEnv       = "OUTBOX_RETENTION_DAYS"
+	outboxArchiveFlag            = "outbox-archive"
+	outboxArchiveEnv             = "OUTBOX_ARCHIVE"
 )
 
 const (
-	defaultGRPCPort = 9090
+	defaultGRPCPort         = 9090
+	defaultFeedPollInterval = 500 * time.Millisecond
+	defaultFeedBatchSize    = 500
+	defaultPayloadFormat    = kafka.FormatJSON
+	defaultInboxTTL         = 7 * 24 * time.Hour
+	defaultInboxCleanup     = 1 * time.Minute
+	defaultInboxBatchSize   = 1000
+	defaultOutboxRetention  = 7
 )
 
 var defaultRetryAttempts = []time.Duration{
@@ -34,12 +66,33 @@ type Config struct {
 	PostgresConfig postgres.Config
 	GRPCConfig     grpc.Config
 	RetryAttempts  []time.Duration
+	FeedConfig     services.FeedConfig
+	PayloadFormat  kafka.Format
+	// SchemaRegistryFile is the file-based schema registry used with the protobuf payload format.
+	SchemaRegistryFile string
+	EventWriterConfig  services.EventWriterConfig
+	// InboxTTL is how long handled consumer events are remembered.
+	InboxTTL             time.Duration
+	InboxCleanupInterval time.Duration
+	InboxCleanupBatch    int
+	OutboxConfig         services.OutboxConfig
+	OutboxPartitions     services.OutboxPartitionsConfig
 }
 
 func Load() (*Config, error) {
 
 	postgresConnectionString := ""
 	grpcPort := defaultGRPCPort
+	feedPollInterval := defaultFeedPollInterval
+	feedBatchSize := defaultFeedBatchSize
+	payloadFormat := defaultPayloadFormat
+	schemaRegistryFile := ""
+	eventDetails := map[kafka.Topic]kafka.EventDetail{}
+	inboxTTL := defaultInboxTTL
+	inboxCleanupInterval := defaultInboxCleanup
+	outboxKeepPublished := false
+	outboxRetentionDays := defaultOutboxRetention
+	outboxArchive := false
 
 	// Flags Definition.
 
@@ -49,6 +102,36 @@ func Load() (*Config, error) {
 	grpcPortFlagVal := flagtypes.NewInt()
 	flag.Var(grpcPortFlagVal, grpcPortFlag, "gRPC port")
 
+	feedPollIntervalFlagVal := flagtypes.NewInt()
+	flag.Var(feedPollIntervalFlagVal, feedPollIntervalFlag, "Invoice events feed poll interval in milliseconds")
+
+	inboxTTLFlagVal := flagtypes.NewInt()
+	flag.Var(inboxTTLFlagVal, inboxTTLFlag, "Handled consumer events retention in milliseconds")
+
+	inboxCleanupIntervalFlagVal := flagtypes.NewInt()
+	flag.Var(inboxCleanupIntervalFlagVal, inboxCleanupIntervalFlag, "Expired handled consumer events cleanup interval in milliseconds")
+
+	outboxKeepPublishedFlagVal := flagtypes.NewBool()
+	flag.Var(outboxKeepPublishedFlagVal, outboxKeepPublishedFlag, "Mark sent outbox messages published instead of deleting them")
+
+	outboxRetentionDaysFlagVal := flagtypes.NewInt()
+	flag.Var(outboxRetentionDaysFlagVal, outboxRetentionDaysFlag, "Days outbox partitions are kept before they are dropped")
+
+	outboxArchiveFlagVal := flagtypes.NewBool()
+	flag.Var(outboxArchiveFlagVal, outboxArchiveFlag, "Archive published messages of dropped outbox partitions")
+
+	feedBatchSizeFlagVal := flagtypes.NewInt()
+	flag.Var(feedBatchSizeFlagVal, feedBatchSizeFlag, "Max invoice events read from the database at once")
+
+	outboxPayloadFormatFlagVal := flagtypes.NewString()
+	flag.Var(outboxPayloadFormatFlagVal, outboxPayloadFormatFlag, "Outbox payload format: json or protobuf")
+
+	schemaRegistryFileFlagVal := flagtypes.NewString()
+	flag.Var(schemaRegistryFileFlagVal, schemaRegistryFileFlag, "Schema registry file used with protobuf payloads")
+
+	eventDetailsFlagVal := flagtypes.NewString()
+	flag.Var(eventDetailsFlagVal, eventDetailsFlag, "Detail of events per topic, e.g. 'invoice_approved=snapshot,invoice_rejected=id'")
+
 	flag.Parse()
 
 	// Flags Parse.
@@ -61,6 +144,54 @@ func Load() (*Config, error) {
 		grpcPort = val
 	}
 
+	if val, ok := feedPollIntervalFlagVal.Value(); ok {
+		feedPollInterval = time.Duration(val) * time.Millisecond
+	}
+
+	if val, ok := inboxTTLFlagVal.Value(); ok {
+		inboxTTL = time.Duration(val) * time.Millisecond
+	}
+
+	if val, ok := inboxCleanupIntervalFlagVal.Value(); ok {
+		inboxCleanupInterval = time.Duration(val) * time.Millisecond
+	}
+
+	if val, ok := outboxKeepPublishedFlagVal.Value(); ok {
+		outboxKeepPublished = val
+	}
+
+	if val, ok := outboxRetentionDaysFlagVal.Value(); ok {
+		outboxRetentionDays = val
+	}
+
+	if val, ok := outboxArchiveFlagVal.Value(); ok {
+		outboxArchive = val
+	}
+
+	if val, ok := feedBatchSizeFlagVal.Value(); ok {
+		feedBatchSize = val
+	}
+
+	if val, ok := outboxPayloadFormatFlagVal.Value(); ok {
+		format, err := kafka.ParseFormat(val)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' flag parsing failed", err, outboxPayloadFormatFlag)
+		}
+		payloadFormat = format
+	}
+
+	if val, ok := schemaRegistryFileFlagVal.Value(); ok {
+		schemaRegistryFile = val
+	}
+
+	if val, ok := eventDetailsFlagVal.Value(); ok {
+		details, err := parseEventDetails(val)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' flag parsing failed", err, eventDetailsFlag)
+		}
+		eventDetails = details
+	}
+
 	// Environment Variables.
 
 	if valStr, ok := os.LookupEnv(postgresConnectionStringEnv); ok {
@@ -75,6 +206,82 @@ func Load() (*Config, error) {
 		grpcPort = val
 	}
 
+	if valStr, ok := os.LookupEnv(feedPollIntervalEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, feedPollIntervalEnv)
+		}
+		feedPollInterval = time.Duration(val) * time.Millisecond
+	}
+
+	if valStr, ok := os.LookupEnv(inboxTTLEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, inboxTTLEnv)
+		}
+		inboxTTL = time.Duration(val) * time.Millisecond
+	}
+
+	if valStr, ok := os.LookupEnv(inboxCleanupIntervalEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, inboxCleanupIntervalEnv)
+		}
+		inboxCleanupInterval = time.Duration(val) * time.Millisecond
+	}
+
+	if valStr, ok := os.LookupEnv(outboxKeepPublishedEnv); ok {
+		val, err := strconv.ParseBool(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, outboxKeepPublishedEnv)
+		}
+		outboxKeepPublished = val
+	}
+
+	if valStr, ok := os.LookupEnv(outboxRetentionDaysEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, outboxRetentionDaysEnv)
+		}
+		outboxRetentionDays = val
+	}
+
+	if valStr, ok := os.LookupEnv(outboxArchiveEnv); ok {
+		val, err := strconv.ParseBool(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, outboxArchiveEnv)
+		}
+		outboxArchive = val
+	}
+
+	if valStr, ok := os.LookupEnv(feedBatchSizeEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, feedBatchSizeEnv)
+		}
+		feedBatchSize = val
+	}
+
+	if valStr, ok := os.LookupEnv(outboxPayloadFormatEnv); ok {
+		format, err := kafka.ParseFormat(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, outboxPayloadFormatEnv)
+		}
+		payloadFormat = format
+	}
+
+	if valStr, ok := os.LookupEnv(schemaRegistryFileEnv); ok {
+		schemaRegistryFile = valStr
+	}
+
+	if valStr, ok := os.LookupEnv(eventDetailsEnv); ok {
+		details, err := parseEventDetails(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, eventDetailsEnv)
+		}
+		eventDetails = details
+	}
+
 	// Validation.
 
 	if postgresConnectionString == "" {
@@ -85,6 +292,34 @@ func Load() (*Config, error) {
 		return &Config{}, errors.New("grpc port must be between 0 and 65535")
 	}
 
+	if feedPollInterval <= 0 {
+		return &Config{}, errors.New("feed poll interval must be greater than zero")
+	}
+
+	if feedBatchSize < 1 || feedBatchSize > math.MaxInt32 {
+		return &Config{}, errors.New("feed batch size must be between 1 and 2147483647")
+	}
+
+	if inboxTTL <= 0 {
+		return &Config{}, errors.New("inbox ttl must be greater than zero")
+	}
+
+	if inboxCleanupInterval <= 0 {
+		return &Config{}, errors.New("inbox cleanup interval must be greater than zero")
+	}
+
+	if outboxRetentionDays < 1 {
+		return &Config{}, errors.New("outbox retention days must be greater than zero")
+	}
+
+	if outboxArchive && !outboxKeepPublished {
+		return &Config{}, errors.New("outbox archive requires published outbox messages to be kept")
+	}
+
+	if payloadFormat == kafka.FormatProtobuf && schemaRegistryFile == "" {
+		return &Config{}, errors.New("schema registry file required for protobuf payload format")
+	}
+
 	return &Config{
 		PostgresConfig: postgres.Config{
 			ConnectionString: postgresConnectionString,
@@ -93,5 +328,49 @@ func Load() (*Config, error) {
 			Port: uint16(grpcPort),
 		},
 		RetryAttempts: defaultRetryAttempts,
+		FeedConfig: services.FeedConfig{
+			PollInterval: feedPollInterval,
+			BatchSize:    int32(feedBatchSize),
+		},
+		PayloadFormat:      payloadFormat,
+		SchemaRegistryFile: schemaRegistryFile,
+		EventWriterConfig: services.EventWriterConfig{
+			Details: eventDetails,
+		},
+		InboxTTL:             inboxTTL,
+		InboxCleanupInterval: inboxCleanupInterval,
+		InboxCleanupBatch:    defaultInboxBatchSize,
+		OutboxConfig: services.OutboxConfig{
+			KeepPublished: outboxKeepPublished,
+		},
+		OutboxPartitions: services.OutboxPartitionsConfig{
+			RetentionDays: outboxRetentionDays,
+			Archive:       outboxArchive,
+		},
 	}, nil
 }
+
+// parseEventDetails parses a comma-separated list of topic=detail pairs.
+func parseEventDetails(s string) (map[kafka.Topic]kafka.EventDetail, error) {
+	res := map[kafka.Topic]kafka.EventDetail{}
+	for pair := range strings.SplitSeq(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		topic, detailStr, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid event detail '%s'", pair)
+		}
+		if !slices.ContainsFunc(kafka.Events, func(e kafka.Event) bool { return e.EventType() == kafka.Topic(topic) }) {
+			return nil, fmt.Errorf("unknown event topic '%s'", topic)
+		}
+		detail, err := kafka.ParseEventDetail(detailStr)
+		if err != nil {
+			return nil, err
+		}
+		res[kafka.Topic(topic)] = detail
+	}
+	return res, nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"go-invoice-service/common/pkg/inbox"
 	"go-invoice-service/common/pkg/logging"
 	"go-invoice-service/common/pkg/transactions"
+	"go-invoice-service/common/protocol/kafka"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"golang.org/x/sync/errgroup"
@@ -52,6 +54,13 @@ func main() {
 		log.Fatal(err)
 	}
 
+	outboxListener, err := postgres.NewListener(cfg.PostgresConfig, postgres.OutboxChannel, func(err error) {
+		logger.ErrorCtx(rootCtx, "outbox listener error", zap.Error(err))
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	tm := transactions.NewManager(db)
 	dbtxWithRetry := data.NewDBTXWithRetry(
 		db,
@@ -63,23 +72,92 @@ func main() {
 
 	invoiceRepository := repositories.NewInvoice(dbtxWithRetry)
 	outboxRepository := repositories.NewOutbox(dbtxWithRetry)
+	importRepository := repositories.NewImport(dbtxWithRetry)
+	exportRepository := repositories.NewExport()
+	reportingRepository := repositories.NewReporting(dbtxWithRetry)
+	invoiceEventRepository := repositories.NewInvoiceEvent(dbtxWithRetry)
+	webhookRepository := repositories.NewWebhook(dbtxWithRetry)
+	notificationRepository := repositories.NewNotification(dbtxWithRetry)
+	outboxPartitionRepository := repositories.NewOutboxPartitions(dbtxWithRetry)
+
+	eventEncoder := kafka.NewJSONEncoder()
+	if cfg.PayloadFormat == kafka.FormatProtobuf {
+		eventEncoder = kafka.NewProtobufEncoder(kafka.NewFileSchemaRegistry(cfg.SchemaRegistryFile))
+	}
 
-	invoiceService := services.NewInvoice(tm, invoiceRepository, outboxRepository)
-	outboxService := services.NewOutbox(tm, outboxRepository, logger)
-	validationService := services.NewValidation(tm, invoiceRepository, outboxRepository)
-
-	grpcServer := grpc.NewServer(cfg.GRPCConfig, invoiceService, outboxService, validationService)
+	eventWriter := services.NewEventWriter(cfg.EventWriterConfig, outboxRepository, invoiceRepository, eventEncoder)
+
+	invoiceService := services.NewInvoice(tm, invoiceRepository, eventWriter, invoiceEventRepository)
+	outboxService := services.NewOutbox(cfg.OutboxConfig, tm, outboxRepository, webhookRepository, outboxListener, logger)
+	outboxPartitionsService := services.NewOutboxPartitions(cfg.OutboxPartitions, tm, outboxPartitionRepository, logger)
+	inboxStore := inbox.NewPostgresStore(db, cfg.InboxTTL)
+	validationService := services.NewValidation(tm, invoiceRepository, eventWriter, invoiceEventRepository, inboxStore)
+	importService := services.NewImport(
+		tm,
+		importRepository,
+		invoiceRepository,
+		eventWriter,
+		invoiceEventRepository,
+	)
+	exportService := services.NewExport(tm, exportRepository)
+	reportingService := services.NewReporting(reportingRepository)
+	feedService := services.NewFeed(cfg.FeedConfig, invoiceEventRepository)
+	webhookService := services.NewWebhook(tm, webhookRepository)
+	notificationService := services.NewNotification(tm, invoiceRepository, notificationRepository)
+
+	grpcServer := grpc.NewServer(
+		cfg.GRPCConfig,
+		invoiceService,
+		outboxService,
+		validationService,
+		importService,
+		exportService,
+		reportingService,
+		feedService,
+		webhookService,
+		notificationService,
+	)
 
-	if err := run(rootCtx, grpcServer, logger); err != nil {
+	if err := run(rootCtx, cfg, grpcServer, outboxListener, outboxPartitionsService, inboxStore, logger); err != nil {
 		logger.ErrorCtx(rootCtx, "Service shutdown with error", zap.Error(err))
 	} else {
 		logger.InfoCtx(rootCtx, "Service shutdown gracefully")
 	}
 }
 
-func run(rootCtx context.Context, grpcServer *grpc.Server, logger *logging.ZapLogger) error {
+func run(
+	rootCtx context.Context,
+	cfg *config.Config,
+	grpcServer *grpc.Server,
+	outboxListener *postgres.Listener,
+	outboxPartitions *services.OutboxPartitions,
+	inboxStore inbox.Store,
+	logger *logging.ZapLogger,
+) error {
 	g, ctx := errgroup.WithContext(rootCtx)
 
+	g.Go(func() error {
+		defer logger.InfoCtx(ctx, "Inbox cleanup stopped")
+		inbox.RunCleanup(ctx, inboxStore, cfg.InboxCleanupInterval, cfg.InboxCleanupBatch, func(ctx context.Context, err error) {
+			logger.ErrorCtx(ctx, "inbox cleanup error", zap.Error(err))
+		})
+		return nil
+	})
+
+	g.Go(func() error {
+		defer logger.InfoCtx(ctx, "Outbox partitions maintenance stopped")
+		outboxPartitions.Run(ctx)
+		return nil
+	})
+
+	g.Go(func() error {
+		defer logger.InfoCtx(ctx, "Outbox listener stopped")
+		if err := outboxListener.Run(ctx); err != nil {
+			return fmt.Errorf("outbox listener error: %w", err)
+		}
+		return nil
+	})
+
 	g.Go(func() error {
 		defer logger.InfoCtx(ctx, "gRPC server shutdown")
 		if err := grpcServer.Run(); err != nil {
@@ -5,12 +5,38 @@
 package queries
 
 import (
+	"database/sql"
 	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type ImportJob struct {
+	ID            uuid.UUID
+	Format        string
+	Status        string
+	ProcessedRows int64
+	SucceededRows int64
+	FailedRows    int64
+	Error         string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type ImportJobResult struct {
+	JobID     uuid.UUID
+	RowNumber int64
+	InvoiceID uuid.NullUUID
+	Error     string
+}
+
+type Inbox struct {
+	EventID       uuid.UUID
+	ConsumerGroup string
+	ExpiresAt     time.Time
+}
+
 type Invoice struct {
 	ID         uuid.UUID
 	CustomerID uuid.UUID
@@ -23,6 +49,15 @@ type Invoice struct {
 	Status     string
 }
 
+type InvoiceEvent struct {
+	ID         int64
+	TxID       int64
+	InvoiceID  uuid.UUID
+	Type       string
+	Status     string
+	OccurredAt time.Time
+}
+
 type InvoiceItem struct {
 	ID          int64
 	InvoiceID   uuid.UUID
@@ -32,9 +67,85 @@ type InvoiceItem struct {
 	Total       int64
 }
 
+type Notification struct {
+	InvoiceID   uuid.UUID
+	EventType   string
+	Recipient   string
+	Status      string
+	LockedUntil time.Time
+	SentAt      sql.NullTime
+	CreatedAt   time.Time
+}
+
 type Outbox struct {
-	ID         int64
-	Payload    json.RawMessage
-	Topic      string
-	NextSendAt time.Time
+	ID             int64
+	Payload        []byte
+	Topic          string
+	NextSendAt     time.Time
+	Key            sql.NullString
+	Headers        json.RawMessage
+	Data           json.RawMessage
+	AggregateID    uuid.NullUUID
+	Sequence       sql.NullInt64
+	LeaseOwner     sql.NullString
+	LeaseExpiresAt sql.NullTime
+	Status         string
+	Attempts       int32
+	LastError      string
+	FirstFailedAt  sql.NullTime
+	CreatedAt      time.Time
+	PublishedAt    sql.NullTime
+}
+
+type OutboxAggregate struct {
+	AggregateID  uuid.UUID
+	LastSequence int64
+}
+
+type OutboxArchive struct {
+	ID          int64
+	Payload     []byte
+	Topic       string
+	NextSendAt  time.Time
+	Key         sql.NullString
+	Headers     json.RawMessage
+	Data        json.RawMessage
+	AggregateID uuid.NullUUID
+	Sequence    sql.NullInt64
+	CreatedAt   time.Time
+	PublishedAt time.Time
+}
+
+type WebhookDelivery struct {
+	ID             int64
+	EndpointID     uuid.UUID
+	EventType      string
+	Payload        json.RawMessage
+	Status         string
+	Attempts       int32
+	NextAttemptAt  time.Time
+	LastError      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	LeaseOwner     sql.NullString
+	LeaseExpiresAt sql.NullTime
+}
+
+type WebhookDeliveryAttempt struct {
+	ID          int64
+	DeliveryID  int64
+	Attempt     int32
+	StatusCode  int32
+	Error       string
+	Response    string
+	DurationMs  int64
+	AttemptedAt time.Time
+}
+
+type WebhookEndpoint struct {
+	ID         uuid.UUID
+	Url        string
+	Secret     string
+	EventTypes []string
+	CreatedAt  time.Time
 }
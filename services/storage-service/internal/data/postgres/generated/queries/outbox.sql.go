@@ -7,42 +7,114 @@ package queries
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/lib/pq"
 )
 
-const deleteMessage = `-- name: DeleteMessage :exec
+const countDueMessages = `-- name: CountDueMessages :one
+select count(*) from outbox o
+where o.status = 'Pending'
+  and o.next_send_at <= $1::timestamp
+  and (o.lease_expires_at is null or o.lease_expires_at <= $1::timestamp)
+  and not exists (select 1
+                  from outbox p
+                  where p.aggregate_id = o.aggregate_id
+                    and p.sequence < o.sequence
+                    and p.status <> 'Published')
+`
+
+func (q *Queries) CountDueMessages(ctx context.Context, now time.Time) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countDueMessages, now)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteMessages = `-- name: DeleteMessages :exec
 delete from outbox
-where id = $1
+where id = any ($1::bigint[])
 `
 
-func (q *Queries) DeleteMessage(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteMessage, id)
+func (q *Queries) DeleteMessages(ctx context.Context, ids []int64) error {
+	_, err := q.db.ExecContext(ctx, deleteMessages, pq.Array(ids))
 	return err
 }
 
+const failMessage = `-- name: FailMessage :execrows
+update outbox
+set status           = $1::text,
+    attempts         = attempts + 1,
+    last_error       = $2::text,
+    first_failed_at  = coalesce(first_failed_at, $3::timestamp),
+    next_send_at     = $4::timestamp,
+    lease_owner      = null,
+    lease_expires_at = null
+where id = $5
+  and lease_owner = $6::text
+`
+
+type FailMessageParams struct {
+	Status     string
+	LastError  string
+	Now        time.Time
+	NextSendAt time.Time
+	ID         int64
+	LeaseOwner string
+}
+
+func (q *Queries) FailMessage(ctx context.Context, arg FailMessageParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, failMessage,
+		arg.Status,
+		arg.LastError,
+		arg.Now,
+		arg.NextSendAt,
+		arg.ID,
+		arg.LeaseOwner,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getMessages = `-- name: GetMessages :many
-select id, payload, topic from outbox
-where next_send_at<=$1
+select id, payload, topic, key, headers, aggregate_id, sequence, lease_owner, attempts from outbox o
+where o.status = 'Pending'
+  and o.next_send_at <= $1::timestamp
+  and (o.lease_expires_at is null or o.lease_expires_at <= $1::timestamp)
+  and not exists (select 1
+                  from outbox p
+                  where p.aggregate_id = o.aggregate_id
+                    and p.sequence < o.sequence
+                    and p.status <> 'Published')
+order by o.id
 limit $2
-for update
+for update skip locked
 `
 
 type GetMessagesParams struct {
-	NextSendAt time.Time
-	Limit      int32
+	Now      time.Time
+	MaxCount int32
 }
 
 type GetMessagesRow struct {
-	ID      int64
-	Payload json.RawMessage
-	Topic   string
+	ID          int64
+	Payload     []byte
+	Topic       string
+	Key         sql.NullString
+	Headers     json.RawMessage
+	AggregateID uuid.NullUUID
+	Sequence    sql.NullInt64
+	LeaseOwner  sql.NullString
+	Attempts    int32
 }
 
 func (q *Queries) GetMessages(ctx context.Context, arg GetMessagesParams) ([]GetMessagesRow, error) {
-	rows, err := q.db.QueryContext(ctx, getMessages, arg.NextSendAt, arg.Limit)
+	rows, err := q.db.QueryContext(ctx, getMessages, arg.Now, arg.MaxCount)
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +122,112 @@ func (q *Queries) GetMessages(ctx context.Context, arg GetMessagesParams) ([]Get
 	var items []GetMessagesRow
 	for rows.Next() {
 		var i GetMessagesRow
-		if err := rows.Scan(&i.ID, &i.Payload, &i.Topic); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Payload,
+			&i.Topic,
+			&i.Key,
+			&i.Headers,
+			&i.AggregateID,
+			&i.Sequence,
+			&i.LeaseOwner,
+			&i.Attempts,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const leaseMessages = `-- name: LeaseMessages :exec
+update outbox
+set lease_owner      = $1::text,
+    lease_expires_at = $2::timestamp
+where id = any ($3::bigint[])
+`
+
+type LeaseMessagesParams struct {
+	LeaseOwner     string
+	LeaseExpiresAt time.Time
+	Ids            []int64
+}
+
+func (q *Queries) LeaseMessages(ctx context.Context, arg LeaseMessagesParams) error {
+	_, err := q.db.ExecContext(ctx, leaseMessages, arg.LeaseOwner, arg.LeaseExpiresAt, pq.Array(arg.Ids))
+	return err
+}
+
+const listOutboxPartitions = `-- name: ListOutboxPartitions :many
+select c.relname::text                           as name,
+       i.inhrelid is null                        as detached,
+       coalesce(i.inhdetachpending, false)::bool as detach_pending
+from pg_class c
+         left join pg_inherits i on i.inhrelid = c.oid and i.inhparent = 'outbox'::regclass
+where c.relkind = 'r'
+  and c.relnamespace = current_schema()::regnamespace
+  and c.relname like 'outbox\_p%'
+order by c.relname
+`
+
+type ListOutboxPartitionsRow struct {
+	Name          string
+	Detached      bool
+	DetachPending bool
+}
+
+func (q *Queries) ListOutboxPartitions(ctx context.Context) ([]ListOutboxPartitionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listOutboxPartitions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOutboxPartitionsRow
+	for rows.Next() {
+		var i ListOutboxPartitionsRow
+		if err := rows.Scan(&i.Name, &i.Detached, &i.DetachPending); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockMessages = `-- name: LockMessages :many
+select id, lease_owner from outbox
+where id = any ($1::bigint[])
+  and status <> 'Published'
+order by id
+for update
+`
+
+type LockMessagesRow struct {
+	ID         int64
+	LeaseOwner sql.NullString
+}
+
+func (q *Queries) LockMessages(ctx context.Context, ids []int64) ([]LockMessagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, lockMessages, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LockMessagesRow
+	for rows.Next() {
+		var i LockMessagesRow
+		if err := rows.Scan(&i.ID, &i.LeaseOwner); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -64,34 +241,369 @@ func (q *Queries) GetMessages(ctx context.Context, arg GetMessagesParams) ([]Get
 	return items, nil
 }
 
-const increaseNextSendAt = `-- name: IncreaseNextSendAt :exec
+const nextOutboxSequence = `-- name: NextOutboxSequence :one
+insert into outbox_aggregates (aggregate_id, last_sequence)
+values ($1, 1)
+on conflict (aggregate_id) do update set last_sequence = outbox_aggregates.last_sequence + 1
+returning last_sequence
+`
+
+func (q *Queries) NextOutboxSequence(ctx context.Context, aggregateID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, nextOutboxSequence, aggregateID)
+	var last_sequence int64
+	err := row.Scan(&last_sequence)
+	return last_sequence, err
+}
+
+const notifyOutbox = `-- name: NotifyOutbox :exec
+notify outbox
+`
+
+func (q *Queries) NotifyOutbox(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, notifyOutbox)
+	return err
+}
+
+const publishMessages = `-- name: PublishMessages :exec
 update outbox
-set next_send_at = current_timestamp + ($1::bigint || ' seconds')::interval
-where id = ANY($2::bigint[])
+set status           = 'Published',
+    published_at     = $1::timestamp,
+    lease_owner      = null,
+    lease_expires_at = null
+where id = any ($2::bigint[])
 `
 
-type IncreaseNextSendAtParams struct {
-	TimeToAddSec int64
-	Ids          []int64
+type PublishMessagesParams struct {
+	PublishedAt time.Time
+	Ids         []int64
 }
 
-func (q *Queries) IncreaseNextSendAt(ctx context.Context, arg IncreaseNextSendAtParams) error {
-	_, err := q.db.ExecContext(ctx, increaseNextSendAt, arg.TimeToAddSec, pq.Array(arg.Ids))
+func (q *Queries) PublishMessages(ctx context.Context, arg PublishMessagesParams) error {
+	_, err := q.db.ExecContext(ctx, publishMessages, arg.PublishedAt, pq.Array(arg.Ids))
 	return err
 }
 
+const purgeMessage = `-- name: PurgeMessage :execrows
+delete from outbox
+where id = $1
+  and status = 'Parked'
+`
+
+func (q *Queries) PurgeMessage(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeMessage, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const releaseMessages = `-- name: ReleaseMessages :execrows
+update outbox
+set lease_owner      = null,
+    lease_expires_at = null
+where id = any ($1::bigint[])
+  and lease_owner = $2::text
+`
+
+type ReleaseMessagesParams struct {
+	Ids        []int64
+	LeaseOwner string
+}
+
+func (q *Queries) ReleaseMessages(ctx context.Context, arg ReleaseMessagesParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, releaseMessages, pq.Array(arg.Ids), arg.LeaseOwner)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const requeueMessage = `-- name: RequeueMessage :execrows
+update outbox
+set status          = 'Pending',
+    attempts        = 0,
+    last_error      = '',
+    first_failed_at = null,
+    next_send_at    = $2
+where id = $1
+  and status = 'Parked'
+`
+
+type RequeueMessageParams struct {
+	ID         int64
+	NextSendAt time.Time
+}
+
+func (q *Queries) RequeueMessage(ctx context.Context, arg RequeueMessageParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, requeueMessage, arg.ID, arg.NextSendAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const scheduleMessage = `-- name: ScheduleMessage :exec
-insert into outbox (payload, topic, next_send_at)
-values ($1, $2, $3)
+insert into outbox (payload, data, topic, key, headers, next_send_at, aggregate_id, sequence)
+values ($1, $2, $3, $4, $5, $6, $7, $8)
 `
 
 type ScheduleMessageParams struct {
-	Payload    json.RawMessage
-	Topic      string
-	NextSendAt time.Time
+	Payload     []byte
+	Data        json.RawMessage
+	Topic       string
+	Key         sql.NullString
+	Headers     json.RawMessage
+	NextSendAt  time.Time
+	AggregateID uuid.NullUUID
+	Sequence    sql.NullInt64
 }
 
 func (q *Queries) ScheduleMessage(ctx context.Context, arg ScheduleMessageParams) error {
-	_, err := q.db.ExecContext(ctx, scheduleMessage, arg.Payload, arg.Topic, arg.NextSendAt)
+	_, err := q.db.ExecContext(ctx, scheduleMessage,
+		arg.Payload,
+		arg.Data,
+		arg.Topic,
+		arg.Key,
+		arg.Headers,
+		arg.NextSendAt,
+		arg.AggregateID,
+		arg.Sequence,
+	)
 	return err
 }
+
+const selectOutboxMessage = `-- name: SelectOutboxMessage :one
+select id, payload, topic, next_send_at, key, headers, data, aggregate_id, sequence, lease_owner, lease_expires_at, status, attempts, last_error, first_failed_at, created_at, published_at
+from outbox
+where id = $1
+`
+
+func (q *Queries) SelectOutboxMessage(ctx context.Context, id int64) (Outbox, error) {
+	row := q.db.QueryRowContext(ctx, selectOutboxMessage, id)
+	var i Outbox
+	err := row.Scan(
+		&i.ID,
+		&i.Payload,
+		&i.Topic,
+		&i.NextSendAt,
+		&i.Key,
+		&i.Headers,
+		&i.Data,
+		&i.AggregateID,
+		&i.Sequence,
+		&i.LeaseOwner,
+		&i.LeaseExpiresAt,
+		&i.Status,
+		&i.Attempts,
+		&i.LastError,
+		&i.FirstFailedAt,
+		&i.CreatedAt,
+		&i.PublishedAt,
+	)
+	return i, err
+}
+
+const selectParkedMessages = `-- name: SelectParkedMessages :many
+select id, payload, topic, next_send_at, key, headers, data, aggregate_id, sequence, lease_owner, lease_expires_at, status, attempts, last_error, first_failed_at, created_at, published_at
+from outbox
+where status = 'Parked'
+  and ($1::bigint is null or id < $1)
+order by id desc
+limit $2
+`
+
+type SelectParkedMessagesParams struct {
+	BeforeID sql.NullInt64
+	MaxCount int32
+}
+
+func (q *Queries) SelectParkedMessages(ctx context.Context, arg SelectParkedMessagesParams) ([]Outbox, error) {
+	rows, err := q.db.QueryContext(ctx, selectParkedMessages, arg.BeforeID, arg.MaxCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Outbox
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.Payload,
+			&i.Topic,
+			&i.NextSendAt,
+			&i.Key,
+			&i.Headers,
+			&i.Data,
+			&i.AggregateID,
+			&i.Sequence,
+			&i.LeaseOwner,
+			&i.LeaseExpiresAt,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.FirstFailedAt,
+			&i.CreatedAt,
+			&i.PublishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectPublishedMessages = `-- name: SelectPublishedMessages :many
+select id, payload, topic, next_send_at, key, headers, data, aggregate_id, sequence, created_at, published_at
+from outbox
+where aggregate_id = $1
+  and status = 'Published'
+  and ($2::bigint is null or id < $2)
+union all
+select id, payload, topic, next_send_at, key, headers, data, aggregate_id, sequence, created_at, published_at
+from outbox_archive
+where aggregate_id = $1
+  and ($2::bigint is null or id < $2)
+order by id desc
+limit $3
+`
+
+type SelectPublishedMessagesParams struct {
+	AggregateID uuid.NullUUID
+	BeforeID    sql.NullInt64
+	MaxCount    int32
+}
+
+type SelectPublishedMessagesRow struct {
+	ID          int64
+	Payload     []byte
+	Topic       string
+	NextSendAt  time.Time
+	Key         sql.NullString
+	Headers     json.RawMessage
+	Data        json.RawMessage
+	AggregateID uuid.NullUUID
+	Sequence    sql.NullInt64
+	CreatedAt   time.Time
+	PublishedAt sql.NullTime
+}
+
+func (q *Queries) SelectPublishedMessages(ctx context.Context, arg SelectPublishedMessagesParams) ([]SelectPublishedMessagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectPublishedMessages, arg.AggregateID, arg.BeforeID, arg.MaxCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectPublishedMessagesRow
+	for rows.Next() {
+		var i SelectPublishedMessagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Payload,
+			&i.Topic,
+			&i.NextSendAt,
+			&i.Key,
+			&i.Headers,
+			&i.Data,
+			&i.AggregateID,
+			&i.Sequence,
+			&i.CreatedAt,
+			&i.PublishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectReplayMessages = `-- name: SelectReplayMessages :many
+select id, payload, topic, key, headers, data, aggregate_id
+from outbox
+where status = 'Published'
+  and id > $1
+  and headers ->> 'replay-of' is null
+  and ($2::text is null or topic = $2)
+  and ($3::uuid is null or aggregate_id = $3)
+  and ($4::timestamp is null or created_at >= $4)
+  and ($5::timestamp is null or created_at < $5)
+union all
+select id, payload, topic, key, headers, data, aggregate_id
+from outbox_archive
+where id > $1
+  and headers ->> 'replay-of' is null
+  and ($2::text is null or topic = $2)
+  and ($3::uuid is null or aggregate_id = $3)
+  and ($4::timestamp is null or created_at >= $4)
+  and ($5::timestamp is null or created_at < $5)
+order by id
+limit $6
+`
+
+type SelectReplayMessagesParams struct {
+	AfterID     int64
+	Topic       sql.NullString
+	AggregateID uuid.NullUUID
+	CreatedFrom sql.NullTime
+	CreatedTo   sql.NullTime
+	MaxCount    int32
+}
+
+type SelectReplayMessagesRow struct {
+	ID          int64
+	Payload     []byte
+	Topic       string
+	Key         sql.NullString
+	Headers     json.RawMessage
+	Data        json.RawMessage
+	AggregateID uuid.NullUUID
+}
+
+func (q *Queries) SelectReplayMessages(ctx context.Context, arg SelectReplayMessagesParams) ([]SelectReplayMessagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectReplayMessages,
+		arg.AfterID,
+		arg.Topic,
+		arg.AggregateID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.MaxCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectReplayMessagesRow
+	for rows.Next() {
+		var i SelectReplayMessagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Payload,
+			&i.Topic,
+			&i.Key,
+			&i.Headers,
+			&i.Data,
+			&i.AggregateID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/lib/pq"
+	"sync"
+	"time"
+)
+
+// OutboxChannel is notified when outbox messages are scheduled or deleted.
+const OutboxChannel = "outbox"
+
+const (
+	listenerMinReconnectInterval = 100 * time.Millisecond
+	listenerMaxReconnectInterval = 10 * time.Second
+	listenerPingInterval         = 90 * time.Second
+)
+
+// Listener passes notifications of a Postgres channel to all its subscribers.
+// Subscribers are also signalled after the connection is restored, because
+// notifications sent while it was lost are never delivered.
+type Listener struct {
+	listener    *pq.Listener
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+}
+
+func NewListener(cfg Config, channel string, onError func(error)) (*Listener, error) {
+	listener := pq.NewListener(
+		cfg.ConnectionString,
+		listenerMinReconnectInterval,
+		listenerMaxReconnectInterval,
+		func(_ pq.ListenerEventType, err error) {
+			if err != nil {
+				onError(err)
+			}
+		},
+	)
+	if err := listener.Listen(channel); err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("failed to listen %s channel: %w", channel, err)
+	}
+
+	return &Listener{
+		listener:    listener,
+		subscribers: make(map[chan struct{}]struct{}),
+	}, nil
+}
+
+// Run passes notifications to subscribers until ctx is done.
+func (l *Listener) Run(ctx context.Context) error {
+	ticker := time.NewTicker(listenerPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := l.listener.Close(); err != nil {
+				return fmt.Errorf("failed to close listener: %w", err)
+			}
+			return nil
+		case <-l.listener.Notify:
+			// A nil notification means that the connection was restored.
+			l.broadcast()
+		case <-ticker.C:
+			// Ping detects a lost connection even when nothing is sent on the channel.
+			go func() { _ = l.listener.Ping() }()
+		}
+	}
+}
+
+// Subscribe returns a channel signalled on every notification. Signals coming faster
+// than they are read are merged into one. The returned function ends the subscription.
+func (l *Listener) Subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	l.mu.Lock()
+	l.subscribers[ch] = struct{}{}
+	l.mu.Unlock()
+
+	return ch, func() {
+		l.mu.Lock()
+		delete(l.subscribers, ch)
+		l.mu.Unlock()
+	}
+}
+
+func (l *Listener) broadcast() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for ch := range l.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,25 @@
+begin transaction;
+
+create table import_jobs
+(
+    id             uuid primary key,
+    format         varchar(10)                                                          not null,
+    status         varchar(20) check (status in ('Running', 'Completed', 'Failed')) not null default 'Running',
+    processed_rows bigint                                                               not null default 0,
+    succeeded_rows bigint                                                               not null default 0,
+    failed_rows    bigint                                                               not null default 0,
+    error          text                                                                 not null default '',
+    created_at     timestamp                                                            not null,
+    updated_at     timestamp                                                            not null
+);
+
+create table import_job_results
+(
+    job_id     uuid references import_jobs (id) not null,
+    row_number bigint                           not null,
+    invoice_id uuid,
+    error      text                             not null,
+    primary key (job_id, row_number)
+);
+
+commit;
@@ -0,0 +1,6 @@
+begin transaction;
+
+create index invoices_created_at_idx on invoices (created_at, id);
+create index invoice_items_invoice_id_idx on invoice_items (invoice_id);
+
+commit;
@@ -0,0 +1,28 @@
+begin transaction;
+
+-- tx_id is the id of the transaction that recorded the event. The feed is read in (tx_id, id)
+-- order and only up to the oldest running transaction, so events committed out of id order
+-- are never skipped.
+create table invoice_events
+(
+    id          bigint generated always as identity,
+    tx_id       bigint                                                                 not null default pg_current_xact_id()::text::bigint,
+    invoice_id  uuid references invoices (id)                                          not null,
+    type        varchar(20) check (type in ('Created', 'StatusChanged'))               not null,
+    status      varchar(20) check (status in ('Pending', 'Approved', 'Rejected'))      not null,
+    occurred_at timestamp                                                              not null,
+    primary key (tx_id, id)
+);
+
+insert into invoice_events (invoice_id, type, status, occurred_at)
+select id, 'Created', 'Pending', created_at
+from invoices
+order by created_at, id;
+
+insert into invoice_events (invoice_id, type, status, occurred_at)
+select id, 'StatusChanged', status, updated_at
+from invoices
+where status <> 'Pending'
+order by updated_at, id;
+
+commit;
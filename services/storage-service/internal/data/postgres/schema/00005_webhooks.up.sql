@@ -0,0 +1,43 @@
+begin transaction;
+
+create table webhook_endpoints
+(
+    id          uuid primary key,
+    url         text      not null,
+    secret      text      not null,
+    event_types text[]    not null,
+    created_at  timestamp not null
+);
+
+create table webhook_deliveries
+(
+    id              bigint generated always as identity primary key,
+    endpoint_id     uuid references webhook_endpoints (id) on delete cascade           not null,
+    event_type      text                                                               not null,
+    payload         jsonb                                                              not null,
+    status          varchar(20) check (status in ('Pending', 'Succeeded', 'Dead'))     not null,
+    attempts        int                                                                not null default 0,
+    next_attempt_at timestamp                                                          not null,
+    last_error      text                                                               not null default '',
+    created_at      timestamp                                                          not null,
+    updated_at      timestamp                                                          not null
+);
+
+create index webhook_deliveries_due_idx on webhook_deliveries (next_attempt_at) where status = 'Pending';
+create index webhook_deliveries_endpoint_idx on webhook_deliveries (endpoint_id, id);
+
+create table webhook_delivery_attempts
+(
+    id           bigint generated always as identity primary key,
+    delivery_id  bigint references webhook_deliveries (id) on delete cascade not null,
+    attempt      int                                                         not null,
+    status_code  int                                                         not null,
+    error        text                                                        not null,
+    response     text                                                        not null,
+    duration_ms  bigint                                                      not null,
+    attempted_at timestamp                                                   not null
+);
+
+create index webhook_delivery_attempts_delivery_idx on webhook_delivery_attempts (delivery_id, id);
+
+commit;
@@ -0,0 +1,15 @@
+begin transaction;
+
+create table notifications
+(
+    invoice_id   uuid                                                 not null,
+    event_type   text                                                 not null,
+    recipient    text                                                 not null,
+    status       varchar(20) check (status in ('Sending', 'Sent'))    not null,
+    locked_until timestamp                                            not null,
+    sent_at      timestamp,
+    created_at   timestamp                                            not null,
+    primary key (invoice_id, event_type)
+);
+
+commit;
@@ -0,0 +1,7 @@
+begin transaction;
+
+alter table outbox
+    add column key     text,
+    add column headers jsonb not null default '{}';
+
+commit;
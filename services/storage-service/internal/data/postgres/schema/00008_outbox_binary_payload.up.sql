@@ -0,0 +1,13 @@
+begin transaction;
+
+alter table outbox
+    add column data jsonb;
+
+update outbox
+set data = coalesce(payload -> 'data', payload);
+
+alter table outbox
+    alter column data set not null,
+    alter column payload type bytea using convert_to(payload::text, 'UTF8');
+
+commit;
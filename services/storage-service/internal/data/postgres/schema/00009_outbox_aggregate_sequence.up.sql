@@ -0,0 +1,29 @@
+begin transaction;
+
+create table outbox_aggregates
+(
+    aggregate_id  uuid primary key,
+    last_sequence bigint not null
+);
+
+alter table outbox
+    add column aggregate_id uuid,
+    add column sequence     bigint;
+
+update outbox o
+set aggregate_id = o.key::uuid,
+    sequence     = s.sequence
+from (select id, row_number() over (partition by key order by id) as sequence
+      from outbox
+      where key is not null) s
+where o.id = s.id;
+
+insert into outbox_aggregates (aggregate_id, last_sequence)
+select aggregate_id, max(sequence)
+from outbox
+where aggregate_id is not null
+group by aggregate_id;
+
+create unique index outbox_aggregate_sequence_idx on outbox (aggregate_id, sequence);
+
+commit;
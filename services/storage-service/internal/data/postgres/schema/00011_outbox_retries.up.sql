@@ -0,0 +1,11 @@
+begin transaction;
+
+alter table outbox
+    add column status          varchar(20) check (status in ('Pending', 'Parked')) not null default 'Pending',
+    add column attempts        int                                                 not null default 0,
+    add column last_error      text                                                not null default '',
+    add column first_failed_at timestamp;
+
+create index outbox_parked_idx on outbox (id) where status = 'Parked';
+
+commit;
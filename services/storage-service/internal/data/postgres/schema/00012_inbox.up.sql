@@ -0,0 +1,13 @@
+begin transaction;
+
+create table inbox
+(
+    event_id       uuid      not null,
+    consumer_group text      not null,
+    expires_at     timestamp not null,
+    primary key (event_id, consumer_group)
+);
+
+create index inbox_expires_at_idx on inbox (expires_at);
+
+commit;
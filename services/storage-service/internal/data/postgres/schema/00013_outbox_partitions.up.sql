@@ -0,0 +1,100 @@
+begin transaction;
+
+alter table outbox
+    rename to outbox_legacy;
+
+create table outbox
+(
+    id               bigint generated always as identity,
+    payload          bytea                                                            not null,
+    topic            text                                                             not null,
+    next_send_at     timestamp                                                        not null,
+    key              text,
+    headers          jsonb                                                            not null default '{}',
+    data             jsonb                                                            not null,
+    aggregate_id     uuid,
+    sequence         bigint,
+    lease_owner      text,
+    lease_expires_at timestamp,
+    status           varchar(20) check (status in ('Pending', 'Parked', 'Published')) not null default 'Pending',
+    attempts         int                                                              not null default 0,
+    last_error       text                                                             not null default '',
+    first_failed_at  timestamp,
+    created_at       timestamp                                                        not null default (now() at time zone 'utc'),
+    published_at     timestamp
+) partition by range (created_at);
+
+-- Partitions are named outbox_pYYYYMMDD and hold the messages created that day (UTC).
+-- Storage service creates the partitions of the next days and drops expired ones.
+do
+$$
+    declare
+        day date;
+    begin
+        for day in select generate_series((now() at time zone 'utc')::date, (now() at time zone 'utc')::date + 7, interval '1 day')::date
+            loop
+                execute format(
+                        'create table %I partition of outbox for values from (%L) to (%L)',
+                        'outbox_p' || to_char(day, 'YYYYMMDD'), day, day + 1
+                        );
+            end loop;
+    end
+$$;
+
+insert into outbox (id, payload, topic, next_send_at, key, headers, data, aggregate_id, sequence, lease_owner,
+                    lease_expires_at, status, attempts, last_error, first_failed_at, created_at)
+    overriding system value
+select id,
+       payload,
+       topic,
+       next_send_at,
+       key,
+       headers,
+       data,
+       aggregate_id,
+       sequence,
+       lease_owner,
+       lease_expires_at,
+       status,
+       attempts,
+       last_error,
+       first_failed_at,
+       (now() at time zone 'utc')::date
+from outbox_legacy;
+
+select setval(pg_get_serial_sequence('outbox', 'id'), coalesce((select max(id) from outbox_legacy), 0) + 1, false);
+
+drop table outbox_legacy;
+
+alter table outbox
+    add primary key (id, created_at);
+
+create index outbox_parked_idx on outbox (id) where status = 'Parked';
+-- A unique index of a partitioned table must include the partition key, so the unique
+-- (aggregate_id, sequence) index of 00009 can't be kept as is. With created_at included a
+-- sequence is unique within a day. Across days uniqueness relies on outbox_aggregates: sequences
+-- are only taken from its last_sequence, which is incremented under a row lock.
+create unique index outbox_aggregate_sequence_idx on outbox (aggregate_id, sequence, created_at);
+create index outbox_aggregate_pending_idx on outbox (aggregate_id, sequence) where status <> 'Published';
+create index outbox_published_idx on outbox (aggregate_id, id) where status = 'Published';
+
+-- Published messages of dropped partitions, kept when archiving is enabled.
+create table outbox_archive
+(
+    id           bigint primary key,
+    payload      bytea     not null,
+    topic        text      not null,
+    next_send_at timestamp not null,
+    key          text,
+    headers      jsonb     not null,
+    data         jsonb     not null,
+    aggregate_id uuid,
+    sequence     bigint,
+    created_at   timestamp not null,
+    published_at timestamp not null
+);
+
+create index outbox_archive_aggregate_idx on outbox_archive (aggregate_id, id);
+create index outbox_archive_topic_idx on outbox_archive (topic, published_at);
+
+commit;
@@ -0,0 +1,7 @@
+begin transaction;
+
+alter table webhook_deliveries
+    add column lease_owner      text,
+    add column lease_expires_at timestamp;
+
+commit;
@@ -0,0 +1,73 @@
+-- name: CreateImportJob :exec
+insert into import_jobs (id, format, created_at, updated_at)
+values ($1, $2, $3, $3)
+on conflict (id) do nothing;
+
+-- name: SelectImportJob :one
+select id,
+       format,
+       status,
+       processed_rows,
+       succeeded_rows,
+       failed_rows,
+       error,
+       created_at,
+       updated_at
+from import_jobs
+where id = $1;
+
+-- name: LockImportJob :one
+select id,
+       format,
+       status,
+       processed_rows,
+       succeeded_rows,
+       failed_rows,
+       error,
+       created_at,
+       updated_at
+from import_jobs
+where id = $1
+for update;
+
+-- name: SelectImportJobsByStatus :many
+select id,
+       format,
+       status,
+       processed_rows,
+       succeeded_rows,
+       failed_rows,
+       error,
+       created_at,
+       updated_at
+from import_jobs
+where status = $1
+order by created_at;
+
+-- name: UpdateImportJobProgress :exec
+update import_jobs
+set processed_rows = $2,
+    succeeded_rows = $3,
+    failed_rows    = $4,
+    updated_at     = $5
+where id = $1;
+
+-- name: FinishImportJob :exec
+update import_jobs
+set status     = $2,
+    error      = $3,
+    updated_at = $4
+where id = $1;
+
+-- name: AddImportJobResult :exec
+insert into import_job_results (job_id, row_number, invoice_id, error)
+values ($1, $2, $3, $4);
+
+-- name: SelectImportJobResults :many
+select row_number, invoice_id, error
+from import_job_results
+where job_id = sqlc.arg(job_id)
+  and row_number > sqlc.arg(after_row)
+  and (not sqlc.arg(only_failed)::bool or error <> '')
+order by row_number
+limit sqlc.arg(max_count);
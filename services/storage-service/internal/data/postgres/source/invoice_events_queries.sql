@@ -0,0 +1,14 @@
+-- name: AddInvoiceEvent :exec
+insert into invoice_events (invoice_id, type, status, occurred_at)
+values ($1, $2, $3, $4);
+
+-- name: SelectInvoiceEvents :many
+select *
+from invoice_events
+where (tx_id, id) > (sqlc.arg(after_tx_id)::bigint, sqlc.arg(after_id)::bigint)
+  and tx_id < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+order by tx_id, id
+limit sqlc.arg(max_count);
+
+-- name: SelectInvoiceEventsHead :one
+select pg_snapshot_xmin(pg_current_snapshot())::text::bigint as tx_id;
@@ -0,0 +1,29 @@
+-- name: ClaimNotification :execrows
+insert into notifications (invoice_id, event_type, recipient, status, locked_until, created_at)
+values ($1, $2, $3, 'Sending', $4, $5)
+on conflict (invoice_id, event_type) do update
+    set recipient    = excluded.recipient,
+        locked_until = excluded.locked_until
+where notifications.status = 'Sending'
+  and notifications.locked_until <= excluded.created_at;
+
+-- name: SelectNotification :one
+select *
+from notifications
+where invoice_id = $1
+  and event_type = $2;
+
+-- name: MarkNotificationSent :execrows
+update notifications
+set status  = 'Sent',
+    sent_at = $3
+where invoice_id = $1
+  and event_type = $2
+  and status = 'Sending';
+
+-- name: ReleaseNotification :exec
+delete
+from notifications
+where invoice_id = $1
+  and event_type = $2
+  and status = 'Sending';
@@ -1,18 +1,156 @@
+-- name: NextOutboxSequence :one
+insert into outbox_aggregates (aggregate_id, last_sequence)
+values ($1, 1)
+on conflict (aggregate_id) do update set last_sequence = outbox_aggregates.last_sequence + 1
+returning last_sequence;
+
+-- name: NotifyOutbox :exec
+notify outbox;
+
 -- name: ScheduleMessage :exec
-insert into outbox (payload, topic, next_send_at)
-values ($1, $2, $3);
+insert into outbox (payload, data, topic, key, headers, next_send_at, aggregate_id, sequence)
+values ($1, $2, $3, $4, $5, $6, $7, $8);
 
 -- name: GetMessages :many
-select id, payload, topic from outbox
-where next_send_at<=$1
-limit $2
+select id, payload, topic, key, headers, aggregate_id, sequence, lease_owner, attempts from outbox o
+where o.status = 'Pending'
+  and o.next_send_at <= sqlc.arg(now)::timestamp
+  and (o.lease_expires_at is null or o.lease_expires_at <= sqlc.arg(now)::timestamp)
+  and not exists (select 1
+                  from outbox p
+                  where p.aggregate_id = o.aggregate_id
+                    and p.sequence < o.sequence
+                    and p.status <> 'Published')
+order by o.id
+limit sqlc.arg(max_count)
+for update skip locked;
+
+-- name: CountDueMessages :one
+select count(*) from outbox o
+where o.status = 'Pending'
+  and o.next_send_at <= sqlc.arg(now)::timestamp
+  and (o.lease_expires_at is null or o.lease_expires_at <= sqlc.arg(now)::timestamp)
+  and not exists (select 1
+                  from outbox p
+                  where p.aggregate_id = o.aggregate_id
+                    and p.sequence < o.sequence
+                    and p.status <> 'Published');
+
+-- name: LeaseMessages :exec
+update outbox
+set lease_owner      = sqlc.arg(lease_owner)::text,
+    lease_expires_at = sqlc.arg(lease_expires_at)::timestamp
+where id = any (sqlc.arg(ids)::bigint[]);
+
+-- name: LockMessages :many
+select id, lease_owner from outbox
+where id = any (sqlc.arg(ids)::bigint[])
+  and status <> 'Published'
+order by id
 for update;
 
--- name: IncreaseNextSendAt :exec
+-- name: DeleteMessages :exec
+delete from outbox
+where id = any (sqlc.arg(ids)::bigint[]);
+
+-- name: ReleaseMessages :execrows
+update outbox
+set lease_owner      = null,
+    lease_expires_at = null
+where id = any (sqlc.arg(ids)::bigint[])
+  and lease_owner = sqlc.arg(lease_owner)::text;
+
+-- name: PublishMessages :exec
 update outbox
-set next_send_at = current_timestamp + (sqlc.arg(time_to_add_sec)::bigint || ' seconds')::interval
-where id = ANY(sqlc.arg(ids)::bigint[]);
+set status           = 'Published',
+    published_at     = sqlc.arg(published_at)::timestamp,
+    lease_owner      = null,
+    lease_expires_at = null
+where id = any (sqlc.arg(ids)::bigint[]);
 
--- name: DeleteMessage :exec
+-- name: FailMessage :execrows
+update outbox
+set status           = sqlc.arg(status)::text,
+    attempts         = attempts + 1,
+    last_error       = sqlc.arg(last_error)::text,
+    first_failed_at  = coalesce(first_failed_at, sqlc.arg(now)::timestamp),
+    next_send_at     = sqlc.arg(next_send_at)::timestamp,
+    lease_owner      = null,
+    lease_expires_at = null
+where id = sqlc.arg(id)
+  and lease_owner = sqlc.arg(lease_owner)::text;
+
+-- name: SelectParkedMessages :many
+select *
+from outbox
+where status = 'Parked'
+  and (sqlc.narg(before_id)::bigint is null or id < sqlc.narg(before_id))
+order by id desc
+limit sqlc.arg(max_count);
+
+-- name: SelectOutboxMessage :one
+select *
+from outbox
+where id = $1;
+
+-- name: RequeueMessage :execrows
+update outbox
+set status          = 'Pending',
+    attempts        = 0,
+    last_error      = '',
+    first_failed_at = null,
+    next_send_at    = $2
+where id = $1
+  and status = 'Parked';
+
+-- name: PurgeMessage :execrows
 delete from outbox
-where id = $1;
\ No newline at end of file
+where id = $1
+  and status = 'Parked';
+
+-- name: SelectPublishedMessages :many
+select id, payload, topic, next_send_at, key, headers, data, aggregate_id, sequence, created_at, published_at
+from outbox
+where aggregate_id = sqlc.arg(aggregate_id)
+  and status = 'Published'
+  and (sqlc.narg(before_id)::bigint is null or id < sqlc.narg(before_id))
+union all
+select id, payload, topic, next_send_at, key, headers, data, aggregate_id, sequence, created_at, published_at
+from outbox_archive
+where aggregate_id = sqlc.arg(aggregate_id)
+  and (sqlc.narg(before_id)::bigint is null or id < sqlc.narg(before_id))
+order by id desc
+limit sqlc.arg(max_count);
+
+-- name: ListOutboxPartitions :many
+select c.relname::text                           as name,
+       i.inhrelid is null                        as detached,
+       coalesce(i.inhdetachpending, false)::bool as detach_pending
+from pg_class c
+         left join pg_inherits i on i.inhrelid = c.oid and i.inhparent = 'outbox'::regclass
+where c.relkind = 'r'
+  and c.relnamespace = current_schema()::regnamespace
+  and c.relname like 'outbox\_p%'
+order by c.relname;
+
+-- name: SelectReplayMessages :many
+select id, payload, topic, key, headers, data, aggregate_id
+from outbox
+where status = 'Published'
+  and id > sqlc.arg(after_id)
+  and headers ->> 'replay-of' is null
+  and (sqlc.narg(topic)::text is null or topic = sqlc.narg(topic))
+  and (sqlc.narg(aggregate_id)::uuid is null or aggregate_id = sqlc.narg(aggregate_id))
+  and (sqlc.narg(created_from)::timestamp is null or created_at >= sqlc.narg(created_from))
+  and (sqlc.narg(created_to)::timestamp is null or created_at < sqlc.narg(created_to))
+union all
+select id, payload, topic, key, headers, data, aggregate_id
+from outbox_archive
+where id > sqlc.arg(after_id)
+  and headers ->> 'replay-of' is null
+  and (sqlc.narg(topic)::text is null or topic = sqlc.narg(topic))
+  and (sqlc.narg(aggregate_id)::uuid is null or aggregate_id = sqlc.narg(aggregate_id))
+  and (sqlc.narg(created_from)::timestamp is null or created_at >= sqlc.narg(created_from))
+  and (sqlc.narg(created_to)::timestamp is null or created_at < sqlc.narg(created_to))
+order by id
+limit sqlc.arg(max_count);
@@ -0,0 +1,51 @@
+-- name: SelectAgingBuckets :many
+select currency,
+       case
+           when sqlc.arg(as_of)::date - due_data < 0 then 'current'
+           when sqlc.arg(as_of)::date - due_data <= 30 then '0-30'
+           when sqlc.arg(as_of)::date - due_data <= 60 then '31-60'
+           when sqlc.arg(as_of)::date - due_data <= 90 then '61-90'
+           else '90+'
+           end::text         as bucket,
+       count(*)              as invoices,
+       sum(amount)::bigint   as amount
+from invoices
+where status <> 'Rejected'
+  and created_at < sqlc.arg(created_before)
+group by currency, bucket
+order by currency, bucket;
+
+-- name: SelectRevenueByMonth :many
+select date_trunc('month', created_at at time zone 'UTC' at time zone sqlc.arg(time_zone)::text)::date as month,
+       currency,
+       count(*)            as invoices,
+       sum(amount)::bigint as amount
+from invoices
+where status = 'Approved'
+  and created_at >= sqlc.arg(from_time)
+  and created_at < sqlc.arg(to_time)
+group by month, currency
+order by month, currency;
+
+-- name: SelectRevenueByCustomer :many
+select date_trunc('month', created_at at time zone 'UTC' at time zone sqlc.arg(time_zone)::text)::date as month,
+       currency,
+       customer_id,
+       count(*)            as invoices,
+       sum(amount)::bigint as amount
+from invoices
+where status = 'Approved'
+  and created_at >= sqlc.arg(from_time)
+  and created_at < sqlc.arg(to_time)
+group by month, currency, customer_id
+order by month, currency, customer_id;
+
+-- name: SelectDSOTotals :many
+select currency,
+       sum(amount)::bigint                                                          as receivables,
+       coalesce(sum(amount) filter (where created_at >= sqlc.arg(from_time)), 0)::bigint as sales
+from invoices
+where status <> 'Rejected'
+  and created_at < sqlc.arg(to_time)
+group by currency
+order by currency;
@@ -0,0 +1,92 @@
+-- name: CreateWebhookEndpoint :exec
+insert into webhook_endpoints (id, url, secret, event_types, created_at)
+values ($1, $2, $3, $4, $5);
+
+-- name: SelectWebhookEndpoints :many
+select *
+from webhook_endpoints
+order by created_at, id;
+
+-- name: DeleteWebhookEndpoint :execrows
+delete
+from webhook_endpoints
+where id = $1;
+
+-- name: FanOutWebhookDeliveries :exec
+insert into webhook_deliveries (endpoint_id, event_type, payload, status, next_attempt_at, created_at, updated_at)
+select e.id, o.topic, o.data, 'Pending', sqlc.arg(now)::timestamp, sqlc.arg(now)::timestamp, sqlc.arg(now)::timestamp
+from outbox o
+         join webhook_endpoints e on o.topic = any (e.event_types)
+where o.id = any (sqlc.arg(outbox_ids)::bigint[])
+order by o.id;
+
+-- name: SelectDueWebhookDeliveries :many
+select d.id, d.event_type, d.payload, d.attempts, e.url, e.secret
+from webhook_deliveries d
+         join webhook_endpoints e on e.id = d.endpoint_id
+where d.status = 'Pending'
+  and d.next_attempt_at <= sqlc.arg(now)::timestamp
+  and (d.lease_expires_at is null or d.lease_expires_at <= sqlc.arg(now)::timestamp)
+order by d.next_attempt_at
+limit sqlc.arg(max_count) for update of d skip locked;
+
+-- name: LeaseWebhookDeliveries :exec
+update webhook_deliveries
+set lease_owner      = sqlc.arg(lease_owner)::text,
+    lease_expires_at = sqlc.arg(lease_expires_at)::timestamp
+where id = any (sqlc.arg(ids)::bigint[]);
+
+-- name: AddWebhookDeliveryAttempt :exec
+insert into webhook_delivery_attempts (delivery_id, attempt, status_code, error, response, duration_ms, attempted_at)
+select d.id,
+       d.attempts + 1,
+       sqlc.arg(status_code)::int,
+       sqlc.arg(error)::text,
+       sqlc.arg(response)::text,
+       sqlc.arg(duration_ms)::bigint,
+       sqlc.arg(attempted_at)::timestamp
+from webhook_deliveries d
+where d.id = sqlc.arg(delivery_id);
+
+-- name: CompleteWebhookDelivery :execrows
+update webhook_deliveries
+set status           = sqlc.arg(status),
+    attempts         = attempts + 1,
+    next_attempt_at  = sqlc.arg(next_attempt_at),
+    last_error       = sqlc.arg(last_error),
+    updated_at       = sqlc.arg(updated_at),
+    lease_owner      = null,
+    lease_expires_at = null
+where id = sqlc.arg(id)
+  and lease_owner = sqlc.arg(lease_owner)::text;
+
+-- name: SelectWebhookDeliveries :many
+select *
+from webhook_deliveries
+where (sqlc.narg(endpoint_id)::uuid is null or endpoint_id = sqlc.narg(endpoint_id))
+  and (sqlc.narg(status)::text is null or status = sqlc.narg(status))
+  and (sqlc.narg(before_id)::bigint is null or id < sqlc.narg(before_id))
+order by id desc
+limit sqlc.arg(max_count);
+
+-- name: SelectWebhookDelivery :one
+select *
+from webhook_deliveries
+where id = $1;
+
+-- name: SelectWebhookDeliveryAttempts :many
+select *
+from webhook_delivery_attempts
+where delivery_id = $1
+order by id;
+
+-- name: RedeliverWebhookDelivery :execrows
+update webhook_deliveries
+set status           = 'Pending',
+    attempts         = 0,
+    next_attempt_at  = $2,
+    last_error       = '',
+    updated_at       = $2,
+    lease_owner      = null,
+    lease_expires_at = null
+where id = $1;
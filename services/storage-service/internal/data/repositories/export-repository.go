@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"storage-service/internal/dto"
+)
+
+// Cursors are not supported by sqlc, so the export statements are kept here.
+const declareExportCursor = `declare invoice_export no scroll cursor for
+select i.id,
+       i.customer_id,
+       i.amount,
+       i.currency,
+       i.due_data,
+       i.created_at,
+       i.updated_at,
+       i.notes,
+       i.status,
+       coalesce((select json_agg(json_build_object(
+                                         'description', it.description,
+                                         'quantity', it.quantity,
+                                         'unit_price', it.unit_price,
+                                         'total', it.total) order by it.id)
+                 from invoice_items it
+                 where it.invoice_id = i.id), '[]')
+from invoices i
+where ($1::timestamp is null or i.created_at >= $1)
+  and ($2::timestamp is null or i.created_at < $2)
+  and ($3::text is null or i.status = $3)
+  and ($4::uuid is null or i.customer_id = $4)
+order by i.created_at, i.id`
+
+const fetchExportCursor = `fetch forward %d from invoice_export`
+
+const closeExportCursor = `close invoice_export`
+
+type exportItem struct {
+	Description string `json:"description"`
+	Quantity    int32  `json:"quantity"`
+	UnitPrice   int64  `json:"unit_price"`
+	Total       int64  `json:"total"`
+}
+
+type Export struct{}
+
+func NewExport() *Export {
+	return &Export{}
+}
+
+// Stream reads the invoices matching filter through a server-side cursor and passes them
+// to consume in batches of batchSize, so only one batch is held in memory at a time.
+func (r *Export) Stream(
+	ctx context.Context,
+	tx *sql.Tx,
+	filter dto.ExportFilter,
+	batchSize int32,
+	consume func([]dto.ExportedInvoice) error,
+) error {
+	_, err := tx.ExecContext(ctx, declareExportCursor,
+		sql.NullTime{Time: filter.From, Valid: !filter.From.IsZero()},
+		sql.NullTime{Time: filter.To, Valid: !filter.To.IsZero()},
+		sql.NullString{String: string(filter.Status), Valid: filter.Status != dto.StatusNil},
+		uuid.NullUUID{UUID: filter.CustomerID, Valid: filter.CustomerID != uuid.Nil},
+	)
+	if err != nil {
+		return fmt.Errorf("declare export cursor query failed: %w", err)
+	}
+
+	for {
+		batch, err := fetchExportBatch(ctx, tx, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		if err := consume(batch); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, closeExportCursor); err != nil {
+		return fmt.Errorf("close export cursor query failed: %w", err)
+	}
+
+	return nil
+}
+
+func fetchExportBatch(ctx context.Context, tx *sql.Tx, batchSize int32) ([]dto.ExportedInvoice, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(fetchExportCursor, batchSize))
+	if err != nil {
+		return nil, fmt.Errorf("fetch export cursor query failed: %w", err)
+	}
+	defer rows.Close()
+
+	res := make([]dto.ExportedInvoice, 0, batchSize)
+	for rows.Next() {
+		var invoice dto.ExportedInvoice
+		var status string
+		var itemsJSON []byte
+		err := rows.Scan(
+			&invoice.Invoice.ID,
+			&invoice.Invoice.CustomerID,
+			&invoice.Invoice.Amount,
+			&invoice.Invoice.Currency,
+			&invoice.Invoice.DueDate,
+			&invoice.Invoice.CreatedAt,
+			&invoice.Invoice.UpdatedAt,
+			&invoice.Invoice.Notes,
+			&status,
+			&itemsJSON,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exported invoice: %w", err)
+		}
+		invoice.Status = dto.InvoiceStatus(status)
+		invoice.Invoice.Items, err = exportItemsFromDB(itemsJSON)
+		if err != nil {
+			return nil, fmt.Errorf("invalid items of invoice %s: %w", invoice.Invoice.ID, err)
+		}
+		res = append(res, invoice)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read exported invoices: %w", err)
+	}
+
+	return res, nil
+}
+
+func exportItemsFromDB(itemsJSON []byte) ([]dto.Item, error) {
+	var items []exportItem
+	if err := json.Unmarshal(itemsJSON, &items); err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.Item, len(items))
+	for i, item := range items {
+		res[i] = dto.Item{
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Total:       item.Total,
+		}
+	}
+
+	return res, nil
+}
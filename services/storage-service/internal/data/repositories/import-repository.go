@@ -0,0 +1,206 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/google/uuid"
+	"storage-service/internal/data/postgres/generated/queries"
+	"storage-service/internal/dto"
+	"time"
+)
+
+type Import struct {
+	qs *queries.Queries
+}
+
+func NewImport(dbtx queries.DBTX) *Import {
+	return &Import{
+		qs: queries.New(dbtx),
+	}
+}
+
+func (r *Import) Create(ctx context.Context, tx *sql.Tx, id uuid.UUID, format string) error {
+	qs := r.qs.WithTx(tx)
+
+	err := qs.CreateImportJob(ctx, createImportJobParams(id, format, time.Now().UTC()))
+	if err != nil {
+		return fmt.Errorf("create import job query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Import) Get(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*dto.ImportJob, error) {
+	qs := r.qs.WithTx(tx)
+
+	job, err := qs.SelectImportJob(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get import job query failed: %w", err)
+	}
+
+	return importJobFromDB(job), nil
+}
+
+func (r *Import) Lock(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*dto.ImportJob, error) {
+	qs := r.qs.WithTx(tx)
+
+	job, err := qs.LockImportJob(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("lock import job query failed: %w", err)
+	}
+
+	return importJobFromDB(job), nil
+}
+
+func (r *Import) GetByStatus(ctx context.Context, tx *sql.Tx, status dto.ImportJobStatus) ([]dto.ImportJob, error) {
+	qs := r.qs.WithTx(tx)
+
+	jobs, err := qs.SelectImportJobsByStatus(ctx, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("get import jobs query failed: %w", err)
+	}
+
+	res := make([]dto.ImportJob, len(jobs))
+	for i, job := range jobs {
+		res[i] = *importJobFromDB(job)
+	}
+
+	return res, nil
+}
+
+func (r *Import) UpdateProgress(ctx context.Context, tx *sql.Tx, job *dto.ImportJob) error {
+	qs := r.qs.WithTx(tx)
+
+	err := qs.UpdateImportJobProgress(ctx, createUpdateImportJobProgressParams(job, time.Now().UTC()))
+	if err != nil {
+		return fmt.Errorf("update import job progress query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Import) Finish(
+	ctx context.Context,
+	tx *sql.Tx,
+	id uuid.UUID,
+	status dto.ImportJobStatus,
+	errorMessage string,
+) error {
+	qs := r.qs.WithTx(tx)
+
+	err := qs.FinishImportJob(ctx, createFinishImportJobParams(id, status, errorMessage, time.Now().UTC()))
+	if err != nil {
+		return fmt.Errorf("finish import job query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Import) AddResult(ctx context.Context, tx *sql.Tx, jobID uuid.UUID, result dto.ImportRowResult) error {
+	qs := r.qs.WithTx(tx)
+
+	err := qs.AddImportJobResult(ctx, importRowResultToDB(jobID, result))
+	if err != nil {
+		return fmt.Errorf("add import job result query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Import) GetResults(
+	ctx context.Context,
+	tx *sql.Tx,
+	jobID uuid.UUID,
+	filter dto.ImportResultFilter,
+) ([]dto.ImportRowResult, error) {
+	qs := r.qs.WithTx(tx)
+
+	rows, err := qs.SelectImportJobResults(ctx, createSelectImportJobResultsParams(jobID, filter))
+	if err != nil {
+		return nil, fmt.Errorf("get import job results query failed: %w", err)
+	}
+
+	return importRowResultsFromDB(rows), nil
+}
+
+func createImportJobParams(id uuid.UUID, format string, now time.Time) queries.CreateImportJobParams {
+	return queries.CreateImportJobParams{
+		ID:        id,
+		Format:    format,
+		CreatedAt: now,
+	}
+}
+
+func createUpdateImportJobProgressParams(job *dto.ImportJob, now time.Time) queries.UpdateImportJobProgressParams {
+	return queries.UpdateImportJobProgressParams{
+		ID:            job.ID,
+		ProcessedRows: job.ProcessedRows,
+		SucceededRows: job.SucceededRows,
+		FailedRows:    job.FailedRows,
+		UpdatedAt:     now,
+	}
+}
+
+func createFinishImportJobParams(
+	id uuid.UUID,
+	status dto.ImportJobStatus,
+	errorMessage string,
+	now time.Time,
+) queries.FinishImportJobParams {
+	return queries.FinishImportJobParams{
+		ID:        id,
+		Status:    string(status),
+		Error:     errorMessage,
+		UpdatedAt: now,
+	}
+}
+
+func createSelectImportJobResultsParams(jobID uuid.UUID, filter dto.ImportResultFilter) queries.SelectImportJobResultsParams {
+	return queries.SelectImportJobResultsParams{
+		JobID:      jobID,
+		AfterRow:   filter.AfterRow,
+		OnlyFailed: filter.OnlyFailed,
+		MaxCount:   filter.Limit,
+	}
+}
+
+func importRowResultToDB(jobID uuid.UUID, result dto.ImportRowResult) queries.AddImportJobResultParams {
+	return queries.AddImportJobResultParams{
+		JobID:     jobID,
+		RowNumber: result.Number,
+		InvoiceID: uuid.NullUUID{
+			UUID:  result.InvoiceID,
+			Valid: result.InvoiceID != uuid.Nil,
+		},
+		Error: result.Error,
+	}
+}
+
+func importJobFromDB(job queries.ImportJob) *dto.ImportJob {
+	return &dto.ImportJob{
+		ID:            job.ID,
+		Format:        job.Format,
+		Status:        dto.ImportJobStatus(job.Status),
+		ProcessedRows: job.ProcessedRows,
+		SucceededRows: job.SucceededRows,
+		FailedRows:    job.FailedRows,
+		Error:         job.Error,
+		CreatedAt:     job.CreatedAt,
+		UpdatedAt:     job.UpdatedAt,
+	}
+}
+
+func importRowResultsFromDB(rows []queries.SelectImportJobResultsRow) []dto.ImportRowResult {
+	res := make([]dto.ImportRowResult, len(rows))
+
+	for i, row := range rows {
+		res[i] = dto.ImportRowResult{
+			Number:    row.RowNumber,
+			InvoiceID: row.InvoiceID.UUID,
+			Error:     row.Error,
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/google/uuid"
+	"storage-service/internal/data/postgres/generated/queries"
+	"storage-service/internal/dto"
+	"time"
+)
+
+type InvoiceEvent struct {
+	qs *queries.Queries
+}
+
+func NewInvoiceEvent(dbtx queries.DBTX) *InvoiceEvent {
+	return &InvoiceEvent{
+		qs: queries.New(dbtx),
+	}
+}
+
+func (r *InvoiceEvent) Add(
+	ctx context.Context,
+	tx *sql.Tx,
+	invoiceID uuid.UUID,
+	eventType dto.InvoiceEventType,
+	status dto.InvoiceStatus,
+) error {
+	qs := r.qs.WithTx(tx)
+
+	err := qs.AddInvoiceEvent(ctx, queries.AddInvoiceEventParams{
+		InvoiceID:  invoiceID,
+		Type:       string(eventType),
+		Status:     string(status),
+		OccurredAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("add invoice event query failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetAfter must not be called in a transaction: only events of transactions finished
+// before the query started are returned, so a transaction snapshot would stall the feed.
+func (r *InvoiceEvent) GetAfter(ctx context.Context, cursor dto.EventCursor, maxCount int32) ([]dto.InvoiceEvent, error) {
+	events, err := r.qs.SelectInvoiceEvents(ctx, queries.SelectInvoiceEventsParams{
+		AfterTxID: cursor.TxID,
+		AfterID:   cursor.ID,
+		MaxCount:  maxCount,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("select invoice events query failed: %w", err)
+	}
+
+	res := make([]dto.InvoiceEvent, len(events))
+	for i, event := range events {
+		res[i] = dto.InvoiceEvent{
+			Cursor: dto.EventCursor{
+				TxID: event.TxID,
+				ID:   event.ID,
+			},
+			Type:       dto.InvoiceEventType(event.Type),
+			InvoiceID:  event.InvoiceID,
+			Status:     dto.InvoiceStatus(event.Status),
+			OccurredAt: event.OccurredAt,
+		}
+	}
+
+	return res, nil
+}
+
+// Head returns the cursor after which only events of running and future transactions follow.
+func (r *InvoiceEvent) Head(ctx context.Context) (dto.EventCursor, error) {
+	txID, err := r.qs.SelectInvoiceEventsHead(ctx)
+	if err != nil {
+		return dto.EventCursor{}, fmt.Errorf("select invoice events head query failed: %w", err)
+	}
+
+	return dto.EventCursor{TxID: txID}, nil
+}
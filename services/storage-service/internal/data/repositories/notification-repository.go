@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"storage-service/internal/data/postgres/generated/queries"
+	"storage-service/internal/dto"
+	"time"
+)
+
+type Notification struct {
+	qs *queries.Queries
+}
+
+func NewNotification(dbtx queries.DBTX) *Notification {
+	return &Notification{
+		qs: queries.New(dbtx),
+	}
+}
+
+// Claim reserves the notification until lockedUntil. It fails if the notification
+// was already sent or is reserved by someone else.
+func (r *Notification) Claim(
+	ctx context.Context,
+	tx *sql.Tx,
+	key dto.NotificationKey,
+	recipient string,
+	lockedUntil time.Time,
+) (bool, error) {
+	qs := r.qs.WithTx(tx)
+
+	rows, err := qs.ClaimNotification(ctx, queries.ClaimNotificationParams{
+		InvoiceID:   key.InvoiceID,
+		EventType:   key.EventType,
+		Recipient:   recipient,
+		LockedUntil: lockedUntil,
+		CreatedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("claim notification query failed: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (r *Notification) GetStatus(ctx context.Context, tx *sql.Tx, key dto.NotificationKey) (string, error) {
+	qs := r.qs.WithTx(tx)
+
+	notification, err := qs.SelectNotification(ctx, queries.SelectNotificationParams{
+		InvoiceID: key.InvoiceID,
+		EventType: key.EventType,
+	})
+	if err != nil {
+		return "", fmt.Errorf("select notification query failed: %w", err)
+	}
+
+	return notification.Status, nil
+}
+
+func (r *Notification) MarkSent(ctx context.Context, tx *sql.Tx, key dto.NotificationKey) (bool, error) {
+	qs := r.qs.WithTx(tx)
+
+	rows, err := qs.MarkNotificationSent(ctx, queries.MarkNotificationSentParams{
+		InvoiceID: key.InvoiceID,
+		EventType: key.EventType,
+		SentAt: sql.NullTime{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("mark notification sent query failed: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (r *Notification) Release(ctx context.Context, tx *sql.Tx, key dto.NotificationKey) error {
+	qs := r.qs.WithTx(tx)
+
+	err := qs.ReleaseNotification(ctx, queries.ReleaseNotificationParams{
+		InvoiceID: key.InvoiceID,
+		EventType: key.EventType,
+	})
+	if err != nil {
+		return fmt.Errorf("release notification query failed: %w", err)
+	}
+
+	return nil
+}
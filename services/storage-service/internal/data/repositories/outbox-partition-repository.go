@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"storage-service/internal/data/postgres/generated/queries"
+	"storage-service/internal/dto"
+	"strings"
+	"time"
+)
+
+// Partitions are managed with DDL, which is not supported by sqlc, so the statements are kept here.
+const createOutboxPartition = `create table if not exists %s partition of outbox for values from ('%s') to ('%s')`
+
+const hasUnpublishedMessages = `select exists (select 1 from %s where status <> 'Published')`
+
+const archivePublishedMessages = `insert into outbox_archive (id, payload, topic, next_send_at, key, headers, data,
+                            aggregate_id, sequence, created_at, published_at)
+select id,
+       payload,
+       topic,
+       next_send_at,
+       key,
+       headers,
+       data,
+       aggregate_id,
+       sequence,
+       created_at,
+       published_at
+from %s
+where status = 'Published'
+on conflict (id) do nothing`
+
+// A concurrent detach doesn't block writes to the outbox, but it can't run in a transaction.
+const detachOutboxPartition = `alter table outbox detach partition %s concurrently`
+
+// finalizeOutboxPartition completes a concurrent detach that was interrupted.
+const finalizeOutboxPartition = `alter table outbox detach partition %s finalize`
+
+const dropOutboxPartition = `drop table if exists %s`
+
+const (
+	outboxPartitionPrefix = "outbox_p"
+	outboxPartitionLayout = "20060102"
+	partitionBoundLayout  = "2006-01-02"
+)
+
+type OutboxPartitions struct {
+	dbtx queries.DBTX
+	qs   *queries.Queries
+}
+
+func NewOutboxPartitions(dbtx queries.DBTX) *OutboxPartitions {
+	return &OutboxPartitions{
+		dbtx: dbtx,
+		qs:   queries.New(dbtx),
+	}
+}
+
+// List returns the daily partitions of the outbox ordered by day, including tables
+// detached but not dropped yet. Partitions that are not named by the day they hold
+// are left out.
+func (r *OutboxPartitions) List(ctx context.Context, tx *sql.Tx) ([]dto.OutboxPartition, error) {
+	qs := r.qs.WithTx(tx)
+
+	rows, err := qs.ListOutboxPartitions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list outbox partitions query failed: %w", err)
+	}
+
+	res := make([]dto.OutboxPartition, 0, len(rows))
+	for _, row := range rows {
+		day, ok := strings.CutPrefix(row.Name, outboxPartitionPrefix)
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(outboxPartitionLayout, day)
+		if err != nil {
+			continue
+		}
+		res = append(res, dto.OutboxPartition{
+			Name:          row.Name,
+			Day:           t,
+			Detached:      row.Detached,
+			DetachPending: row.DetachPending,
+		})
+	}
+
+	return res, nil
+}
+
+// Create creates the partition of the day unless it exists.
+func (r *OutboxPartitions) Create(ctx context.Context, tx *sql.Tx, day time.Time) (dto.OutboxPartition, error) {
+	partition := dto.OutboxPartition{
+		Name: outboxPartitionPrefix + day.Format(outboxPartitionLayout),
+		Day:  day,
+	}
+
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(createOutboxPartition,
+		pq.QuoteIdentifier(partition.Name),
+		day.Format(partitionBoundLayout),
+		day.AddDate(0, 0, 1).Format(partitionBoundLayout),
+	))
+	if err != nil {
+		return dto.OutboxPartition{}, fmt.Errorf("create outbox partition query failed: %w", err)
+	}
+
+	return partition, nil
+}
+
+// HasUnpublished reports whether the partition holds messages that are not published yet.
+func (r *OutboxPartitions) HasUnpublished(ctx context.Context, tx *sql.Tx, partition dto.OutboxPartition) (bool, error) {
+	var res bool
+	err := tx.QueryRowContext(ctx, fmt.Sprintf(hasUnpublishedMessages, pq.QuoteIdentifier(partition.Name))).Scan(&res)
+	if err != nil {
+		return false, fmt.Errorf("has unpublished messages query failed: %w", err)
+	}
+
+	return res, nil
+}
+
+// Archive copies the published messages of the partition to the archive and
+// returns their number.
+func (r *OutboxPartitions) Archive(ctx context.Context, tx *sql.Tx, partition dto.OutboxPartition) (int64, error) {
+	res, err := tx.ExecContext(ctx, fmt.Sprintf(archivePublishedMessages, pq.QuoteIdentifier(partition.Name)))
+	if err != nil {
+		return 0, fmt.Errorf("archive published messages query failed: %w", err)
+	}
+
+	return res.RowsAffected()
+}
+
+// Detach detaches the partition from the outbox. It runs outside of a transaction.
+func (r *OutboxPartitions) Detach(ctx context.Context, partition dto.OutboxPartition) error {
+	query := detachOutboxPartition
+	if partition.DetachPending {
+		query = finalizeOutboxPartition
+	}
+
+	_, err := r.dbtx.ExecContext(ctx, fmt.Sprintf(query, pq.QuoteIdentifier(partition.Name)))
+	if err != nil {
+		return fmt.Errorf("detach outbox partition query failed: %w", err)
+	}
+
+	return nil
+}
+
+// Drop drops the table of a detached partition.
+func (r *OutboxPartitions) Drop(ctx context.Context, tx *sql.Tx, partition dto.OutboxPartition) error {
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(dropOutboxPartition, pq.QuoteIdentifier(partition.Name)))
+	if err != nil {
+		return fmt.Errorf("drop outbox partition query failed: %w", err)
+	}
+
+	return nil
+}
@@ -3,7 +3,9 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"github.com/google/uuid"
 	"go-invoice-service/common/protocol/kafka"
 	"storage-service/internal/data/postgres/generated/queries"
 	"storage-service/internal/dto"
@@ -23,12 +25,28 @@ func NewOutbox(dbtx queries.DBTX) *Outbox {
 func (r *Outbox) ScheduleMessage(ctx context.Context, tx *sql.Tx, message dto.OutboxMessageStencil, sendAt time.Time) error {
 	qs := r.qs.WithTx(tx)
 
-	err := qs.ScheduleMessage(ctx, convertOutboxMessage(message, sendAt))
+	var sequence int64
+	if message.AggregateID != uuid.Nil {
+		// The aggregate row stays locked until tx ends, so messages of one
+		// aggregate are committed in the order of their sequence numbers.
+		var err error
+		sequence, err = qs.NextOutboxSequence(ctx, message.AggregateID)
+		if err != nil {
+			return fmt.Errorf("next outbox sequence query failed: %w", err)
+		}
+	}
+
+	params, err := convertOutboxMessage(message, sequence, sendAt)
+	if err != nil {
+		return err
+	}
+
+	err = qs.ScheduleMessage(ctx, params)
 	if err != nil {
 		return fmt.Errorf("schedule message query failed: %w", err)
 	}
 
-	return nil
+	return notify(ctx, qs)
 }
 
 func (r *Outbox) GetMessages(ctx context.Context, tx *sql.Tx, limit int32) ([]dto.OutboxMessage, error) {
@@ -39,74 +57,403 @@ func (r *Outbox) GetMessages(ctx context.Context, tx *sql.Tx, limit int32) ([]dt
 		return nil, fmt.Errorf("get outbox messages query failed: %w", err)
 	}
 
-	return retrieveMessages(res), nil
+	return retrieveMessages(res)
 }
 
-func (r *Outbox) UpdateMessagesSendTime(
+// CountDue returns the number of pending messages that are due and not leased, and
+// not held back by an unpublished earlier message of their aggregate.
+func (r *Outbox) CountDue(ctx context.Context, tx *sql.Tx) (int64, error) {
+	qs := r.qs.WithTx(tx)
+
+	res, err := qs.CountDueMessages(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("count due messages query failed: %w", err)
+	}
+
+	return res, nil
+}
+
+func (r *Outbox) Lease(
 	ctx context.Context,
 	tx *sql.Tx,
 	ids []int64,
-	deltaSec int64,
+	owner string,
+	expiresAt time.Time,
 ) error {
 	qs := r.qs.WithTx(tx)
 
-	err := qs.IncreaseNextSendAt(ctx, createIncreaseNextSendAtParams(ids, deltaSec))
+	err := qs.LeaseMessages(ctx, createLeaseMessagesParams(ids, owner, expiresAt))
 	if err != nil {
-		return fmt.Errorf("update messages send time query failed: %w", err)
+		return fmt.Errorf("lease messages query failed: %w", err)
 	}
 
 	return nil
 }
 
-func (r *Outbox) Delete(ctx context.Context, tx *sql.Tx, id int64) error {
+// LockLeaseOwners locks the messages and returns their lease owners by ID.
+// Messages that don't exist are left out.
+func (r *Outbox) LockLeaseOwners(ctx context.Context, tx *sql.Tx, ids []int64) (map[int64]string, error) {
+	qs := r.qs.WithTx(tx)
+
+	rows, err := qs.LockMessages(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("lock messages query failed: %w", err)
+	}
+
+	res := make(map[int64]string, len(rows))
+	for _, row := range rows {
+		res[row.ID] = row.LeaseOwner.String
+	}
+	return res, nil
+}
+
+// Release clears the leases of messages leased to owner, so they can be fetched
+// right away. It returns the number of released messages.
+func (r *Outbox) Release(ctx context.Context, tx *sql.Tx, owner string, ids []int64) (int64, error) {
+	qs := r.qs.WithTx(tx)
+
+	rows, err := qs.ReleaseMessages(ctx, queries.ReleaseMessagesParams{
+		Ids:        ids,
+		LeaseOwner: owner,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("release messages query failed: %w", err)
+	}
+	if rows == 0 {
+		return 0, nil
+	}
+
+	return rows, notify(ctx, qs)
+}
+
+// Fail records a failed publish attempt of a message leased to owner and releases
+// the lease. It returns false if the message is not leased to owner anymore.
+func (r *Outbox) Fail(ctx context.Context, tx *sql.Tx, owner string, failure dto.OutboxFailure) (bool, error) {
+	qs := r.qs.WithTx(tx)
+	now := time.Now().UTC()
+
+	nextSendAt := now
+	if failure.Status == dto.OutboxStatusPending {
+		nextSendAt = failure.NextSendAt.UTC()
+	}
+
+	rows, err := qs.FailMessage(ctx, queries.FailMessageParams{
+		Status:     string(failure.Status),
+		LastError:  failure.Error,
+		Now:        now,
+		NextSendAt: nextSendAt,
+		ID:         failure.MessageID,
+		LeaseOwner: owner,
+	})
+	if err != nil {
+		return false, fmt.Errorf("fail message query failed: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (r *Outbox) GetParked(ctx context.Context, tx *sql.Tx, filter dto.OutboxEntryFilter) ([]dto.OutboxEntry, error) {
+	qs := r.qs.WithTx(tx)
+
+	messages, err := qs.SelectParkedMessages(ctx, queries.SelectParkedMessagesParams{
+		BeforeID: sql.NullInt64{Int64: filter.BeforeID, Valid: filter.BeforeID > 0},
+		MaxCount: filter.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("select parked messages query failed: %w", err)
+	}
+
+	res := make([]dto.OutboxEntry, len(messages))
+	for i, m := range messages {
+		entry, err := outboxEntryFromDB(m)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = entry
+	}
+
+	return res, nil
+}
+
+func (r *Outbox) GetEntry(ctx context.Context, tx *sql.Tx, id int64) (*dto.OutboxEntry, error) {
 	qs := r.qs.WithTx(tx)
 
-	err := qs.DeleteMessage(ctx, id)
+	message, err := qs.SelectOutboxMessage(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("select outbox message query failed: %w", err)
+	}
+
+	res, err := outboxEntryFromDB(message)
 	if err != nil {
-		return fmt.Errorf("delete message query failed: %w", err)
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Requeue moves a parked message back to pending with a fresh attempts budget.
+// It returns false if there is no such parked message.
+func (r *Outbox) Requeue(ctx context.Context, tx *sql.Tx, id int64) (bool, error) {
+	qs := r.qs.WithTx(tx)
+
+	rows, err := qs.RequeueMessage(ctx, queries.RequeueMessageParams{
+		ID:         id,
+		NextSendAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("requeue message query failed: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	return true, notify(ctx, qs)
+}
+
+// Purge deletes a parked message. It returns false if there is no such parked message.
+func (r *Outbox) Purge(ctx context.Context, tx *sql.Tx, id int64) (bool, error) {
+	qs := r.qs.WithTx(tx)
+
+	rows, err := qs.PurgeMessage(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("purge message query failed: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	// The parked message held back the next messages of its aggregate.
+	return true, notify(ctx, qs)
+}
+
+func (r *Outbox) Delete(ctx context.Context, tx *sql.Tx, ids []int64) error {
+	qs := r.qs.WithTx(tx)
+
+	err := qs.DeleteMessages(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("delete messages query failed: %w", err)
+	}
+
+	// Next messages of the same aggregates are ready to be sent now.
+	return notify(ctx, qs)
+}
+
+// Publish marks messages published instead of deleting them, they are removed
+// with their partition.
+func (r *Outbox) Publish(ctx context.Context, tx *sql.Tx, ids []int64) error {
+	qs := r.qs.WithTx(tx)
+
+	err := qs.PublishMessages(ctx, queries.PublishMessagesParams{
+		PublishedAt: time.Now().UTC(),
+		Ids:         ids,
+	})
+	if err != nil {
+		return fmt.Errorf("publish messages query failed: %w", err)
+	}
+
+	// Next messages of the same aggregates are ready to be sent now.
+	return notify(ctx, qs)
+}
+
+// GetPublished returns published messages of the aggregate, both kept in the outbox
+// and archived, from the newest.
+func (r *Outbox) GetPublished(ctx context.Context, tx *sql.Tx, filter dto.OutboxPublishedFilter) ([]dto.OutboxEntry, error) {
+	qs := r.qs.WithTx(tx)
+
+	messages, err := qs.SelectPublishedMessages(ctx, queries.SelectPublishedMessagesParams{
+		AggregateID: uuid.NullUUID{UUID: filter.AggregateID, Valid: true},
+		BeforeID:    sql.NullInt64{Int64: filter.BeforeID, Valid: filter.BeforeID > 0},
+		MaxCount:    filter.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("select published messages query failed: %w", err)
+	}
+
+	res := make([]dto.OutboxEntry, len(messages))
+	for i, m := range messages {
+		headers, err := unmarshalHeaders(m.ID, m.Headers)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = dto.OutboxEntry{
+			Message: dto.OutboxMessage{
+				ID:       m.ID,
+				Sequence: m.Sequence.Int64,
+				Stencil: dto.OutboxMessageStencil{
+					Topic:       kafka.Topic(m.Topic),
+					AggregateID: m.AggregateID.UUID,
+					Key:         m.Key.String,
+					Headers:     headers,
+					Payload:     m.Payload,
+					Data:        m.Data,
+				},
+			},
+			Status:      dto.OutboxStatusPublished,
+			NextSendAt:  m.NextSendAt,
+			CreatedAt:   m.CreatedAt,
+			PublishedAt: m.PublishedAt.Time,
+		}
+	}
+
+	return res, nil
+}
+
+// GetReplayable returns published messages matching filter in the order of IDs.
+// Replays of other messages are left out.
+func (r *Outbox) GetReplayable(ctx context.Context, tx *sql.Tx, filter dto.OutboxReplayFilter) ([]dto.OutboxMessage, error) {
+	qs := r.qs.WithTx(tx)
+
+	messages, err := qs.SelectReplayMessages(ctx, queries.SelectReplayMessagesParams{
+		AfterID:     filter.AfterID,
+		Topic:       sql.NullString{String: string(filter.Topic), Valid: filter.Topic != ""},
+		AggregateID: uuid.NullUUID{UUID: filter.AggregateID, Valid: filter.AggregateID != uuid.Nil},
+		CreatedFrom: sql.NullTime{Time: filter.CreatedFrom, Valid: !filter.CreatedFrom.IsZero()},
+		CreatedTo:   sql.NullTime{Time: filter.CreatedTo, Valid: !filter.CreatedTo.IsZero()},
+		MaxCount:    filter.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("select replay messages query failed: %w", err)
+	}
+
+	res := make([]dto.OutboxMessage, len(messages))
+	for i, m := range messages {
+		headers, err := unmarshalHeaders(m.ID, m.Headers)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = dto.OutboxMessage{
+			ID: m.ID,
+			Stencil: dto.OutboxMessageStencil{
+				Topic:       kafka.Topic(m.Topic),
+				AggregateID: m.AggregateID.UUID,
+				Key:         m.Key.String,
+				Headers:     headers,
+				Payload:     m.Payload,
+				Data:        m.Data,
+			},
+		}
+	}
+
+	return res, nil
+}
+
+// notify wakes up outbox watchers once tx is committed. Postgres sends one
+// notification per transaction however many times it is called.
+func notify(ctx context.Context, qs *queries.Queries) error {
+	err := qs.NotifyOutbox(ctx)
+	if err != nil {
+		return fmt.Errorf("notify outbox query failed: %w", err)
 	}
 
 	return nil
 }
 
-func createIncreaseNextSendAtParams(ids []int64, deltaSec int64) queries.IncreaseNextSendAtParams {
-	return queries.IncreaseNextSendAtParams{
-		TimeToAddSec: deltaSec,
-		Ids:          ids,
+func createLeaseMessagesParams(ids []int64, owner string, expiresAt time.Time) queries.LeaseMessagesParams {
+	return queries.LeaseMessagesParams{
+		LeaseOwner:     owner,
+		LeaseExpiresAt: expiresAt,
+		Ids:            ids,
 	}
 }
 
-func retrieveMessages(messages []queries.GetMessagesRow) []dto.OutboxMessage {
+func retrieveMessages(messages []queries.GetMessagesRow) ([]dto.OutboxMessage, error) {
 	res := make([]dto.OutboxMessage, len(messages))
 
 	for i, m := range messages {
-		res[i] = retrieveMessage(m)
+		msg, err := retrieveMessage(m)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = msg
 	}
 
-	return res
+	return res, nil
 }
 
-func retrieveMessage(m queries.GetMessagesRow) dto.OutboxMessage {
+func retrieveMessage(m queries.GetMessagesRow) (dto.OutboxMessage, error) {
+	headers, err := unmarshalHeaders(m.ID, m.Headers)
+	if err != nil {
+		return dto.OutboxMessage{}, err
+	}
 	return dto.OutboxMessage{
-		ID: m.ID,
+		ID:           m.ID,
+		Sequence:     m.Sequence.Int64,
+		LeaseExpired: m.LeaseOwner.Valid,
+		Attempts:     m.Attempts,
 		Stencil: dto.OutboxMessageStencil{
-			Topic:   kafka.Topic(m.Topic),
-			Payload: m.Payload,
+			Topic:       kafka.Topic(m.Topic),
+			AggregateID: m.AggregateID.UUID,
+			Key:         m.Key.String,
+			Headers:     headers,
+			Payload:     m.Payload,
+		},
+	}, nil
+}
+
+func outboxEntryFromDB(m queries.Outbox) (dto.OutboxEntry, error) {
+	headers, err := unmarshalHeaders(m.ID, m.Headers)
+	if err != nil {
+		return dto.OutboxEntry{}, err
+	}
+	return dto.OutboxEntry{
+		Message: dto.OutboxMessage{
+			ID:           m.ID,
+			Sequence:     m.Sequence.Int64,
+			LeaseExpired: m.LeaseOwner.Valid,
+			Attempts:     m.Attempts,
+			Stencil: dto.OutboxMessageStencil{
+				Topic:       kafka.Topic(m.Topic),
+				AggregateID: m.AggregateID.UUID,
+				Key:         m.Key.String,
+				Headers:     headers,
+				Payload:     m.Payload,
+				Data:        m.Data,
+			},
 		},
+		Status:        dto.OutboxMessageStatus(m.Status),
+		LastError:     m.LastError,
+		FirstFailedAt: m.FirstFailedAt.Time,
+		NextSendAt:    m.NextSendAt,
+		CreatedAt:     m.CreatedAt,
+		PublishedAt:   m.PublishedAt.Time,
+	}, nil
+}
+
+func unmarshalHeaders(id int64, data json.RawMessage) (map[string]string, error) {
+	var headers map[string]string
+	if err := json.Unmarshal(data, &headers); err != nil {
+		return nil, fmt.Errorf("invalid headers of outbox message %d: %w", id, err)
 	}
+	return headers, nil
 }
 
 func createGetMessagesParams(limit int32, now time.Time) queries.GetMessagesParams {
 	return queries.GetMessagesParams{
-		NextSendAt: now,
-		Limit:      limit,
+		Now:      now,
+		MaxCount: limit,
 	}
 }
 
-func convertOutboxMessage(message dto.OutboxMessageStencil, sendAt time.Time) queries.ScheduleMessageParams {
-	return queries.ScheduleMessageParams{
-		Payload:    message.Payload,
-		Topic:      string(message.Topic),
-		NextSendAt: sendAt,
+func convertOutboxMessage(
+	message dto.OutboxMessageStencil,
+	sequence int64,
+	sendAt time.Time,
+) (queries.ScheduleMessageParams, error) {
+	headers := message.Headers
+	if headers == nil {
+		headers = map[string]string{}
 	}
+	headersJSON, err := json.Marshal(headers)
+	if err != nil {
+		return queries.ScheduleMessageParams{}, fmt.Errorf("marshalling outbox message headers failed: %w", err)
+	}
+	return queries.ScheduleMessageParams{
+		Payload:     message.Payload,
+		Data:        message.Data,
+		Topic:       string(message.Topic),
+		Key:         sql.NullString{String: message.Key, Valid: message.Key != ""},
+		Headers:     headersJSON,
+		NextSendAt:  sendAt,
+		AggregateID: uuid.NullUUID{UUID: message.AggregateID, Valid: message.AggregateID != uuid.Nil},
+		Sequence:    sql.NullInt64{Int64: sequence, Valid: message.AggregateID != uuid.Nil},
+	}, nil
 }
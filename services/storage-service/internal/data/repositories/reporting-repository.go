@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"storage-service/internal/data/postgres/generated/queries"
+	"storage-service/internal/dto"
+	"time"
+)
+
+type Reporting struct {
+	qs *queries.Queries
+}
+
+func NewReporting(dbtx queries.DBTX) *Reporting {
+	return &Reporting{
+		qs: queries.New(dbtx),
+	}
+}
+
+func (r *Reporting) GetAging(ctx context.Context, asOf time.Time, createdBefore time.Time) ([]dto.AgingBucket, error) {
+	rows, err := r.qs.SelectAgingBuckets(ctx, queries.SelectAgingBucketsParams{
+		AsOf:          asOf,
+		CreatedBefore: createdBefore.UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("select aging buckets query failed: %w", err)
+	}
+
+	res := make([]dto.AgingBucket, len(rows))
+	for i, row := range rows {
+		res[i] = dto.AgingBucket{
+			Currency: row.Currency,
+			Bucket:   row.Bucket,
+			Invoices: row.Invoices,
+			Amount:   row.Amount,
+		}
+	}
+
+	return res, nil
+}
+
+func (r *Reporting) GetRevenue(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+	timeZone string,
+	byCustomer bool,
+) ([]dto.RevenueRow, error) {
+	if byCustomer {
+		return r.getRevenueByCustomer(ctx, from, to, timeZone)
+	}
+
+	rows, err := r.qs.SelectRevenueByMonth(ctx, queries.SelectRevenueByMonthParams{
+		TimeZone: timeZone,
+		FromTime: from.UTC(),
+		ToTime:   to.UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("select revenue by month query failed: %w", err)
+	}
+
+	res := make([]dto.RevenueRow, len(rows))
+	for i, row := range rows {
+		res[i] = dto.RevenueRow{
+			Month:    row.Month,
+			Currency: row.Currency,
+			Invoices: row.Invoices,
+			Amount:   row.Amount,
+		}
+	}
+
+	return res, nil
+}
+
+func (r *Reporting) getRevenueByCustomer(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+	timeZone string,
+) ([]dto.RevenueRow, error) {
+	rows, err := r.qs.SelectRevenueByCustomer(ctx, queries.SelectRevenueByCustomerParams{
+		TimeZone: timeZone,
+		FromTime: from.UTC(),
+		ToTime:   to.UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("select revenue by customer query failed: %w", err)
+	}
+
+	res := make([]dto.RevenueRow, len(rows))
+	for i, row := range rows {
+		res[i] = dto.RevenueRow{
+			Month:      row.Month,
+			Currency:   row.Currency,
+			CustomerID: row.CustomerID,
+			Invoices:   row.Invoices,
+			Amount:     row.Amount,
+		}
+	}
+
+	return res, nil
+}
+
+func (r *Reporting) GetDSOTotals(ctx context.Context, from time.Time, to time.Time) ([]dto.DSORow, error) {
+	rows, err := r.qs.SelectDSOTotals(ctx, queries.SelectDSOTotalsParams{
+		FromTime: from.UTC(),
+		ToTime:   to.UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("select dso totals query failed: %w", err)
+	}
+
+	res := make([]dto.DSORow, len(rows))
+	for i, row := range rows {
+		res[i] = dto.DSORow{
+			Currency:    row.Currency,
+			Receivables: row.Receivables,
+			Sales:       row.Sales,
+		}
+	}
+
+	return res, nil
+}
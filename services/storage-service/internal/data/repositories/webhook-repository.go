@@ -0,0 +1,254 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/google/uuid"
+	"storage-service/internal/data/postgres/generated/queries"
+	"storage-service/internal/dto"
+	"time"
+)
+
+type Webhook struct {
+	qs *queries.Queries
+}
+
+func NewWebhook(dbtx queries.DBTX) *Webhook {
+	return &Webhook{
+		qs: queries.New(dbtx),
+	}
+}
+
+func (r *Webhook) CreateEndpoint(ctx context.Context, tx *sql.Tx, endpoint *dto.WebhookEndpoint) error {
+	qs := r.qs.WithTx(tx)
+
+	err := qs.CreateWebhookEndpoint(ctx, queries.CreateWebhookEndpointParams{
+		ID:         endpoint.ID,
+		Url:        endpoint.URL,
+		Secret:     endpoint.Secret,
+		EventTypes: endpoint.EventTypes,
+		CreatedAt:  endpoint.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("create webhook endpoint query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Webhook) GetEndpoints(ctx context.Context, tx *sql.Tx) ([]dto.WebhookEndpoint, error) {
+	qs := r.qs.WithTx(tx)
+
+	endpoints, err := qs.SelectWebhookEndpoints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("select webhook endpoints query failed: %w", err)
+	}
+
+	res := make([]dto.WebhookEndpoint, len(endpoints))
+	for i, endpoint := range endpoints {
+		res[i] = dto.WebhookEndpoint{
+			ID:         endpoint.ID,
+			URL:        endpoint.Url,
+			Secret:     endpoint.Secret,
+			EventTypes: endpoint.EventTypes,
+			CreatedAt:  endpoint.CreatedAt,
+		}
+	}
+
+	return res, nil
+}
+
+func (r *Webhook) DeleteEndpoint(ctx context.Context, tx *sql.Tx, id uuid.UUID) (bool, error) {
+	qs := r.qs.WithTx(tx)
+
+	rows, err := qs.DeleteWebhookEndpoint(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("delete webhook endpoint query failed: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// FanOut creates a delivery of every outbox message for every endpoint subscribed to its topic.
+func (r *Webhook) FanOut(ctx context.Context, tx *sql.Tx, outboxIDs []int64) error {
+	qs := r.qs.WithTx(tx)
+
+	err := qs.FanOutWebhookDeliveries(ctx, queries.FanOutWebhookDeliveriesParams{
+		Now:       time.Now().UTC(),
+		OutboxIds: outboxIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("fan out webhook deliveries query failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Webhook) GetDue(ctx context.Context, tx *sql.Tx, limit int32) ([]dto.DueWebhookDelivery, error) {
+	qs := r.qs.WithTx(tx)
+
+	deliveries, err := qs.SelectDueWebhookDeliveries(ctx, queries.SelectDueWebhookDeliveriesParams{
+		Now:      time.Now().UTC(),
+		MaxCount: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("select due webhook deliveries query failed: %w", err)
+	}
+
+	res := make([]dto.DueWebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		res[i] = dto.DueWebhookDelivery{
+			ID:        delivery.ID,
+			EventType: delivery.EventType,
+			Payload:   delivery.Payload,
+			Attempts:  delivery.Attempts,
+			URL:       delivery.Url,
+			Secret:    delivery.Secret,
+		}
+	}
+
+	return res, nil
+}
+
+func (r *Webhook) Lease(ctx context.Context, tx *sql.Tx, owner string, ids []int64, until time.Time) error {
+	qs := r.qs.WithTx(tx)
+
+	err := qs.LeaseWebhookDeliveries(ctx, queries.LeaseWebhookDeliveriesParams{
+		LeaseOwner:     owner,
+		LeaseExpiresAt: until.UTC(),
+		Ids:            ids,
+	})
+	if err != nil {
+		return fmt.Errorf("lease webhook deliveries query failed: %w", err)
+	}
+
+	return nil
+}
+
+// Complete records the attempt and applies its result, if the delivery is leased to owner.
+// It returns false if the delivery is leased to another owner now.
+func (r *Webhook) Complete(ctx context.Context, tx *sql.Tx, owner string, result dto.WebhookAttemptResult) (bool, error) {
+	qs := r.qs.WithTx(tx)
+	now := time.Now().UTC()
+
+	err := qs.AddWebhookDeliveryAttempt(ctx, queries.AddWebhookDeliveryAttemptParams{
+		StatusCode:  result.StatusCode,
+		Error:       result.Error,
+		Response:    result.Response,
+		DurationMs:  result.Duration.Milliseconds(),
+		AttemptedAt: now,
+		DeliveryID:  result.DeliveryID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("add webhook delivery attempt query failed: %w", err)
+	}
+
+	nextAttemptAt := now
+	if result.Status == dto.WebhookStatusPending {
+		nextAttemptAt = result.NextAttemptAt.UTC()
+	}
+
+	rows, err := qs.CompleteWebhookDelivery(ctx, queries.CompleteWebhookDeliveryParams{
+		Status:        string(result.Status),
+		NextAttemptAt: nextAttemptAt,
+		LastError:     result.Error,
+		UpdatedAt:     now,
+		ID:            result.DeliveryID,
+		LeaseOwner:    owner,
+	})
+	if err != nil {
+		return false, fmt.Errorf("complete webhook delivery query failed: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (r *Webhook) GetDeliveries(
+	ctx context.Context,
+	tx *sql.Tx,
+	filter dto.WebhookDeliveryFilter,
+) ([]dto.WebhookDelivery, error) {
+	qs := r.qs.WithTx(tx)
+
+	deliveries, err := qs.SelectWebhookDeliveries(ctx, queries.SelectWebhookDeliveriesParams{
+		EndpointID: uuid.NullUUID{UUID: filter.EndpointID, Valid: filter.EndpointID != uuid.Nil},
+		Status:     sql.NullString{String: string(filter.Status), Valid: filter.Status != dto.WebhookStatusNil},
+		BeforeID:   sql.NullInt64{Int64: filter.BeforeID, Valid: filter.BeforeID > 0},
+		MaxCount:   filter.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("select webhook deliveries query failed: %w", err)
+	}
+
+	res := make([]dto.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		res[i] = webhookDeliveryFromDB(delivery)
+	}
+
+	return res, nil
+}
+
+func (r *Webhook) GetDelivery(ctx context.Context, tx *sql.Tx, id int64) (*dto.WebhookDelivery, error) {
+	qs := r.qs.WithTx(tx)
+
+	delivery, err := qs.SelectWebhookDelivery(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("select webhook delivery query failed: %w", err)
+	}
+
+	res := webhookDeliveryFromDB(delivery)
+	return &res, nil
+}
+
+func (r *Webhook) GetAttempts(ctx context.Context, tx *sql.Tx, deliveryID int64) ([]dto.WebhookAttempt, error) {
+	qs := r.qs.WithTx(tx)
+
+	attempts, err := qs.SelectWebhookDeliveryAttempts(ctx, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("select webhook delivery attempts query failed: %w", err)
+	}
+
+	res := make([]dto.WebhookAttempt, len(attempts))
+	for i, attempt := range attempts {
+		res[i] = dto.WebhookAttempt{
+			Attempt:     attempt.Attempt,
+			StatusCode:  attempt.StatusCode,
+			Error:       attempt.Error,
+			Response:    attempt.Response,
+			Duration:    time.Duration(attempt.DurationMs) * time.Millisecond,
+			AttemptedAt: attempt.AttemptedAt,
+		}
+	}
+
+	return res, nil
+}
+
+func (r *Webhook) Redeliver(ctx context.Context, tx *sql.Tx, id int64) (bool, error) {
+	qs := r.qs.WithTx(tx)
+
+	rows, err := qs.RedeliverWebhookDelivery(ctx, queries.RedeliverWebhookDeliveryParams{
+		ID:            id,
+		NextAttemptAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("redeliver webhook delivery query failed: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+func webhookDeliveryFromDB(delivery queries.WebhookDelivery) dto.WebhookDelivery {
+	return dto.WebhookDelivery{
+		ID:            delivery.ID,
+		EndpointID:    delivery.EndpointID,
+		EventType:     delivery.EventType,
+		Payload:       delivery.Payload,
+		Status:        dto.WebhookDeliveryStatus(delivery.Status),
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt,
+		LastError:     delivery.LastError,
+		CreatedAt:     delivery.CreatedAt,
+		UpdatedAt:     delivery.UpdatedAt,
+	}
+}
@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ExportFilter selects invoices created in [From, To). Zero values disable the
+// corresponding condition.
+type ExportFilter struct {
+	From       time.Time
+	To         time.Time
+	Status     InvoiceStatus
+	CustomerID uuid.UUID
+}
+
+type ExportedInvoice struct {
+	Invoice Invoice
+	Status  InvoiceStatus
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ImportJobStatus string
+
+const (
+	ImportStatusNil       ImportJobStatus = ""
+	ImportStatusRunning   ImportJobStatus = "Running"
+	ImportStatusCompleted ImportJobStatus = "Completed"
+	ImportStatusFailed    ImportJobStatus = "Failed"
+)
+
+type ImportJob struct {
+	ID            uuid.UUID
+	Format        string
+	Status        ImportJobStatus
+	ProcessedRows int64
+	SucceededRows int64
+	FailedRows    int64
+	Error         string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type ImportRow struct {
+	Number  int64
+	Invoice *Invoice
+	Error   string
+}
+
+type ImportRowResult struct {
+	Number    int64
+	InvoiceID uuid.UUID
+	Error     string
+}
+
+// ImportResultFilter selects a page of job results: at most Limit results of rows
+// numbered after AfterRow. A zero Limit returns no results.
+type ImportResultFilter struct {
+	OnlyFailed bool
+	AfterRow   int64
+	Limit      int32
+}
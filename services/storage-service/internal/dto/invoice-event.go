@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidEventCursor = errors.New("invalid event cursor")
+
+type InvoiceEventType string
+
+const (
+	InvoiceEventCreated       InvoiceEventType = "Created"
+	InvoiceEventStatusChanged InvoiceEventType = "StatusChanged"
+)
+
+// EventCursor is a position in the invoice events feed. Events are ordered by the id of
+// the transaction that recorded them and then by their own id.
+type EventCursor struct {
+	TxID int64
+	ID   int64
+}
+
+func ParseEventCursor(s string) (EventCursor, error) {
+	txIDStr, idStr, ok := strings.Cut(s, "-")
+	if !ok {
+		return EventCursor{}, fmt.Errorf("%w: '%s'", ErrInvalidEventCursor, s)
+	}
+	txID, err := strconv.ParseInt(txIDStr, 10, 64)
+	if err != nil {
+		return EventCursor{}, fmt.Errorf("%w: '%s'", ErrInvalidEventCursor, s)
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return EventCursor{}, fmt.Errorf("%w: '%s'", ErrInvalidEventCursor, s)
+	}
+	return EventCursor{TxID: txID, ID: id}, nil
+}
+
+func (c EventCursor) String() string {
+	return strconv.FormatInt(c.TxID, 10) + "-" + strconv.FormatInt(c.ID, 10)
+}
+
+type InvoiceEvent struct {
+	Cursor     EventCursor
+	Type       InvoiceEventType
+	InvoiceID  uuid.UUID
+	Status     InvoiceStatus
+	OccurredAt time.Time
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestEventCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		cursor  string
+		want    EventCursor
+		wantErr bool
+	}{
+		{name: "zero", cursor: "0-0", want: EventCursor{}},
+		{name: "tx_and_id", cursor: "1042-17", want: EventCursor{TxID: 1042, ID: 17}},
+		{name: "large_tx_id", cursor: "9223372036854775807-1", want: EventCursor{TxID: 9223372036854775807, ID: 1}},
+		{name: "empty", cursor: "", wantErr: true},
+		{name: "id_only", cursor: "17", wantErr: true},
+		{name: "missing_id", cursor: "1042-", wantErr: true},
+		{name: "missing_tx_id", cursor: "-17", wantErr: true},
+		{name: "not_a_number", cursor: "abc-17", wantErr: true},
+		{name: "extra_part", cursor: "1-2-3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := ParseEventCursor(tt.cursor)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidEventCursor)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cursor)
+			assert.Equal(t, tt.cursor, cursor.String())
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+type NotificationClaimResult string
+
+const (
+	NotificationClaimed     NotificationClaimResult = "Claimed"
+	NotificationAlreadySent NotificationClaimResult = "AlreadySent"
+	NotificationInProgress  NotificationClaimResult = "InProgress"
+)
+
+const NotificationStatusSent = "Sent"
+
+// NotificationKey identifies a notification, every invoice event is notified about once.
+type NotificationKey struct {
+	InvoiceID uuid.UUID
+	EventType string
+}
@@ -1,13 +1,108 @@
 package dto
 
-import "go-invoice-service/common/protocol/kafka"
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"go-invoice-service/common/protocol/kafka"
+	"time"
+)
+
+type OutboxMessageStatus string
+
+const (
+	OutboxStatusPending OutboxMessageStatus = "Pending"
+	// OutboxStatusParked messages ran out of attempts and wait for an admin.
+	OutboxStatusParked OutboxMessageStatus = "Parked"
+	// OutboxStatusPublished messages were sent and are kept until their partition expires.
+	OutboxStatusPublished OutboxMessageStatus = "Published"
+)
 
 type OutboxMessageStencil struct {
-	Topic   kafka.Topic
-	Payload []byte
+	Topic kafka.Topic
+	// AggregateID is the invoice the message is about. Messages of one aggregate
+	// are numbered and sent in that order.
+	AggregateID uuid.UUID
+	Key         string
+	Headers     map[string]string
+	Payload     []byte
+	// Data is the event data as JSON, sent to webhooks whatever the payload format is.
+	Data json.RawMessage
 }
 
 type OutboxMessage struct {
-	ID      int64
-	Stencil OutboxMessageStencil
+	ID       int64
+	Sequence int64
+	// LeaseExpired is set when the message was leased before and not deleted in time.
+	LeaseExpired bool
+	// Attempts is the number of failed publish attempts.
+	Attempts int32
+	Stencil  OutboxMessageStencil
+}
+
+// OutboxBatch is a batch of messages leased for sending. Backlog is the number of
+// messages due for sending that are left in the outbox.
+type OutboxBatch struct {
+	Messages []OutboxMessage
+	Backlog  int64
+}
+
+// OutboxFailure is reported by the dispatcher when a message was not published.
+// Status is the new status of the message, NextSendAt is used only when it is
+// still pending.
+type OutboxFailure struct {
+	MessageID  int64
+	Status     OutboxMessageStatus
+	Error      string
+	NextSendAt time.Time
+}
+
+// OutboxEntry is an outbox message together with its delivery state.
+type OutboxEntry struct {
+	Message   OutboxMessage
+	Status    OutboxMessageStatus
+	LastError string
+	// FirstFailedAt is zero if no attempt has failed yet.
+	FirstFailedAt time.Time
+	NextSendAt    time.Time
+	CreatedAt     time.Time
+	// PublishedAt is zero unless the message is published.
+	PublishedAt time.Time
+}
+
+type OutboxEntryFilter struct {
+	BeforeID int64
+	Limit    int32
+}
+
+type OutboxPublishedFilter struct {
+	AggregateID uuid.UUID
+	BeforeID    int64
+	Limit       int32
+}
+
+// OutboxReplayFilter selects published messages to replay after AfterID. Zero
+// fields match all messages.
+type OutboxReplayFilter struct {
+	AfterID     int64
+	Limit       int32
+	Topic       kafka.Topic
+	AggregateID uuid.UUID
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+type OutboxReplayBatch struct {
+	Count int32
+	// LastID is the ID of the last replayed message, zero if none was replayed.
+	LastID int64
+}
+
+// OutboxPartition holds the outbox messages created on Day (UTC).
+type OutboxPartition struct {
+	Name string
+	Day  time.Time
+	// Detached is set for a table detached from the outbox but not dropped yet.
+	Detached bool
+	// DetachPending is set for a partition whose concurrent detach was interrupted.
+	DetachPending bool
 }
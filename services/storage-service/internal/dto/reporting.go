@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type AgingBucket struct {
+	Currency string
+	Bucket   string
+	Invoices int64
+	Amount   int64
+}
+
+type RevenueRow struct {
+	Month      time.Time
+	Currency   string
+	CustomerID uuid.UUID
+	Invoices   int64
+	Amount     int64
+}
+
+type DSORow struct {
+	Currency    string
+	Receivables int64
+	Sales       int64
+	Days        int32
+	// DSO is nil when there were no sales in the period.
+	DSO *float64
+}
@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookStatusNil       WebhookDeliveryStatus = ""
+	WebhookStatusPending   WebhookDeliveryStatus = "Pending"
+	WebhookStatusSucceeded WebhookDeliveryStatus = "Succeeded"
+	WebhookStatusDead      WebhookDeliveryStatus = "Dead"
+)
+
+type WebhookEndpoint struct {
+	ID         uuid.UUID
+	URL        string
+	Secret     string
+	EventTypes []string
+	CreatedAt  time.Time
+}
+
+type WebhookDelivery struct {
+	ID            int64
+	EndpointID    uuid.UUID
+	EventType     string
+	Payload       []byte
+	Status        WebhookDeliveryStatus
+	Attempts      int32
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// DueWebhookDelivery is a delivery handed to the dispatcher together with its endpoint.
+type DueWebhookDelivery struct {
+	ID        int64
+	EventType string
+	Payload   []byte
+	Attempts  int32
+	URL       string
+	Secret    string
+}
+
+type WebhookAttempt struct {
+	Attempt     int32
+	StatusCode  int32
+	Error       string
+	Response    string
+	Duration    time.Duration
+	AttemptedAt time.Time
+}
+
+// WebhookAttemptResult is reported by the dispatcher after every attempt. Status is the
+// new status of the delivery, NextAttemptAt is used only when it is still pending.
+type WebhookAttemptResult struct {
+	DeliveryID    int64
+	Status        WebhookDeliveryStatus
+	StatusCode    int32
+	Error         string
+	Response      string
+	Duration      time.Duration
+	NextAttemptAt time.Time
+}
+
+type WebhookDeliveryFilter struct {
+	EndpointID uuid.UUID
+	Status     WebhookDeliveryStatus
+	BeforeID   int64
+	Limit      int32
+}
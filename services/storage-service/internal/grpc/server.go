@@ -2,8 +2,10 @@ package grpc
 
 import (
 	"fmt"
+	"go-invoice-service/common/pkg/tracing"
 	apiservicepb "go-invoice-service/common/protocol/proto/apiservice"
 	messageschedulerpb "go-invoice-service/common/protocol/proto/messagescheduler"
+	notificationspb "go-invoice-service/common/protocol/proto/notifications"
 	validationpb "go-invoice-service/common/protocol/proto/validation"
 	"google.golang.org/grpc"
 	"net"
@@ -16,22 +18,55 @@ type InvoiceService interface {
 
 type OutboxService interface {
 	servers.OutboxService
+	servers.OutboxAdminService
 }
 
 type ValidationService interface {
 	servers.ValidationService
 }
 
+type ImportService interface {
+	servers.ImportService
+}
+
+type ExportService interface {
+	servers.ExportService
+}
+
+type ReportingService interface {
+	servers.ReportingService
+}
+
+type FeedService interface {
+	servers.FeedService
+}
+
+type WebhookService interface {
+	servers.WebhookService
+	servers.WebhookDeliveryService
+}
+
+type NotificationService interface {
+	servers.NotificationService
+}
+
 type Config struct {
 	Port uint16
 }
 
 type Server struct {
-	cfg               Config
-	invoiceService    InvoiceService
-	outboxService     OutboxService
-	validationService ValidationService
-	server            *grpc.Server
+	cfg                 Config
+	invoiceService      InvoiceService
+	validationService   ValidationService
+	importService       ImportService
+	exportService       ExportService
+	reportingService    ReportingService
+	webhookService      WebhookService
+	notificationService NotificationService
+	outboxService       OutboxService
+	outboxServer        *servers.OutboxServer
+	feedServer          *servers.FeedServer
+	server              *grpc.Server
 }
 
 func NewServer(
@@ -39,13 +74,29 @@ func NewServer(
 	invoiceService InvoiceService,
 	outboxService OutboxService,
 	validationService ValidationService,
+	importService ImportService,
+	exportService ExportService,
+	reportingService ReportingService,
+	feedService FeedService,
+	webhookService WebhookService,
+	notificationService NotificationService,
 ) *Server {
 	return &Server{
-		invoiceService:    invoiceService,
-		outboxService:     outboxService,
-		validationService: validationService,
-		server:            grpc.NewServer(),
-		cfg:               cfg,
+		invoiceService:      invoiceService,
+		validationService:   validationService,
+		importService:       importService,
+		exportService:       exportService,
+		reportingService:    reportingService,
+		webhookService:      webhookService,
+		notificationService: notificationService,
+		outboxService:       outboxService,
+		outboxServer:        servers.NewOutboxServer(outboxService),
+		feedServer:          servers.NewFeedServer(feedService),
+		server: grpc.NewServer(
+			grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor()),
+			grpc.ChainStreamInterceptor(tracing.StreamServerInterceptor()),
+		),
+		cfg: cfg,
 	}
 }
 
@@ -56,12 +107,26 @@ func (s *Server) Run() error {
 	}
 
 	invoiceServer := servers.NewInvoiceServer(s.invoiceService)
-	outboxServer := servers.NewOutboxServer(s.outboxService)
 	validationServer := servers.NewValidationServer(s.validationService)
+	importServer := servers.NewImportServer(s.importService)
+	exportServer := servers.NewExportServer(s.exportService)
+	reportingServer := servers.NewReportingServer(s.reportingService)
+	webhookServer := servers.NewWebhookServer(s.webhookService)
+	webhookDeliveryServer := servers.NewWebhookDeliveryServer(s.webhookService)
+	notificationServer := servers.NewNotificationServer(s.notificationService)
+	outboxAdminServer := servers.NewOutboxAdminServer(s.outboxService)
 
 	apiservicepb.RegisterInvoiceStorageServer(s.server, invoiceServer)
-	messageschedulerpb.RegisterOutboxStorageServer(s.server, outboxServer)
+	messageschedulerpb.RegisterOutboxStorageServer(s.server, s.outboxServer)
 	validationpb.RegisterInvoiceStorageServer(s.server, validationServer)
+	apiservicepb.RegisterInvoiceImportServer(s.server, importServer)
+	apiservicepb.RegisterInvoiceExportServer(s.server, exportServer)
+	apiservicepb.RegisterInvoiceReportingServer(s.server, reportingServer)
+	apiservicepb.RegisterInvoiceFeedServer(s.server, s.feedServer)
+	apiservicepb.RegisterWebhookStorageServer(s.server, webhookServer)
+	messageschedulerpb.RegisterWebhookDeliveryStorageServer(s.server, webhookDeliveryServer)
+	notificationspb.RegisterNotificationStorageServer(s.server, notificationServer)
+	apiservicepb.RegisterOutboxAdminServer(s.server, outboxAdminServer)
 
 	if err := s.server.Serve(listen); err != nil {
 		return fmt.Errorf("failed to serve: %w", err)
@@ -71,5 +136,7 @@ func (s *Server) Run() error {
 }
 
 func (s *Server) Shutdown() {
+	s.outboxServer.Close()
+	s.feedServer.Close()
 	s.server.GracefulStop()
 }
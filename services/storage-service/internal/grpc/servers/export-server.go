@@ -0,0 +1,127 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	pb "go-invoice-service/common/protocol/proto/apiservice"
+	"go-invoice-service/common/protocol/proto/types"
+	"google.golang.org/grpc"
+	"storage-service/internal/dto"
+)
+
+const (
+	defaultExportBatchSize int32 = 500
+	maxExportBatchSize     int32 = 5000
+)
+
+var _ pb.InvoiceExportServer = (*ExportServer)(nil)
+
+type ExportService interface {
+	Export(
+		ctx context.Context,
+		filter dto.ExportFilter,
+		batchSize int32,
+		consume func([]dto.ExportedInvoice) error,
+	) error
+}
+
+type ExportServer struct {
+	pb.UnimplementedInvoiceExportServer
+	service ExportService
+}
+
+func NewExportServer(service ExportService) *ExportServer {
+	return &ExportServer{
+		service: service,
+	}
+}
+
+func (s *ExportServer) Export(
+	request *pb.ExportInvoicesRequest,
+	stream grpc.ServerStreamingServer[pb.ExportInvoicesResponse],
+) error {
+	filter, err := exportFilterFromProto(request)
+	if err != nil {
+		return fmt.Errorf("invalid export filter: %w", err)
+	}
+
+	batchSize := request.GetBatchSize()
+	if batchSize <= 0 {
+		batchSize = defaultExportBatchSize
+	}
+	batchSize = min(batchSize, maxExportBatchSize)
+
+	err = s.service.Export(stream.Context(), filter, batchSize, func(invoices []dto.ExportedInvoice) error {
+		resp, err := exportedInvoicesToProto(invoices)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return fmt.Errorf("failed to send exported invoices: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export invoices: %w", err)
+	}
+
+	return nil
+}
+
+func exportFilterFromProto(request *pb.ExportInvoicesRequest) (dto.ExportFilter, error) {
+	var filter dto.ExportFilter
+
+	if request.From != nil {
+		filter.From = request.GetFrom().AsTime()
+	}
+	if request.To != nil {
+		filter.To = request.GetTo().AsTime()
+	}
+	if request.Status != nil {
+		status, err := statusFromProto(request.GetStatus())
+		if err != nil {
+			return dto.ExportFilter{}, err
+		}
+		filter.Status = status
+	}
+	if request.CustomerId != nil {
+		customerID, err := uuidFromProto(request.GetCustomerId())
+		if err != nil {
+			return dto.ExportFilter{}, fmt.Errorf("invalid customer id: %w", err)
+		}
+		filter.CustomerID = customerID
+	}
+
+	return filter, nil
+}
+
+func exportedInvoicesToProto(invoices []dto.ExportedInvoice) (*pb.ExportInvoicesResponse, error) {
+	res := make([]*pb.ExportedInvoice, len(invoices))
+
+	for i, invoice := range invoices {
+		status, err := statusToProto(invoice.Status)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert status of invoice %s: %w", invoice.Invoice.ID, err)
+		}
+		res[i] = &pb.ExportedInvoice{
+			Invoice: invoiceToProto(&invoice.Invoice),
+			Status:  &status,
+		}
+	}
+
+	return &pb.ExportInvoicesResponse{
+		Invoices: res,
+	}, nil
+}
+
+func statusFromProto(status types.InvoiceStatus) (dto.InvoiceStatus, error) {
+	switch status {
+	case types.InvoiceStatus_Pending:
+		return dto.StatusPending, nil
+	case types.InvoiceStatus_Approved:
+		return dto.StatusApproved, nil
+	case types.InvoiceStatus_Rejected:
+		return dto.StatusRejected, nil
+	}
+	return dto.StatusNil, fmt.Errorf("unknown status: %v", status)
+}
@@ -0,0 +1,132 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	pb "go-invoice-service/common/protocol/proto/apiservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"storage-service/internal/dto"
+	"sync"
+)
+
+var _ pb.InvoiceFeedServer = (*FeedServer)(nil)
+
+type FeedService interface {
+	Head(ctx context.Context) (dto.EventCursor, error)
+	Watch(ctx context.Context, after dto.EventCursor, consume func([]dto.InvoiceEvent) error) error
+}
+
+type FeedServer struct {
+	pb.UnimplementedInvoiceFeedServer
+	service   FeedService
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func NewFeedServer(service FeedService) *FeedServer {
+	return &FeedServer{
+		service: service,
+		done:    make(chan struct{}),
+	}
+}
+
+// Close ends all watch streams, they never finish on their own and would block
+// a graceful shutdown. Clients are expected to reconnect with the last received cursor.
+func (s *FeedServer) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+func (s *FeedServer) WatchInvoices(
+	request *pb.WatchInvoicesRequest,
+	stream grpc.ServerStreamingServer[pb.InvoiceEvent],
+) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var after dto.EventCursor
+	switch {
+	case request.GetCursor() != "":
+		cursor, err := dto.ParseEventCursor(request.GetCursor())
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		after = cursor
+	case !request.GetFromStart():
+		cursor, err := s.service.Head(ctx)
+		if err != nil {
+			return err
+		}
+		after = cursor
+	}
+
+	// Headers tell the client that the request was accepted before any event is available.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return fmt.Errorf("failed to send header: %w", err)
+	}
+
+	err := s.service.Watch(ctx, after, func(events []dto.InvoiceEvent) error {
+		for _, event := range events {
+			pbEvent, err := invoiceEventToProto(event)
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(pbEvent); err != nil {
+				return fmt.Errorf("failed to send invoice event: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil && !isClosed(s.done) {
+		return fmt.Errorf("failed to watch invoices: %w", err)
+	}
+
+	return nil
+}
+
+func isClosed(done <-chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
+
+func invoiceEventToProto(event dto.InvoiceEvent) (*pb.InvoiceEvent, error) {
+	var eventType pb.InvoiceEventType
+	switch event.Type {
+	case dto.InvoiceEventCreated:
+		eventType = pb.InvoiceEventType_Created
+	case dto.InvoiceEventStatusChanged:
+		eventType = pb.InvoiceEventType_StatusChanged
+	default:
+		return nil, fmt.Errorf("unknown invoice event type: %s", event.Type)
+	}
+
+	invoiceStatus, err := statusToProto(event.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert status of invoice %s: %w", event.InvoiceID, err)
+	}
+
+	return &pb.InvoiceEvent{
+		Cursor:     proto.String(event.Cursor.String()),
+		Type:       &eventType,
+		InvoiceId:  uuidToProto(event.InvoiceID),
+		Status:     &invoiceStatus,
+		OccurredAt: timestamppb.New(event.OccurredAt),
+	}, nil
+}
@@ -0,0 +1,151 @@
+package servers
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "go-invoice-service/common/protocol/proto/apiservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"storage-service/internal/dto"
+	"testing"
+	"time"
+)
+
+// feedService passes its events to the watcher once. With block set Watch then waits
+// until ctx is done.
+type feedService struct {
+	head   dto.EventCursor
+	events []dto.InvoiceEvent
+	block  bool
+
+	headCalls int
+	after     *dto.EventCursor
+}
+
+func (s *feedService) Head(context.Context) (dto.EventCursor, error) {
+	s.headCalls++
+	return s.head, nil
+}
+
+func (s *feedService) Watch(ctx context.Context, after dto.EventCursor, consume func([]dto.InvoiceEvent) error) error {
+	s.after = &after
+	if err := consume(s.events); err != nil {
+		return err
+	}
+	if !s.block {
+		return nil
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+type watchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*pb.InvoiceEvent
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchStream) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *watchStream) Send(event *pb.InvoiceEvent) error {
+	s.sent = append(s.sent, event)
+	return nil
+}
+
+func TestFeedServer_WatchInvoices(t *testing.T) {
+	head := dto.EventCursor{TxID: 900, ID: 50}
+	event := dto.InvoiceEvent{
+		Cursor:     dto.EventCursor{TxID: 8, ID: 4},
+		Type:       dto.InvoiceEventStatusChanged,
+		InvoiceID:  uuid.New(),
+		Status:     dto.StatusApproved,
+		OccurredAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name          string
+		request       *pb.WatchInvoicesRequest
+		wantAfter     *dto.EventCursor
+		wantHeadCalls int
+		wantCode      codes.Code
+	}{
+		{
+			name:      "resumes_after_cursor",
+			request:   &pb.WatchInvoicesRequest{Cursor: proto.String("7-3")},
+			wantAfter: &dto.EventCursor{TxID: 7, ID: 3},
+		},
+		{
+			name:      "cursor_takes_precedence_over_from_start",
+			request:   &pb.WatchInvoicesRequest{Cursor: proto.String("7-3"), FromStart: proto.Bool(true)},
+			wantAfter: &dto.EventCursor{TxID: 7, ID: 3},
+		},
+		{
+			name:      "from_start",
+			request:   &pb.WatchInvoicesRequest{FromStart: proto.Bool(true)},
+			wantAfter: &dto.EventCursor{},
+		},
+		{
+			name:          "new_events_only",
+			request:       &pb.WatchInvoicesRequest{},
+			wantAfter:     &head,
+			wantHeadCalls: 1,
+		},
+		{
+			name:     "invalid_cursor",
+			request:  &pb.WatchInvoicesRequest{Cursor: proto.String("7:3")},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &feedService{head: head, events: []dto.InvoiceEvent{event}}
+			stream := &watchStream{ctx: context.Background()}
+
+			err := NewFeedServer(service).WatchInvoices(tt.request, stream)
+			assert.Equal(t, tt.wantAfter, service.after)
+			assert.Equal(t, tt.wantHeadCalls, service.headCalls)
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				assert.Empty(t, stream.sent)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, stream.sent, 1)
+			assert.Equal(t, "8-4", stream.sent[0].GetCursor())
+			assert.Equal(t, pb.InvoiceEventType_StatusChanged, stream.sent[0].GetType())
+			assert.True(t, event.OccurredAt.Equal(stream.sent[0].GetOccurredAt().AsTime()))
+		})
+	}
+}
+
+func TestFeedServer_Close(t *testing.T) {
+	server := NewFeedServer(&feedService{block: true})
+	stream := &watchStream{ctx: context.Background()}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- server.WatchInvoices(&pb.WatchInvoicesRequest{FromStart: proto.Bool(true)}, stream)
+	}()
+	server.Close()
+
+	select {
+	case err := <-done:
+		// A stream ended by Close is not an error, the client reconnects with its cursor.
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch not ended by close")
+	}
+}
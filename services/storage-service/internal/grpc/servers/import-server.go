@@ -0,0 +1,226 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	pb "go-invoice-service/common/protocol/proto/apiservice"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"storage-service/internal/dto"
+)
+
+const (
+	defaultImportResultsLimit int32 = 500
+	maxImportResultsLimit     int32 = 5000
+)
+
+var _ pb.InvoiceImportServer = (*ImportServer)(nil)
+
+type ImportService interface {
+	CreateJob(ctx context.Context, id uuid.UUID, format string) (*dto.ImportJob, error)
+	ImportBatch(ctx context.Context, jobID uuid.UUID, rows []dto.ImportRow) (*dto.ImportJob, error)
+	FinishJob(ctx context.Context, id uuid.UUID, status dto.ImportJobStatus, errorMessage string) error
+	GetJob(ctx context.Context, id uuid.UUID, filter dto.ImportResultFilter) (*dto.ImportJob, []dto.ImportRowResult, error)
+	ListJobs(ctx context.Context, status dto.ImportJobStatus) ([]dto.ImportJob, error)
+}
+
+type ImportServer struct {
+	pb.UnimplementedInvoiceImportServer
+	service ImportService
+}
+
+func NewImportServer(service ImportService) *ImportServer {
+	return &ImportServer{
+		service: service,
+	}
+}
+
+func (s *ImportServer) CreateJob(ctx context.Context, request *pb.CreateImportJobRequest) (*pb.ImportJob, error) {
+	id, err := uuidFromProto(request.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid import job id: %w", err)
+	}
+
+	job, err := s.service.CreateJob(ctx, id, request.GetFormat())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	return importJobToProto(job)
+}
+
+func (s *ImportServer) ImportBatch(ctx context.Context, request *pb.ImportBatchRequest) (*pb.ImportJob, error) {
+	jobID, err := uuidFromProto(request.GetJobId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid import job id: %w", err)
+	}
+
+	rows, err := importRowsFromProto(request.GetRows())
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert import rows: %w", err)
+	}
+
+	job, err := s.service.ImportBatch(ctx, jobID, rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import batch: %w", err)
+	}
+
+	return importJobToProto(job)
+}
+
+func (s *ImportServer) FinishJob(ctx context.Context, request *pb.FinishImportJobRequest) (*emptypb.Empty, error) {
+	id, err := uuidFromProto(request.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid import job id: %w", err)
+	}
+
+	status, err := importStatusFromProto(request.GetStatus())
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert import job status: %w", err)
+	}
+
+	if err := s.service.FinishJob(ctx, id, status, request.GetError()); err != nil {
+		return nil, fmt.Errorf("failed to finish import job: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ImportServer) GetJob(ctx context.Context, request *pb.GetImportJobRequest) (*pb.GetImportJobResponse, error) {
+	id, err := uuidFromProto(request.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid import job id: %w", err)
+	}
+
+	filter := dto.ImportResultFilter{
+		OnlyFailed: request.GetOnlyFailed(),
+		AfterRow:   request.GetAfterRow(),
+		Limit:      request.GetLimit(),
+	}
+	if request.GetWithoutResults() {
+		filter.Limit = 0
+	} else if filter.Limit <= 0 {
+		filter.Limit = defaultImportResultsLimit
+	}
+	filter.Limit = min(filter.Limit, maxImportResultsLimit)
+
+	job, results, err := s.service.GetJob(ctx, id, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+
+	jobPB, err := importJobToProto(job)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetImportJobResponse{
+		Job:     jobPB,
+		Results: importRowResultsToProto(results),
+	}, nil
+}
+
+func (s *ImportServer) ListJobs(ctx context.Context, request *pb.ListImportJobsRequest) (*pb.ListImportJobsResponse, error) {
+	status, err := importStatusFromProto(request.GetStatus())
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert import job status: %w", err)
+	}
+
+	jobs, err := s.service.ListJobs(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list import jobs: %w", err)
+	}
+
+	res := make([]*pb.ImportJob, len(jobs))
+	for i := range jobs {
+		res[i], err = importJobToProto(&jobs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &pb.ListImportJobsResponse{
+		Jobs: res,
+	}, nil
+}
+
+func importRowsFromProto(rows []*pb.ImportRow) ([]dto.ImportRow, error) {
+	res := make([]dto.ImportRow, len(rows))
+
+	for i, row := range rows {
+		res[i] = dto.ImportRow{
+			Number: row.GetNumber(),
+			Error:  row.GetError(),
+		}
+		if row.GetError() != "" {
+			continue
+		}
+		invoice, err := invoiceFromProto(row.GetInvoice())
+		if err != nil {
+			return nil, fmt.Errorf("invalid row %d: %w", row.GetNumber(), err)
+		}
+		res[i].Invoice = invoice
+	}
+
+	return res, nil
+}
+
+func importJobToProto(job *dto.ImportJob) (*pb.ImportJob, error) {
+	status, err := importStatusToProto(job.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert import job status: %w", err)
+	}
+
+	return &pb.ImportJob{
+		Id:            uuidToProto(job.ID),
+		Format:        &job.Format,
+		Status:        &status,
+		ProcessedRows: &job.ProcessedRows,
+		SucceededRows: &job.SucceededRows,
+		FailedRows:    &job.FailedRows,
+		Error:         &job.Error,
+		CreatedAt:     timestamppb.New(job.CreatedAt),
+		UpdatedAt:     timestamppb.New(job.UpdatedAt),
+	}, nil
+}
+
+func importRowResultsToProto(results []dto.ImportRowResult) []*pb.ImportRowResult {
+	res := make([]*pb.ImportRowResult, len(results))
+
+	for i, result := range results {
+		res[i] = &pb.ImportRowResult{
+			Number: &result.Number,
+			Error:  &result.Error,
+		}
+		if result.InvoiceID != uuid.Nil {
+			res[i].InvoiceId = uuidToProto(result.InvoiceID)
+		}
+	}
+
+	return res
+}
+
+func importStatusToProto(status dto.ImportJobStatus) (pb.ImportJobStatus, error) {
+	switch status {
+	case dto.ImportStatusRunning:
+		return pb.ImportJobStatus_Running, nil
+	case dto.ImportStatusCompleted:
+		return pb.ImportJobStatus_Completed, nil
+	case dto.ImportStatusFailed:
+		return pb.ImportJobStatus_Failed, nil
+	}
+	return 0, fmt.Errorf("unknown import job status: %s", status)
+}
+
+func importStatusFromProto(status pb.ImportJobStatus) (dto.ImportJobStatus, error) {
+	switch status {
+	case pb.ImportJobStatus_Running:
+		return dto.ImportStatusRunning, nil
+	case pb.ImportJobStatus_Completed:
+		return dto.ImportStatusCompleted, nil
+	case pb.ImportJobStatus_Failed:
+		return dto.ImportStatusFailed, nil
+	}
+	return dto.ImportStatusNil, fmt.Errorf("unknown import job status: %v", status)
+}
@@ -65,12 +65,16 @@ func (s *InvoiceServer) Get(ctx context.Context, request *pb.GetRequest) (*pb.Ge
 }
 
 func invoiceToPB(request *pb.UploadRequest) (*dto.Invoice, error) {
-	id, err := uuid.FromBytes(request.Invoice.Id.Value)
+	return invoiceFromProto(request.Invoice)
+}
+
+func invoiceFromProto(invoice *types.Invoice) (*dto.Invoice, error) {
+	id, err := uuid.FromBytes(invoice.Id.Value)
 	if err != nil {
 		return nil, fmt.Errorf("invalid invoice id: %w", err)
 	}
 
-	customerId, err := uuid.FromBytes(request.Invoice.CustomerId.Value)
+	customerId, err := uuid.FromBytes(invoice.CustomerId.Value)
 	if err != nil {
 		return nil, fmt.Errorf("invalid customer id: %w", err)
 	}
@@ -78,13 +82,13 @@ func invoiceToPB(request *pb.UploadRequest) (*dto.Invoice, error) {
 	return &dto.Invoice{
 		ID:         id,
 		CustomerID: customerId,
-		Amount:     *request.Invoice.Amount,
-		Currency:   *request.Invoice.Currency,
-		DueDate:    request.Invoice.DueDate.AsTime(),
-		CreatedAt:  request.Invoice.CreatedAt.AsTime(),
-		UpdatedAt:  request.Invoice.UpdatedAt.AsTime(),
-		Items:      itemsToPB(request.Invoice.Items),
-		Notes:      *request.Invoice.Notes,
+		Amount:     *invoice.Amount,
+		Currency:   *invoice.Currency,
+		DueDate:    invoice.DueDate.AsTime(),
+		CreatedAt:  invoice.CreatedAt.AsTime(),
+		UpdatedAt:  invoice.UpdatedAt.AsTime(),
+		Items:      itemsToPB(invoice.Items),
+		Notes:      *invoice.Notes,
 	}, nil
 }
 
@@ -0,0 +1,133 @@
+package servers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	pb "go-invoice-service/common/protocol/proto/notifications"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"storage-service/internal/dto"
+	"storage-service/internal/services"
+	"time"
+)
+
+var _ pb.NotificationStorageServer = (*NotificationServer)(nil)
+
+type NotificationService interface {
+	GetInvoice(ctx context.Context, id uuid.UUID) (*dto.Invoice, dto.InvoiceStatus, error)
+	Claim(
+		ctx context.Context,
+		key dto.NotificationKey,
+		recipient string,
+		leaseFor time.Duration,
+	) (dto.NotificationClaimResult, error)
+	MarkSent(ctx context.Context, key dto.NotificationKey) error
+	Release(ctx context.Context, key dto.NotificationKey) error
+}
+
+type NotificationServer struct {
+	pb.UnimplementedNotificationStorageServer
+	service NotificationService
+}
+
+func NewNotificationServer(service NotificationService) *NotificationServer {
+	return &NotificationServer{
+		service: service,
+	}
+}
+
+func (s *NotificationServer) GetInvoice(ctx context.Context, request *pb.GetInvoiceRequest) (*pb.GetInvoiceResponse, error) {
+	id, err := uuidFromProto(request.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve invoice ID: %w", err)
+	}
+
+	invoice, invoiceStatus, err := s.service.GetInvoice(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	statusPb, err := statusToProto(invoiceStatus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert status to proto: %w", err)
+	}
+
+	return &pb.GetInvoiceResponse{
+		Invoice: invoiceToProto(invoice),
+		Status:  &statusPb,
+	}, nil
+}
+
+func (s *NotificationServer) ClaimNotification(
+	ctx context.Context,
+	request *pb.ClaimNotificationRequest,
+) (*pb.ClaimNotificationResponse, error) {
+	key, err := notificationKeyFromProto(request.GetKey())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.service.Claim(ctx, key, request.GetRecipient(), request.GetLeaseFor().AsDuration())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim notification: %w", err)
+	}
+
+	var resultPb pb.NotificationClaimResult
+	switch result {
+	case dto.NotificationClaimed:
+		resultPb = pb.NotificationClaimResult_Claimed
+	case dto.NotificationAlreadySent:
+		resultPb = pb.NotificationClaimResult_AlreadySent
+	case dto.NotificationInProgress:
+		resultPb = pb.NotificationClaimResult_InProgress
+	default:
+		return nil, fmt.Errorf("unknown notification claim result: %s", result)
+	}
+
+	return &pb.ClaimNotificationResponse{
+		Result: &resultPb,
+	}, nil
+}
+
+func (s *NotificationServer) MarkNotificationSent(ctx context.Context, request *pb.NotificationKey) (*emptypb.Empty, error) {
+	key, err := notificationKeyFromProto(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.MarkSent(ctx, key); err != nil {
+		if errors.Is(err, services.ErrNotificationNotClaimed) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, fmt.Errorf("failed to mark notification sent: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *NotificationServer) ReleaseNotification(ctx context.Context, request *pb.NotificationKey) (*emptypb.Empty, error) {
+	key, err := notificationKeyFromProto(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.Release(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to release notification: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func notificationKeyFromProto(key *pb.NotificationKey) (dto.NotificationKey, error) {
+	invoiceID, err := uuidFromProto(key.GetInvoiceId())
+	if err != nil {
+		return dto.NotificationKey{}, fmt.Errorf("failed to retrieve invoice ID: %w", err)
+	}
+	return dto.NotificationKey{
+		InvoiceID: invoiceID,
+		EventType: key.GetEventType(),
+	}, nil
+}
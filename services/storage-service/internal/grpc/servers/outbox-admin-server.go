@@ -0,0 +1,197 @@
+package servers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	pb "go-invoice-service/common/protocol/proto/apiservice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"storage-service/internal/dto"
+	"storage-service/internal/services"
+)
+
+const (
+	defaultOutboxMessagesLimit int32 = 50
+	maxOutboxMessagesLimit     int32 = 500
+)
+
+var _ pb.OutboxAdminServer = (*OutboxAdminServer)(nil)
+
+type OutboxAdminService interface {
+	ListParked(ctx context.Context, filter dto.OutboxEntryFilter) ([]dto.OutboxEntry, error)
+	ListPublished(ctx context.Context, filter dto.OutboxPublishedFilter) ([]dto.OutboxEntry, error)
+	GetEntry(ctx context.Context, id int64) (*dto.OutboxEntry, error)
+	Requeue(ctx context.Context, id int64) (*dto.OutboxEntry, error)
+	Purge(ctx context.Context, id int64) error
+}
+
+type OutboxAdminServer struct {
+	pb.UnimplementedOutboxAdminServer
+	service OutboxAdminService
+}
+
+func NewOutboxAdminServer(service OutboxAdminService) *OutboxAdminServer {
+	return &OutboxAdminServer{
+		service: service,
+	}
+}
+
+func (s *OutboxAdminServer) ListParked(
+	ctx context.Context,
+	request *pb.ListParkedOutboxMessagesRequest,
+) (*pb.ListParkedOutboxMessagesResponse, error) {
+	filter := dto.OutboxEntryFilter{
+		BeforeID: request.GetBeforeId(),
+		Limit:    request.GetLimit(),
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultOutboxMessagesLimit
+	}
+	filter.Limit = min(filter.Limit, maxOutboxMessagesLimit)
+
+	entries, err := s.service.ListParked(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list parked outbox messages: %w", err)
+	}
+
+	res, err := outboxEntriesToProto(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListParkedOutboxMessagesResponse{
+		Messages: res,
+	}, nil
+}
+
+func (s *OutboxAdminServer) ListPublished(
+	ctx context.Context,
+	request *pb.ListPublishedOutboxMessagesRequest,
+) (*pb.ListPublishedOutboxMessagesResponse, error) {
+	aggregateID, err := uuidFromProto(request.GetAggregateId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid aggregate id: %v", err))
+	}
+
+	filter := dto.OutboxPublishedFilter{
+		AggregateID: aggregateID,
+		BeforeID:    request.GetBeforeId(),
+		Limit:       request.GetLimit(),
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultOutboxMessagesLimit
+	}
+	filter.Limit = min(filter.Limit, maxOutboxMessagesLimit)
+
+	entries, err := s.service.ListPublished(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list published outbox messages: %w", err)
+	}
+
+	res, err := outboxEntriesToProto(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListPublishedOutboxMessagesResponse{
+		Messages: res,
+	}, nil
+}
+
+func (s *OutboxAdminServer) Get(ctx context.Context, request *pb.GetOutboxMessageRequest) (*pb.OutboxMessage, error) {
+	entry, err := s.service.GetEntry(ctx, request.GetId())
+	if err != nil {
+		return nil, outboxAdminError("failed to get outbox message", err)
+	}
+
+	return outboxEntryToProto(entry)
+}
+
+func (s *OutboxAdminServer) Requeue(
+	ctx context.Context,
+	request *pb.RequeueOutboxMessageRequest,
+) (*pb.OutboxMessage, error) {
+	entry, err := s.service.Requeue(ctx, request.GetId())
+	if err != nil {
+		return nil, outboxAdminError("failed to requeue outbox message", err)
+	}
+
+	return outboxEntryToProto(entry)
+}
+
+func (s *OutboxAdminServer) Purge(ctx context.Context, request *pb.PurgeOutboxMessageRequest) (*emptypb.Empty, error) {
+	if err := s.service.Purge(ctx, request.GetId()); err != nil {
+		return nil, outboxAdminError("failed to purge outbox message", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func outboxAdminError(msg string, err error) error {
+	if errors.Is(err, services.ErrOutboxMessageNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+func outboxEntriesToProto(entries []dto.OutboxEntry) ([]*pb.OutboxMessage, error) {
+	res := make([]*pb.OutboxMessage, len(entries))
+	for i := range entries {
+		entry, err := outboxEntryToProto(&entries[i])
+		if err != nil {
+			return nil, err
+		}
+		res[i] = entry
+	}
+	return res, nil
+}
+
+func outboxEntryToProto(entry *dto.OutboxEntry) (*pb.OutboxMessage, error) {
+	messageStatus, err := outboxStatusToProto(entry.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	message := entry.Message
+	topic := string(message.Stencil.Topic)
+	res := &pb.OutboxMessage{
+		Id:         &message.ID,
+		Topic:      &topic,
+		Key:        &message.Stencil.Key,
+		Headers:    message.Stencil.Headers,
+		Payload:    message.Stencil.Payload,
+		Data:       message.Stencil.Data,
+		Sequence:   &message.Sequence,
+		Status:     &messageStatus,
+		Attempts:   &message.Attempts,
+		LastError:  &entry.LastError,
+		NextSendAt: timestamppb.New(entry.NextSendAt),
+		CreatedAt:  timestamppb.New(entry.CreatedAt),
+	}
+	if message.Stencil.AggregateID != uuid.Nil {
+		res.AggregateId = uuidToProto(message.Stencil.AggregateID)
+	}
+	if !entry.FirstFailedAt.IsZero() {
+		res.FirstFailedAt = timestamppb.New(entry.FirstFailedAt)
+	}
+	if !entry.PublishedAt.IsZero() {
+		res.PublishedAt = timestamppb.New(entry.PublishedAt)
+	}
+	return res, nil
+}
+
+func outboxStatusToProto(messageStatus dto.OutboxMessageStatus) (pb.OutboxMessageStatus, error) {
+	switch messageStatus {
+	case dto.OutboxStatusPending:
+		return pb.OutboxMessageStatus_OutboxPending, nil
+	case dto.OutboxStatusParked:
+		return pb.OutboxMessageStatus_OutboxParked, nil
+	case dto.OutboxStatusPublished:
+		return pb.OutboxMessageStatus_OutboxPublished, nil
+	}
+	return 0, fmt.Errorf("unknown outbox message status: %s", messageStatus)
+}
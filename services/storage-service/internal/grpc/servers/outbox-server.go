@@ -2,51 +2,196 @@ package servers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/google/uuid"
+	"go-invoice-service/common/protocol/kafka"
 	pb "go-invoice-service/common/protocol/proto/messagescheduler"
 	"go-invoice-service/common/protocol/proto/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"storage-service/internal/dto"
+	"storage-service/internal/services"
+	"sync"
 	"time"
 )
 
+// maxReplayBatch limits the messages replayed in one transaction.
+const maxReplayBatch int32 = 1000
+
 var _ pb.OutboxStorageServer = (*OutboxServer)(nil)
 
 type OutboxService interface {
-	Get(ctx context.Context, maxCount int32, retryAfter time.Duration) ([]dto.OutboxMessage, error)
-	Delete(ctx context.Context, id int64) error
+	Get(ctx context.Context, owner string, maxCount int32, leaseFor time.Duration) (dto.OutboxBatch, error)
+	Delete(ctx context.Context, owner string, id int64) error
+	DeleteBatch(ctx context.Context, owner string, ids []int64) ([]int64, error)
+	Release(ctx context.Context, owner string, ids []int64) (int64, error)
+	Fail(ctx context.Context, owner string, failure dto.OutboxFailure) error
+	Watch(ctx context.Context, signal func() error) error
+	Replay(ctx context.Context, filter dto.OutboxReplayFilter) (dto.OutboxReplayBatch, error)
 }
 
 type OutboxServer struct {
 	pb.UnimplementedOutboxStorageServer
 	outboxService OutboxService
+	done          chan struct{}
+	closeOnce     sync.Once
 }
 
 func NewOutboxServer(outboxService OutboxService) *OutboxServer {
 	return &OutboxServer{
 		outboxService: outboxService,
+		done:          make(chan struct{}),
 	}
 }
 
+// Close ends all watch streams, so they don't block a graceful shutdown.
+// Clients are expected to reconnect.
+func (o *OutboxServer) Close() {
+	o.closeOnce.Do(func() {
+		close(o.done)
+	})
+}
+
 func (o *OutboxServer) Get(ctx context.Context, request *pb.GetMessagesRequest) (*pb.GetMessagesResponse, error) {
-	messages, err := o.outboxService.Get(ctx, request.GetMaxCount(), request.GetRetryAfter().AsDuration())
+	batch, err := o.outboxService.Get(
+		ctx,
+		request.GetLeaseOwner(),
+		request.GetMaxCount(),
+		request.GetRetryAfter().AsDuration(),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get outbox messages: %w", err)
 	}
 	response := &pb.GetMessagesResponse{
-		OutboxMessages: convertMessages(messages),
+		OutboxMessages: convertMessages(batch.Messages),
+		Backlog:        &batch.Backlog,
 	}
 	return response, nil
 }
 
 func (o *OutboxServer) Delete(ctx context.Context, request *pb.DeleteMessageRequest) (*emptypb.Empty, error) {
-	err := o.outboxService.Delete(ctx, request.GetId())
+	err := o.outboxService.Delete(ctx, request.GetLeaseOwner(), request.GetId())
+	if errors.Is(err, services.ErrOutboxLeaseLost) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete outbox message: %w", err)
 	}
 	return &emptypb.Empty{}, nil
 }
 
+func (o *OutboxServer) DeleteBatch(
+	ctx context.Context,
+	request *pb.DeleteBatchRequest,
+) (*pb.DeleteBatchResponse, error) {
+	rejected, err := o.outboxService.DeleteBatch(ctx, request.GetLeaseOwner(), request.GetIds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete outbox messages: %w", err)
+	}
+	return &pb.DeleteBatchResponse{
+		RejectedIds: rejected,
+	}, nil
+}
+
+func (o *OutboxServer) Release(
+	ctx context.Context,
+	request *pb.ReleaseMessagesRequest,
+) (*pb.ReleaseMessagesResponse, error) {
+	released, err := o.outboxService.Release(ctx, request.GetLeaseOwner(), request.GetIds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to release outbox messages: %w", err)
+	}
+	return &pb.ReleaseMessagesResponse{
+		ReleasedCount: &released,
+	}, nil
+}
+
+func (o *OutboxServer) Fail(ctx context.Context, request *pb.FailMessageRequest) (*emptypb.Empty, error) {
+	failure := dto.OutboxFailure{
+		MessageID:  request.GetId(),
+		Status:     dto.OutboxStatusPending,
+		Error:      request.GetError(),
+		NextSendAt: request.GetNextSendAt().AsTime(),
+	}
+	if request.GetPark() {
+		failure.Status = dto.OutboxStatusParked
+	}
+
+	err := o.outboxService.Fail(ctx, request.GetLeaseOwner(), failure)
+	if errors.Is(err, services.ErrOutboxLeaseLost) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fail outbox message: %w", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (o *OutboxServer) WatchOutbox(
+	_ *pb.WatchOutboxRequest,
+	stream grpc.ServerStreamingServer[pb.OutboxSignal],
+) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-o.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err := o.outboxService.Watch(ctx, func() error {
+		if err := stream.Send(&pb.OutboxSignal{}); err != nil {
+			return fmt.Errorf("failed to send outbox signal: %w", err)
+		}
+		return nil
+	})
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to watch outbox: %w", err)
+	}
+
+	return nil
+}
+
+// Replay schedules copies of at most maxReplayBatch published messages.
+func (o *OutboxServer) Replay(ctx context.Context, request *pb.ReplayMessagesRequest) (*pb.ReplayMessagesResponse, error) {
+	filter := dto.OutboxReplayFilter{
+		AfterID: request.GetAfterId(),
+		Limit:   min(request.GetMaxCount(), maxReplayBatch),
+		Topic:   kafka.Topic(request.GetTopic()),
+	}
+	if filter.Limit <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "max count must be greater than zero")
+	}
+	if request.GetAggregateId() != nil {
+		aggregateID, err := uuidFromProto(request.GetAggregateId())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid aggregate id: %v", err))
+		}
+		filter.AggregateID = aggregateID
+	}
+	if request.GetCreatedFrom() != nil {
+		filter.CreatedFrom = request.GetCreatedFrom().AsTime()
+	}
+	if request.GetCreatedTo() != nil {
+		filter.CreatedTo = request.GetCreatedTo().AsTime()
+	}
+
+	batch, err := o.outboxService.Replay(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ReplayMessagesResponse{
+		Count:  &batch.Count,
+		LastId: &batch.LastID,
+	}, nil
+}
+
 func convertMessages(messages []dto.OutboxMessage) []*types.OutboxMessage {
 	res := make([]*types.OutboxMessage, len(messages))
 
@@ -59,9 +204,18 @@ func convertMessages(messages []dto.OutboxMessage) []*types.OutboxMessage {
 
 func convertMessage(message dto.OutboxMessage) *types.OutboxMessage {
 	topicString := string(message.Stencil.Topic)
-	return &types.OutboxMessage{
-		Id:      &message.ID,
-		Topic:   &topicString,
-		Payload: message.Stencil.Payload,
+	res := &types.OutboxMessage{
+		Id:           &message.ID,
+		Topic:        &topicString,
+		Payload:      message.Stencil.Payload,
+		Key:          &message.Stencil.Key,
+		Headers:      message.Stencil.Headers,
+		Sequence:     &message.Sequence,
+		LeaseExpired: &message.LeaseExpired,
+		Attempts:     &message.Attempts,
 	}
+	if message.Stencil.AggregateID != uuid.Nil {
+		res.AggregateId = &types.UUID{Value: message.Stencil.AggregateID[:]}
+	}
+	return res
 }
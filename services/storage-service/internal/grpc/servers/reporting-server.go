@@ -0,0 +1,141 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	pb "go-invoice-service/common/protocol/proto/apiservice"
+	"google.golang.org/protobuf/proto"
+	"storage-service/internal/dto"
+	"time"
+)
+
+const reportDateLayout = time.DateOnly
+
+var _ pb.InvoiceReportingServer = (*ReportingServer)(nil)
+
+type ReportingService interface {
+	GetAging(ctx context.Context, asOf time.Time, loc *time.Location) ([]dto.AgingBucket, error)
+	GetRevenue(ctx context.Context, from time.Time, to time.Time, loc *time.Location, byCustomer bool) ([]dto.RevenueRow, error)
+	GetDSO(ctx context.Context, from time.Time, to time.Time, loc *time.Location) ([]dto.DSORow, error)
+}
+
+type ReportingServer struct {
+	pb.UnimplementedInvoiceReportingServer
+	service ReportingService
+}
+
+func NewReportingServer(service ReportingService) *ReportingServer {
+	return &ReportingServer{
+		service: service,
+	}
+}
+
+func (s *ReportingServer) GetAging(ctx context.Context, request *pb.AgingRequest) (*pb.AgingResponse, error) {
+	loc, err := time.LoadLocation(request.GetTimeZone())
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone: %w", err)
+	}
+
+	asOf := time.Now().In(loc)
+	if request.AsOf != nil {
+		asOf, err = time.Parse(reportDateLayout, request.GetAsOf())
+		if err != nil {
+			return nil, fmt.Errorf("invalid as of date: %w", err)
+		}
+	}
+
+	buckets, err := s.service.GetAging(ctx, asOf, loc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aging report: %w", err)
+	}
+
+	res := make([]*pb.AgingBucket, len(buckets))
+	for i, bucket := range buckets {
+		res[i] = &pb.AgingBucket{
+			Currency: &bucket.Currency,
+			Bucket:   &bucket.Bucket,
+			Invoices: &bucket.Invoices,
+			Amount:   &bucket.Amount,
+		}
+	}
+
+	return &pb.AgingResponse{
+		AsOf:    proto.String(asOf.Format(reportDateLayout)),
+		Buckets: res,
+	}, nil
+}
+
+func (s *ReportingServer) GetRevenue(ctx context.Context, request *pb.RevenueRequest) (*pb.RevenueResponse, error) {
+	loc, from, to, err := reportPeriodFromProto(request.GetTimeZone(), request.GetFrom(), request.GetTo())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.service.GetRevenue(ctx, from, to, loc, request.GetByCustomer())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revenue report: %w", err)
+	}
+
+	res := make([]*pb.RevenueRow, len(rows))
+	for i, row := range rows {
+		res[i] = &pb.RevenueRow{
+			Month:    proto.String(row.Month.Format("2006-01")),
+			Currency: &row.Currency,
+			Invoices: &row.Invoices,
+			Amount:   &row.Amount,
+		}
+		if request.GetByCustomer() {
+			res[i].CustomerId = uuidToProto(row.CustomerID)
+		}
+	}
+
+	return &pb.RevenueResponse{
+		Rows: res,
+	}, nil
+}
+
+func (s *ReportingServer) GetDSO(ctx context.Context, request *pb.DSORequest) (*pb.DSOResponse, error) {
+	loc, from, to, err := reportPeriodFromProto(request.GetTimeZone(), request.GetFrom(), request.GetTo())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.service.GetDSO(ctx, from, to, loc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dso report: %w", err)
+	}
+
+	res := make([]*pb.DSORow, len(rows))
+	for i, row := range rows {
+		res[i] = &pb.DSORow{
+			Currency:    &row.Currency,
+			Receivables: &row.Receivables,
+			Sales:       &row.Sales,
+			Days:        &row.Days,
+			Dso:         row.DSO,
+		}
+	}
+
+	return &pb.DSOResponse{
+		Rows: res,
+	}, nil
+}
+
+func reportPeriodFromProto(timeZone string, from string, to string) (*time.Location, time.Time, time.Time, error) {
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, time.Time{}, time.Time{}, fmt.Errorf("invalid time zone: %w", err)
+	}
+
+	fromDate, err := time.Parse(reportDateLayout, from)
+	if err != nil {
+		return nil, time.Time{}, time.Time{}, fmt.Errorf("invalid from date: %w", err)
+	}
+
+	toDate, err := time.Parse(reportDateLayout, to)
+	if err != nil {
+		return nil, time.Time{}, time.Time{}, fmt.Errorf("invalid to date: %w", err)
+	}
+
+	return loc, fromDate, toDate, nil
+}
@@ -2,13 +2,18 @@ package servers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"go-invoice-service/common/pkg/inbox"
 	"go-invoice-service/common/protocol/proto/types"
 	pb "go-invoice-service/common/protocol/proto/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"storage-service/internal/dto"
+	"storage-service/internal/services"
 	"time"
 )
 
@@ -16,8 +21,8 @@ var _ pb.InvoiceStorageServer = (*ValidationServer)(nil)
 
 type ValidationService interface {
 	Get(ctx context.Context, id uuid.UUID) (*dto.Invoice, dto.InvoiceStatus, error)
-	SetApproved(ctx context.Context, id uuid.UUID) error
-	SetRejected(ctx context.Context, id uuid.UUID) error
+	SetApproved(ctx context.Context, id uuid.UUID, inboxKey *inbox.Key) error
+	SetRejected(ctx context.Context, id uuid.UUID, inboxKey *inbox.Key) error
 }
 
 type ValidationServer struct {
@@ -36,11 +41,14 @@ func (s *ValidationServer) Get(ctx context.Context, request *pb.GetInvoiceReques
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve invoice ID: %w", err)
 	}
-	invoice, status, err := s.service.Get(ctx, id)
+	invoice, invoiceStatus, err := s.service.Get(ctx, id)
+	if errors.Is(err, services.ErrInvoiceNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get invoice: %w", err)
 	}
-	resp, err := createGetInvoiceResponse(invoice, status)
+	resp, err := createGetInvoiceResponse(invoice, invoiceStatus)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create response: %w", err)
 	}
@@ -52,7 +60,14 @@ func (s *ValidationServer) SetApproved(ctx context.Context, request *pb.SetAppro
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve invoice ID: %w", err)
 	}
-	err = s.service.SetApproved(ctx, id)
+	inboxKey, err := inboxKeyFromProto(request.GetInboxKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve inbox key: %w", err)
+	}
+	err = s.service.SetApproved(ctx, id, inboxKey)
+	if errors.Is(err, inbox.ErrAlreadyHandled) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to set invoice approved: %w", err)
 	}
@@ -64,13 +79,37 @@ func (s *ValidationServer) SetRejected(ctx context.Context, request *pb.SetRejec
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve invoice ID: %w", err)
 	}
-	err = s.service.SetApproved(ctx, id)
+	inboxKey, err := inboxKeyFromProto(request.GetInboxKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve inbox key: %w", err)
+	}
+	err = s.service.SetRejected(ctx, id, inboxKey)
+	if errors.Is(err, inbox.ErrAlreadyHandled) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to set invoice rejected: %w", err)
 	}
 	return &emptypb.Empty{}, nil
 }
 
+func inboxKeyFromProto(key *pb.InboxKey) (*inbox.Key, error) {
+	if key == nil {
+		return nil, nil
+	}
+	eventID, err := uuidFromProto(key.GetEventId())
+	if err != nil {
+		return nil, err
+	}
+	if key.GetConsumerGroup() == "" {
+		return nil, errors.New("consumer group must not be empty")
+	}
+	return &inbox.Key{
+		EventID:       eventID,
+		ConsumerGroup: key.GetConsumerGroup(),
+	}, nil
+}
+
 func createGetInvoiceResponse(invoice *dto.Invoice, status dto.InvoiceStatus) (*pb.GetInvoiceResponse, error) {
 	statusPb, err := statusToProto(status)
 	if err != nil {
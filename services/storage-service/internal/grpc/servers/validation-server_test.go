@@ -0,0 +1,114 @@
+package servers
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-invoice-service/common/pkg/inbox"
+	pb "go-invoice-service/common/protocol/proto/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"storage-service/internal/dto"
+	"testing"
+)
+
+// validationService records the status changes it was asked for.
+type validationService struct {
+	calls    []string
+	id       uuid.UUID
+	inboxKey *inbox.Key
+	err      error
+}
+
+func (s *validationService) Get(_ context.Context, _ uuid.UUID) (*dto.Invoice, dto.InvoiceStatus, error) {
+	return nil, "", nil
+}
+
+func (s *validationService) SetApproved(_ context.Context, id uuid.UUID, inboxKey *inbox.Key) error {
+	s.calls = append(s.calls, "SetApproved")
+	s.id = id
+	s.inboxKey = inboxKey
+	return s.err
+}
+
+func (s *validationService) SetRejected(_ context.Context, id uuid.UUID, inboxKey *inbox.Key) error {
+	s.calls = append(s.calls, "SetRejected")
+	s.id = id
+	s.inboxKey = inboxKey
+	return s.err
+}
+
+func TestValidationServer_SetStatus(t *testing.T) {
+	id := uuid.New()
+	eventID := uuid.New()
+	consumerGroup := "validation-service"
+	inboxKey := &pb.InboxKey{EventId: uuidToProto(eventID), ConsumerGroup: &consumerGroup}
+
+	tests := []struct {
+		name      string
+		call      func(*ValidationServer) error
+		err       error
+		wantCall  string
+		wantCode  codes.Code
+		wantInbox *inbox.Key
+	}{
+		{
+			name: "approved",
+			call: func(s *ValidationServer) error {
+				_, err := s.SetApproved(context.Background(), &pb.SetApprovedRequest{Id: uuidToProto(id)})
+				return err
+			},
+			wantCall: "SetApproved",
+			wantCode: codes.OK,
+		},
+		{
+			name: "rejected",
+			call: func(s *ValidationServer) error {
+				_, err := s.SetRejected(context.Background(), &pb.SetRejectedRequest{Id: uuidToProto(id)})
+				return err
+			},
+			wantCall: "SetRejected",
+			wantCode: codes.OK,
+		},
+		{
+			name: "rejected_with_inbox_key",
+			call: func(s *ValidationServer) error {
+				_, err := s.SetRejected(context.Background(), &pb.SetRejectedRequest{
+					Id:       uuidToProto(id),
+					InboxKey: inboxKey,
+				})
+				return err
+			},
+			wantCall:  "SetRejected",
+			wantCode:  codes.OK,
+			wantInbox: &inbox.Key{EventID: eventID, ConsumerGroup: consumerGroup},
+		},
+		{
+			name: "rejected_already_handled",
+			call: func(s *ValidationServer) error {
+				_, err := s.SetRejected(context.Background(), &pb.SetRejectedRequest{
+					Id:       uuidToProto(id),
+					InboxKey: inboxKey,
+				})
+				return err
+			},
+			err:       inbox.ErrAlreadyHandled,
+			wantCall:  "SetRejected",
+			wantCode:  codes.AlreadyExists,
+			wantInbox: &inbox.Key{EventID: eventID, ConsumerGroup: consumerGroup},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &validationService{err: tt.err}
+			err := tt.call(NewValidationServer(service))
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			require.Equal(t, []string{tt.wantCall}, service.calls)
+			assert.Equal(t, id, service.id)
+			assert.Equal(t, tt.wantInbox, service.inboxKey)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package servers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	pb "go-invoice-service/common/protocol/proto/messagescheduler"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"storage-service/internal/dto"
+	"storage-service/internal/services"
+	"time"
+)
+
+var _ pb.WebhookDeliveryStorageServer = (*WebhookDeliveryServer)(nil)
+
+type WebhookDeliveryService interface {
+	GetDueDeliveries(ctx context.Context, owner string, maxCount int32, leaseFor time.Duration) ([]dto.DueWebhookDelivery, error)
+	CompleteAttempt(ctx context.Context, owner string, result dto.WebhookAttemptResult) error
+}
+
+type WebhookDeliveryServer struct {
+	pb.UnimplementedWebhookDeliveryStorageServer
+	service WebhookDeliveryService
+}
+
+func NewWebhookDeliveryServer(service WebhookDeliveryService) *WebhookDeliveryServer {
+	return &WebhookDeliveryServer{
+		service: service,
+	}
+}
+
+func (s *WebhookDeliveryServer) GetDeliveries(
+	ctx context.Context,
+	request *pb.GetWebhookDeliveriesRequest,
+) (*pb.GetWebhookDeliveriesResponse, error) {
+	deliveries, err := s.service.GetDueDeliveries(
+		ctx,
+		request.GetLeaseOwner(),
+		request.GetMaxCount(),
+		request.GetLeaseFor().AsDuration(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	res := make([]*pb.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		res[i] = &pb.WebhookDelivery{
+			Id:        &delivery.ID,
+			EventType: &delivery.EventType,
+			Payload:   delivery.Payload,
+			Attempts:  &delivery.Attempts,
+			Url:       &delivery.URL,
+			Secret:    &delivery.Secret,
+		}
+	}
+
+	return &pb.GetWebhookDeliveriesResponse{
+		Deliveries: res,
+	}, nil
+}
+
+func (s *WebhookDeliveryServer) CompleteAttempt(
+	ctx context.Context,
+	request *pb.CompleteWebhookAttemptRequest,
+) (*emptypb.Empty, error) {
+	var deliveryStatus dto.WebhookDeliveryStatus
+	switch request.GetOutcome() {
+	case pb.WebhookAttemptOutcome_Succeeded:
+		deliveryStatus = dto.WebhookStatusSucceeded
+	case pb.WebhookAttemptOutcome_Retry:
+		deliveryStatus = dto.WebhookStatusPending
+	case pb.WebhookAttemptOutcome_Dead:
+		deliveryStatus = dto.WebhookStatusDead
+	default:
+		return nil, fmt.Errorf("unknown webhook attempt outcome: %v", request.GetOutcome())
+	}
+
+	err := s.service.CompleteAttempt(ctx, request.GetLeaseOwner(), dto.WebhookAttemptResult{
+		DeliveryID:    request.GetDeliveryId(),
+		Status:        deliveryStatus,
+		StatusCode:    request.GetStatusCode(),
+		Error:         request.GetError(),
+		Response:      request.GetResponse(),
+		Duration:      request.GetDuration().AsDuration(),
+		NextAttemptAt: request.GetNextAttemptAt().AsTime(),
+	})
+	if errors.Is(err, services.ErrWebhookLeaseLost) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete webhook attempt: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,247 @@
+package servers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	pb "go-invoice-service/common/protocol/proto/apiservice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"storage-service/internal/dto"
+	"storage-service/internal/services"
+)
+
+const (
+	defaultWebhookDeliveriesLimit int32 = 50
+	maxWebhookDeliveriesLimit     int32 = 500
+)
+
+var _ pb.WebhookStorageServer = (*WebhookServer)(nil)
+
+type WebhookService interface {
+	CreateEndpoint(ctx context.Context, endpoint dto.WebhookEndpoint) (*dto.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context) ([]dto.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, id uuid.UUID) error
+	ListDeliveries(ctx context.Context, filter dto.WebhookDeliveryFilter) ([]dto.WebhookDelivery, error)
+	GetDelivery(ctx context.Context, id int64) (*dto.WebhookDelivery, []dto.WebhookAttempt, error)
+	Redeliver(ctx context.Context, id int64) (*dto.WebhookDelivery, error)
+}
+
+type WebhookServer struct {
+	pb.UnimplementedWebhookStorageServer
+	service WebhookService
+}
+
+func NewWebhookServer(service WebhookService) *WebhookServer {
+	return &WebhookServer{
+		service: service,
+	}
+}
+
+func (s *WebhookServer) CreateEndpoint(
+	ctx context.Context,
+	request *pb.CreateWebhookEndpointRequest,
+) (*pb.WebhookEndpoint, error) {
+	id, err := uuidFromProto(request.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook endpoint id: %w", err)
+	}
+
+	endpoint, err := s.service.CreateEndpoint(ctx, dto.WebhookEndpoint{
+		ID:         id,
+		URL:        request.GetUrl(),
+		Secret:     request.GetSecret(),
+		EventTypes: request.GetEventTypes(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+
+	res := webhookEndpointToProto(endpoint)
+	res.Secret = &endpoint.Secret
+	return res, nil
+}
+
+func (s *WebhookServer) ListEndpoints(ctx context.Context, _ *emptypb.Empty) (*pb.ListWebhookEndpointsResponse, error) {
+	endpoints, err := s.service.ListEndpoints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+
+	res := make([]*pb.WebhookEndpoint, len(endpoints))
+	for i := range endpoints {
+		res[i] = webhookEndpointToProto(&endpoints[i])
+	}
+
+	return &pb.ListWebhookEndpointsResponse{
+		Endpoints: res,
+	}, nil
+}
+
+func (s *WebhookServer) DeleteEndpoint(
+	ctx context.Context,
+	request *pb.DeleteWebhookEndpointRequest,
+) (*emptypb.Empty, error) {
+	id, err := uuidFromProto(request.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook endpoint id: %w", err)
+	}
+
+	if err := s.service.DeleteEndpoint(ctx, id); err != nil {
+		return nil, webhookError("failed to delete webhook endpoint", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *WebhookServer) ListDeliveries(
+	ctx context.Context,
+	request *pb.ListWebhookDeliveriesRequest,
+) (*pb.ListWebhookDeliveriesResponse, error) {
+	filter := dto.WebhookDeliveryFilter{
+		BeforeID: request.GetBeforeId(),
+		Limit:    request.GetLimit(),
+	}
+	if request.EndpointId != nil {
+		endpointID, err := uuidFromProto(request.GetEndpointId())
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook endpoint id: %w", err)
+		}
+		filter.EndpointID = endpointID
+	}
+	if request.Status != nil {
+		deliveryStatus, err := webhookStatusFromProto(request.GetStatus())
+		if err != nil {
+			return nil, err
+		}
+		filter.Status = deliveryStatus
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultWebhookDeliveriesLimit
+	}
+	filter.Limit = min(filter.Limit, maxWebhookDeliveriesLimit)
+
+	deliveries, err := s.service.ListDeliveries(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	res := make([]*pb.WebhookDelivery, len(deliveries))
+	for i := range deliveries {
+		delivery, err := webhookDeliveryToProto(&deliveries[i])
+		if err != nil {
+			return nil, err
+		}
+		res[i] = delivery
+	}
+
+	return &pb.ListWebhookDeliveriesResponse{
+		Deliveries: res,
+	}, nil
+}
+
+func (s *WebhookServer) GetDelivery(
+	ctx context.Context,
+	request *pb.GetWebhookDeliveryRequest,
+) (*pb.GetWebhookDeliveryResponse, error) {
+	delivery, attempts, err := s.service.GetDelivery(ctx, request.GetId())
+	if err != nil {
+		return nil, webhookError("failed to get webhook delivery", err)
+	}
+
+	pbDelivery, err := webhookDeliveryToProto(delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	pbAttempts := make([]*pb.WebhookAttempt, len(attempts))
+	for i, attempt := range attempts {
+		pbAttempts[i] = &pb.WebhookAttempt{
+			Attempt:     &attempt.Attempt,
+			StatusCode:  &attempt.StatusCode,
+			Error:       &attempt.Error,
+			Response:    &attempt.Response,
+			Duration:    durationpb.New(attempt.Duration),
+			AttemptedAt: timestamppb.New(attempt.AttemptedAt),
+		}
+	}
+
+	return &pb.GetWebhookDeliveryResponse{
+		Delivery: pbDelivery,
+		Attempts: pbAttempts,
+	}, nil
+}
+
+func (s *WebhookServer) Redeliver(ctx context.Context, request *pb.RedeliverWebhookRequest) (*pb.WebhookDelivery, error) {
+	delivery, err := s.service.Redeliver(ctx, request.GetId())
+	if err != nil {
+		return nil, webhookError("failed to redeliver webhook", err)
+	}
+
+	return webhookDeliveryToProto(delivery)
+}
+
+func webhookError(msg string, err error) error {
+	if errors.Is(err, services.ErrWebhookNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// webhookEndpointToProto leaves the secret out, it is returned only on creation.
+func webhookEndpointToProto(endpoint *dto.WebhookEndpoint) *pb.WebhookEndpoint {
+	return &pb.WebhookEndpoint{
+		Id:         uuidToProto(endpoint.ID),
+		Url:        &endpoint.URL,
+		EventTypes: endpoint.EventTypes,
+		CreatedAt:  timestamppb.New(endpoint.CreatedAt),
+	}
+}
+
+func webhookDeliveryToProto(delivery *dto.WebhookDelivery) (*pb.WebhookDelivery, error) {
+	deliveryStatus, err := webhookStatusToProto(delivery.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.WebhookDelivery{
+		Id:            &delivery.ID,
+		EndpointId:    uuidToProto(delivery.EndpointID),
+		EventType:     &delivery.EventType,
+		Payload:       delivery.Payload,
+		Status:        &deliveryStatus,
+		Attempts:      &delivery.Attempts,
+		NextAttemptAt: timestamppb.New(delivery.NextAttemptAt),
+		LastError:     &delivery.LastError,
+		CreatedAt:     timestamppb.New(delivery.CreatedAt),
+		UpdatedAt:     timestamppb.New(delivery.UpdatedAt),
+	}, nil
+}
+
+func webhookStatusToProto(deliveryStatus dto.WebhookDeliveryStatus) (pb.WebhookDeliveryStatus, error) {
+	switch deliveryStatus {
+	case dto.WebhookStatusPending:
+		return pb.WebhookDeliveryStatus_Pending, nil
+	case dto.WebhookStatusSucceeded:
+		return pb.WebhookDeliveryStatus_Succeeded, nil
+	case dto.WebhookStatusDead:
+		return pb.WebhookDeliveryStatus_Dead, nil
+	}
+	return 0, fmt.Errorf("unknown webhook delivery status: %s", deliveryStatus)
+}
+
+func webhookStatusFromProto(deliveryStatus pb.WebhookDeliveryStatus) (dto.WebhookDeliveryStatus, error) {
+	switch deliveryStatus {
+	case pb.WebhookDeliveryStatus_Pending:
+		return dto.WebhookStatusPending, nil
+	case pb.WebhookDeliveryStatus_Succeeded:
+		return dto.WebhookStatusSucceeded, nil
+	case pb.WebhookDeliveryStatus_Dead:
+		return dto.WebhookStatusDead, nil
+	}
+	return dto.WebhookStatusNil, fmt.Errorf("unknown webhook delivery status: %v", deliveryStatus)
+}
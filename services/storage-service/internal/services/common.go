@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"database/sql"
+	"github.com/google/uuid"
 	"storage-service/internal/dto"
 	"time"
 )
@@ -15,3 +16,7 @@ type TransactionsManager interface {
 type OutboxScheduleRepository interface {
 	ScheduleMessage(ctx context.Context, tx *sql.Tx, message dto.OutboxMessageStencil, sendAt time.Time) error
 }
+
+type InvoiceEventRepository interface {
+	Add(ctx context.Context, tx *sql.Tx, invoiceID uuid.UUID, eventType dto.InvoiceEventType, status dto.InvoiceStatus) error
+}
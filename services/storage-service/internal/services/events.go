@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"go-invoice-service/common/pkg/tracing"
+	"go-invoice-service/common/protocol/kafka"
+	"storage-service/internal/dto"
+	"time"
+)
+
+type EventWriterConfig struct {
+	// Details sets per topic how much of the invoice events carry, EventDetailID by default.
+	Details map[kafka.Topic]kafka.EventDetail
+}
+
+type InvoiceGetRepository interface {
+	GetInvoice(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*dto.Invoice, dto.InvoiceStatus, error)
+}
+
+// EventWriter writes invoice events to the outbox.
+type EventWriter struct {
+	cfg        EventWriterConfig
+	outboxRep  OutboxScheduleRepository
+	invoiceRep InvoiceGetRepository
+	encoder    *kafka.Encoder
+}
+
+func NewEventWriter(
+	cfg EventWriterConfig,
+	outboxRep OutboxScheduleRepository,
+	invoiceRep InvoiceGetRepository,
+	encoder *kafka.Encoder,
+) *EventWriter {
+	return &EventWriter{
+		cfg:        cfg,
+		outboxRep:  outboxRep,
+		invoiceRep: invoiceRep,
+		encoder:    encoder,
+	}
+}
+
+func (w *EventWriter) NewInvoice(ctx context.Context, tx *sql.Tx, id uuid.UUID) error {
+	return writeEvent(ctx, tx, w, id, func(snapshot *kafka.InvoiceSnapshot) kafka.NewInvoice {
+		return kafka.NewInvoice{ID: id, Invoice: snapshot}
+	})
+}
+
+func (w *EventWriter) InvoiceApproved(ctx context.Context, tx *sql.Tx, id uuid.UUID) error {
+	return writeEvent(ctx, tx, w, id, func(snapshot *kafka.InvoiceSnapshot) kafka.ApprovedInvoice {
+		return kafka.ApprovedInvoice{ID: id, Invoice: snapshot}
+	})
+}
+
+func (w *EventWriter) InvoiceRejected(ctx context.Context, tx *sql.Tx, id uuid.UUID) error {
+	return writeEvent(ctx, tx, w, id, func(snapshot *kafka.InvoiceSnapshot) kafka.RejectedInvoice {
+		return kafka.RejectedInvoice{ID: id, Invoice: snapshot}
+	})
+}
+
+// writeEvent writes the event of the invoice to the outbox. Messages are
+// keyed by the invoice ID, so events of one invoice stay in one partition.
+// The snapshot is read in tx, so it includes the changes the event is about.
+func writeEvent[T kafka.Event](
+	ctx context.Context,
+	tx *sql.Tx,
+	w *EventWriter,
+	invoiceID uuid.UUID,
+	event func(snapshot *kafka.InvoiceSnapshot) T,
+) error {
+	var zero T
+	topic := zero.EventType()
+
+	var snapshot *kafka.InvoiceSnapshot
+	if w.cfg.Details[topic] == kafka.EventDetailSnapshot {
+		invoice, status, err := w.invoiceRep.GetInvoice(ctx, tx, invoiceID)
+		if err != nil {
+			return fmt.Errorf("failed to get invoice snapshot: %w", err)
+		}
+		snapshot = invoiceSnapshot(invoice, status)
+	}
+	data := event(snapshot)
+
+	ctx = tracing.Ensure(ctx)
+	now := time.Now().UTC()
+
+	envelope := kafka.NewEnvelope(data, now, tracing.CorrelationID(ctx))
+	payload, headers, err := kafka.Encode(ctx, w.encoder, envelope)
+	if err != nil {
+		return fmt.Errorf("encoding %s kafka message failed: %w", topic, err)
+	}
+	tracing.Inject(ctx, headers)
+
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("marshalling %s event data failed: %w", topic, err)
+	}
+
+	msg := dto.OutboxMessageStencil{
+		Topic:       topic,
+		AggregateID: invoiceID,
+		Key:         invoiceID.String(),
+		Headers:     headers,
+		Payload:     payload,
+		Data:        dataJSON,
+	}
+	err = w.outboxRep.ScheduleMessage(ctx, tx, msg, now)
+	if err != nil {
+		return fmt.Errorf("failed to write message to outbox: %w", err)
+	}
+	return nil
+}
+
+func invoiceSnapshot(invoice *dto.Invoice, status dto.InvoiceStatus) *kafka.InvoiceSnapshot {
+	items := make([]kafka.InvoiceSnapshotItem, len(invoice.Items))
+	for i, item := range invoice.Items {
+		items[i] = kafka.InvoiceSnapshotItem{
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Total:       item.Total,
+		}
+	}
+
+	return &kafka.InvoiceSnapshot{
+		CustomerID: invoice.CustomerID,
+		Amount:     invoice.Amount,
+		Currency:   invoice.Currency,
+		DueDate:    invoice.DueDate,
+		Status:     string(status),
+		Items:      items,
+		Notes:      invoice.Notes,
+		CreatedAt:  invoice.CreatedAt,
+		UpdatedAt:  invoice.UpdatedAt,
+	}
+}
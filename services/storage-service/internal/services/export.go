@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"storage-service/internal/dto"
+)
+
+type ExportRepository interface {
+	Stream(
+		ctx context.Context,
+		tx *sql.Tx,
+		filter dto.ExportFilter,
+		batchSize int32,
+		consume func([]dto.ExportedInvoice) error,
+	) error
+}
+
+type Export struct {
+	tm        TransactionsManager
+	exportRep ExportRepository
+}
+
+func NewExport(tm TransactionsManager, exportRep ExportRepository) *Export {
+	return &Export{
+		tm:        tm,
+		exportRep: exportRep,
+	}
+}
+
+func (s *Export) Export(
+	ctx context.Context,
+	filter dto.ExportFilter,
+	batchSize int32,
+	consume func([]dto.ExportedInvoice) error,
+) error {
+	return s.tm.DoOpts(ctx,
+		&sql.TxOptions{
+			Isolation: sql.LevelRepeatableRead,
+			ReadOnly:  true,
+		},
+		func(ctx context.Context, tx *sql.Tx) error {
+			return s.exportRep.Stream(ctx, tx, filter, batchSize, consume)
+		},
+	)
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"storage-service/internal/dto"
+	"time"
+)
+
+type InvoiceEventFeedRepository interface {
+	GetAfter(ctx context.Context, cursor dto.EventCursor, maxCount int32) ([]dto.InvoiceEvent, error)
+	Head(ctx context.Context) (dto.EventCursor, error)
+}
+
+type FeedConfig struct {
+	PollInterval time.Duration
+	BatchSize    int32
+}
+
+type Feed struct {
+	cfg      FeedConfig
+	eventRep InvoiceEventFeedRepository
+}
+
+func NewFeed(cfg FeedConfig, eventRep InvoiceEventFeedRepository) *Feed {
+	return &Feed{
+		cfg:      cfg,
+		eventRep: eventRep,
+	}
+}
+
+func (s *Feed) Head(ctx context.Context) (dto.EventCursor, error) {
+	cursor, err := s.eventRep.Head(ctx)
+	if err != nil {
+		return dto.EventCursor{}, fmt.Errorf("failed to get feed head: %w", err)
+	}
+	return cursor, nil
+}
+
+// Watch passes events after the cursor to consume until ctx is done or an error occurs.
+// The events table is polled while there are no new events.
+func (s *Feed) Watch(ctx context.Context, after dto.EventCursor, consume func([]dto.InvoiceEvent) error) error {
+	for {
+		events, err := s.eventRep.GetAfter(ctx, after, s.cfg.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to get invoice events: %w", err)
+		}
+
+		if len(events) > 0 {
+			if err := consume(events); err != nil {
+				return err
+			}
+			after = events[len(events)-1].Cursor
+		}
+
+		if len(events) == int(s.cfg.BatchSize) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.cfg.PollInterval):
+		}
+	}
+}
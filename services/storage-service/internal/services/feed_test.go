@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"storage-service/internal/dto"
+	"sync"
+	"testing"
+	"time"
+)
+
+// eventFeedRepository serves events ordered by cursor and records the cursors asked for.
+type eventFeedRepository struct {
+	mu     sync.Mutex
+	events []dto.InvoiceEvent
+	err    error
+	afters []dto.EventCursor
+}
+
+func (r *eventFeedRepository) GetAfter(_ context.Context, cursor dto.EventCursor, maxCount int32) ([]dto.InvoiceEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.afters = append(r.afters, cursor)
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	var res []dto.InvoiceEvent
+	for _, event := range r.events {
+		c := event.Cursor
+		if c.TxID > cursor.TxID || (c.TxID == cursor.TxID && c.ID > cursor.ID) {
+			res = append(res, event)
+		}
+		if len(res) == int(maxCount) {
+			break
+		}
+	}
+	return res, nil
+}
+
+func (r *eventFeedRepository) Head(context.Context) (dto.EventCursor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.events) == 0 {
+		return dto.EventCursor{}, nil
+	}
+	return r.events[len(r.events)-1].Cursor, nil
+}
+
+func (r *eventFeedRepository) add(events ...dto.InvoiceEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, events...)
+}
+
+func (r *eventFeedRepository) calls() []dto.EventCursor {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]dto.EventCursor(nil), r.afters...)
+}
+
+// feedEvents returns events of two per transaction starting with transaction 100.
+func feedEvents(count int) []dto.InvoiceEvent {
+	res := make([]dto.InvoiceEvent, count)
+	for i := range res {
+		res[i] = dto.InvoiceEvent{
+			Cursor: dto.EventCursor{TxID: int64(100 + i/2), ID: int64(i + 1)},
+			Type:   dto.InvoiceEventCreated,
+		}
+	}
+	return res
+}
+
+// watch runs Watch until consume received count events.
+func watch(t *testing.T, feed *Feed, after dto.EventCursor, count int) ([][]dto.InvoiceEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var batches [][]dto.InvoiceEvent
+	received := 0
+	err := feed.Watch(ctx, after, func(events []dto.InvoiceEvent) error {
+		batches = append(batches, events)
+		received += len(events)
+		if received >= count {
+			cancel()
+		}
+		return nil
+	})
+	return batches, err
+}
+
+func TestFeed_Watch_ResumesAfterCursor(t *testing.T) {
+	events := feedEvents(5)
+	repo := &eventFeedRepository{events: events}
+	feed := NewFeed(FeedConfig{PollInterval: time.Hour, BatchSize: 10}, repo)
+
+	batches, err := watch(t, feed, events[1].Cursor, 3)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, [][]dto.InvoiceEvent{events[2:]}, batches)
+	assert.Equal(t, []dto.EventCursor{events[1].Cursor}, repo.calls())
+}
+
+func TestFeed_Watch_FullBatches(t *testing.T) {
+	events := feedEvents(5)
+	repo := &eventFeedRepository{events: events}
+	// Full batches are followed by the next one right away, the feed is polled only
+	// once a batch is not full.
+	feed := NewFeed(FeedConfig{PollInterval: time.Hour, BatchSize: 2}, repo)
+
+	batches, err := watch(t, feed, dto.EventCursor{}, 5)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, [][]dto.InvoiceEvent{events[:2], events[2:4], events[4:]}, batches)
+	assert.Equal(t, []dto.EventCursor{{}, events[1].Cursor, events[3].Cursor}, repo.calls())
+}
+
+func TestFeed_Watch_Polls(t *testing.T) {
+	events := feedEvents(3)
+	repo := &eventFeedRepository{events: events[:1]}
+	feed := NewFeed(FeedConfig{PollInterval: time.Millisecond, BatchSize: 10}, repo)
+
+	go func() {
+		// Added while the feed is polled after the first event.
+		for len(repo.calls()) < 3 {
+			time.Sleep(time.Millisecond)
+		}
+		repo.add(events[1:]...)
+	}()
+
+	batches, err := watch(t, feed, dto.EventCursor{}, 3)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Len(t, batches, 2)
+	assert.Equal(t, events[:1], batches[0])
+	assert.Equal(t, events[1:], batches[1])
+	for _, after := range repo.calls()[1:] {
+		assert.Equal(t, events[0].Cursor, after)
+	}
+}
+
+func TestFeed_Watch_Errors(t *testing.T) {
+	repoErr := errors.New("query failed")
+	feed := NewFeed(FeedConfig{PollInterval: time.Hour, BatchSize: 10}, &eventFeedRepository{err: repoErr})
+	err := feed.Watch(context.Background(), dto.EventCursor{}, func([]dto.InvoiceEvent) error { return nil })
+	require.ErrorIs(t, err, repoErr)
+
+	consumeErr := errors.New("stream closed")
+	feed = NewFeed(FeedConfig{PollInterval: time.Hour, BatchSize: 10}, &eventFeedRepository{events: feedEvents(1)})
+	err = feed.Watch(context.Background(), dto.EventCursor{}, func([]dto.InvoiceEvent) error { return consumeErr })
+	require.ErrorIs(t, err, consumeErr)
+}
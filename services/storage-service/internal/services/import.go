@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go-invoice-service/common/pkg/transactions"
+	"storage-service/internal/dto"
+)
+
+var ErrImportJobFinished = errors.New("import job is already finished")
+
+type ImportRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, id uuid.UUID, format string) error
+	Get(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*dto.ImportJob, error)
+	Lock(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*dto.ImportJob, error)
+	GetByStatus(ctx context.Context, tx *sql.Tx, status dto.ImportJobStatus) ([]dto.ImportJob, error)
+	UpdateProgress(ctx context.Context, tx *sql.Tx, job *dto.ImportJob) error
+	Finish(ctx context.Context, tx *sql.Tx, id uuid.UUID, status dto.ImportJobStatus, errorMessage string) error
+	AddResult(ctx context.Context, tx *sql.Tx, jobID uuid.UUID, result dto.ImportRowResult) error
+	GetResults(ctx context.Context, tx *sql.Tx, jobID uuid.UUID, filter dto.ImportResultFilter) ([]dto.ImportRowResult, error)
+}
+
+type InvoiceImportRepository interface {
+	Add(ctx context.Context, tx *sql.Tx, invoice *dto.Invoice, status dto.InvoiceStatus) error
+}
+
+type Import struct {
+	tm          TransactionsManager
+	importRep   ImportRepository
+	invoiceRep  InvoiceImportRepository
+	eventWriter *EventWriter
+	eventRep    InvoiceEventRepository
+}
+
+func NewImport(
+	tm TransactionsManager,
+	importRep ImportRepository,
+	invoiceRep InvoiceImportRepository,
+	eventWriter *EventWriter,
+	eventRep InvoiceEventRepository,
+) *Import {
+	return &Import{
+		tm:          tm,
+		importRep:   importRep,
+		invoiceRep:  invoiceRep,
+		eventWriter: eventWriter,
+		eventRep:    eventRep,
+	}
+}
+
+func (s *Import) CreateJob(ctx context.Context, id uuid.UUID, format string) (*dto.ImportJob, error) {
+	var res *dto.ImportJob
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		if err := s.importRep.Create(ctx, tx, id, format); err != nil {
+			return fmt.Errorf("failed to create import job: %w", err)
+		}
+		job, err := s.importRep.Get(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get import job: %w", err)
+		}
+		res = job
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ImportBatch stores rows numbered above the job checkpoint, so a batch re-sent after
+// a lost response is not imported twice. Every row is written in its own savepoint:
+// a rejected row is reported in the job results without failing the whole batch.
+func (s *Import) ImportBatch(ctx context.Context, jobID uuid.UUID, rows []dto.ImportRow) (*dto.ImportJob, error) {
+	var res *dto.ImportJob
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		job, err := s.importRep.Lock(ctx, tx, jobID)
+		if err != nil {
+			return fmt.Errorf("failed to lock import job: %w", err)
+		}
+		if job.Status != dto.ImportStatusRunning {
+			return ErrImportJobFinished
+		}
+
+		for _, row := range rows {
+			if row.Number <= job.ProcessedRows {
+				continue
+			}
+
+			result := s.importRow(ctx, tx, row)
+			if err := s.importRep.AddResult(ctx, tx, jobID, result); err != nil {
+				return fmt.Errorf("failed to add import result: %w", err)
+			}
+
+			if result.Error != "" {
+				job.FailedRows++
+			} else {
+				job.SucceededRows++
+			}
+			job.ProcessedRows = row.Number
+		}
+
+		if err := s.importRep.UpdateProgress(ctx, tx, job); err != nil {
+			return fmt.Errorf("failed to update import job progress: %w", err)
+		}
+		res = job
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *Import) importRow(ctx context.Context, tx *sql.Tx, row dto.ImportRow) dto.ImportRowResult {
+	result := dto.ImportRowResult{
+		Number: row.Number,
+		Error:  row.Error,
+	}
+	if row.Error != "" {
+		return result
+	}
+
+	err := transactions.Savepoint(ctx, tx, "import_row", func() error {
+		if err := s.invoiceRep.Add(ctx, tx, row.Invoice, dto.StatusPending); err != nil {
+			return fmt.Errorf("adding invoice failed: %w", err)
+		}
+		if err := s.eventRep.Add(ctx, tx, row.Invoice.ID, dto.InvoiceEventCreated, dto.StatusPending); err != nil {
+			return fmt.Errorf("adding invoice event failed: %w", err)
+		}
+		return s.eventWriter.NewInvoice(ctx, tx, row.Invoice.ID)
+	})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.InvoiceID = row.Invoice.ID
+	return result
+}
+
+func (s *Import) FinishJob(ctx context.Context, id uuid.UUID, status dto.ImportJobStatus, errorMessage string) error {
+	return s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		job, err := s.importRep.Lock(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to lock import job: %w", err)
+		}
+		if job.Status != dto.ImportStatusRunning {
+			return ErrImportJobFinished
+		}
+		if err := s.importRep.Finish(ctx, tx, id, status, errorMessage); err != nil {
+			return fmt.Errorf("failed to finish import job: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetJob returns the job with a page of its results, the results are not read for a zero limit.
+func (s *Import) GetJob(
+	ctx context.Context,
+	id uuid.UUID,
+	filter dto.ImportResultFilter,
+) (*dto.ImportJob, []dto.ImportRowResult, error) {
+	var resJob *dto.ImportJob
+	var resResults []dto.ImportRowResult
+
+	err := s.tm.DoOpts(ctx,
+		&sql.TxOptions{
+			Isolation: sql.LevelRepeatableRead,
+			ReadOnly:  true,
+		},
+		func(ctx context.Context, tx *sql.Tx) error {
+			job, err := s.importRep.Get(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			resJob = job
+			if filter.Limit <= 0 {
+				return nil
+			}
+			results, err := s.importRep.GetResults(ctx, tx, id, filter)
+			if err != nil {
+				return err
+			}
+			resResults = results
+			return nil
+		},
+	)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resJob, resResults, nil
+}
+
+func (s *Import) ListJobs(ctx context.Context, status dto.ImportJobStatus) ([]dto.ImportJob, error) {
+	var res []dto.ImportJob
+	err := s.tm.DoOpts(ctx,
+		&sql.TxOptions{
+			ReadOnly: true,
+		},
+		func(ctx context.Context, tx *sql.Tx) error {
+			jobs, err := s.importRep.GetByStatus(ctx, tx, status)
+			if err != nil {
+				return err
+			}
+			res = jobs
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
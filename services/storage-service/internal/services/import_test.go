@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-invoice-service/common/protocol/kafka"
+	"storage-service/internal/dto"
+	"sync"
+	"testing"
+	"time"
+)
+
+// statementLog is a database/sql connector that accepts any statement and records it,
+// so the savepoints made in a transaction can be checked.
+type statementLog struct {
+	mu         sync.Mutex
+	statements []string
+}
+
+func (l *statementLog) Connect(context.Context) (driver.Conn, error) {
+	return &statementLogConn{log: l}, nil
+}
+
+func (l *statementLog) Driver() driver.Driver {
+	return l
+}
+
+func (l *statementLog) Open(string) (driver.Conn, error) {
+	return &statementLogConn{log: l}, nil
+}
+
+func (l *statementLog) all() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.statements...)
+}
+
+type statementLogConn struct {
+	log *statementLog
+}
+
+func (c *statementLogConn) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepared statements are not supported")
+}
+
+func (c *statementLogConn) Close() error {
+	return nil
+}
+
+func (c *statementLogConn) Begin() (driver.Tx, error) {
+	return statementLogTx{}, nil
+}
+
+func (c *statementLogConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.log.mu.Lock()
+	defer c.log.mu.Unlock()
+	c.log.statements = append(c.log.statements, query)
+	return driver.RowsAffected(0), nil
+}
+
+type statementLogTx struct{}
+
+func (statementLogTx) Commit() error {
+	return nil
+}
+
+func (statementLogTx) Rollback() error {
+	return nil
+}
+
+// dbTransactionsManager runs functions in transactions of db.
+type dbTransactionsManager struct {
+	db *sql.DB
+}
+
+func (m *dbTransactionsManager) Do(ctx context.Context, f func(ctx context.Context, tx *sql.Tx) error) error {
+	return m.DoOpts(ctx, nil, f)
+}
+
+func (m *dbTransactionsManager) DoOpts(ctx context.Context, opts *sql.TxOptions, f func(ctx context.Context, tx *sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if err := f(ctx, tx); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
+
+// importRepository keeps a single job and its results in memory.
+type importRepository struct {
+	ImportRepository
+	job     dto.ImportJob
+	results []dto.ImportRowResult
+}
+
+func (r *importRepository) Lock(context.Context, *sql.Tx, uuid.UUID) (*dto.ImportJob, error) {
+	job := r.job
+	return &job, nil
+}
+
+func (r *importRepository) UpdateProgress(_ context.Context, _ *sql.Tx, job *dto.ImportJob) error {
+	r.job = *job
+	return nil
+}
+
+func (r *importRepository) AddResult(_ context.Context, _ *sql.Tx, _ uuid.UUID, result dto.ImportRowResult) error {
+	r.results = append(r.results, result)
+	return nil
+}
+
+// importInvoiceRepository rejects the invoices in rejected and records the others.
+type importInvoiceRepository struct {
+	rejected map[uuid.UUID]bool
+	added    []uuid.UUID
+}
+
+func (r *importInvoiceRepository) Add(_ context.Context, _ *sql.Tx, invoice *dto.Invoice, _ dto.InvoiceStatus) error {
+	if r.rejected[invoice.ID] {
+		return errors.New("duplicate key value violates unique constraint")
+	}
+	r.added = append(r.added, invoice.ID)
+	return nil
+}
+
+type importEventRepository struct{}
+
+func (importEventRepository) Add(context.Context, *sql.Tx, uuid.UUID, dto.InvoiceEventType, dto.InvoiceStatus) error {
+	return nil
+}
+
+type importOutboxRepository struct {
+	scheduled []uuid.UUID
+}
+
+func (r *importOutboxRepository) ScheduleMessage(
+	_ context.Context,
+	_ *sql.Tx,
+	message dto.OutboxMessageStencil,
+	_ time.Time,
+) error {
+	r.scheduled = append(r.scheduled, message.AggregateID)
+	return nil
+}
+
+type testImport struct {
+	service    *Import
+	statements *statementLog
+	jobs       *importRepository
+	invoices   *importInvoiceRepository
+	outbox     *importOutboxRepository
+}
+
+func newTestImport(t *testing.T, job dto.ImportJob, rejected ...uuid.UUID) testImport {
+	statements := &statementLog{}
+	db := sql.OpenDB(statements)
+	t.Cleanup(func() { _ = db.Close() })
+
+	ti := testImport{
+		statements: statements,
+		jobs:       &importRepository{job: job},
+		invoices:   &importInvoiceRepository{rejected: make(map[uuid.UUID]bool)},
+		outbox:     &importOutboxRepository{},
+	}
+	for _, id := range rejected {
+		ti.invoices.rejected[id] = true
+	}
+	eventWriter := NewEventWriter(EventWriterConfig{}, ti.outbox, nil, kafka.NewJSONEncoder())
+	ti.service = NewImport(&dbTransactionsManager{db: db}, ti.jobs, ti.invoices, eventWriter, importEventRepository{})
+	return ti
+}
+
+func importRows(ids ...uuid.UUID) []dto.ImportRow {
+	rows := make([]dto.ImportRow, len(ids))
+	for i, id := range ids {
+		rows[i] = dto.ImportRow{Number: int64(i + 1), Invoice: &dto.Invoice{ID: id}}
+	}
+	return rows
+}
+
+func TestImport_ImportBatch_RowSavepoints(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+	ti := newTestImport(t, dto.ImportJob{Status: dto.ImportStatusRunning}, ids[1])
+
+	rows := importRows(ids...)
+	// A row that failed to parse is reported without reaching the database.
+	rows[3] = dto.ImportRow{Number: 4, Error: "invalid amount"}
+
+	job, err := ti.service.ImportBatch(context.Background(), uuid.New(), rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(4), job.ProcessedRows)
+	assert.Equal(t, int64(2), job.SucceededRows)
+	assert.Equal(t, int64(2), job.FailedRows)
+	assert.Equal(t, *job, ti.jobs.job)
+
+	require.Len(t, ti.jobs.results, 4)
+	assert.Equal(t, dto.ImportRowResult{Number: 1, InvoiceID: ids[0]}, ti.jobs.results[0])
+	assert.Equal(t, int64(2), ti.jobs.results[1].Number)
+	assert.Equal(t, uuid.Nil, ti.jobs.results[1].InvoiceID)
+	assert.Contains(t, ti.jobs.results[1].Error, "adding invoice failed")
+	assert.Equal(t, dto.ImportRowResult{Number: 3, InvoiceID: ids[2]}, ti.jobs.results[2])
+	assert.Equal(t, dto.ImportRowResult{Number: 4, Error: "invalid amount"}, ti.jobs.results[3])
+
+	// The rejected row is rolled back alone, the rows around it are kept.
+	assert.Equal(t, []string{
+		"savepoint import_row", "release savepoint import_row",
+		"savepoint import_row", "rollback to savepoint import_row",
+		"savepoint import_row", "release savepoint import_row",
+	}, ti.statements.all())
+	assert.Equal(t, []uuid.UUID{ids[0], ids[2]}, ti.invoices.added)
+	assert.Equal(t, []uuid.UUID{ids[0], ids[2]}, ti.outbox.scheduled)
+}
+
+func TestImport_ImportBatch_Resume(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+
+	tests := []struct {
+		name    string
+		job     dto.ImportJob
+		wantErr error
+		// wantImported are the rows imported by the batch.
+		wantImported []int
+		wantJob      dto.ImportJob
+	}{
+		{
+			name:         "new_job",
+			job:          dto.ImportJob{Status: dto.ImportStatusRunning},
+			wantImported: []int{0, 1, 2, 3},
+			wantJob:      dto.ImportJob{Status: dto.ImportStatusRunning, ProcessedRows: 4, SucceededRows: 4},
+		},
+		{
+			name:         "batch_resent_after_checkpoint",
+			job:          dto.ImportJob{Status: dto.ImportStatusRunning, ProcessedRows: 2, SucceededRows: 1, FailedRows: 1},
+			wantImported: []int{2, 3},
+			wantJob:      dto.ImportJob{Status: dto.ImportStatusRunning, ProcessedRows: 4, SucceededRows: 3, FailedRows: 1},
+		},
+		{
+			name:    "batch_fully_imported",
+			job:     dto.ImportJob{Status: dto.ImportStatusRunning, ProcessedRows: 4, SucceededRows: 4},
+			wantJob: dto.ImportJob{Status: dto.ImportStatusRunning, ProcessedRows: 4, SucceededRows: 4},
+		},
+		{
+			name:    "finished_job",
+			job:     dto.ImportJob{Status: dto.ImportStatusFailed, ProcessedRows: 1, SucceededRows: 1},
+			wantErr: ErrImportJobFinished,
+			wantJob: dto.ImportJob{Status: dto.ImportStatusFailed, ProcessedRows: 1, SucceededRows: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestImport(t, tt.job)
+
+			_, err := ti.service.ImportBatch(context.Background(), uuid.New(), importRows(ids...))
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var wantAdded []uuid.UUID
+			for _, i := range tt.wantImported {
+				wantAdded = append(wantAdded, ids[i])
+			}
+			assert.Equal(t, wantAdded, ti.invoices.added)
+			assert.Len(t, ti.jobs.results, len(tt.wantImported))
+			assert.Equal(t, tt.wantJob, ti.jobs.job)
+		})
+	}
+}
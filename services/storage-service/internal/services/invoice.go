@@ -3,12 +3,9 @@ package services
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
 	"fmt"
 	"github.com/google/uuid"
-	"go-invoice-service/common/protocol/kafka"
 	"storage-service/internal/dto"
-	"time"
 )
 
 type InvoiceAddRepository interface {
@@ -17,20 +14,23 @@ type InvoiceAddRepository interface {
 }
 
 type Invoice struct {
-	tm         TransactionsManager
-	invoiceRep InvoiceAddRepository
-	outboxRep  OutboxScheduleRepository
+	tm          TransactionsManager
+	invoiceRep  InvoiceAddRepository
+	eventWriter *EventWriter
+	eventRep    InvoiceEventRepository
 }
 
 func NewInvoice(
 	tm TransactionsManager,
 	invoiceRep InvoiceAddRepository,
-	outboxRep OutboxScheduleRepository,
+	eventWriter *EventWriter,
+	eventRep InvoiceEventRepository,
 ) *Invoice {
 	return &Invoice{
-		tm:         tm,
-		invoiceRep: invoiceRep,
-		outboxRep:  outboxRep,
+		tm:          tm,
+		invoiceRep:  invoiceRep,
+		eventWriter: eventWriter,
+		eventRep:    eventRep,
 	}
 }
 
@@ -41,23 +41,12 @@ func (s *Invoice) AddNew(ctx context.Context, invoice *dto.Invoice) error {
 			return fmt.Errorf("adding invoice failed: %w", err)
 		}
 
-		payload := kafka.NewInvoice{
-			ID: invoice.ID,
-		}
-		payloadJSON, err := json.Marshal(payload)
-		if err != nil {
-			return fmt.Errorf("marshalling new invoice kafka message failed: %w", err)
-		}
-		msg := dto.OutboxMessageStencil{
-			Topic:   kafka.TopicNewInvoice,
-			Payload: payloadJSON,
-		}
-		err = s.outboxRep.ScheduleMessage(ctx, tx, msg, time.Now().UTC())
+		err = s.eventRep.Add(ctx, tx, invoice.ID, dto.InvoiceEventCreated, dto.StatusPending)
 		if err != nil {
-			return fmt.Errorf("scheduled message failed: %w", err)
+			return fmt.Errorf("adding invoice event failed: %w", err)
 		}
 
-		return nil
+		return s.eventWriter.NewInvoice(ctx, tx, invoice.ID)
 	})
 }
 
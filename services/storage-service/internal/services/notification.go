@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"storage-service/internal/dto"
+	"time"
+)
+
+var ErrNotificationNotClaimed = errors.New("notification is not claimed")
+
+type NotificationRepository interface {
+	Claim(ctx context.Context, tx *sql.Tx, key dto.NotificationKey, recipient string, lockedUntil time.Time) (bool, error)
+	GetStatus(ctx context.Context, tx *sql.Tx, key dto.NotificationKey) (string, error)
+	MarkSent(ctx context.Context, tx *sql.Tx, key dto.NotificationKey) (bool, error)
+	Release(ctx context.Context, tx *sql.Tx, key dto.NotificationKey) error
+}
+
+type Notification struct {
+	tm              TransactionsManager
+	invoiceRep      InvoiceRepository
+	notificationRep NotificationRepository
+}
+
+func NewNotification(
+	tm TransactionsManager,
+	invoiceRep InvoiceRepository,
+	notificationRep NotificationRepository,
+) *Notification {
+	return &Notification{
+		tm:              tm,
+		invoiceRep:      invoiceRep,
+		notificationRep: notificationRep,
+	}
+}
+
+func (s *Notification) GetInvoice(ctx context.Context, id uuid.UUID) (*dto.Invoice, dto.InvoiceStatus, error) {
+	var resInvoice *dto.Invoice
+	var resStatus dto.InvoiceStatus
+
+	err := s.tm.DoOpts(ctx,
+		&sql.TxOptions{
+			ReadOnly: true,
+		},
+		func(ctx context.Context, tx *sql.Tx) error {
+			invoice, status, err := s.invoiceRep.GetInvoice(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			resInvoice = invoice
+			resStatus = status
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, dto.StatusNil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	return resInvoice, resStatus, nil
+}
+
+// Claim reserves the notification for leaseFor. A notification that was claimed but
+// neither marked sent nor released in time can be claimed again.
+func (s *Notification) Claim(
+	ctx context.Context,
+	key dto.NotificationKey,
+	recipient string,
+	leaseFor time.Duration,
+) (dto.NotificationClaimResult, error) {
+	var res dto.NotificationClaimResult
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		claimed, err := s.notificationRep.Claim(ctx, tx, key, recipient, time.Now().UTC().Add(leaseFor))
+		if err != nil {
+			return err
+		}
+		if claimed {
+			res = dto.NotificationClaimed
+			return nil
+		}
+
+		status, err := s.notificationRep.GetStatus(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+		if status == dto.NotificationStatusSent {
+			res = dto.NotificationAlreadySent
+		} else {
+			res = dto.NotificationInProgress
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to claim notification: %w", err)
+	}
+	return res, nil
+}
+
+func (s *Notification) MarkSent(ctx context.Context, key dto.NotificationKey) error {
+	return s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		marked, err := s.notificationRep.MarkSent(ctx, tx, key)
+		if err != nil {
+			return fmt.Errorf("failed to mark notification sent: %w", err)
+		}
+		if !marked {
+			return ErrNotificationNotClaimed
+		}
+		return nil
+	})
+}
+
+func (s *Notification) Release(ctx context.Context, key dto.NotificationKey) error {
+	return s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		if err := s.notificationRep.Release(ctx, tx, key); err != nil {
+			return fmt.Errorf("failed to release notification: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-invoice-service/common/pkg/logging"
+	"go.uber.org/zap"
+	"storage-service/internal/dto"
+	"time"
+)
+
+const (
+	// outboxPartitionsAhead is the number of days partitions are created in advance for.
+	outboxPartitionsAhead = 7
+	outboxMaintenanceTick = 1 * time.Hour
+)
+
+type OutboxPartitionRepository interface {
+	List(ctx context.Context, tx *sql.Tx) ([]dto.OutboxPartition, error)
+	Create(ctx context.Context, tx *sql.Tx, day time.Time) (dto.OutboxPartition, error)
+	HasUnpublished(ctx context.Context, tx *sql.Tx, partition dto.OutboxPartition) (bool, error)
+	Archive(ctx context.Context, tx *sql.Tx, partition dto.OutboxPartition) (int64, error)
+	Detach(ctx context.Context, partition dto.OutboxPartition) error
+	Drop(ctx context.Context, tx *sql.Tx, partition dto.OutboxPartition) error
+}
+
+type OutboxPartitionsConfig struct {
+	// RetentionDays is the number of days partitions are kept after their day ends.
+	RetentionDays int
+	// Archive copies published messages of expired partitions to the archive before
+	// the partitions are dropped.
+	Archive bool
+}
+
+// OutboxPartitions maintains the daily partitions of the outbox table.
+type OutboxPartitions struct {
+	cfg                 OutboxPartitionsConfig
+	tm                  TransactionsManager
+	partitionRepository OutboxPartitionRepository
+	logger              *logging.ZapLogger
+}
+
+func NewOutboxPartitions(
+	cfg OutboxPartitionsConfig,
+	tm TransactionsManager,
+	partitionRepository OutboxPartitionRepository,
+	logger *logging.ZapLogger,
+) *OutboxPartitions {
+	return &OutboxPartitions{
+		cfg:                 cfg,
+		tm:                  tm,
+		partitionRepository: partitionRepository,
+		logger:              logger,
+	}
+}
+
+// Run maintains partitions right away and then every hour until ctx is done.
+func (s *OutboxPartitions) Run(ctx context.Context) {
+	ticker := time.NewTicker(outboxMaintenanceTick)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Maintain(ctx, time.Now().UTC()); err != nil {
+			s.logger.ErrorCtx(ctx, "outbox partitions maintenance error", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Maintain creates the partitions of the next days and drops partitions older than
+// the retention. A partition that still holds unpublished messages is kept.
+func (s *OutboxPartitions) Maintain(ctx context.Context, now time.Time) error {
+	today := now.Truncate(24 * time.Hour)
+
+	var partitions []dto.OutboxPartition
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		for i := range outboxPartitionsAhead + 1 {
+			if _, err := s.partitionRepository.Create(ctx, tx, today.AddDate(0, 0, i)); err != nil {
+				return err
+			}
+		}
+		var err error
+		partitions, err = s.partitionRepository.List(ctx, tx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create outbox partitions: %w", err)
+	}
+
+	expiredBefore := today.AddDate(0, 0, -s.cfg.RetentionDays)
+	for _, partition := range partitions {
+		if partition.Day.AddDate(0, 0, 1).After(expiredBefore) {
+			continue
+		}
+		if err := s.expire(ctx, partition); err != nil {
+			return fmt.Errorf("failed to expire outbox partition %s: %w", partition.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// expire archives the messages of the partition, detaches it concurrently, so writes
+// to the outbox are not blocked, and drops it. A table left detached by an earlier run
+// was archived before, it is only dropped.
+func (s *OutboxPartitions) expire(ctx context.Context, partition dto.OutboxPartition) error {
+	if !partition.Detached {
+		keep := false
+		err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+			unpublished, err := s.partitionRepository.HasUnpublished(ctx, tx, partition)
+			if err != nil {
+				return err
+			}
+			if unpublished {
+				keep = true
+				return nil
+			}
+
+			if s.cfg.Archive {
+				archived, err := s.partitionRepository.Archive(ctx, tx, partition)
+				if err != nil {
+					return err
+				}
+				s.logger.InfoCtx(ctx, fmt.Sprintf("Archived %d messages of outbox partition %s", archived, partition.Name))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if keep {
+			s.logger.WarnCtx(ctx, fmt.Sprintf("Outbox partition %s kept, it has unpublished messages", partition.Name))
+			return nil
+		}
+
+		if err := s.partitionRepository.Detach(ctx, partition); err != nil {
+			return err
+		}
+	}
+
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return s.partitionRepository.Drop(ctx, tx, partition)
+	})
+	if err != nil {
+		return err
+	}
+	s.logger.InfoCtx(ctx, fmt.Sprintf("Outbox partition %s dropped", partition.Name))
+	return nil
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-invoice-service/common/pkg/logging"
+	"storage-service/internal/dto"
+	"testing"
+	"time"
+)
+
+type transactionsManager struct {
+	txs int
+}
+
+func (m *transactionsManager) Do(ctx context.Context, f func(ctx context.Context, tx *sql.Tx) error) error {
+	return m.DoOpts(ctx, nil, f)
+}
+
+func (m *transactionsManager) DoOpts(ctx context.Context, _ *sql.TxOptions, f func(ctx context.Context, tx *sql.Tx) error) error {
+	m.txs++
+	return f(ctx, nil)
+}
+
+// partitionRepository records the calls made for each partition as "<call> <name>".
+type partitionRepository struct {
+	partitions  []dto.OutboxPartition
+	unpublished map[string]bool
+	detachErr   error
+	created     []time.Time
+	calls       []string
+}
+
+func (r *partitionRepository) List(context.Context, *sql.Tx) ([]dto.OutboxPartition, error) {
+	return r.partitions, nil
+}
+
+func (r *partitionRepository) Create(_ context.Context, _ *sql.Tx, day time.Time) (dto.OutboxPartition, error) {
+	r.created = append(r.created, day)
+	return dto.OutboxPartition{Name: outboxPartitionName(day), Day: day}, nil
+}
+
+func (r *partitionRepository) HasUnpublished(_ context.Context, _ *sql.Tx, partition dto.OutboxPartition) (bool, error) {
+	r.calls = append(r.calls, "check "+partition.Name)
+	return r.unpublished[partition.Name], nil
+}
+
+func (r *partitionRepository) Archive(_ context.Context, _ *sql.Tx, partition dto.OutboxPartition) (int64, error) {
+	r.calls = append(r.calls, "archive "+partition.Name)
+	return 1, nil
+}
+
+func (r *partitionRepository) Detach(_ context.Context, partition dto.OutboxPartition) error {
+	if partition.DetachPending {
+		r.calls = append(r.calls, "finalize "+partition.Name)
+	} else {
+		r.calls = append(r.calls, "detach "+partition.Name)
+	}
+	return r.detachErr
+}
+
+func (r *partitionRepository) Drop(_ context.Context, _ *sql.Tx, partition dto.OutboxPartition) error {
+	r.calls = append(r.calls, "drop "+partition.Name)
+	return nil
+}
+
+func outboxPartitionName(day time.Time) string {
+	return "outbox_p" + day.Format("20060102")
+}
+
+func outboxPartition(day time.Time) dto.OutboxPartition {
+	return dto.OutboxPartition{Name: outboxPartitionName(day), Day: day}
+}
+
+func TestOutboxPartitions_Maintain_CreatesPartitions(t *testing.T) {
+	repo := &partitionRepository{}
+	s := NewOutboxPartitions(OutboxPartitionsConfig{RetentionDays: 7}, &transactionsManager{}, repo, logging.NewNopLogger())
+
+	now := time.Date(2025, 3, 10, 15, 30, 0, 0, time.UTC)
+	require.NoError(t, s.Maintain(context.Background(), now))
+
+	require.Len(t, repo.created, outboxPartitionsAhead+1)
+	for i, day := range repo.created {
+		assert.Equal(t, time.Date(2025, 3, 10+i, 0, 0, 0, 0, time.UTC), day)
+	}
+	assert.Empty(t, repo.calls)
+}
+
+func TestOutboxPartitions_Maintain_Expires(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 30, 0, 0, time.UTC)
+	// With a retention of 2 days the partition of March 7 is the last one expired.
+	expired := outboxPartition(time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC))
+	kept := outboxPartition(time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name        string
+		archive     bool
+		partition   dto.OutboxPartition
+		unpublished bool
+		wantCalls   []string
+	}{
+		{
+			name:      "within_retention",
+			partition: kept,
+			wantCalls: nil,
+		},
+		{
+			name:      "expired",
+			partition: expired,
+			wantCalls: []string{"check outbox_p20250307", "detach outbox_p20250307", "drop outbox_p20250307"},
+		},
+		{
+			name:      "expired_archived",
+			archive:   true,
+			partition: expired,
+			wantCalls: []string{
+				"check outbox_p20250307",
+				"archive outbox_p20250307",
+				"detach outbox_p20250307",
+				"drop outbox_p20250307",
+			},
+		},
+		{
+			name:        "expired_with_unpublished_messages",
+			archive:     true,
+			partition:   expired,
+			unpublished: true,
+			wantCalls:   []string{"check outbox_p20250307"},
+		},
+		{
+			name:      "interrupted_detach_finalized",
+			archive:   true,
+			partition: dto.OutboxPartition{Name: expired.Name, Day: expired.Day, DetachPending: true},
+			wantCalls: []string{
+				"check outbox_p20250307",
+				"archive outbox_p20250307",
+				"finalize outbox_p20250307",
+				"drop outbox_p20250307",
+			},
+		},
+		{
+			// Archived before it was detached by an earlier run.
+			name:      "detached_table_dropped",
+			archive:   true,
+			partition: dto.OutboxPartition{Name: expired.Name, Day: expired.Day, Detached: true},
+			wantCalls: []string{"drop outbox_p20250307"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &partitionRepository{
+				partitions:  []dto.OutboxPartition{tt.partition},
+				unpublished: map[string]bool{tt.partition.Name: tt.unpublished},
+			}
+			cfg := OutboxPartitionsConfig{RetentionDays: 2, Archive: tt.archive}
+			s := NewOutboxPartitions(cfg, &transactionsManager{}, repo, logging.NewNopLogger())
+
+			require.NoError(t, s.Maintain(context.Background(), now))
+			assert.Equal(t, tt.wantCalls, repo.calls)
+		})
+	}
+}
+
+func TestOutboxPartitions_Maintain_DetachFailed(t *testing.T) {
+	detachErr := errors.New("detach failed")
+	partition := outboxPartition(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	repo := &partitionRepository{partitions: []dto.OutboxPartition{partition}, detachErr: detachErr}
+	tm := &transactionsManager{}
+	s := NewOutboxPartitions(OutboxPartitionsConfig{RetentionDays: 2}, tm, repo, logging.NewNopLogger())
+
+	err := s.Maintain(context.Background(), time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC))
+	require.ErrorIs(t, err, detachErr)
+	assert.Equal(t, []string{"check outbox_p20250301", "detach outbox_p20250301"}, repo.calls)
+	// The detach runs outside of the transactions that create and check partitions.
+	assert.Equal(t, 2, tm.txs)
+}
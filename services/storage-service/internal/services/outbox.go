@@ -3,66 +3,390 @@ package services
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"go-invoice-service/common/pkg/logging"
+	"go-invoice-service/common/protocol/kafka"
+	"maps"
+	"slices"
 	"storage-service/internal/dto"
+	"strconv"
 	"time"
 )
 
+var (
+	ErrOutboxLeaseLost       = errors.New("outbox message is leased to another owner")
+	ErrOutboxMessageNotFound = errors.New("outbox message not found")
+)
+
 type OutboxRepository interface {
+	OutboxScheduleRepository
 	GetMessages(ctx context.Context, tx *sql.Tx, limit int32) ([]dto.OutboxMessage, error)
-	UpdateMessagesSendTime(ctx context.Context, tx *sql.Tx, ids []int64, deltaSec int64) error
-	Delete(ctx context.Context, tx *sql.Tx, id int64) error
+	CountDue(ctx context.Context, tx *sql.Tx) (int64, error)
+	Lease(ctx context.Context, tx *sql.Tx, ids []int64, owner string, expiresAt time.Time) error
+	LockLeaseOwners(ctx context.Context, tx *sql.Tx, ids []int64) (map[int64]string, error)
+	Delete(ctx context.Context, tx *sql.Tx, ids []int64) error
+	Publish(ctx context.Context, tx *sql.Tx, ids []int64) error
+	Release(ctx context.Context, tx *sql.Tx, owner string, ids []int64) (int64, error)
+	Fail(ctx context.Context, tx *sql.Tx, owner string, failure dto.OutboxFailure) (bool, error)
+	GetParked(ctx context.Context, tx *sql.Tx, filter dto.OutboxEntryFilter) ([]dto.OutboxEntry, error)
+	GetPublished(ctx context.Context, tx *sql.Tx, filter dto.OutboxPublishedFilter) ([]dto.OutboxEntry, error)
+	GetEntry(ctx context.Context, tx *sql.Tx, id int64) (*dto.OutboxEntry, error)
+	GetReplayable(ctx context.Context, tx *sql.Tx, filter dto.OutboxReplayFilter) ([]dto.OutboxMessage, error)
+	Requeue(ctx context.Context, tx *sql.Tx, id int64) (bool, error)
+	Purge(ctx context.Context, tx *sql.Tx, id int64) (bool, error)
+}
+
+type WebhookFanOutRepository interface {
+	FanOut(ctx context.Context, tx *sql.Tx, outboxIDs []int64) error
+}
+
+type OutboxListener interface {
+	Subscribe() (<-chan struct{}, func())
+}
+
+type OutboxConfig struct {
+	// KeepPublished marks sent messages published instead of deleting them.
+	KeepPublished bool
 }
 
 type Outbox struct {
-	tm               TransactionsManager
-	outboxRepository OutboxRepository
-	logger           *logging.ZapLogger
+	cfg               OutboxConfig
+	tm                TransactionsManager
+	outboxRepository  OutboxRepository
+	webhookRepository WebhookFanOutRepository
+	listener          OutboxListener
+	logger            *logging.ZapLogger
 }
 
-func NewOutbox(tm TransactionsManager, outboxRepository OutboxRepository, logger *logging.ZapLogger) *Outbox {
+func NewOutbox(
+	cfg OutboxConfig,
+	tm TransactionsManager,
+	outboxRepository OutboxRepository,
+	webhookRepository WebhookFanOutRepository,
+	listener OutboxListener,
+	logger *logging.ZapLogger,
+) *Outbox {
 	return &Outbox{
-		tm:               tm,
-		outboxRepository: outboxRepository,
-		logger:           logger,
+		cfg:               cfg,
+		tm:                tm,
+		outboxRepository:  outboxRepository,
+		webhookRepository: webhookRepository,
+		listener:          listener,
+		logger:            logger,
 	}
 }
 
-func (s *Outbox) Get(ctx context.Context, maxCount int32, retryAfter time.Duration) ([]dto.OutboxMessage, error) {
-	var res []dto.OutboxMessage
+// Get leases messages to the owner for leaseFor. Messages locked by a concurrent Get
+// are skipped, and a leased message is returned again only after its lease expires.
+func (s *Outbox) Get(
+	ctx context.Context,
+	owner string,
+	maxCount int32,
+	leaseFor time.Duration,
+) (dto.OutboxBatch, error) {
+	var res dto.OutboxBatch
 	if err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		messages, err := s.outboxRepository.GetMessages(ctx, tx, maxCount)
 		if err != nil {
 			return fmt.Errorf("failed to get outbox messages: %w", err)
 		}
 		s.logger.InfoCtx(ctx, fmt.Sprintf("Got %d outbox messages", len(messages)))
-		if len(messages) == 0 {
-			res = make([]dto.OutboxMessage, 0)
-			return nil
-		}
-		ids := make([]int64, len(messages))
-		for i, msg := range messages {
-			ids[i] = msg.ID
+		if len(messages) > 0 {
+			ids := make([]int64, len(messages))
+			for i, msg := range messages {
+				ids[i] = msg.ID
+			}
+			err = s.outboxRepository.Lease(ctx, tx, ids, owner, time.Now().UTC().Add(leaseFor))
+			if err != nil {
+				return fmt.Errorf("failed to lease outbox messages: %w", err)
+			}
 		}
-		err = s.outboxRepository.UpdateMessagesSendTime(ctx, tx, ids, int64(retryAfter.Seconds()))
+		// Counted after leasing, so the batch itself is not part of the backlog.
+		backlog, err := s.outboxRepository.CountDue(ctx, tx)
 		if err != nil {
-			return fmt.Errorf("failed to update outbox messages' retry time¬: %w", err)
+			return fmt.Errorf("failed to count outbox backlog: %w", err)
+		}
+		res = dto.OutboxBatch{
+			Messages: messages,
+			Backlog:  backlog,
 		}
-		res = messages
 		return nil
 	}); err != nil {
-		return nil, err
+		return dto.OutboxBatch{}, err
 	}
 
 	return res, nil
 }
 
-func (s *Outbox) Delete(ctx context.Context, id int64) error {
+func (s *Outbox) Delete(ctx context.Context, owner string, id int64) error {
+	rejected, err := s.DeleteBatch(ctx, owner, []int64{id})
+	if err != nil {
+		return err
+	}
+	if len(rejected) > 0 {
+		return ErrOutboxLeaseLost
+	}
+	return nil
+}
+
+// DeleteBatch removes messages sent to Kafka and returns IDs of those leased to
+// another owner, which are left in place: the new owner sends them again and
+// deletes them. Webhook deliveries of the deleted messages are created in the
+// same transaction, so they are neither lost nor duplicated.
+// IDs of messages that are already deleted are ignored. With KeepPublished the
+// messages are marked published instead.
+func (s *Outbox) DeleteBatch(ctx context.Context, owner string, ids []int64) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var rejected []int64
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		rejected = nil
+		owners, err := s.outboxRepository.LockLeaseOwners(ctx, tx, ids)
+		if err != nil {
+			return fmt.Errorf("failed to lock outbox messages: %w", err)
+		}
+		var owned []int64
+		for id, leaseOwner := range owners {
+			if leaseOwner == owner {
+				owned = append(owned, id)
+			} else {
+				rejected = append(rejected, id)
+			}
+		}
+		slices.Sort(owned)
+		slices.Sort(rejected)
+
+		if len(owned) > 0 {
+			if err := s.webhookRepository.FanOut(ctx, tx, owned); err != nil {
+				return fmt.Errorf("failed to create webhook deliveries: %w", err)
+			}
+			if err := s.removePublished(ctx, tx, owned); err != nil {
+				return err
+			}
+		}
+
+		action := "Deleted"
+		if s.cfg.KeepPublished {
+			action = "Published"
+		}
+		s.logger.InfoCtx(ctx, fmt.Sprintf(
+			"%s %d outbox messages, %d leased to another owner", action, len(owned), len(rejected),
+		))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rejected, nil
+}
+
+func (s *Outbox) removePublished(ctx context.Context, tx *sql.Tx, ids []int64) error {
+	if s.cfg.KeepPublished {
+		if err := s.outboxRepository.Publish(ctx, tx, ids); err != nil {
+			return fmt.Errorf("failed to mark outbox messages published: %w", err)
+		}
+		return nil
+	}
+	if err := s.outboxRepository.Delete(ctx, tx, ids); err != nil {
+		return fmt.Errorf("failed to delete outbox messages: %w", err)
+	}
+	return nil
+}
+
+// Release gives back the leases of messages the owner won't send, e.g. on shutdown,
+// so other owners send them without waiting for the leases to expire. Messages
+// leased to another owner are left as they are.
+func (s *Outbox) Release(ctx context.Context, owner string, ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	var released int64
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		released, err = s.outboxRepository.Release(ctx, tx, owner, ids)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to release outbox messages: %w", err)
+	}
+	s.logger.InfoCtx(ctx, fmt.Sprintf("Released %d outbox messages of %s", released, owner))
+	return released, nil
+}
+
+// Fail records a failed publish attempt, so the message is sent again at
+// failure.NextSendAt or parked. Until then the next messages of its aggregate
+// are held back.
+func (s *Outbox) Fail(ctx context.Context, owner string, failure dto.OutboxFailure) error {
 	return s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
-		if err := s.outboxRepository.Delete(ctx, tx, id); err != nil {
-			return fmt.Errorf("failed to delete outbox: %w", err)
+		found, err := s.outboxRepository.Fail(ctx, tx, owner, failure)
+		if err != nil {
+			return fmt.Errorf("failed to fail outbox message: %w", err)
+		}
+		if !found {
+			return ErrOutboxLeaseLost
+		}
+		if failure.Status == dto.OutboxStatusParked {
+			s.logger.WarnCtx(ctx, fmt.Sprintf("Outbox message %d parked: %s", failure.MessageID, failure.Error))
 		}
 		return nil
 	})
 }
+
+func (s *Outbox) ListParked(ctx context.Context, filter dto.OutboxEntryFilter) ([]dto.OutboxEntry, error) {
+	var res []dto.OutboxEntry
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		entries, err := s.outboxRepository.GetParked(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		res = entries
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list parked outbox messages: %w", err)
+	}
+	return res, nil
+}
+
+// ListPublished lists the published messages of an invoice, kept in the outbox
+// or archived, from the newest.
+func (s *Outbox) ListPublished(ctx context.Context, filter dto.OutboxPublishedFilter) ([]dto.OutboxEntry, error) {
+	var res []dto.OutboxEntry
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		entries, err := s.outboxRepository.GetPublished(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		res = entries
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list published outbox messages: %w", err)
+	}
+	return res, nil
+}
+
+func (s *Outbox) GetEntry(ctx context.Context, id int64) (*dto.OutboxEntry, error) {
+	var res *dto.OutboxEntry
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		entry, err := s.outboxRepository.GetEntry(ctx, tx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrOutboxMessageNotFound
+		}
+		if err != nil {
+			return err
+		}
+		res = entry
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Requeue moves a parked message back to pending with a fresh attempts budget.
+func (s *Outbox) Requeue(ctx context.Context, id int64) (*dto.OutboxEntry, error) {
+	var res *dto.OutboxEntry
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		found, err := s.outboxRepository.Requeue(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to requeue outbox message: %w", err)
+		}
+		if !found {
+			return ErrOutboxMessageNotFound
+		}
+		entry, err := s.outboxRepository.GetEntry(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		res = entry
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.logger.InfoCtx(ctx, fmt.Sprintf("Outbox message %d requeued", id))
+	return res, nil
+}
+
+// Purge deletes a parked message without publishing it.
+func (s *Outbox) Purge(ctx context.Context, id int64) error {
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		found, err := s.outboxRepository.Purge(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to purge outbox message: %w", err)
+		}
+		if !found {
+			return ErrOutboxMessageNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.logger.WarnCtx(ctx, fmt.Sprintf("Outbox message %d purged", id))
+	return nil
+}
+
+// Replay schedules copies of the published messages matching filter, marked with
+// the replay headers. Copies get the next sequence numbers of their invoices, so
+// they are sent after the messages already scheduled.
+func (s *Outbox) Replay(ctx context.Context, filter dto.OutboxReplayFilter) (dto.OutboxReplayBatch, error) {
+	var res dto.OutboxReplayBatch
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		res = dto.OutboxReplayBatch{}
+		messages, err := s.outboxRepository.GetReplayable(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		for _, msg := range messages {
+			stencil := msg.Stencil
+			stencil.Headers = maps.Clone(stencil.Headers)
+			if stencil.Headers == nil {
+				stencil.Headers = map[string]string{}
+			}
+			stencil.Headers[kafka.HeaderReplay] = "true"
+			stencil.Headers[kafka.HeaderReplayOf] = strconv.FormatInt(msg.ID, 10)
+			if err := s.outboxRepository.ScheduleMessage(ctx, tx, stencil, now); err != nil {
+				return fmt.Errorf("failed to schedule replay of outbox message %d: %w", msg.ID, err)
+			}
+			res.Count++
+			res.LastID = msg.ID
+		}
+		return nil
+	})
+	if err != nil {
+		return dto.OutboxReplayBatch{}, fmt.Errorf("failed to replay outbox messages: %w", err)
+	}
+	s.logger.InfoCtx(ctx, fmt.Sprintf("Replayed %d outbox messages", res.Count))
+	return res, nil
+}
+
+// Watch calls signal once right away, since messages may have been scheduled before
+// the watch started, and then on every outbox notification until ctx is done or
+// signal fails.
+func (s *Outbox) Watch(ctx context.Context, signal func() error) error {
+	notifications, unsubscribe := s.listener.Subscribe()
+	defer unsubscribe()
+
+	if err := signal(); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notifications:
+			if err := signal(); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"storage-service/internal/dto"
+	"time"
+)
+
+// Payments are not tracked, so every invoice that was not rejected is
+// considered outstanding and every approved invoice is counted as revenue.
+
+// AgingBuckets are ordered by days past due, "current" holds invoices that are not due yet.
+var AgingBuckets = []string{"current", "0-30", "31-60", "61-90", "90+"}
+
+var ErrInvalidReportPeriod = errors.New("invalid report period")
+
+type ReportingRepository interface {
+	GetAging(ctx context.Context, asOf time.Time, createdBefore time.Time) ([]dto.AgingBucket, error)
+	GetRevenue(ctx context.Context, from time.Time, to time.Time, timeZone string, byCustomer bool) ([]dto.RevenueRow, error)
+	GetDSOTotals(ctx context.Context, from time.Time, to time.Time) ([]dto.DSORow, error)
+}
+
+type Reporting struct {
+	reportingRep ReportingRepository
+}
+
+func NewReporting(reportingRep ReportingRepository) *Reporting {
+	return &Reporting{
+		reportingRep: reportingRep,
+	}
+}
+
+// GetAging groups invoices created up to the end of asOf by days past due.
+// Dates are calendar dates in loc, only their year, month and day are used.
+func (s *Reporting) GetAging(ctx context.Context, asOf time.Time, loc *time.Location) ([]dto.AgingBucket, error) {
+	day := calendarDate(asOf)
+
+	buckets, err := s.reportingRep.GetAging(ctx, day, startOfDay(day.AddDate(0, 0, 1), loc))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aging buckets: %w", err)
+	}
+
+	return fillAgingBuckets(buckets), nil
+}
+
+// GetRevenue sums approved invoices created in [from, to] per calendar month in loc.
+func (s *Reporting) GetRevenue(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+	loc *time.Location,
+	byCustomer bool,
+) ([]dto.RevenueRow, error) {
+	start, end, err := periodBounds(from, to, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.reportingRep.GetRevenue(ctx, start, end, loc.String(), byCustomer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revenue: %w", err)
+	}
+
+	return rows, nil
+}
+
+// GetDSO calculates days sales outstanding for [from, to] as receivables at the
+// end of the period divided by the period sales and multiplied by its length in days.
+func (s *Reporting) GetDSO(ctx context.Context, from time.Time, to time.Time, loc *time.Location) ([]dto.DSORow, error) {
+	start, end, err := periodBounds(from, to, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.reportingRep.GetDSOTotals(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dso totals: %w", err)
+	}
+
+	days := int32(calendarDate(to).Sub(calendarDate(from)).Hours()/24) + 1
+	for i := range rows {
+		rows[i].Days = days
+		if rows[i].Sales > 0 {
+			dso := float64(rows[i].Receivables) / float64(rows[i].Sales) * float64(days)
+			rows[i].DSO = &dso
+		}
+	}
+
+	return rows, nil
+}
+
+func periodBounds(from time.Time, to time.Time, loc *time.Location) (time.Time, time.Time, error) {
+	from, to = calendarDate(from), calendarDate(to)
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: 'to' is before 'from'", ErrInvalidReportPeriod)
+	}
+
+	return startOfDay(from, loc), startOfDay(to.AddDate(0, 0, 1), loc), nil
+}
+
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func startOfDay(date time.Time, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+}
+
+func fillAgingBuckets(buckets []dto.AgingBucket) []dto.AgingBucket {
+	var res []dto.AgingBucket
+
+	for i := 0; i < len(buckets); {
+		currency := buckets[i].Currency
+		filled := make(map[string]dto.AgingBucket, len(AgingBuckets))
+		for ; i < len(buckets) && buckets[i].Currency == currency; i++ {
+			filled[buckets[i].Bucket] = buckets[i]
+		}
+
+		for _, name := range AgingBuckets {
+			bucket, ok := filled[name]
+			if !ok {
+				bucket = dto.AgingBucket{Currency: currency, Bucket: name}
+			}
+			res = append(res, bucket)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"storage-service/internal/dto"
+	"testing"
+	"time"
+)
+
+// reportingRepository returns the configured rows and records the arguments of the last call.
+type reportingRepository struct {
+	aging []dto.AgingBucket
+	dso   []dto.DSORow
+
+	asOf          time.Time
+	createdBefore time.Time
+	from          time.Time
+	to            time.Time
+	timeZone      string
+}
+
+func (r *reportingRepository) GetAging(_ context.Context, asOf time.Time, createdBefore time.Time) ([]dto.AgingBucket, error) {
+	r.asOf, r.createdBefore = asOf, createdBefore
+	return r.aging, nil
+}
+
+func (r *reportingRepository) GetRevenue(
+	_ context.Context,
+	from time.Time,
+	to time.Time,
+	timeZone string,
+	_ bool,
+) ([]dto.RevenueRow, error) {
+	r.from, r.to, r.timeZone = from, to, timeZone
+	return nil, nil
+}
+
+func (r *reportingRepository) GetDSOTotals(_ context.Context, from time.Time, to time.Time) ([]dto.DSORow, error) {
+	r.from, r.to = from, to
+	return r.dso, nil
+}
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestFillAgingBuckets(t *testing.T) {
+	tests := []struct {
+		name    string
+		buckets []dto.AgingBucket
+		want    []dto.AgingBucket
+	}{
+		{
+			name: "no_invoices",
+		},
+		{
+			name: "missing_buckets_filled_in_order",
+			buckets: []dto.AgingBucket{
+				{Currency: "EUR", Bucket: "90+", Invoices: 1, Amount: 300},
+				{Currency: "EUR", Bucket: "current", Invoices: 2, Amount: 50},
+			},
+			want: []dto.AgingBucket{
+				{Currency: "EUR", Bucket: "current", Invoices: 2, Amount: 50},
+				{Currency: "EUR", Bucket: "0-30"},
+				{Currency: "EUR", Bucket: "31-60"},
+				{Currency: "EUR", Bucket: "61-90"},
+				{Currency: "EUR", Bucket: "90+", Invoices: 1, Amount: 300},
+			},
+		},
+		{
+			name: "per_currency",
+			buckets: []dto.AgingBucket{
+				{Currency: "EUR", Bucket: "0-30", Invoices: 1, Amount: 10},
+				{Currency: "USD", Bucket: "31-60", Invoices: 3, Amount: 20},
+			},
+			want: []dto.AgingBucket{
+				{Currency: "EUR", Bucket: "current"},
+				{Currency: "EUR", Bucket: "0-30", Invoices: 1, Amount: 10},
+				{Currency: "EUR", Bucket: "31-60"},
+				{Currency: "EUR", Bucket: "61-90"},
+				{Currency: "EUR", Bucket: "90+"},
+				{Currency: "USD", Bucket: "current"},
+				{Currency: "USD", Bucket: "0-30"},
+				{Currency: "USD", Bucket: "31-60", Invoices: 3, Amount: 20},
+				{Currency: "USD", Bucket: "61-90"},
+				{Currency: "USD", Bucket: "90+"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fillAgingBuckets(tt.buckets))
+		})
+	}
+}
+
+func TestPeriodBounds(t *testing.T) {
+	berlin := loadLocation(t, "Europe/Berlin")
+	newYork := loadLocation(t, "America/New_York")
+
+	tests := []struct {
+		name      string
+		from      time.Time
+		to        time.Time
+		loc       *time.Location
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   error
+	}{
+		{
+			name:      "single_day_utc",
+			from:      date(2025, 6, 30),
+			to:        date(2025, 6, 30),
+			loc:       time.UTC,
+			wantStart: date(2025, 6, 30),
+			wantEnd:   date(2025, 7, 1),
+		},
+		{
+			name:      "month_in_berlin",
+			from:      date(2025, 1, 1),
+			to:        date(2025, 1, 31),
+			loc:       berlin,
+			wantStart: time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 1, 31, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			// The period starts in winter and ends in summer time.
+			name:      "dst_change_in_berlin",
+			from:      date(2025, 3, 30),
+			to:        date(2025, 3, 30),
+			loc:       berlin,
+			wantStart: time.Date(2025, 3, 29, 23, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 3, 30, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			// Only the calendar date of from and to is used, not their time or zone.
+			name:      "time_of_day_ignored",
+			from:      time.Date(2025, 6, 1, 23, 30, 0, 0, berlin),
+			to:        time.Date(2025, 6, 1, 1, 0, 0, 0, newYork),
+			loc:       newYork,
+			wantStart: time.Date(2025, 6, 1, 4, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 6, 2, 4, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "to_before_from",
+			from:    date(2025, 6, 2),
+			to:      date(2025, 6, 1),
+			loc:     time.UTC,
+			wantErr: ErrInvalidReportPeriod,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := periodBounds(tt.from, tt.to, tt.loc)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.wantStart.Equal(start), "start %s, want %s", start, tt.wantStart)
+			assert.True(t, tt.wantEnd.Equal(end), "end %s, want %s", end, tt.wantEnd)
+		})
+	}
+}
+
+func TestReporting_GetAging(t *testing.T) {
+	berlin := loadLocation(t, "Europe/Berlin")
+	repo := &reportingRepository{}
+	s := NewReporting(repo)
+
+	// Invoices created up to the end of June 30 in Berlin are aged as of June 30.
+	buckets, err := s.GetAging(context.Background(), date(2025, 6, 30), berlin)
+	require.NoError(t, err)
+	assert.Empty(t, buckets)
+	assert.Equal(t, date(2025, 6, 30), repo.asOf)
+	assert.True(t, time.Date(2025, 6, 30, 22, 0, 0, 0, time.UTC).Equal(repo.createdBefore))
+}
+
+func TestReporting_GetRevenue(t *testing.T) {
+	berlin := loadLocation(t, "Europe/Berlin")
+	repo := &reportingRepository{}
+	s := NewReporting(repo)
+
+	_, err := s.GetRevenue(context.Background(), date(2025, 1, 1), date(2025, 6, 30), berlin, false)
+	require.NoError(t, err)
+	assert.True(t, time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC).Equal(repo.from))
+	assert.True(t, time.Date(2025, 6, 30, 22, 0, 0, 0, time.UTC).Equal(repo.to))
+	assert.Equal(t, "Europe/Berlin", repo.timeZone)
+
+	_, err = s.GetRevenue(context.Background(), date(2025, 6, 30), date(2025, 1, 1), berlin, false)
+	require.ErrorIs(t, err, ErrInvalidReportPeriod)
+}
+
+func TestReporting_GetDSO(t *testing.T) {
+	dso := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		to       time.Time
+		rows     []dto.DSORow
+		wantDays int32
+		wantDSO  []*float64
+	}{
+		{
+			name:     "month",
+			from:     date(2025, 1, 1),
+			to:       date(2025, 1, 31),
+			rows:     []dto.DSORow{{Currency: "EUR", Receivables: 1500, Sales: 3100}},
+			wantDays: 31,
+			wantDSO:  []*float64{dso(15)},
+		},
+		{
+			name:     "single_day",
+			from:     date(2025, 1, 1),
+			to:       date(2025, 1, 1),
+			rows:     []dto.DSORow{{Currency: "EUR", Receivables: 200, Sales: 100}},
+			wantDays: 1,
+			wantDSO:  []*float64{dso(2)},
+		},
+		{
+			// Days are counted by calendar date, also across a daylight saving change.
+			name:     "across_dst_change",
+			from:     time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+			to:       time.Date(2025, 3, 31, 0, 30, 0, 0, time.UTC),
+			rows:     []dto.DSORow{{Currency: "EUR", Receivables: 100, Sales: 310}},
+			wantDays: 31,
+			wantDSO:  []*float64{dso(10)},
+		},
+		{
+			name: "no_sales",
+			from: date(2025, 1, 1),
+			to:   date(2025, 1, 31),
+			rows: []dto.DSORow{
+				{Currency: "EUR", Receivables: 100, Sales: 0},
+				{Currency: "USD", Receivables: 100, Sales: 100},
+			},
+			wantDays: 31,
+			wantDSO:  []*float64{nil, dso(31)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewReporting(&reportingRepository{dso: tt.rows})
+
+			rows, err := s.GetDSO(context.Background(), tt.from, tt.to, loadLocation(t, "Europe/Berlin"))
+			require.NoError(t, err)
+			require.Len(t, rows, len(tt.wantDSO))
+			for i, row := range rows {
+				assert.Equal(t, tt.wantDays, row.Days)
+				if tt.wantDSO[i] == nil {
+					assert.Nil(t, row.DSO)
+				} else {
+					require.NotNil(t, row.DSO)
+					assert.InDelta(t, *tt.wantDSO[i], *row.DSO, 1e-9)
+				}
+			}
+		})
+	}
+}
@@ -3,34 +3,41 @@ package services
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
-	"go-invoice-service/common/protocol/kafka"
+	"go-invoice-service/common/pkg/inbox"
 	"storage-service/internal/dto"
-	"time"
 )
 
+var ErrInvoiceNotFound = errors.New("invoice not found")
+
 type InvoiceRepository interface {
 	GetInvoice(ctx context.Context, tx *sql.Tx, id uuid.UUID) (*dto.Invoice, dto.InvoiceStatus, error)
 	SetStatus(ctx context.Context, tx *sql.Tx, id uuid.UUID, status dto.InvoiceStatus) error
 }
 
 type Validation struct {
-	tm         TransactionsManager
-	invoiceRep InvoiceRepository
-	outboxRep  OutboxScheduleRepository
+	tm          TransactionsManager
+	invoiceRep  InvoiceRepository
+	eventWriter *EventWriter
+	eventRep    InvoiceEventRepository
+	inboxStore  inbox.Store
 }
 
 func NewValidation(
 	tm TransactionsManager,
 	invoiceRep InvoiceRepository,
-	outboxRep OutboxScheduleRepository,
+	eventWriter *EventWriter,
+	eventRep InvoiceEventRepository,
+	inboxStore inbox.Store,
 ) *Validation {
 	return &Validation{
-		tm:         tm,
-		invoiceRep: invoiceRep,
-		outboxRep:  outboxRep,
+		tm:          tm,
+		invoiceRep:  invoiceRep,
+		eventWriter: eventWriter,
+		eventRep:    eventRep,
+		inboxStore:  inboxStore,
 	}
 }
 
@@ -45,6 +52,9 @@ func (s *Validation) Get(ctx context.Context, id uuid.UUID) (*dto.Invoice, dto.I
 		},
 		func(ctx context.Context, tx *sql.Tx) error {
 			invoice, status, err := s.invoiceRep.GetInvoice(ctx, tx, id)
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInvoiceNotFound
+			}
 			if err != nil {
 				return err
 			}
@@ -61,58 +71,71 @@ func (s *Validation) Get(ctx context.Context, id uuid.UUID) (*dto.Invoice, dto.I
 	return resInvoice, resStatus, nil
 }
 
-func (s *Validation) SetApproved(ctx context.Context, id uuid.UUID) error {
+// SetApproved approves the invoice. If inboxKey is set, the event is recorded handled in
+// the same transaction, and inbox.ErrAlreadyHandled is returned if it was handled already.
+func (s *Validation) SetApproved(ctx context.Context, id uuid.UUID, inboxKey *inbox.Key) error {
 	return s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
-		err := s.invoiceRep.SetStatus(ctx, tx, id, dto.StatusApproved)
+		err := s.recordHandled(ctx, tx, inboxKey)
 		if err != nil {
-			return fmt.Errorf("failed to set approved status: %w", err)
+			return err
 		}
 
-		payload := kafka.ApprovedInvoice{
-			ID: id,
-		}
-		payloadJSON, err := json.Marshal(payload)
+		err = s.invoiceRep.SetStatus(ctx, tx, id, dto.StatusApproved)
 		if err != nil {
-			return fmt.Errorf("marshalling new invoice kafka message failed: %w", err)
+			return fmt.Errorf("failed to set approved status: %w", err)
 		}
-		msg := dto.OutboxMessageStencil{
-			Topic:   kafka.TopicInvoiceApproved,
-			Payload: payloadJSON,
+
+		err = s.eventRep.Add(ctx, tx, id, dto.InvoiceEventStatusChanged, dto.StatusApproved)
+		if err != nil {
+			return fmt.Errorf("failed to add invoice event: %w", err)
 		}
 
-		err = s.outboxRep.ScheduleMessage(ctx, tx, msg, time.Now().UTC())
+		err = s.eventWriter.InvoiceApproved(ctx, tx, id)
 		if err != nil {
-			return fmt.Errorf("failed to write message to outbox: %w", err)
+			return err
 		}
 
 		return nil
 	})
 }
 
-func (s *Validation) SetRejected(ctx context.Context, id uuid.UUID) error {
+// SetRejected rejects the invoice, recording the event like SetApproved.
+func (s *Validation) SetRejected(ctx context.Context, id uuid.UUID, inboxKey *inbox.Key) error {
 	return s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
-		err := s.invoiceRep.SetStatus(ctx, tx, id, dto.StatusRejected)
+		err := s.recordHandled(ctx, tx, inboxKey)
 		if err != nil {
-			return fmt.Errorf("failed to set rejected status: %w", err)
+			return err
 		}
 
-		payload := kafka.RejectedInvoice{
-			ID: id,
-		}
-		payloadJSON, err := json.Marshal(payload)
+		err = s.invoiceRep.SetStatus(ctx, tx, id, dto.StatusRejected)
 		if err != nil {
-			return fmt.Errorf("marshalling new invoice kafka message failed: %w", err)
+			return fmt.Errorf("failed to set rejected status: %w", err)
 		}
-		msg := dto.OutboxMessageStencil{
-			Topic:   kafka.TopicInvoiceRejected,
-			Payload: payloadJSON,
+
+		err = s.eventRep.Add(ctx, tx, id, dto.InvoiceEventStatusChanged, dto.StatusRejected)
+		if err != nil {
+			return fmt.Errorf("failed to add invoice event: %w", err)
 		}
 
-		err = s.outboxRep.ScheduleMessage(ctx, tx, msg, time.Now().UTC())
+		err = s.eventWriter.InvoiceRejected(ctx, tx, id)
 		if err != nil {
-			return fmt.Errorf("failed to write message to outbox: %w", err)
+			return err
 		}
 
 		return nil
 	})
 }
+
+func (s *Validation) recordHandled(ctx context.Context, tx *sql.Tx, inboxKey *inbox.Key) error {
+	if inboxKey == nil {
+		return nil
+	}
+	recorded, err := s.inboxStore.Record(ctx, tx, *inboxKey)
+	if err != nil {
+		return err
+	}
+	if !recorded {
+		return inbox.ErrAlreadyHandled
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"storage-service/internal/dto"
+	"time"
+)
+
+const webhookSecretBytes = 32
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrWebhookLeaseLost = errors.New("webhook delivery is leased to another owner")
+)
+
+type WebhookRepository interface {
+	CreateEndpoint(ctx context.Context, tx *sql.Tx, endpoint *dto.WebhookEndpoint) error
+	GetEndpoints(ctx context.Context, tx *sql.Tx) ([]dto.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, tx *sql.Tx, id uuid.UUID) (bool, error)
+	GetDue(ctx context.Context, tx *sql.Tx, limit int32) ([]dto.DueWebhookDelivery, error)
+	Lease(ctx context.Context, tx *sql.Tx, owner string, ids []int64, until time.Time) error
+	Complete(ctx context.Context, tx *sql.Tx, owner string, result dto.WebhookAttemptResult) (bool, error)
+	GetDeliveries(ctx context.Context, tx *sql.Tx, filter dto.WebhookDeliveryFilter) ([]dto.WebhookDelivery, error)
+	GetDelivery(ctx context.Context, tx *sql.Tx, id int64) (*dto.WebhookDelivery, error)
+	GetAttempts(ctx context.Context, tx *sql.Tx, deliveryID int64) ([]dto.WebhookAttempt, error)
+	Redeliver(ctx context.Context, tx *sql.Tx, id int64) (bool, error)
+}
+
+type Webhook struct {
+	tm         TransactionsManager
+	webhookRep WebhookRepository
+	now        func() time.Time
+}
+
+func NewWebhook(tm TransactionsManager, webhookRep WebhookRepository) *Webhook {
+	return &Webhook{
+		tm:         tm,
+		webhookRep: webhookRep,
+		now:        time.Now,
+	}
+}
+
+// CreateEndpoint stores the endpoint, a random secret is generated if none is given.
+func (s *Webhook) CreateEndpoint(ctx context.Context, endpoint dto.WebhookEndpoint) (*dto.WebhookEndpoint, error) {
+	if endpoint.Secret == "" {
+		secret := make([]byte, webhookSecretBytes)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+		}
+		endpoint.Secret = hex.EncodeToString(secret)
+	}
+	endpoint.CreatedAt = time.Now().UTC()
+
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return s.webhookRep.CreateEndpoint(ctx, tx, &endpoint)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+
+	return &endpoint, nil
+}
+
+func (s *Webhook) ListEndpoints(ctx context.Context) ([]dto.WebhookEndpoint, error) {
+	var res []dto.WebhookEndpoint
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		endpoints, err := s.webhookRep.GetEndpoints(ctx, tx)
+		if err != nil {
+			return err
+		}
+		res = endpoints
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+	return res, nil
+}
+
+func (s *Webhook) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	return s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		found, err := s.webhookRep.DeleteEndpoint(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete webhook endpoint: %w", err)
+		}
+		if !found {
+			return ErrWebhookNotFound
+		}
+		return nil
+	})
+}
+
+// GetDueDeliveries returns pending deliveries whose next attempt is due and leases them
+// to owner for leaseFor, so they are not handed out twice while being sent. Deliveries
+// locked by a concurrent call are skipped.
+func (s *Webhook) GetDueDeliveries(
+	ctx context.Context,
+	owner string,
+	maxCount int32,
+	leaseFor time.Duration,
+) ([]dto.DueWebhookDelivery, error) {
+	var res []dto.DueWebhookDelivery
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		deliveries, err := s.webhookRep.GetDue(ctx, tx, maxCount)
+		if err != nil {
+			return fmt.Errorf("failed to get due webhook deliveries: %w", err)
+		}
+		if len(deliveries) == 0 {
+			res = make([]dto.DueWebhookDelivery, 0)
+			return nil
+		}
+		ids := make([]int64, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+		}
+		if err := s.webhookRep.Lease(ctx, tx, owner, ids, s.now().Add(leaseFor)); err != nil {
+			return fmt.Errorf("failed to lease webhook deliveries: %w", err)
+		}
+		res = deliveries
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CompleteAttempt records an attempt of a delivery leased to owner. Once the lease is
+// taken over by another owner, the attempt is not recorded and ErrWebhookLeaseLost is returned.
+func (s *Webhook) CompleteAttempt(ctx context.Context, owner string, result dto.WebhookAttemptResult) error {
+	return s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		found, err := s.webhookRep.Complete(ctx, tx, owner, result)
+		if err != nil {
+			return fmt.Errorf("failed to complete webhook attempt: %w", err)
+		}
+		if !found {
+			return ErrWebhookLeaseLost
+		}
+		return nil
+	})
+}
+
+func (s *Webhook) ListDeliveries(ctx context.Context, filter dto.WebhookDeliveryFilter) ([]dto.WebhookDelivery, error) {
+	var res []dto.WebhookDelivery
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		deliveries, err := s.webhookRep.GetDeliveries(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		res = deliveries
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return res, nil
+}
+
+func (s *Webhook) GetDelivery(ctx context.Context, id int64) (*dto.WebhookDelivery, []dto.WebhookAttempt, error) {
+	var resDelivery *dto.WebhookDelivery
+	var resAttempts []dto.WebhookAttempt
+
+	err := s.tm.DoOpts(ctx,
+		&sql.TxOptions{
+			Isolation: sql.LevelRepeatableRead,
+			ReadOnly:  true,
+		},
+		func(ctx context.Context, tx *sql.Tx) error {
+			delivery, err := s.webhookRep.GetDelivery(ctx, tx, id)
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrWebhookNotFound
+			}
+			if err != nil {
+				return err
+			}
+			attempts, err := s.webhookRep.GetAttempts(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			resDelivery = delivery
+			resAttempts = attempts
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resDelivery, resAttempts, nil
+}
+
+// Redeliver moves the delivery back to pending with a fresh attempts budget.
+// Attempts made so far are kept in its log.
+func (s *Webhook) Redeliver(ctx context.Context, id int64) (*dto.WebhookDelivery, error) {
+	var res *dto.WebhookDelivery
+	err := s.tm.Do(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		found, err := s.webhookRep.Redeliver(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to redeliver webhook: %w", err)
+		}
+		if !found {
+			return ErrWebhookNotFound
+		}
+		delivery, err := s.webhookRep.GetDelivery(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		res = delivery
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
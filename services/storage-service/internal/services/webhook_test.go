@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"storage-service/internal/dto"
+	"testing"
+	"time"
+)
+
+// webhookDelivery is a row of webhook_deliveries kept by webhookRepository.
+type webhookDelivery struct {
+	dto.WebhookDelivery
+	leaseOwner     string
+	leaseExpiresAt time.Time
+	attempts       []dto.WebhookAttempt
+}
+
+// webhookRepository keeps deliveries in memory and follows the semantics of the
+// delivery queries, with now as the database clock.
+type webhookRepository struct {
+	WebhookRepository
+	deliveries []*webhookDelivery
+	now        func() time.Time
+}
+
+func (r *webhookRepository) GetDue(_ context.Context, _ *sql.Tx, limit int32) ([]dto.DueWebhookDelivery, error) {
+	now := r.now()
+	var res []dto.DueWebhookDelivery
+	for _, d := range r.deliveries {
+		if len(res) == int(limit) {
+			break
+		}
+		if d.Status != dto.WebhookStatusPending || d.NextAttemptAt.After(now) || d.leaseExpiresAt.After(now) {
+			continue
+		}
+		res = append(res, dto.DueWebhookDelivery{ID: d.ID, EventType: d.EventType, Attempts: d.Attempts})
+	}
+	return res, nil
+}
+
+func (r *webhookRepository) Lease(_ context.Context, _ *sql.Tx, owner string, ids []int64, until time.Time) error {
+	for _, id := range ids {
+		d := r.delivery(id)
+		d.leaseOwner, d.leaseExpiresAt = owner, until
+	}
+	return nil
+}
+
+func (r *webhookRepository) Complete(
+	_ context.Context,
+	_ *sql.Tx,
+	owner string,
+	result dto.WebhookAttemptResult,
+) (bool, error) {
+	d := r.delivery(result.DeliveryID)
+	if d.leaseOwner != owner {
+		return false, nil
+	}
+
+	now := r.now()
+	d.attempts = append(d.attempts, dto.WebhookAttempt{
+		Attempt:     d.Attempts + 1,
+		StatusCode:  result.StatusCode,
+		Error:       result.Error,
+		AttemptedAt: now,
+	})
+	d.Status = result.Status
+	d.Attempts++
+	d.NextAttemptAt = now
+	if result.Status == dto.WebhookStatusPending {
+		d.NextAttemptAt = result.NextAttemptAt
+	}
+	d.LastError = result.Error
+	d.leaseOwner, d.leaseExpiresAt = "", time.Time{}
+	return true, nil
+}
+
+func (r *webhookRepository) delivery(id int64) *webhookDelivery {
+	for _, d := range r.deliveries {
+		if d.ID == id {
+			return d
+		}
+	}
+	panic("unknown delivery")
+}
+
+// testWebhook returns the service on a repository with a pending delivery 1 and a clock
+// moved by the returned function.
+func testWebhook() (*Webhook, *webhookRepository, func(time.Duration)) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	repo := &webhookRepository{
+		deliveries: []*webhookDelivery{{WebhookDelivery: dto.WebhookDelivery{
+			ID:            1,
+			EventType:     "invoice_approved",
+			Status:        dto.WebhookStatusPending,
+			NextAttemptAt: now,
+		}}},
+		now: clock,
+	}
+	service := NewWebhook(&transactionsManager{}, repo)
+	service.now = clock
+	return service, repo, func(d time.Duration) { now = now.Add(d) }
+}
+
+func dueIDs(t *testing.T, service *Webhook, owner string) []int64 {
+	t.Helper()
+	deliveries, err := service.GetDueDeliveries(context.Background(), owner, 10, time.Minute)
+	require.NoError(t, err)
+	ids := make([]int64, len(deliveries))
+	for i, d := range deliveries {
+		ids[i] = d.ID
+	}
+	return ids
+}
+
+func TestWebhook_GetDueDeliveries_Lease(t *testing.T) {
+	service, repo, advance := testWebhook()
+
+	assert.Equal(t, []int64{1}, dueIDs(t, service, "scheduler-1"))
+	assert.Equal(t, "scheduler-1", repo.deliveries[0].leaseOwner)
+
+	advance(time.Minute - time.Second)
+	assert.Empty(t, dueIDs(t, service, "scheduler-2"))
+
+	// The lease expired without the attempt being completed, so the delivery goes to another owner.
+	advance(time.Second)
+	assert.Equal(t, []int64{1}, dueIDs(t, service, "scheduler-2"))
+
+	err := service.CompleteAttempt(context.Background(), "scheduler-1", dto.WebhookAttemptResult{
+		DeliveryID: 1,
+		Status:     dto.WebhookStatusSucceeded,
+		StatusCode: 200,
+	})
+	require.ErrorIs(t, err, ErrWebhookLeaseLost)
+	assert.Equal(t, dto.WebhookStatusPending, repo.deliveries[0].Status)
+	assert.Empty(t, repo.deliveries[0].attempts)
+
+	err = service.CompleteAttempt(context.Background(), "scheduler-2", dto.WebhookAttemptResult{
+		DeliveryID: 1,
+		Status:     dto.WebhookStatusSucceeded,
+		StatusCode: 200,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, dto.WebhookStatusSucceeded, repo.deliveries[0].Status)
+	assert.Len(t, repo.deliveries[0].attempts, 1)
+}
+
+func TestWebhook_CompleteAttempt(t *testing.T) {
+	backoff := 5 * time.Minute
+
+	tests := []struct {
+		name   string
+		result dto.WebhookAttemptResult
+		// dueAfter is the time after which the delivery is due again, zero if it is not.
+		dueAfter   time.Duration
+		wantStatus dto.WebhookDeliveryStatus
+	}{
+		{
+			name: "succeeded",
+			result: dto.WebhookAttemptResult{
+				Status:     dto.WebhookStatusSucceeded,
+				StatusCode: 204,
+			},
+			wantStatus: dto.WebhookStatusSucceeded,
+		},
+		{
+			name: "retried_after_backoff",
+			result: dto.WebhookAttemptResult{
+				Status:     dto.WebhookStatusPending,
+				StatusCode: 503,
+				Error:      "unexpected status code 503",
+			},
+			dueAfter:   backoff,
+			wantStatus: dto.WebhookStatusPending,
+		},
+		{
+			name: "dead",
+			result: dto.WebhookAttemptResult{
+				Status:     dto.WebhookStatusDead,
+				StatusCode: 500,
+				Error:      "unexpected status code 500",
+			},
+			wantStatus: dto.WebhookStatusDead,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo, advance := testWebhook()
+			require.Equal(t, []int64{1}, dueIDs(t, service, "scheduler-1"))
+
+			result := tt.result
+			result.DeliveryID = 1
+			result.NextAttemptAt = service.now().Add(backoff)
+			require.NoError(t, service.CompleteAttempt(context.Background(), "scheduler-1", result))
+
+			d := repo.deliveries[0]
+			assert.Equal(t, tt.wantStatus, d.Status)
+			assert.Equal(t, int32(1), d.Attempts)
+			assert.Equal(t, tt.result.Error, d.LastError)
+			assert.Empty(t, d.leaseOwner)
+			require.Len(t, d.attempts, 1)
+			assert.Equal(t, tt.result.StatusCode, d.attempts[0].StatusCode)
+
+			if tt.dueAfter == 0 {
+				advance(time.Hour)
+				assert.Empty(t, dueIDs(t, service, "scheduler-1"))
+				return
+			}
+			advance(tt.dueAfter - time.Second)
+			assert.Empty(t, dueIDs(t, service, "scheduler-1"))
+			advance(time.Second)
+			assert.Equal(t, []int64{1}, dueIDs(t, service, "scheduler-1"))
+		})
+	}
+}
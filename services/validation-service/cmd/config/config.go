@@ -4,8 +4,10 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"go-invoice-service/common/pkg/breaker"
 	"go-invoice-service/common/pkg/flagtypes"
 	"go-invoice-service/common/pkg/meterutils"
+	kafkaProtocol "go-invoice-service/common/protocol/kafka"
 	"os"
 	"strconv"
 	"time"
@@ -20,6 +22,26 @@ const (
 	storageAddressEnv        = "STORAGE_ADDRESS"
 	kafkaPollTimeoutMsFlag   = "kafka-poll-timeout-ms"
 	kafkaPollTimeoutMsEnv    = "KAFKA_POLL_TIMEOUT_MS"
+	workersCountFlag         = "workers-count"
+	workersCountEnv          = "WORKERS_COUNT"
+	workerQueueSizeFlag      = "worker-queue-size"
+	workerQueueSizeEnv       = "WORKER_QUEUE_SIZE"
+	commitIntervalMsFlag     = "commit-interval-ms"
+	commitIntervalMsEnv      = "COMMIT_INTERVAL_MS"
+	rebalanceTimeoutMsFlag   = "rebalance-timeout-ms"
+	rebalanceTimeoutMsEnv    = "REBALANCE_TIMEOUT_MS"
+	drainTimeoutMsFlag       = "drain-timeout-ms"
+	drainTimeoutMsEnv        = "DRAIN_TIMEOUT_MS"
+	maxAttemptsFlag          = "message-max-attempts"
+	maxAttemptsEnv           = "MESSAGE_MAX_ATTEMPTS"
+	retryBackoffMsFlag       = "message-retry-backoff-ms"
+	retryBackoffMsEnv        = "MESSAGE_RETRY_BACKOFF_MS"
+	breakerFailuresFlag      = "circuit-breaker-failure-threshold"
+	breakerFailuresEnv       = "CIRCUIT_BREAKER_FAILURE_THRESHOLD"
+	breakerOpenTimeoutMsFlag = "circuit-breaker-open-timeout-ms"
+	breakerOpenTimeoutMsEnv  = "CIRCUIT_BREAKER_OPEN_TIMEOUT_MS"
+	breakerSuccessesFlag     = "circuit-breaker-success-threshold"
+	breakerSuccessesEnv      = "CIRCUIT_BREAKER_SUCCESS_THRESHOLD"
 	prometheusPortFlag       = "prometheus-port"
 	prometheusPortEnv        = "PROMETHEUS_PORT"
 	otelCollectorAddressFlag = "otel-collector-address"
@@ -30,6 +52,16 @@ const (
 	defaultKafkaAddress         = "localhost:9092"
 	defaultStorageAddress       = "localhost:5000"
 	defaultKafkaPollTimeoutMs   = 100
+	defaultWorkersCount         = 6
+	defaultWorkerQueueSize      = 10
+	defaultCommitIntervalMs     = 1000
+	defaultRebalanceTimeoutMs   = 30000
+	defaultDrainTimeoutMs       = 10000
+	defaultMaxAttempts          = 3
+	defaultRetryBackoffMs       = 1000
+	defaultBreakerFailures      = 5
+	defaultBreakerOpenTimeoutMs = 5000
+	defaultBreakerSuccesses     = 1
 	defaultShutdownTimeout      = 5 * time.Second
 	defaultPrometheusPort       = 9090
 	defaultOtelCollectorAddress = "localhost:4318"
@@ -44,10 +76,12 @@ var defaultRetryAttempts = []time.Duration{
 
 type Config struct {
 	KafkaConsumerConfig   kafka.ConsumerConfig
+	KafkaProducerConfig   kafka.ProducerConfig
 	KafkaDispatcherConfig services.MessagesDispatcherConfig
 	PrometheusConfig      meterutils.PrometheusConfig
 	OpenTelemetryConfig   meterutils.OpenTelemetryConfig
 	StorageAddress        string
+	BreakerConfig         breaker.Config
 }
 
 func Load() (*Config, error) {
@@ -55,6 +89,16 @@ func Load() (*Config, error) {
 	kafkaAddress := defaultKafkaAddress
 	storageAddress := defaultStorageAddress
 	kafkaPollTimeoutMs := defaultKafkaPollTimeoutMs
+	workersCount := defaultWorkersCount
+	workerQueueSize := defaultWorkerQueueSize
+	commitIntervalMs := defaultCommitIntervalMs
+	rebalanceTimeoutMs := defaultRebalanceTimeoutMs
+	drainTimeoutMs := defaultDrainTimeoutMs
+	maxAttempts := defaultMaxAttempts
+	retryBackoffMs := defaultRetryBackoffMs
+	breakerFailures := defaultBreakerFailures
+	breakerOpenTimeoutMs := defaultBreakerOpenTimeoutMs
+	breakerSuccesses := defaultBreakerSuccesses
 	prometheusPort := defaultPrometheusPort
 	otelCollectorAddress := defaultOtelCollectorAddress
 
@@ -69,6 +113,36 @@ func Load() (*Config, error) {
 	kafkaPollTimeoutMsFlagVal := flagtypes.NewInt()
 	flag.Var(kafkaPollTimeoutMsFlagVal, kafkaPollTimeoutMsFlag, "Kafka poll timeout (ms)")
 
+	workersCountFlagVal := flagtypes.NewInt()
+	flag.Var(workersCountFlagVal, workersCountFlag, "Count of workers processing partitions in parallel")
+
+	workerQueueSizeFlagVal := flagtypes.NewInt()
+	flag.Var(workerQueueSizeFlagVal, workerQueueSizeFlag, "Count of polled messages waiting for a worker")
+
+	commitIntervalMsFlagVal := flagtypes.NewInt()
+	flag.Var(commitIntervalMsFlagVal, commitIntervalMsFlag, "Kafka offsets commit interval (ms)")
+
+	rebalanceTimeoutMsFlagVal := flagtypes.NewInt()
+	flag.Var(rebalanceTimeoutMsFlagVal, rebalanceTimeoutMsFlag, "Wait for in-flight messages of revoked partitions (ms)")
+
+	drainTimeoutMsFlagVal := flagtypes.NewInt()
+	flag.Var(drainTimeoutMsFlagVal, drainTimeoutMsFlag, "Wait for polled messages to be handled on shutdown (ms)")
+
+	maxAttemptsFlagVal := flagtypes.NewInt()
+	flag.Var(maxAttemptsFlagVal, maxAttemptsFlag, "Message handling attempts before moving it to dead-letter topic")
+
+	retryBackoffMsFlagVal := flagtypes.NewInt()
+	flag.Var(retryBackoffMsFlagVal, retryBackoffMsFlag, "Message handling retry initial backoff (ms)")
+
+	breakerFailuresFlagVal := flagtypes.NewInt()
+	flag.Var(breakerFailuresFlagVal, breakerFailuresFlag, "Consecutive storage or Kafka failures opening a circuit breaker")
+
+	breakerOpenTimeoutMsFlagVal := flagtypes.NewInt()
+	flag.Var(breakerOpenTimeoutMsFlagVal, breakerOpenTimeoutMsFlag, "Duration of an open circuit breaker rejecting calls (ms)")
+
+	breakerSuccessesFlagVal := flagtypes.NewInt()
+	flag.Var(breakerSuccessesFlagVal, breakerSuccessesFlag, "Successful trial calls closing a half-open circuit breaker")
+
 	prometheusPortFlagVal := flagtypes.NewInt()
 	flag.Var(prometheusPortFlagVal, prometheusPortFlag, "Prometheus port")
 
@@ -91,6 +165,46 @@ func Load() (*Config, error) {
 		kafkaPollTimeoutMs = val
 	}
 
+	if val, ok := workersCountFlagVal.Value(); ok {
+		workersCount = val
+	}
+
+	if val, ok := workerQueueSizeFlagVal.Value(); ok {
+		workerQueueSize = val
+	}
+
+	if val, ok := commitIntervalMsFlagVal.Value(); ok {
+		commitIntervalMs = val
+	}
+
+	if val, ok := rebalanceTimeoutMsFlagVal.Value(); ok {
+		rebalanceTimeoutMs = val
+	}
+
+	if val, ok := drainTimeoutMsFlagVal.Value(); ok {
+		drainTimeoutMs = val
+	}
+
+	if val, ok := maxAttemptsFlagVal.Value(); ok {
+		maxAttempts = val
+	}
+
+	if val, ok := retryBackoffMsFlagVal.Value(); ok {
+		retryBackoffMs = val
+	}
+
+	if val, ok := breakerFailuresFlagVal.Value(); ok {
+		breakerFailures = val
+	}
+
+	if val, ok := breakerOpenTimeoutMsFlagVal.Value(); ok {
+		breakerOpenTimeoutMs = val
+	}
+
+	if val, ok := breakerSuccessesFlagVal.Value(); ok {
+		breakerSuccesses = val
+	}
+
 	if val, ok := prometheusPortFlagVal.Value(); ok {
 		prometheusPort = val
 	}
@@ -117,6 +231,86 @@ func Load() (*Config, error) {
 		kafkaPollTimeoutMs = val
 	}
 
+	if valStr, ok := os.LookupEnv(workersCountEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, workersCountEnv)
+		}
+		workersCount = val
+	}
+
+	if valStr, ok := os.LookupEnv(workerQueueSizeEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, workerQueueSizeEnv)
+		}
+		workerQueueSize = val
+	}
+
+	if valStr, ok := os.LookupEnv(commitIntervalMsEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, commitIntervalMsEnv)
+		}
+		commitIntervalMs = val
+	}
+
+	if valStr, ok := os.LookupEnv(rebalanceTimeoutMsEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, rebalanceTimeoutMsEnv)
+		}
+		rebalanceTimeoutMs = val
+	}
+
+	if valStr, ok := os.LookupEnv(drainTimeoutMsEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, drainTimeoutMsEnv)
+		}
+		drainTimeoutMs = val
+	}
+
+	if valStr, ok := os.LookupEnv(maxAttemptsEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, maxAttemptsEnv)
+		}
+		maxAttempts = val
+	}
+
+	if valStr, ok := os.LookupEnv(retryBackoffMsEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, retryBackoffMsEnv)
+		}
+		retryBackoffMs = val
+	}
+
+	if valStr, ok := os.LookupEnv(breakerFailuresEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, breakerFailuresEnv)
+		}
+		breakerFailures = val
+	}
+
+	if valStr, ok := os.LookupEnv(breakerOpenTimeoutMsEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, breakerOpenTimeoutMsEnv)
+		}
+		breakerOpenTimeoutMs = val
+	}
+
+	if valStr, ok := os.LookupEnv(breakerSuccessesEnv); ok {
+		val, err := strconv.Atoi(valStr)
+		if err != nil {
+			return &Config{}, fmt.Errorf("%w: '%s' env variable parsing failed", err, breakerSuccessesEnv)
+		}
+		breakerSuccesses = val
+	}
+
 	if valStr, ok := os.LookupEnv(prometheusPortEnv); ok {
 		val, err := strconv.Atoi(valStr)
 		if err != nil {
@@ -135,6 +329,42 @@ func Load() (*Config, error) {
 		return &Config{}, errors.New("kafka poll timeout must be greater than one")
 	}
 
+	if workersCount < 1 {
+		return &Config{}, errors.New("workers count must be at least one")
+	}
+
+	if workerQueueSize < 0 {
+		return &Config{}, errors.New("worker queue size must not be negative")
+	}
+
+	if commitIntervalMs < 1 {
+		return &Config{}, errors.New("commit interval must be greater than zero")
+	}
+
+	if rebalanceTimeoutMs < 1 {
+		return &Config{}, errors.New("rebalance timeout must be greater than zero")
+	}
+
+	if drainTimeoutMs < 1 {
+		return &Config{}, errors.New("drain timeout must be greater than zero")
+	}
+
+	if maxAttempts < 1 {
+		return &Config{}, errors.New("message max attempts must be at least one")
+	}
+
+	if retryBackoffMs < 0 {
+		return &Config{}, errors.New("message retry backoff must not be negative")
+	}
+
+	if breakerFailures < 1 || breakerSuccesses < 1 {
+		return &Config{}, errors.New("circuit breaker failure and success thresholds must be greater than zero")
+	}
+
+	if breakerOpenTimeoutMs < 1 {
+		return &Config{}, errors.New("circuit breaker open timeout must be greater than zero")
+	}
+
 	if prometheusPort < 0 || prometheusPort > 65535 {
 		return &Config{}, errors.New("prometheus port must be between 0 and 65535")
 	}
@@ -144,8 +374,21 @@ func Load() (*Config, error) {
 			ServerAddress: kafkaAddress,
 			RetryAttempts: defaultRetryAttempts,
 		},
+		KafkaProducerConfig: kafka.ProducerConfig{
+			ServerAddress: kafkaAddress,
+		},
 		KafkaDispatcherConfig: services.MessagesDispatcherConfig{
-			PollTimeoutMs: kafkaPollTimeoutMs,
+			ConsumerGroup:           "validation-service",
+			PollTimeoutMs:           kafkaPollTimeoutMs,
+			NumWorkers:              workersCount,
+			WorkerQueueSize:         workerQueueSize,
+			CommitInterval:          time.Duration(commitIntervalMs) * time.Millisecond,
+			RebalanceTimeout:        time.Duration(rebalanceTimeoutMs) * time.Millisecond,
+			DrainTimeout:            time.Duration(drainTimeoutMs) * time.Millisecond,
+			MaxAttempts:             maxAttempts,
+			RetryBackoff:            time.Duration(retryBackoffMs) * time.Millisecond,
+			DeadLetterTopic:         string(kafkaProtocol.TopicNewInvoiceDLQ),
+			DeadLetterRetryAttempts: defaultRetryAttempts,
 		},
 		PrometheusConfig: meterutils.PrometheusConfig{
 			PortToListen:    uint16(prometheusPort),
@@ -156,5 +399,10 @@ func Load() (*Config, error) {
 			CollectorAddress: otelCollectorAddress,
 		},
 		StorageAddress: storageAddress,
+		BreakerConfig: breaker.Config{
+			FailureThreshold: breakerFailures,
+			OpenTimeout:      time.Duration(breakerOpenTimeoutMs) * time.Millisecond,
+			SuccessThreshold: breakerSuccesses,
+		},
 	}, nil
 }
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"go-invoice-service/common/pkg/breaker"
+	"go-invoice-service/common/pkg/logging"
+	kafkaProtocol "go-invoice-service/common/protocol/kafka"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	"validation-service/internal/kafka"
+	"validation-service/internal/metrics"
+	"validation-service/internal/services"
+)
+
+const (
+	kafkaAddressEnv      = "KAFKA_ADDRESS"
+	defaultKafkaAddress  = "localhost:9092"
+	replayConsumerGroup  = "validation-service-dlq-replay"
+	replayPollTimeoutMs  = 100
+	defaultIdleTimeoutMs = 5000
+)
+
+// The replay stops on the first failed send, so the breaker only reports the state.
+var replayBreakerConfig = breaker.Config{
+	FailureThreshold: 1,
+	OpenTimeout:      5 * time.Second,
+	SuccessThreshold: 1,
+}
+
+var defaultRetryAttempts = []time.Duration{
+	1 * time.Second,
+	2 * time.Second,
+	4 * time.Second,
+	8 * time.Second,
+}
+
+// Replays messages from the new_invoice dead-letter topic back to their source topic.
+func main() {
+	kafkaAddress := flag.String("kafka-address", defaultKafkaAddress, "Kafka bootstrap server address")
+	topic := flag.String("topic", string(kafkaProtocol.TopicNewInvoiceDLQ), "Dead-letter topic to replay")
+	maxMessages := flag.Int("max-messages", 0, "Maximum messages to replay (0 means all)")
+	idleTimeoutMs := flag.Int("idle-timeout-ms", defaultIdleTimeoutMs, "Stop after no messages arrive for this long (ms)")
+	dryRun := flag.Bool("dry-run", false, "Only log messages which would be replayed, without committing offsets")
+	flag.Parse()
+
+	if valStr, ok := os.LookupEnv(kafkaAddressEnv); ok {
+		*kafkaAddress = valStr
+	}
+
+	if *maxMessages < 0 {
+		log.Fatal("max messages must not be negative")
+	}
+
+	logger, err := logging.NewZapLogger(zapcore.InfoLevel)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancelCtx := signal.NotifyContext(
+		context.Background(),
+		syscall.SIGINT,
+		syscall.SIGTERM,
+	)
+	defer cancelCtx()
+
+	metricsCollector := metrics.MustInitCustomMetric()
+
+	consumer, err := kafka.NewKafkaConsumer(
+		kafka.ConsumerConfig{
+			ServerAddress: *kafkaAddress,
+			RetryAttempts: defaultRetryAttempts,
+		},
+		metricsCollector,
+		replayConsumerGroup,
+		*topic,
+		logger,
+	)
+	if err != nil {
+		logger.FatalCtx(ctx, "Failed to create consumer", zap.Error(err))
+	}
+	defer consumer.Close()
+
+	producer, err := kafka.NewKafkaProducer(
+		kafka.ProducerConfig{ServerAddress: *kafkaAddress},
+		metricsCollector,
+		breaker.New("kafka", replayBreakerConfig, metricsCollector, logger),
+	)
+	if err != nil {
+		logger.FatalCtx(ctx, "Failed to create producer", zap.Error(err))
+	}
+	defer producer.Close()
+
+	replayer := services.NewDeadLetterReplayer(
+		services.DeadLetterReplayConfig{
+			PollTimeoutMs: replayPollTimeoutMs,
+			IdleTimeout:   time.Duration(*idleTimeoutMs) * time.Millisecond,
+			MaxMessages:   *maxMessages,
+			DryRun:        *dryRun,
+		},
+		consumer,
+		producer,
+		logger,
+	)
+
+	stats, err := replayer.Run(ctx)
+	fields := []zap.Field{
+		zap.Int("replayed", stats.Replayed),
+		zap.Int("skipped", stats.Skipped),
+		zap.Bool("dry-run", *dryRun),
+	}
+	if err != nil {
+		logger.ErrorCtx(ctx, "Dead-letter replay failed", append(fields, zap.Error(err))...)
+		os.Exit(1)
+	}
+	logger.InfoCtx(ctx, "Dead-letter replay finished", fields...)
+}
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"go-invoice-service/common/pkg/breaker"
 	"go-invoice-service/common/pkg/logging"
 	"go-invoice-service/common/pkg/meterutils"
+	"go-invoice-service/common/pkg/tracing"
 	kafkaProtocol "go-invoice-service/common/protocol/kafka"
 	storagepb "go-invoice-service/common/protocol/proto/validation"
 	"go.uber.org/zap"
@@ -62,7 +64,7 @@ func main() {
 	kafkaConsumer, err := kafka.NewKafkaConsumer(
 		cfg.KafkaConsumerConfig,
 		metricsCollector,
-		"validation-service",
+		cfg.KafkaDispatcherConfig.ConsumerGroup,
 		string(kafkaProtocol.TopicNewInvoice),
 		logger,
 	)
@@ -71,8 +73,23 @@ func main() {
 	}
 	defer kafkaConsumer.Close()
 
+	kafkaBreaker := breaker.New("kafka", cfg.BreakerConfig, metricsCollector, logger)
+	kafkaProducer, err := kafka.NewKafkaProducer(cfg.KafkaProducerConfig, metricsCollector, kafkaBreaker)
+	if err != nil {
+		logger.FatalCtx(rootCtx, "Failed to create producer", zap.Error(err))
+	}
+	defer kafkaProducer.Close()
+
+	storageBreaker := breaker.New("storage", cfg.BreakerConfig, metricsCollector, logger)
 	options := grpc.WithTransportCredentials(insecure.NewCredentials())
-	storageServiceConnection, err := grpc.NewClient(cfg.StorageAddress, options)
+	storageServiceConnection, err := grpc.NewClient(
+		cfg.StorageAddress,
+		options,
+		grpc.WithChainUnaryInterceptor(
+			tracing.UnaryClientInterceptor(),
+			breaker.UnaryClientInterceptor(storageBreaker),
+		),
+	)
 	if err != nil {
 		logger.FatalCtx(rootCtx, "Failed to connect to storage service", zap.Error(err))
 	}
@@ -86,9 +103,11 @@ func main() {
 		cfg.KafkaDispatcherConfig,
 		storageService,
 		kafkaConsumer,
+		kafkaProducer,
 		validationService,
 		logger,
 	)
+	kafkaConsumer.SetRebalanceListener(messagesDispatcher)
 
 	if err := run(rootCtx, cfg, messagesDispatcher, logger); err != nil {
 		logger.ErrorCtx(rootCtx, "Service shutdown with error", zap.Error(err))
@@ -0,0 +1,36 @@
+package dto
+
+type Header struct {
+	Key   string
+	Value []byte
+}
+
+type Message struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   []Header
+}
+
+// PartitionOffset holds the next offset to consume from a partition, i.e. the
+// last processed offset plus one, as Kafka expects it to be committed.
+type PartitionOffset struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+}
+
+type TopicPartition struct {
+	Topic     string
+	Partition int32
+}
+
+func (m Message) NextOffset() PartitionOffset {
+	return PartitionOffset{
+		Topic:     m.Topic,
+		Partition: m.Partition,
+		Offset:    m.Offset + 1,
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"go-invoice-service/common/pkg/timeutils"
 	"go.uber.org/zap"
 	"time"
+	"validation-service/internal/dto"
 )
 
 var (
@@ -20,6 +21,15 @@ type ConsumerMetrics interface {
 	IncKafkaTotalConsumedMessages(ctx context.Context, topic, consumerGroupID string)
 }
 
+// RebalanceListener is called from PeekNext when the consumer group rebalances.
+// Offsets of revoked partitions may still be committed in PartitionsRevoked,
+// while lost partitions already belong to another consumer.
+type RebalanceListener interface {
+	PartitionsAssigned(partitions []dto.TopicPartition)
+	PartitionsRevoked(partitions []dto.TopicPartition)
+	PartitionsLost(partitions []dto.TopicPartition)
+}
+
 type ConsumerConfig struct {
 	ServerAddress string
 	RetryAttempts []time.Duration
@@ -29,6 +39,7 @@ type KafkaConsumer struct {
 	cfg      ConsumerConfig
 	metrics  ConsumerMetrics
 	consumer *kafka.Consumer
+	listener RebalanceListener
 	groupID  string
 	topic    string
 	logger   *logging.ZapLogger
@@ -50,64 +61,103 @@ func NewKafkaConsumer(
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kafka consumer %w", err)
 	}
-	err = consumer.Subscribe(topic, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to subscribe to kafka topic: %w", err)
-	}
-	return &KafkaConsumer{
+	c := &KafkaConsumer{
 		cfg:      cfg,
 		metrics:  metrics,
 		consumer: consumer,
 		groupID:  groupID,
 		topic:    topic,
 		logger:   logger,
-	}, nil
+	}
+	err = consumer.Subscribe(topic, c.rebalance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to kafka topic: %w", err)
+	}
+	return c, nil
+}
+
+// SetRebalanceListener must be called before the first PeekNext.
+func (c *KafkaConsumer) SetRebalanceListener(listener RebalanceListener) {
+	c.listener = listener
+}
+
+func (c *KafkaConsumer) rebalance(consumer *kafka.Consumer, ev kafka.Event) error {
+	ctx := context.Background()
+	switch e := ev.(type) {
+	case kafka.AssignedPartitions:
+		c.logger.InfoCtx(ctx, "kafka partitions assigned", zap.Int32s("partitions", partitionNumbers(e.Partitions)))
+		if c.listener != nil {
+			c.listener.PartitionsAssigned(topicPartitionsFromKafka(e.Partitions))
+		}
+
+	case kafka.RevokedPartitions:
+		lost := consumer.AssignmentLost()
+		c.logger.InfoCtx(ctx, "kafka partitions revoked",
+			zap.Int32s("partitions", partitionNumbers(e.Partitions)),
+			zap.Bool("lost", lost),
+		)
+		if c.listener == nil {
+			return nil
+		}
+		if lost {
+			c.listener.PartitionsLost(topicPartitionsFromKafka(e.Partitions))
+		} else {
+			c.listener.PartitionsRevoked(topicPartitionsFromKafka(e.Partitions))
+		}
+	}
+	return nil
 }
 
 func (c *KafkaConsumer) Close() error {
 	return c.consumer.Close()
 }
 
-func (c *KafkaConsumer) PeekNext(pollTimeoutMs int) ([]byte, error) {
+func (c *KafkaConsumer) PeekNext(pollTimeoutMs int) (dto.Message, error) {
 	ev := c.consumer.Poll(pollTimeoutMs)
 	switch e := ev.(type) {
 	case *kafka.Message:
-		return e.Value, nil
+		c.metrics.IncKafkaTotalConsumedMessages(context.Background(), c.topic, c.groupID)
+		return messageFromKafka(e), nil
 
 	case kafka.PartitionEOF:
-		return nil, ErrPartitionEOF
+		return dto.Message{}, ErrPartitionEOF
 
 	case kafka.Error:
-		return nil, e
+		return dto.Message{}, e
 
 	case nil:
-		return nil, ErrNoMessage
+		return dto.Message{}, ErrNoMessage
 	}
 
-	return nil, errors.New("unknown kafka event")
+	return dto.Message{}, errors.New("unknown kafka event")
 }
 
-func (c *KafkaConsumer) Commit(ctx context.Context) error {
+func (c *KafkaConsumer) CommitOffsets(ctx context.Context, offsets []dto.PartitionOffset) error {
+	tps := make([]kafka.TopicPartition, len(offsets))
+	for i, o := range offsets {
+		tps[i] = kafka.TopicPartition{
+			Topic:     &o.Topic,
+			Partition: o.Partition,
+			Offset:    kafka.Offset(o.Offset),
+		}
+	}
 	err := timeutils.Retry(
 		ctx,
 		c.cfg.RetryAttempts,
 		func(ctx context.Context) error {
-			c.logger.InfoCtx(ctx, "commiting kafka offset")
-			_, err := c.consumer.Commit()
+			_, err := c.consumer.CommitOffsets(tps)
 			return err
 		},
 		func(ctx context.Context, err error) bool {
-			c.logger.ErrorCtx(ctx, "kafka commit offset fail", zap.Error(err))
+			c.logger.ErrorCtx(ctx, "kafka commit offsets fail", zap.Error(err))
 			return true
 		},
 		true,
 	)
-	c.logger.InfoCtx(ctx, "kafka offset commited")
 	if err != nil {
-		return fmt.Errorf("failed to commit message %w", err)
-	} else {
-		c.metrics.IncKafkaTotalConsumedMessages(ctx, c.topic, c.groupID)
+		return fmt.Errorf("failed to commit offsets %w", err)
 	}
+	c.logger.DebugCtx(ctx, "kafka offsets commited", zap.Int("partitions", len(offsets)))
 	return nil
 }
 
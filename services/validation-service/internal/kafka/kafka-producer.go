@@ -0,0 +1,75 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go-invoice-service/common/pkg/breaker"
+	"validation-service/internal/dto"
+)
+
+type ProducerMetrics interface {
+	IncKafkaTotalProducedMessages(ctx context.Context, topic string)
+}
+
+type ProducerConfig struct {
+	ServerAddress string
+}
+
+// KafkaProducer sends messages one by one. Once sends keep failing the circuit
+// breaker opens, and messages are rejected with breaker.ErrOpen meanwhile.
+type KafkaProducer struct {
+	producer *kafka.Producer
+	metrics  ProducerMetrics
+	breaker  *breaker.Breaker
+}
+
+func NewKafkaProducer(cfg ProducerConfig, metrics ProducerMetrics, cb *breaker.Breaker) (*KafkaProducer, error) {
+	producer, err := kafka.NewProducer(&kafka.ConfigMap{
+		"bootstrap.servers": cfg.ServerAddress,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka producer: %w", err)
+	}
+
+	return &KafkaProducer{
+		producer: producer,
+		metrics:  metrics,
+		breaker:  cb,
+	}, nil
+}
+
+func (p *KafkaProducer) Close() {
+	p.producer.Close()
+}
+
+func (p *KafkaProducer) SendMessage(ctx context.Context, msg dto.Message) error {
+	err := p.breaker.Do(func() error {
+		return p.sendMessage(ctx, msg)
+	})
+	if errors.Is(err, breaker.ErrOpen) {
+		return fmt.Errorf("failed to send message to Kafka server: %w", err)
+	}
+	return err
+}
+
+func (p *KafkaProducer) sendMessage(ctx context.Context, msg dto.Message) error {
+	deliveryChan := make(chan kafka.Event, 1)
+	err := p.producer.Produce(messageToKafka(msg), deliveryChan)
+	if err != nil {
+		return fmt.Errorf("failed to send message to Kafka server: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case e := <-deliveryChan:
+		m := e.(*kafka.Message)
+		if m.TopicPartition.Error != nil {
+			return fmt.Errorf("failed to send message to Kafka server: %w", m.TopicPartition.Error)
+		}
+		p.metrics.IncKafkaTotalProducedMessages(ctx, msg.Topic)
+		return nil
+	}
+}
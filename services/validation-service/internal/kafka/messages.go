@@ -0,0 +1,55 @@
+package kafka
+
+import (
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"validation-service/internal/dto"
+)
+
+func messageFromKafka(msg *kafka.Message) dto.Message {
+	res := dto.Message{
+		Partition: msg.TopicPartition.Partition,
+		Offset:    int64(msg.TopicPartition.Offset),
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Headers:   make([]dto.Header, len(msg.Headers)),
+	}
+	if msg.TopicPartition.Topic != nil {
+		res.Topic = *msg.TopicPartition.Topic
+	}
+	for i, h := range msg.Headers {
+		res.Headers[i] = dto.Header{Key: h.Key, Value: h.Value}
+	}
+	return res
+}
+
+func messageToKafka(msg dto.Message) *kafka.Message {
+	res := &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &msg.Topic, Partition: kafka.PartitionAny},
+		Key:            msg.Key,
+		Value:          msg.Value,
+		Headers:        make([]kafka.Header, len(msg.Headers)),
+	}
+	for i, h := range msg.Headers {
+		res.Headers[i] = kafka.Header{Key: h.Key, Value: h.Value}
+	}
+	return res
+}
+
+func topicPartitionsFromKafka(partitions []kafka.TopicPartition) []dto.TopicPartition {
+	res := make([]dto.TopicPartition, 0, len(partitions))
+	for _, p := range partitions {
+		if p.Topic == nil {
+			continue
+		}
+		res = append(res, dto.TopicPartition{Topic: *p.Topic, Partition: p.Partition})
+	}
+	return res
+}
+
+func partitionNumbers(partitions []kafka.TopicPartition) []int32 {
+	res := make([]int32, len(partitions))
+	for i, p := range partitions {
+		res[i] = p.Partition
+	}
+	return res
+}
@@ -9,7 +9,9 @@ import (
 
 type MetricsCollector struct {
 	kafkaTotalConsumedMessages metric.Int64Counter
+	kafkaTotalProducedMessages metric.Int64Counter
 	totalHandledInvoices       metric.Int64Counter
+	circuitBreakerState        metric.Int64Gauge
 }
 
 func MustInitCustomMetric() *MetricsCollector {
@@ -27,6 +29,13 @@ func MustInitCustomMetric() *MetricsCollector {
 		),
 	)
 
+	m.kafkaTotalProducedMessages = must(
+		kafkaMeter.Int64Counter(
+			"kafka_total_produced_messages",
+			metric.WithDescription("Total Kafka produced messages"),
+		),
+	)
+
 	// Invoices.
 	invoicesMeter := metricProvider.Meter("invoices")
 
@@ -37,6 +46,16 @@ func MustInitCustomMetric() *MetricsCollector {
 		),
 	)
 
+	// Circuit breakers.
+	breakersMeter := metricProvider.Meter("breakers")
+
+	m.circuitBreakerState = must(
+		breakersMeter.Int64Gauge(
+			"circuit_breaker_state",
+			metric.WithDescription("Circuit breaker state: 0 closed, 1 half-open, 2 open"),
+		),
+	)
+
 	return m
 }
 
@@ -55,9 +74,23 @@ func (m *MetricsCollector) IncKafkaTotalConsumedMessages(ctx context.Context, to
 	m.kafkaTotalConsumedMessages.Add(ctx, 1, metric.WithAttributeSet(attrSet))
 }
 
+func (m *MetricsCollector) IncKafkaTotalProducedMessages(ctx context.Context, topic string) {
+	attrSet := attribute.NewSet(
+		attribute.KeyValue{Key: "topic", Value: attribute.StringValue(topic)},
+	)
+	m.kafkaTotalProducedMessages.Add(ctx, 1, metric.WithAttributeSet(attrSet))
+}
+
 func (m *MetricsCollector) IncTotalHandledInvoices(ctx context.Context, status string) {
 	attrSet := attribute.NewSet(
 		attribute.KeyValue{Key: "status", Value: attribute.StringValue(status)},
 	)
 	m.totalHandledInvoices.Add(ctx, 1, metric.WithAttributeSet(attrSet))
 }
+
+func (m *MetricsCollector) SetCircuitBreakerState(ctx context.Context, name string, state int64) {
+	attrSet := attribute.NewSet(
+		attribute.KeyValue{Key: "name", Value: attribute.StringValue(name)},
+	)
+	m.circuitBreakerState.Record(ctx, state, metric.WithAttributeSet(attrSet))
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-invoice-service/common/pkg/logging"
+	protocol "go-invoice-service/common/protocol/kafka"
+	"go.uber.org/zap"
+	"slices"
+	"strconv"
+	"time"
+	"validation-service/internal/dto"
+)
+
+var ErrNoSourceTopic = errors.New("dead-letter message has no source topic")
+
+type DeadLetterReplayConfig struct {
+	PollTimeoutMs int
+	IdleTimeout   time.Duration
+	MaxMessages   int
+	DryRun        bool
+}
+
+type DeadLetterReplayStats struct {
+	Replayed int
+	Skipped  int
+}
+
+type DeadLetterReplayer struct {
+	cfg      DeadLetterReplayConfig
+	consumer MessageConsumer
+	producer MessageProducer
+	logger   *logging.ZapLogger
+}
+
+func NewDeadLetterReplayer(
+	cfg DeadLetterReplayConfig,
+	consumer MessageConsumer,
+	producer MessageProducer,
+	logger *logging.ZapLogger,
+) *DeadLetterReplayer {
+	return &DeadLetterReplayer{
+		cfg:      cfg,
+		consumer: consumer,
+		producer: producer,
+		logger:   logger,
+	}
+}
+
+// Run moves dead-letter messages back to their source topics until MaxMessages
+// are handled (0 means no limit) or no message arrives within IdleTimeout.
+func (r *DeadLetterReplayer) Run(ctx context.Context) (DeadLetterReplayStats, error) {
+	var stats DeadLetterReplayStats
+	lastMessageAt := time.Now()
+
+	for r.cfg.MaxMessages == 0 || stats.Replayed+stats.Skipped < r.cfg.MaxMessages {
+		if ctx.Err() != nil {
+			return stats, ctx.Err()
+		}
+
+		msg, err := r.consumer.PeekNext(r.cfg.PollTimeoutMs)
+		if r.consumer.ErrIsNoMessage(err) {
+			if time.Since(lastMessageAt) >= r.cfg.IdleTimeout {
+				break
+			}
+			continue
+		}
+		if err != nil {
+			return stats, err
+		}
+		lastMessageAt = time.Now()
+
+		replay, err := replayMessage(msg)
+		if err != nil {
+			r.logger.WarnCtx(ctx, "dead-letter message skipped",
+				zap.Int32("partition", msg.Partition),
+				zap.Int64("offset", msg.Offset),
+				zap.Error(err),
+			)
+			stats.Skipped++
+		} else {
+			r.logger.InfoCtx(ctx, "replaying dead-letter message",
+				zap.String("topic", replay.Topic),
+				zap.Int64("offset", msg.Offset),
+				zap.String("error", headerValue(msg.Headers, protocol.DeadLetterHeaderError)),
+				zap.Bool("dry-run", r.cfg.DryRun),
+			)
+			if !r.cfg.DryRun {
+				err = r.producer.SendMessage(ctx, replay)
+				if err != nil {
+					return stats, fmt.Errorf("failed to replay message: %w", err)
+				}
+			}
+			stats.Replayed++
+		}
+
+		if r.cfg.DryRun {
+			continue
+		}
+		err = r.consumer.CommitOffsets(ctx, []dto.PartitionOffset{msg.NextOffset()})
+		if err != nil {
+			return stats, err
+		}
+	}
+
+	return stats, nil
+}
+
+func deadLetterMessage(topic string, msg dto.Message, attempts int, handleErr error, failedAt time.Time) dto.Message {
+	headers := withoutDeadLetterHeaders(msg.Headers)
+	headers = append(headers,
+		dto.Header{Key: protocol.DeadLetterHeaderSourceTopic, Value: []byte(msg.Topic)},
+		dto.Header{Key: protocol.DeadLetterHeaderSourcePartition, Value: []byte(strconv.Itoa(int(msg.Partition)))},
+		dto.Header{Key: protocol.DeadLetterHeaderSourceOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		dto.Header{Key: protocol.DeadLetterHeaderError, Value: []byte(handleErr.Error())},
+		dto.Header{Key: protocol.DeadLetterHeaderAttempts, Value: []byte(strconv.Itoa(attempts))},
+		dto.Header{Key: protocol.DeadLetterHeaderFailedAt, Value: []byte(failedAt.UTC().Format(time.RFC3339Nano))},
+	)
+	return dto.Message{
+		Topic:   topic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	}
+}
+
+func replayMessage(msg dto.Message) (dto.Message, error) {
+	topic := headerValue(msg.Headers, protocol.DeadLetterHeaderSourceTopic)
+	if topic == "" {
+		return dto.Message{}, ErrNoSourceTopic
+	}
+	return dto.Message{
+		Topic:   topic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: withoutDeadLetterHeaders(msg.Headers),
+	}, nil
+}
+
+func headerValue(headers []dto.Header, key string) string {
+	for _, h := range headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func withoutDeadLetterHeaders(headers []dto.Header) []dto.Header {
+	return slices.DeleteFunc(slices.Clone(headers), func(h dto.Header) bool {
+		switch h.Key {
+		case protocol.DeadLetterHeaderSourceTopic,
+			protocol.DeadLetterHeaderSourcePartition,
+			protocol.DeadLetterHeaderSourceOffset,
+			protocol.DeadLetterHeaderError,
+			protocol.DeadLetterHeaderAttempts,
+			protocol.DeadLetterHeaderFailedAt:
+			return true
+		}
+		return false
+	})
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	protocol "go-invoice-service/common/protocol/kafka"
+	"testing"
+	"time"
+	"validation-service/internal/dto"
+)
+
+func TestDeadLetterMessage_Replay(t *testing.T) {
+	original := dto.Message{
+		Topic:     string(protocol.TopicNewInvoice),
+		Partition: 3,
+		Offset:    17,
+		Key:       []byte("key"),
+		Value:     []byte(`{"id":"x"}`),
+		Headers:   []dto.Header{{Key: "trace", Value: []byte("abc")}},
+	}
+
+	failedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	dlq := deadLetterMessage(
+		string(protocol.TopicNewInvoiceDLQ),
+		original,
+		3,
+		errors.New("boom"),
+		failedAt,
+	)
+
+	require.Equal(t, string(protocol.TopicNewInvoiceDLQ), dlq.Topic)
+	require.Equal(t, original.Key, dlq.Key)
+	require.Equal(t, original.Value, dlq.Value)
+	require.Equal(t, "abc", headerValue(dlq.Headers, "trace"))
+	require.Equal(t, original.Topic, headerValue(dlq.Headers, protocol.DeadLetterHeaderSourceTopic))
+	require.Equal(t, "3", headerValue(dlq.Headers, protocol.DeadLetterHeaderSourcePartition))
+	require.Equal(t, "17", headerValue(dlq.Headers, protocol.DeadLetterHeaderSourceOffset))
+	require.Equal(t, "boom", headerValue(dlq.Headers, protocol.DeadLetterHeaderError))
+	require.Equal(t, "3", headerValue(dlq.Headers, protocol.DeadLetterHeaderAttempts))
+	require.Equal(t, "2025-01-02T03:04:05Z", headerValue(dlq.Headers, protocol.DeadLetterHeaderFailedAt))
+
+	replay, err := replayMessage(dlq)
+	require.NoError(t, err)
+	require.Equal(t, original.Topic, replay.Topic)
+	require.Equal(t, original.Key, replay.Key)
+	require.Equal(t, original.Value, replay.Value)
+	require.Equal(t, original.Headers, replay.Headers)
+
+	_, err = replayMessage(original)
+	require.ErrorIs(t, err, ErrNoSourceTopic)
+}
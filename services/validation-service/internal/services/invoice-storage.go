@@ -2,14 +2,20 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"go-invoice-service/common/pkg/inbox"
 	"go-invoice-service/common/pkg/logging"
 	"go-invoice-service/common/protocol/proto/types"
 	pb "go-invoice-service/common/protocol/proto/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"validation-service/internal/dto"
 )
 
+var ErrInvoiceNotFound = errors.New("invoice not found")
+
 type InvoiceStorageClient interface {
 	pb.InvoiceStorageClient
 }
@@ -41,6 +47,9 @@ func (s *InvoiceStorageService) GetInvoice(ctx context.Context, id uuid.UUID) (*
 		Id: uuidToProto(id),
 	}
 	resp, err := s.invoiceStorageClient.Get(ctx, req)
+	if status.Code(err) == codes.NotFound {
+		return nil, dto.NilInvoiceStatus, ErrInvoiceNotFound
+	}
 	if err != nil {
 		return nil, dto.NilInvoiceStatus, fmt.Errorf("failed to get invoice: %w", err)
 	}
@@ -55,11 +64,17 @@ func (s *InvoiceStorageService) GetInvoice(ctx context.Context, id uuid.UUID) (*
 	return invoice, invoiceStatus, nil
 }
 
-func (s *InvoiceStorageService) SetApproved(ctx context.Context, id uuid.UUID) error {
+// SetApproved approves the invoice. If inboxKey is set, the event is recorded handled
+// together with the approval, and inbox.ErrAlreadyHandled is returned if it was handled already.
+func (s *InvoiceStorageService) SetApproved(ctx context.Context, id uuid.UUID, inboxKey *inbox.Key) error {
 	req := &pb.SetApprovedRequest{
-		Id: uuidToProto(id),
+		Id:       uuidToProto(id),
+		InboxKey: inboxKeyToProto(inboxKey),
 	}
 	_, err := s.invoiceStorageClient.SetApproved(ctx, req)
+	if status.Code(err) == codes.AlreadyExists {
+		return inbox.ErrAlreadyHandled
+	}
 	if err != nil {
 		return fmt.Errorf("failed to set approved: %w", err)
 	}
@@ -67,11 +82,16 @@ func (s *InvoiceStorageService) SetApproved(ctx context.Context, id uuid.UUID) e
 	return nil
 }
 
-func (s *InvoiceStorageService) SetRejected(ctx context.Context, id uuid.UUID) error {
+// SetRejected rejects the invoice, recording the event like SetApproved.
+func (s *InvoiceStorageService) SetRejected(ctx context.Context, id uuid.UUID, inboxKey *inbox.Key) error {
 	req := &pb.SetRejectedRequest{
-		Id: uuidToProto(id),
+		Id:       uuidToProto(id),
+		InboxKey: inboxKeyToProto(inboxKey),
 	}
 	_, err := s.invoiceStorageClient.SetRejected(ctx, req)
+	if status.Code(err) == codes.AlreadyExists {
+		return inbox.ErrAlreadyHandled
+	}
 	if err != nil {
 		return fmt.Errorf("failed to set rejected: %w", err)
 	}
@@ -79,6 +99,16 @@ func (s *InvoiceStorageService) SetRejected(ctx context.Context, id uuid.UUID) e
 	return nil
 }
 
+func inboxKeyToProto(key *inbox.Key) *pb.InboxKey {
+	if key == nil {
+		return nil
+	}
+	return &pb.InboxKey{
+		EventId:       uuidToProto(key.EventID),
+		ConsumerGroup: &key.ConsumerGroup,
+	}
+}
+
 func invoiceStatusFromProto(status types.InvoiceStatus) (dto.InvoiceStatus, error) {
 	switch status {
 	case types.InvoiceStatus_Pending:
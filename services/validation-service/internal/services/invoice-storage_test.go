@@ -6,10 +6,13 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go-invoice-service/common/pkg/inbox"
 	"go-invoice-service/common/pkg/logging"
 	"go-invoice-service/common/protocol/proto/types"
 	"go-invoice-service/common/protocol/proto/validation"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"testing"
 	"validation-service/internal/dto"
@@ -102,6 +105,15 @@ func TestInvoiceStorage_GetInvoice(t *testing.T) {
 				assert.Equal(t, dto.NilInvoiceStatus, status)
 			},
 		},
+		{
+			name:       "not_found",
+			storageErr: status.Error(codes.NotFound, "invoice not found"),
+			resultCheck: func(t *testing.T, invoice *dto.Invoice, status dto.InvoiceStatus, err error) {
+				require.ErrorIs(t, err, ErrInvoiceNotFound)
+				assert.Nil(t, invoice)
+				assert.Equal(t, dto.NilInvoiceStatus, status)
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -202,6 +214,14 @@ func TestInvoiceStorage_SetApproved(t *testing.T) {
 				require.Error(t, err)
 			},
 		},
+		{
+			name:                   "already_handled",
+			errFromStorage:         status.Error(codes.AlreadyExists, "event already handled"),
+			metricsCollectionTimes: 0,
+			resultCheck: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, inbox.ErrAlreadyHandled)
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -216,10 +236,15 @@ func TestInvoiceStorage_SetApproved(t *testing.T) {
 			invoiceStorage := NewInvoiceStorage(invoiceStorageClient, invoicesMetrics, logger)
 
 			invoiceID := uuid.New()
+			inboxKey := &inbox.Key{EventID: uuid.New(), ConsumerGroup: "validation-service"}
 			request := &validation.SetApprovedRequest{
 				Id: &types.UUID{
 					Value: invoiceID[:],
 				},
+				InboxKey: &validation.InboxKey{
+					EventId:       &types.UUID{Value: inboxKey.EventID[:]},
+					ConsumerGroup: &inboxKey.ConsumerGroup,
+				},
 			}
 			invoiceStorageClient.EXPECT().
 				SetApproved(gomock.Any(), request).
@@ -230,7 +255,7 @@ func TestInvoiceStorage_SetApproved(t *testing.T) {
 				IncTotalHandledInvoices(gomock.Any(), "approved").
 				Times(test.metricsCollectionTimes)
 
-			err := invoiceStorage.SetApproved(context.Background(), invoiceID)
+			err := invoiceStorage.SetApproved(context.Background(), invoiceID, inboxKey)
 			test.resultCheck(t, err)
 		})
 	}
@@ -259,6 +284,14 @@ func TestInvoiceStorage_SetRejected(t *testing.T) {
 				require.Error(t, err)
 			},
 		},
+		{
+			name:                   "already_handled",
+			errFromStorage:         status.Error(codes.AlreadyExists, "event already handled"),
+			metricsCollectionTimes: 0,
+			resultCheck: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, inbox.ErrAlreadyHandled)
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -273,10 +306,15 @@ func TestInvoiceStorage_SetRejected(t *testing.T) {
 			invoiceStorage := NewInvoiceStorage(invoiceStorageClient, invoicesMetrics, logger)
 
 			invoiceID := uuid.New()
+			inboxKey := &inbox.Key{EventID: uuid.New(), ConsumerGroup: "validation-service"}
 			request := &validation.SetRejectedRequest{
 				Id: &types.UUID{
 					Value: invoiceID[:],
 				},
+				InboxKey: &validation.InboxKey{
+					EventId:       &types.UUID{Value: inboxKey.EventID[:]},
+					ConsumerGroup: &inboxKey.ConsumerGroup,
+				},
 			}
 			invoiceStorageClient.EXPECT().
 				SetRejected(gomock.Any(), request).
@@ -287,7 +325,7 @@ func TestInvoiceStorage_SetRejected(t *testing.T) {
 				IncTotalHandledInvoices(gomock.Any(), "rejected").
 				Times(test.metricsCollectionTimes)
 
-			err := invoiceStorage.SetRejected(context.Background(), invoiceID)
+			err := invoiceStorage.SetRejected(context.Background(), invoiceID, inboxKey)
 			test.resultCheck(t, err)
 		})
 	}
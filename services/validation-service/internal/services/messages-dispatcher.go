@@ -1,19 +1,44 @@
 package services
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
-	"go-invoice-service/common/pkg/http/utils"
+	"go-invoice-service/common/pkg/breaker"
+	"go-invoice-service/common/pkg/inbox"
 	"go-invoice-service/common/pkg/logging"
+	"go-invoice-service/common/pkg/timeutils"
+	"go-invoice-service/common/pkg/tracing"
 	protocol "go-invoice-service/common/protocol/kafka"
 	"go.uber.org/zap"
+	"sync"
+	"time"
 	"validation-service/internal/dto"
 )
 
+var ErrInvalidMessage = errors.New("invalid message")
+
+const (
+	finalCommitTimeout = 5 * time.Second
+	drainCheckInterval = 50 * time.Millisecond
+)
+
 type MessagesDispatcherConfig struct {
-	PollTimeoutMs int
+	// ConsumerGroup records handled events, so an event redelivered to the group is skipped.
+	ConsumerGroup    string
+	PollTimeoutMs    int
+	NumWorkers       int
+	WorkerQueueSize  int
+	CommitInterval   time.Duration
+	RebalanceTimeout time.Duration
+	// DrainTimeout limits handling of polled messages on shutdown, messages not
+	// handled by then are redelivered.
+	DrainTimeout            time.Duration
+	MaxAttempts             int
+	RetryBackoff            time.Duration
+	DeadLetterTopic         string
+	DeadLetterRetryAttempts []time.Duration
 }
 
 type InvoiceValidator interface {
@@ -22,88 +47,352 @@ type InvoiceValidator interface {
 
 type InvoiceStorage interface {
 	GetInvoice(ctx context.Context, id uuid.UUID) (*dto.Invoice, dto.InvoiceStatus, error)
-	SetApproved(ctx context.Context, id uuid.UUID) error
-	SetRejected(ctx context.Context, id uuid.UUID) error
+	SetApproved(ctx context.Context, id uuid.UUID, inboxKey *inbox.Key) error
+	SetRejected(ctx context.Context, id uuid.UUID, inboxKey *inbox.Key) error
 }
 
 type MessageConsumer interface {
-	PeekNext(pollTimeoutMs int) ([]byte, error)
-	Commit(ctx context.Context) error
+	PeekNext(pollTimeoutMs int) (dto.Message, error)
+	CommitOffsets(ctx context.Context, offsets []dto.PartitionOffset) error
 	ErrIsNoMessage(error) bool
 }
 
+type MessageProducer interface {
+	SendMessage(ctx context.Context, msg dto.Message) error
+}
+
 type MessagesDispatcher struct {
-	cfg              MessagesDispatcherConfig
-	invoiceStorage   InvoiceStorage
-	messageConsumer  MessageConsumer
-	invoiceValidator InvoiceValidator
-	logger           *logging.ZapLogger
+	cfg                MessagesDispatcherConfig
+	invoiceStorage     InvoiceStorage
+	messageConsumer    MessageConsumer
+	deadLetterProducer MessageProducer
+	invoiceValidator   InvoiceValidator
+	offsets            *OffsetTracker
+	commitMu           sync.Mutex
+	logger             *logging.ZapLogger
 }
 
 func NewMessagesDispatcher(
 	cfg MessagesDispatcherConfig,
 	invoiceStorage InvoiceStorage,
 	messageConsumer MessageConsumer,
+	deadLetterProducer MessageProducer,
 	invoiceValidator InvoiceValidator,
 	logger *logging.ZapLogger,
 ) *MessagesDispatcher {
 	return &MessagesDispatcher{
-		cfg:              cfg,
-		invoiceStorage:   invoiceStorage,
-		messageConsumer:  messageConsumer,
-		invoiceValidator: invoiceValidator,
-		logger:           logger,
+		cfg:                cfg,
+		invoiceStorage:     invoiceStorage,
+		messageConsumer:    messageConsumer,
+		deadLetterProducer: deadLetterProducer,
+		invoiceValidator:   invoiceValidator,
+		offsets:            NewOffsetTracker(),
+		logger:             logger,
 	}
 }
 
 func (d *MessagesDispatcher) Run(ctx context.Context) <-chan error {
+	return d.run(ctx, d.HandleMessage)
+}
+
+// run polls messages and hands them to NumWorkers workers by partition, so
+// partitions are processed in parallel and messages of one partition in order.
+// Once ctx is done polling stops, and the polled messages are still handled for
+// up to DrainTimeout before the final commit.
+func (d *MessagesDispatcher) run(
+	ctx context.Context,
+	handleMessage func(context.Context, []byte) error,
+) <-chan error {
 	errCh := make(chan error)
 
-	go func(ctx context.Context) {
+	drainCtx, cancelDrain := context.WithCancel(context.WithoutCancel(ctx))
+	stopDrainTimer := context.AfterFunc(ctx, func() {
+		time.AfterFunc(d.cfg.DrainTimeout, cancelDrain)
+	})
+
+	queues := make([]chan dto.Message, d.cfg.NumWorkers)
+	wg := sync.WaitGroup{}
+	for i := range queues {
+		queues[i] = make(chan dto.Message, d.cfg.WorkerQueueSize)
+		wg.Add(1)
+		go func(queue <-chan dto.Message) {
+			defer wg.Done()
+			d.worker(drainCtx, queue, handleMessage, errCh)
+		}(queues[i])
+	}
+
+	committerDone := make(chan struct{})
+	go func() {
+		defer close(committerDone)
+		d.committer(ctx, errCh)
+	}()
+
+	go func() {
 		defer close(errCh)
-		for {
-			if ctx.Err() != nil {
-				return
-			}
 
-			err := d.tick(ctx, d.HandleMessage)
+		d.poll(ctx, queues, errCh)
+
+		for _, queue := range queues {
+			close(queue)
+		}
+		wg.Wait()
+		<-committerDone
+		stopDrainTimer()
+		cancelDrain()
+
+		if unfinished := d.offsets.TotalInFlight(); unfinished > 0 {
+			d.logger.WarnCtx(ctx, "messages dispatcher not drained, unfinished messages will be redelivered",
+				zap.Int("in-flight", unfinished),
+			)
+		} else {
+			d.logger.InfoCtx(ctx, "messages dispatcher drained")
+		}
+
+		commitCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), finalCommitTimeout)
+		defer cancel()
+		if err := d.commit(commitCtx); err != nil {
+			errCh <- err
+		}
+		// messages skipped on shutdown must not hold up revocation on consumer close
+		d.offsets.Reset()
+	}()
+
+	return errCh
+}
 
-			if err != nil && !d.messageConsumer.ErrIsNoMessage(err) {
+func (d *MessagesDispatcher) poll(ctx context.Context, queues []chan dto.Message, errCh chan<- error) {
+	for ctx.Err() == nil {
+		msg, err := d.messageConsumer.PeekNext(d.cfg.PollTimeoutMs)
+		if err != nil {
+			if !d.messageConsumer.ErrIsNoMessage(err) {
 				errCh <- err
 			}
+			continue
 		}
-	}(ctx)
 
-	return errCh
+		d.offsets.Track(msg)
+		// workers keep taking messages until the drain is over, so this does not block shutdown
+		queues[int(msg.Partition)%len(queues)] <- msg
+	}
 }
 
-func (d *MessagesDispatcher) tick(
+func (d *MessagesDispatcher) worker(
 	ctx context.Context,
+	queue <-chan dto.Message,
 	handleMessage func(context.Context, []byte) error,
-) error {
-	msg, err := d.messageConsumer.PeekNext(d.cfg.PollTimeoutMs)
+	errCh chan<- error,
+) {
+	for msg := range queue {
+		if ctx.Err() != nil {
+			// drain timed out: queued messages are not committed and will be redelivered
+			continue
+		}
+		if !d.offsets.Tracked(msg) {
+			// the partition was revoked before the message was started
+			continue
+		}
+		err := d.process(messageContext(ctx, msg), msg, handleMessage)
+		if err != nil {
+			errCh <- err
+			continue
+		}
+		d.offsets.Done(msg)
+	}
+}
+
+func (d *MessagesDispatcher) committer(ctx context.Context, errCh chan<- error) {
+	ticker := time.NewTicker(d.cfg.CommitInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.commit(ctx); err != nil {
+				errCh <- err
+			}
+		}
+	}
+}
+
+func (d *MessagesDispatcher) commit(ctx context.Context) error {
+	d.commitMu.Lock()
+	defer d.commitMu.Unlock()
+
+	return d.commitLocked(ctx)
+}
+
+func (d *MessagesDispatcher) commitLocked(ctx context.Context) error {
+	offsets := d.offsets.Committable()
+	if len(offsets) == 0 {
+		return nil
+	}
+	err := d.messageConsumer.CommitOffsets(ctx, offsets)
 	if err != nil {
 		return err
 	}
+	d.offsets.MarkCommitted(offsets)
+	return nil
+}
+
+func (d *MessagesDispatcher) PartitionsAssigned(partitions []dto.TopicPartition) {
+	d.logger.InfoCtx(context.Background(), "partitions assigned", zap.Int("count", len(partitions)))
+}
+
+// PartitionsRevoked waits up to RebalanceTimeout for polled messages of the
+// partitions to be processed and commits them before the partitions go to
+// another consumer.
+func (d *MessagesDispatcher) PartitionsRevoked(partitions []dto.TopicPartition) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.cfg.RebalanceTimeout)
+	defer cancel()
+
+	err := d.drain(ctx, partitions)
+	if err != nil {
+		d.logger.WarnCtx(ctx, "revoked partitions not drained, unfinished messages will be redelivered",
+			zap.Int("in-flight", d.offsets.InFlight(partitions)),
+			zap.Error(err),
+		)
+	}
 
-	err = handleMessage(ctx, msg)
+	d.commitMu.Lock()
+	defer d.commitMu.Unlock()
+
+	err = d.commitLocked(ctx)
 	if err != nil {
-		return err
+		d.logger.ErrorCtx(ctx, "revoked partitions commit fail", zap.Error(err))
 	}
+	d.offsets.Forget(partitions)
+}
 
-	err = d.messageConsumer.Commit(ctx)
+func (d *MessagesDispatcher) PartitionsLost(partitions []dto.TopicPartition) {
+	d.logger.WarnCtx(context.Background(), "partitions lost, unfinished messages will be redelivered",
+		zap.Int("in-flight", d.offsets.InFlight(partitions)),
+	)
+	d.offsets.Forget(partitions)
+}
+
+func (d *MessagesDispatcher) drain(ctx context.Context, partitions []dto.TopicPartition) error {
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+
+	for d.offsets.InFlight(partitions) > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// messageContext continues the trace of the message, so events caused by its
+// handling carry the same trace and correlation ID.
+func messageContext(ctx context.Context, msg dto.Message) context.Context {
+	headers := make(map[string]string, len(msg.Headers))
+	for _, h := range msg.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+	return tracing.Extract(ctx, headers)
+}
+
+// process handles the message, moving it to the dead-letter topic if it keeps
+// failing. An error means the message must not be committed.
+func (d *MessagesDispatcher) process(
+	ctx context.Context,
+	msg dto.Message,
+	handleMessage func(context.Context, []byte) error,
+) error {
+	attempts, err := d.handleWithRetry(ctx, msg, handleMessage)
 	if err != nil {
-		return err
+		if ctx.Err() != nil {
+			// drain timed out: the message is not committed and will be redelivered
+			return err
+		}
+		err = d.deadLetter(ctx, msg, attempts, err)
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
+func (d *MessagesDispatcher) handleWithRetry(
+	ctx context.Context,
+	msg dto.Message,
+	handleMessage func(context.Context, []byte) error,
+) (int, error) {
+	backoff := d.cfg.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := handleMessage(ctx, msg.Value)
+		if err == nil {
+			return attempt, nil
+		}
+		if errors.Is(err, breaker.ErrOpen) {
+			// The dependency is known to be down, so the message is not dead-lettered
+			// for it. Waiting for the breaker does not count as an attempt.
+			attempt--
+			if err := timeutils.SleepCtx(ctx, d.cfg.RetryBackoff); err != nil {
+				return attempt, err
+			}
+			continue
+		}
+		if attempt >= d.cfg.MaxAttempts || isPermanentError(err) {
+			return attempt, err
+		}
+		d.logger.WarnCtx(ctx, "message handling failed, retrying",
+			zap.String("topic", msg.Topic),
+			zap.Int32("partition", msg.Partition),
+			zap.Int64("offset", msg.Offset),
+			zap.Int("attempt", attempt),
+			zap.Error(err),
+		)
+		if err := timeutils.SleepCtx(ctx, backoff); err != nil {
+			return attempt, err
+		}
+		backoff *= 2
+	}
+}
+
+func isPermanentError(err error) bool {
+	return errors.Is(err, ErrInvalidMessage) || errors.Is(err, ErrInvoiceNotFound)
+}
+
+func (d *MessagesDispatcher) deadLetter(ctx context.Context, msg dto.Message, attempts int, handleErr error) error {
+	d.logger.ErrorCtx(ctx, "message handling failed, moving to dead-letter topic",
+		zap.String("topic", msg.Topic),
+		zap.Int32("partition", msg.Partition),
+		zap.Int64("offset", msg.Offset),
+		zap.Int("attempts", attempts),
+		zap.Error(handleErr),
+	)
+
+	dlqMsg := deadLetterMessage(d.cfg.DeadLetterTopic, msg, attempts, handleErr, time.Now())
+
+	err := timeutils.Retry(
+		ctx,
+		d.cfg.DeadLetterRetryAttempts,
+		func(ctx context.Context) error {
+			return d.deadLetterProducer.SendMessage(ctx, dlqMsg)
+		},
+		func(ctx context.Context, err error) bool {
+			d.logger.ErrorCtx(ctx, "dead-letter message sending fail", zap.Error(err))
+			return true
+		},
+		true,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to send message to dead-letter topic: %w", err)
+	}
 	return nil
 }
 
 func (d *MessagesDispatcher) HandleMessage(ctx context.Context, msg []byte) error {
-	newInvoice, err := utils.DecodeJSON[protocol.NewInvoice](bytes.NewBuffer(msg))
+	envelope, err := protocol.DecodeEnvelope[protocol.NewInvoice](msg)
 	if err != nil {
-		return fmt.Errorf("failed to decode new invoice: %w", err)
+		return fmt.Errorf("%w: failed to decode new invoice: %w", ErrInvalidMessage, err)
 	}
+	newInvoice := envelope.Data
 	d.logger.InfoCtx(ctx, "reading invoice", zap.String("id", newInvoice.ID.String()))
 	invoice, invoiceStatus, err := d.invoiceStorage.GetInvoice(ctx, newInvoice.ID)
 	if err != nil {
@@ -115,14 +404,23 @@ func (d *MessagesDispatcher) HandleMessage(ctx context.Context, msg []byte) erro
 		return nil
 	}
 	d.logger.InfoCtx(ctx, "validating invoice", zap.String("id", invoice.ID.String()))
+	inboxKey := d.inboxKey(envelope.EventID)
 	if d.invoiceValidator.Validate(invoice) {
-		err := d.invoiceStorage.SetApproved(ctx, newInvoice.ID)
+		err := d.invoiceStorage.SetApproved(ctx, newInvoice.ID, inboxKey)
+		if errors.Is(err, inbox.ErrAlreadyHandled) {
+			d.logger.InfoCtx(ctx, "duplicated event skipped", zap.String("event-id", envelope.EventID.String()))
+			return nil
+		}
 		if err != nil {
 			return fmt.Errorf("failed to set approved invoice: %w", err)
 		}
 		d.logger.InfoCtx(ctx, "invoice approved", zap.String("id", invoice.ID.String()))
 	} else {
-		err := d.invoiceStorage.SetRejected(ctx, newInvoice.ID)
+		err := d.invoiceStorage.SetRejected(ctx, newInvoice.ID, inboxKey)
+		if errors.Is(err, inbox.ErrAlreadyHandled) {
+			d.logger.InfoCtx(ctx, "duplicated event skipped", zap.String("event-id", envelope.EventID.String()))
+			return nil
+		}
 		if err != nil {
 			return fmt.Errorf("failed to set rejected invoice: %w", err)
 		}
@@ -130,3 +428,14 @@ func (d *MessagesDispatcher) HandleMessage(ctx context.Context, msg []byte) erro
 	}
 	return nil
 }
+
+// inboxKey returns nil for messages without an event ID, published before events were enveloped.
+func (d *MessagesDispatcher) inboxKey(eventID uuid.UUID) *inbox.Key {
+	if eventID == uuid.Nil {
+		return nil
+	}
+	return &inbox.Key{
+		EventID:       eventID,
+		ConsumerGroup: d.cfg.ConsumerGroup,
+	}
+}
@@ -4,11 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"go-invoice-service/common/pkg/breaker"
+	"go-invoice-service/common/pkg/inbox"
 	"go-invoice-service/common/pkg/logging"
+	"go-invoice-service/common/pkg/timeutils"
 	protocol "go-invoice-service/common/protocol/kafka"
 	"go.uber.org/mock/gomock"
+	"path/filepath"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 	"validation-service/internal/dto"
@@ -27,6 +34,7 @@ func TestMessagesDispatcher_HandleMessage(t *testing.T) {
 		storageError          error
 		validateResult        bool
 		storageStatusSetError error
+		wantInboxKey          bool
 		resultCheck           func(*testing.T, error)
 	}{
 		{
@@ -42,6 +50,64 @@ func TestMessagesDispatcher_HandleMessage(t *testing.T) {
 				require.NoError(t, err)
 			},
 		},
+		{
+			name:        "success_enveloped",
+			messageBody: envelopeFromId(protocol.NewInvoice{ID: invoiceID}),
+			invoiceProvider: func() *dto.Invoice {
+				return createInvoiceDTO(invoiceID)
+			},
+			invoiceStatus:  dto.PendingInvoiceStatus,
+			validateResult: true,
+			wantInboxKey:   true,
+			resultCheck: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:        "duplicated_event_skipped",
+			messageBody: envelopeFromId(protocol.NewInvoice{ID: invoiceID}),
+			invoiceProvider: func() *dto.Invoice {
+				return createInvoiceDTO(invoiceID)
+			},
+			invoiceStatus:         dto.PendingInvoiceStatus,
+			validateResult:        false,
+			storageStatusSetError: inbox.ErrAlreadyHandled,
+			wantInboxKey:          true,
+			resultCheck: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:        "success_protobuf",
+			messageBody: protobufFromId(t, protocol.NewInvoice{ID: invoiceID}),
+			invoiceProvider: func() *dto.Invoice {
+				return createInvoiceDTO(invoiceID)
+			},
+			invoiceStatus:  dto.PendingInvoiceStatus,
+			validateResult: true,
+			wantInboxKey:   true,
+			resultCheck: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:        "unexpected_protobuf_event_type_error",
+			messageBody: protobufFromId(t, protocol.RejectedInvoice{ID: invoiceID}),
+			invalidBody: true,
+			resultCheck: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidMessage)
+				require.ErrorIs(t, err, protocol.ErrUnexpectedEventType)
+			},
+		},
+		{
+			name:        "unexpected_event_type_error",
+			messageBody: envelopeFromId(protocol.ApprovedInvoice{ID: invoiceID}),
+			invalidBody: true,
+			resultCheck: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidMessage)
+				require.ErrorIs(t, err, protocol.ErrUnexpectedEventType)
+			},
+		},
 		{
 			name:        "success_approved_skipped",
 			messageBody: messageFromId(invoiceID),
@@ -131,7 +197,7 @@ func TestMessagesDispatcher_HandleMessage(t *testing.T) {
 			validateResult:        false,
 			storageStatusSetError: errors.New("test storage error"),
 			resultCheck: func(t *testing.T, err error) {
-				require.Error(t, err)
+				require.ErrorIs(t, err, ErrInvalidMessage)
 			},
 		},
 	}
@@ -142,11 +208,20 @@ func TestMessagesDispatcher_HandleMessage(t *testing.T) {
 			defer ctl.Finish()
 
 			cfg := MessagesDispatcherConfig{
+				ConsumerGroup: "validation-service",
 				PollTimeoutMs: 100,
 			}
+			inboxKeyMatcher := gomock.Cond(func(x any) bool {
+				key := x.(*inbox.Key)
+				if !test.wantInboxKey {
+					return key == nil
+				}
+				return key != nil && key.EventID != uuid.Nil && key.ConsumerGroup == cfg.ConsumerGroup
+			})
 
 			invoiceStorage := mock_services.NewMockInvoiceStorage(ctl)
 			messagesConsumer := mock_services.NewMockMessageConsumer(ctl)
+			messageProducer := mock_services.NewMockMessageProducer(ctl)
 			invoiceValidator := mock_services.NewMockInvoiceValidator(ctl)
 			logger := logging.NewNopLogger()
 
@@ -173,12 +248,12 @@ func TestMessagesDispatcher_HandleMessage(t *testing.T) {
 
 						if test.validateResult {
 							invoiceStorage.EXPECT().
-								SetApproved(gomock.Any(), gomock.Any()).
+								SetApproved(gomock.Any(), gomock.Any(), inboxKeyMatcher).
 								Return(test.storageStatusSetError).
 								Times(1)
 						} else {
 							invoiceStorage.EXPECT().
-								SetRejected(gomock.Any(), gomock.Any()).
+								SetRejected(gomock.Any(), gomock.Any(), inboxKeyMatcher).
 								Return(test.storageStatusSetError).
 								Times(1)
 						}
@@ -190,6 +265,7 @@ func TestMessagesDispatcher_HandleMessage(t *testing.T) {
 				cfg,
 				invoiceStorage,
 				messagesConsumer,
+				messageProducer,
 				invoiceValidator,
 				logger,
 			)
@@ -211,6 +287,22 @@ func messageFromId(id uuid.UUID) []byte {
 	return res
 }
 
+func envelopeFromId[T protocol.Event](data T) []byte {
+	res, err := json.Marshal(protocol.NewEnvelope(data, time.Now(), "correlation-id"))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func protobufFromId[T protocol.Event](t *testing.T, data T) []byte {
+	registry := protocol.NewFileSchemaRegistry(filepath.Join(t.TempDir(), "registry.json"))
+	encoder := protocol.NewProtobufEncoder(registry)
+	res, _, err := protocol.Encode(context.Background(), encoder, protocol.NewEnvelope(data, time.Now(), "correlation-id"))
+	require.NoError(t, err)
+	return res
+}
+
 func createInvoiceDTO(id uuid.UUID) *dto.Invoice {
 	return &dto.Invoice{
 		ID:         id,
@@ -232,50 +324,83 @@ func createInvoiceDTO(id uuid.UUID) *dto.Invoice {
 	}
 }
 
-func TestMessagesDispatcher_Tick(t *testing.T) {
+func TestMessagesDispatcher_Process(t *testing.T) {
+	message := dto.Message{
+		Topic:     string(protocol.TopicNewInvoice),
+		Partition: 2,
+		Offset:    42,
+		Value:     []byte{1, 2, 3},
+	}
+
 	tests := []struct {
-		name               string
-		peekNext           func() ([]byte, error)
-		handleMessageError error
-		commitError        error
-		resultCheck        func(*testing.T, error)
+		name                string
+		handleMessageErrors []error
+		deadLetterError     error
+		expectDeadLetter    bool
+		resultCheck         func(*testing.T, error)
 	}{
 		{
-			name: "success",
-			peekNext: func() ([]byte, error) {
-				return []byte{1, 2, 3}, nil
+			name:                "success",
+			handleMessageErrors: []error{nil},
+			resultCheck: func(t *testing.T, err error) {
+				require.NoError(t, err)
 			},
+		},
+		{
+			name:                "handle_message_retry_success",
+			handleMessageErrors: []error{errors.New("handle message fail"), nil},
 			resultCheck: func(t *testing.T, err error) {
 				require.NoError(t, err)
 			},
 		},
 		{
-			name: "peek_fail",
-			peekNext: func() ([]byte, error) {
-				return nil, errors.New("peek fail")
+			name: "handle_message_fail_dead_lettered",
+			handleMessageErrors: []error{
+				errors.New("handle message fail"),
+				errors.New("handle message fail"),
+				errors.New("handle message fail"),
 			},
+			expectDeadLetter: true,
 			resultCheck: func(t *testing.T, err error) {
-				require.Error(t, err)
+				require.NoError(t, err)
 			},
 		},
 		{
-			name: "commit_fail",
-			peekNext: func() ([]byte, error) {
-				return []byte{1, 2, 3}, nil
+			name:                "invalid_message_dead_lettered_without_retry",
+			handleMessageErrors: []error{ErrInvalidMessage},
+			expectDeadLetter:    true,
+			resultCheck: func(t *testing.T, err error) {
+				require.NoError(t, err)
 			},
-			commitError: errors.New("commit fail"),
+		},
+		{
+			name:                "invoice_not_found_dead_lettered_without_retry",
+			handleMessageErrors: []error{ErrInvoiceNotFound},
+			expectDeadLetter:    true,
 			resultCheck: func(t *testing.T, err error) {
-				require.Error(t, err)
+				require.NoError(t, err)
 			},
 		},
 		{
-			name: "handle_message_fail",
-			peekNext: func() ([]byte, error) {
-				return []byte{1, 2, 3}, nil
+			name: "breaker_open_not_counted_as_attempt",
+			handleMessageErrors: []error{
+				fmt.Errorf("failed to set approved: %w", breaker.ErrOpen),
+				fmt.Errorf("failed to set approved: %w", breaker.ErrOpen),
+				fmt.Errorf("failed to set approved: %w", breaker.ErrOpen),
+				errors.New("handle message fail"),
+				nil,
 			},
-			handleMessageError: errors.New("handle message fail"),
 			resultCheck: func(t *testing.T, err error) {
-				require.Error(t, err)
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:                "dead_letter_retry_success",
+			handleMessageErrors: []error{ErrInvalidMessage},
+			deadLetterError:     errors.New("dead letter fail"),
+			expectDeadLetter:    true,
+			resultCheck: func(t *testing.T, err error) {
+				require.NoError(t, err)
 			},
 		},
 	}
@@ -286,41 +411,339 @@ func TestMessagesDispatcher_Tick(t *testing.T) {
 			defer ctl.Finish()
 
 			cfg := MessagesDispatcherConfig{
-				PollTimeoutMs: 100,
+				PollTimeoutMs:           100,
+				MaxAttempts:             3,
+				RetryBackoff:            time.Millisecond,
+				DeadLetterTopic:         string(protocol.TopicNewInvoiceDLQ),
+				DeadLetterRetryAttempts: []time.Duration{time.Millisecond},
 			}
 
 			invoiceStorage := mock_services.NewMockInvoiceStorage(ctl)
 			messagesConsumer := mock_services.NewMockMessageConsumer(ctl)
+			messageProducer := mock_services.NewMockMessageProducer(ctl)
 			invoiceValidator := mock_services.NewMockInvoiceValidator(ctl)
 			logger := logging.NewNopLogger()
 
-			peekCall := messagesConsumer.EXPECT().
-				PeekNext(cfg.PollTimeoutMs).
-				Return(test.peekNext()).
-				Times(1)
-
-			messagesConsumer.EXPECT().
-				Commit(gomock.Any()).
-				Return(test.commitError).
-				MaxTimes(1).
-				After(peekCall)
+			if test.expectDeadLetter {
+				checkDeadLetter := func(_ context.Context, msg dto.Message) {
+					require.Equal(t, cfg.DeadLetterTopic, msg.Topic)
+					require.Equal(t, message.Value, msg.Value)
+					require.Equal(t, message.Topic, headerValue(msg.Headers, protocol.DeadLetterHeaderSourceTopic))
+					require.Equal(t, "42", headerValue(msg.Headers, protocol.DeadLetterHeaderSourceOffset))
+					require.Equal(
+						t,
+						strconv.Itoa(len(test.handleMessageErrors)),
+						headerValue(msg.Headers, protocol.DeadLetterHeaderAttempts),
+					)
+					require.NotEmpty(t, headerValue(msg.Headers, protocol.DeadLetterHeaderError))
+				}
+				if test.deadLetterError != nil {
+					messageProducer.EXPECT().
+						SendMessage(gomock.Any(), gomock.Any()).
+						Do(checkDeadLetter).
+						Return(test.deadLetterError).
+						Times(1)
+				}
+				messageProducer.EXPECT().
+					SendMessage(gomock.Any(), gomock.Any()).
+					Do(checkDeadLetter).
+					Return(nil).
+					Times(1)
+			}
 
 			messagesDispatcher := NewMessagesDispatcher(
 				cfg,
 				invoiceStorage,
 				messagesConsumer,
+				messageProducer,
 				invoiceValidator,
 				logger,
 			)
 
-			err := messagesDispatcher.tick(
+			handleCalls := 0
+			err := messagesDispatcher.process(
 				context.Background(),
+				message,
 				func(ctx context.Context, bytes []byte) error {
-					return test.handleMessageError
+					err := test.handleMessageErrors[handleCalls]
+					handleCalls++
+					return err
 				},
 			)
 			test.resultCheck(t, err)
+			require.Equal(t, len(test.handleMessageErrors), handleCalls)
+		})
+	}
+}
+
+func TestMessagesDispatcher_ProcessCanceled(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	cfg := MessagesDispatcherConfig{
+		PollTimeoutMs: 100,
+		MaxAttempts:   3,
+		RetryBackoff:  time.Hour,
+	}
 
+	messagesDispatcher := NewMessagesDispatcher(
+		cfg,
+		mock_services.NewMockInvoiceStorage(ctl),
+		mock_services.NewMockMessageConsumer(ctl),
+		mock_services.NewMockMessageProducer(ctl),
+		mock_services.NewMockInvoiceValidator(ctl),
+		logging.NewNopLogger(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := messagesDispatcher.process(ctx, dto.Message{Value: []byte{1, 2, 3}}, func(ctx context.Context, bytes []byte) error {
+		cancel()
+		return errors.New("handle message fail")
+	})
+	require.Error(t, err)
+}
+
+func TestMessagesDispatcher_Run(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	cfg := MessagesDispatcherConfig{
+		PollTimeoutMs:   100,
+		NumWorkers:      2,
+		WorkerQueueSize: 1,
+		CommitInterval:  time.Hour,
+		MaxAttempts:     1,
+	}
+
+	topic := string(protocol.TopicNewInvoice)
+	var messages []dto.Message
+	for offset := range int64(3) {
+		for partition := range int32(3) {
+			messages = append(messages, dto.Message{
+				Topic:     topic,
+				Partition: partition,
+				Offset:    offset,
+				Value:     []byte(fmt.Sprintf("%d/%d", partition, offset)),
+			})
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errNoMessage := errors.New("no message")
+	messagesConsumer := mock_services.NewMockMessageConsumer(ctl)
+	messagesConsumer.EXPECT().ErrIsNoMessage(gomock.Any()).DoAndReturn(func(err error) bool {
+		return errors.Is(err, errNoMessage)
+	}).AnyTimes()
+
+	polled := 0
+	messagesConsumer.EXPECT().PeekNext(cfg.PollTimeoutMs).DoAndReturn(func(int) (dto.Message, error) {
+		if polled == len(messages) {
+			time.Sleep(time.Millisecond)
+			return dto.Message{}, errNoMessage
+		}
+		polled++
+		return messages[polled-1], nil
+	}).AnyTimes()
+
+	messagesConsumer.EXPECT().
+		CommitOffsets(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, offsets []dto.PartitionOffset) {
+			require.ElementsMatch(t, []dto.PartitionOffset{
+				{Topic: topic, Partition: 0, Offset: 3},
+				{Topic: topic, Partition: 1, Offset: 3},
+				{Topic: topic, Partition: 2, Offset: 3},
+			}, offsets)
+		}).
+		Return(nil).
+		Times(1)
+
+	messagesDispatcher := NewMessagesDispatcher(
+		cfg,
+		mock_services.NewMockInvoiceStorage(ctl),
+		messagesConsumer,
+		mock_services.NewMockMessageProducer(ctl),
+		mock_services.NewMockInvoiceValidator(ctl),
+		logging.NewNopLogger(),
+	)
+
+	mu := sync.Mutex{}
+	handled := make(map[int32][]string)
+	errCh := messagesDispatcher.run(ctx, func(ctx context.Context, value []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		var partition, offset int
+		_, err := fmt.Sscanf(string(value), "%d/%d", &partition, &offset)
+		require.NoError(t, err)
+		handled[int32(partition)] = append(handled[int32(partition)], string(value))
+		if len(handled[0])+len(handled[1])+len(handled[2]) == len(messages) {
+			cancel()
+		}
+		return nil
+	})
+
+	for err := range errCh {
+		require.NoError(t, err)
+	}
+
+	for partition := range int32(3) {
+		require.Equal(t, []string{
+			fmt.Sprintf("%d/0", partition),
+			fmt.Sprintf("%d/1", partition),
+			fmt.Sprintf("%d/2", partition),
+		}, handled[partition])
+	}
+}
+
+func TestMessagesDispatcher_PartitionsRevoked(t *testing.T) {
+	topic := string(protocol.TopicNewInvoice)
+	revoked := []dto.TopicPartition{{Topic: topic, Partition: 0}}
+
+	tests := []struct {
+		name          string
+		finishMessage bool
+		expectCommit  []dto.PartitionOffset
+	}{
+		{
+			name:          "drained",
+			finishMessage: true,
+			expectCommit: []dto.PartitionOffset{
+				{Topic: topic, Partition: 0, Offset: 6},
+			},
+		},
+		{
+			name: "drain_timeout",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+
+			cfg := MessagesDispatcherConfig{
+				RebalanceTimeout: 200 * time.Millisecond,
+			}
+
+			messagesConsumer := mock_services.NewMockMessageConsumer(ctl)
+			if test.expectCommit != nil {
+				messagesConsumer.EXPECT().
+					CommitOffsets(gomock.Any(), test.expectCommit).
+					Return(nil).
+					Times(1)
+			}
+
+			messagesDispatcher := NewMessagesDispatcher(
+				cfg,
+				mock_services.NewMockInvoiceStorage(ctl),
+				messagesConsumer,
+				mock_services.NewMockMessageProducer(ctl),
+				mock_services.NewMockInvoiceValidator(ctl),
+				logging.NewNopLogger(),
+			)
+
+			inFlight := dto.Message{Topic: topic, Partition: 0, Offset: 5}
+			messagesDispatcher.offsets.Track(inFlight)
+			if test.finishMessage {
+				go func() {
+					time.Sleep(20 * time.Millisecond)
+					messagesDispatcher.offsets.Done(inFlight)
+				}()
+			}
+
+			messagesDispatcher.PartitionsRevoked(revoked)
+
+			require.Zero(t, messagesDispatcher.offsets.InFlight(revoked))
+			require.False(t, messagesDispatcher.offsets.Tracked(inFlight))
+		})
+	}
+}
+
+func TestMessagesDispatcher_RunDrain(t *testing.T) {
+	topic := string(protocol.TopicNewInvoice)
+
+	tests := []struct {
+		name         string
+		handleTime   time.Duration
+		expectCommit bool
+	}{
+		{
+			name:         "drained",
+			handleTime:   50 * time.Millisecond,
+			expectCommit: true,
+		},
+		{
+			name:       "drain_timeout",
+			handleTime: time.Hour,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+
+			cfg := MessagesDispatcherConfig{
+				PollTimeoutMs:   100,
+				NumWorkers:      1,
+				WorkerQueueSize: 1,
+				CommitInterval:  time.Hour,
+				DrainTimeout:    200 * time.Millisecond,
+				MaxAttempts:     1,
+			}
+
+			errNoMessage := errors.New("no message")
+			messagesConsumer := mock_services.NewMockMessageConsumer(ctl)
+			messagesConsumer.EXPECT().ErrIsNoMessage(gomock.Any()).DoAndReturn(func(err error) bool {
+				return errors.Is(err, errNoMessage)
+			}).AnyTimes()
+
+			polled := false
+			messagesConsumer.EXPECT().PeekNext(cfg.PollTimeoutMs).DoAndReturn(func(int) (dto.Message, error) {
+				if polled {
+					time.Sleep(time.Millisecond)
+					return dto.Message{}, errNoMessage
+				}
+				polled = true
+				return dto.Message{Topic: topic, Partition: 0, Offset: 7}, nil
+			}).AnyTimes()
+
+			if test.expectCommit {
+				messagesConsumer.EXPECT().
+					CommitOffsets(gomock.Any(), []dto.PartitionOffset{{Topic: topic, Partition: 0, Offset: 8}}).
+					Return(nil).
+					Times(1)
+			}
+
+			messagesDispatcher := NewMessagesDispatcher(
+				cfg,
+				mock_services.NewMockInvoiceStorage(ctl),
+				messagesConsumer,
+				mock_services.NewMockMessageProducer(ctl),
+				mock_services.NewMockInvoiceValidator(ctl),
+				logging.NewNopLogger(),
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			errCh := messagesDispatcher.run(ctx, func(ctx context.Context, _ []byte) error {
+				// shutdown starts while the message is handled
+				cancel()
+				return timeutils.SleepCtx(ctx, test.handleTime)
+			})
+
+			var errs []error
+			for err := range errCh {
+				errs = append(errs, err)
+			}
+			if test.expectCommit {
+				require.Empty(t, errs)
+			} else {
+				require.Len(t, errs, 1)
+				require.ErrorIs(t, errs[0], context.Canceled)
+			}
 		})
 	}
 }
@@ -11,6 +11,7 @@ package mock_services
 
 import (
 	context "context"
+	inbox "go-invoice-service/common/pkg/inbox"
 	reflect "reflect"
 	dto "validation-service/internal/dto"
 
@@ -97,31 +98,31 @@ func (mr *MockInvoiceStorageMockRecorder) GetInvoice(ctx, id any) *gomock.Call {
 }
 
 // SetApproved mocks base method.
-func (m *MockInvoiceStorage) SetApproved(ctx context.Context, id uuid.UUID) error {
+func (m *MockInvoiceStorage) SetApproved(ctx context.Context, id uuid.UUID, inboxKey *inbox.Key) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetApproved", ctx, id)
+	ret := m.ctrl.Call(m, "SetApproved", ctx, id, inboxKey)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SetApproved indicates an expected call of SetApproved.
-func (mr *MockInvoiceStorageMockRecorder) SetApproved(ctx, id any) *gomock.Call {
+func (mr *MockInvoiceStorageMockRecorder) SetApproved(ctx, id, inboxKey any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetApproved", reflect.TypeOf((*MockInvoiceStorage)(nil).SetApproved), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetApproved", reflect.TypeOf((*MockInvoiceStorage)(nil).SetApproved), ctx, id, inboxKey)
 }
 
 // SetRejected mocks base method.
-func (m *MockInvoiceStorage) SetRejected(ctx context.Context, id uuid.UUID) error {
+func (m *MockInvoiceStorage) SetRejected(ctx context.Context, id uuid.UUID, inboxKey *inbox.Key) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetRejected", ctx, id)
+	ret := m.ctrl.Call(m, "SetRejected", ctx, id, inboxKey)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SetRejected indicates an expected call of SetRejected.
-func (mr *MockInvoiceStorageMockRecorder) SetRejected(ctx, id any) *gomock.Call {
+func (mr *MockInvoiceStorageMockRecorder) SetRejected(ctx, id, inboxKey any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRejected", reflect.TypeOf((*MockInvoiceStorage)(nil).SetRejected), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRejected", reflect.TypeOf((*MockInvoiceStorage)(nil).SetRejected), ctx, id, inboxKey)
 }
 
 // MockMessageConsumer is a mock of MessageConsumer interface.
@@ -148,18 +149,18 @@ func (m *MockMessageConsumer) EXPECT() *MockMessageConsumerMockRecorder {
 	return m.recorder
 }
 
-// Commit mocks base method.
-func (m *MockMessageConsumer) Commit(ctx context.Context) error {
+// CommitOffsets mocks base method.
+func (m *MockMessageConsumer) CommitOffsets(ctx context.Context, offsets []dto.PartitionOffset) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Commit", ctx)
+	ret := m.ctrl.Call(m, "CommitOffsets", ctx, offsets)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Commit indicates an expected call of Commit.
-func (mr *MockMessageConsumerMockRecorder) Commit(ctx any) *gomock.Call {
+// CommitOffsets indicates an expected call of CommitOffsets.
+func (mr *MockMessageConsumerMockRecorder) CommitOffsets(ctx, offsets any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockMessageConsumer)(nil).Commit), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitOffsets", reflect.TypeOf((*MockMessageConsumer)(nil).CommitOffsets), ctx, offsets)
 }
 
 // ErrIsNoMessage mocks base method.
@@ -177,10 +178,10 @@ func (mr *MockMessageConsumerMockRecorder) ErrIsNoMessage(arg0 any) *gomock.Call
 }
 
 // PeekNext mocks base method.
-func (m *MockMessageConsumer) PeekNext(pollTimeoutMs int) ([]byte, error) {
+func (m *MockMessageConsumer) PeekNext(pollTimeoutMs int) (dto.Message, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PeekNext", pollTimeoutMs)
-	ret0, _ := ret[0].([]byte)
+	ret0, _ := ret[0].(dto.Message)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -190,3 +191,41 @@ func (mr *MockMessageConsumerMockRecorder) PeekNext(pollTimeoutMs any) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeekNext", reflect.TypeOf((*MockMessageConsumer)(nil).PeekNext), pollTimeoutMs)
 }
+
+// MockMessageProducer is a mock of MessageProducer interface.
+type MockMessageProducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageProducerMockRecorder
+	isgomock struct{}
+}
+
+// MockMessageProducerMockRecorder is the mock recorder for MockMessageProducer.
+type MockMessageProducerMockRecorder struct {
+	mock *MockMessageProducer
+}
+
+// NewMockMessageProducer creates a new mock instance.
+func NewMockMessageProducer(ctrl *gomock.Controller) *MockMessageProducer {
+	mock := &MockMessageProducer{ctrl: ctrl}
+	mock.recorder = &MockMessageProducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageProducer) EXPECT() *MockMessageProducerMockRecorder {
+	return m.recorder
+}
+
+// SendMessage mocks base method.
+func (m *MockMessageProducer) SendMessage(ctx context.Context, msg dto.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessage", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMessage indicates an expected call of SendMessage.
+func (mr *MockMessageProducerMockRecorder) SendMessage(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockMessageProducer)(nil).SendMessage), ctx, msg)
+}
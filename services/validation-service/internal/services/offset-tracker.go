@@ -0,0 +1,149 @@
+package services
+
+import (
+	"slices"
+	"sync"
+	"validation-service/internal/dto"
+)
+
+type partitionOffsets struct {
+	// inFlight holds offsets of tracked messages in the order they were polled.
+	inFlight  []int64
+	done      map[int64]struct{}
+	next      int64
+	committed int64
+}
+
+// OffsetTracker finds per partition the highest offset below which every
+// polled message has been processed, so messages may finish out of order
+// without committing past an unprocessed one.
+type OffsetTracker struct {
+	mu         sync.Mutex
+	partitions map[dto.TopicPartition]*partitionOffsets
+}
+
+func NewOffsetTracker() *OffsetTracker {
+	return &OffsetTracker{
+		partitions: make(map[dto.TopicPartition]*partitionOffsets),
+	}
+}
+
+func (t *OffsetTracker) Track(msg dto.Message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := dto.TopicPartition{Topic: msg.Topic, Partition: msg.Partition}
+	p, ok := t.partitions[key]
+	if !ok {
+		p = &partitionOffsets{
+			done:      make(map[int64]struct{}),
+			next:      -1,
+			committed: -1,
+		}
+		t.partitions[key] = p
+	}
+	p.inFlight = append(p.inFlight, msg.Offset)
+}
+
+// Tracked reports whether the message is still awaited, it is not after its
+// partition has been forgotten.
+func (t *OffsetTracker) Tracked(msg dto.Message) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.partitions[dto.TopicPartition{Topic: msg.Topic, Partition: msg.Partition}]
+	return ok && slices.Contains(p.inFlight, msg.Offset)
+}
+
+func (t *OffsetTracker) Done(msg dto.Message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.partitions[dto.TopicPartition{Topic: msg.Topic, Partition: msg.Partition}]
+	if !ok || !slices.Contains(p.inFlight, msg.Offset) {
+		return
+	}
+	p.done[msg.Offset] = struct{}{}
+	for len(p.inFlight) > 0 {
+		head := p.inFlight[0]
+		if _, ok := p.done[head]; !ok {
+			break
+		}
+		delete(p.done, head)
+		p.inFlight = p.inFlight[1:]
+		p.next = head + 1
+	}
+}
+
+// InFlight returns the count of tracked and not yet processed messages of the partitions.
+func (t *OffsetTracker) InFlight(partitions []dto.TopicPartition) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	res := 0
+	for _, key := range partitions {
+		if p, ok := t.partitions[key]; ok {
+			res += len(p.inFlight)
+		}
+	}
+	return res
+}
+
+// TotalInFlight returns the count of tracked and not yet processed messages of all partitions.
+func (t *OffsetTracker) TotalInFlight() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	res := 0
+	for _, p := range t.partitions {
+		res += len(p.inFlight)
+	}
+	return res
+}
+
+// Committable returns offsets of partitions which advanced since the last
+// MarkCommitted.
+func (t *OffsetTracker) Committable() []dto.PartitionOffset {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var res []dto.PartitionOffset
+	for key, p := range t.partitions {
+		if p.next > p.committed {
+			res = append(res, dto.PartitionOffset{
+				Topic:     key.Topic,
+				Partition: key.Partition,
+				Offset:    p.next,
+			})
+		}
+	}
+	return res
+}
+
+func (t *OffsetTracker) MarkCommitted(offsets []dto.PartitionOffset) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, o := range offsets {
+		p, ok := t.partitions[dto.TopicPartition{Topic: o.Topic, Partition: o.Partition}]
+		if ok && o.Offset > p.committed {
+			p.committed = o.Offset
+		}
+	}
+}
+
+func (t *OffsetTracker) Forget(partitions []dto.TopicPartition) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, key := range partitions {
+		delete(t.partitions, key)
+	}
+}
+
+func (t *OffsetTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	clear(t.partitions)
+}
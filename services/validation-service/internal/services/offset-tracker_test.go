@@ -0,0 +1,88 @@
+package services
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"validation-service/internal/dto"
+)
+
+func TestOffsetTracker(t *testing.T) {
+	tests := []struct {
+		name     string
+		tracked  []int64
+		done     []int64
+		expected []dto.PartitionOffset
+	}{
+		{
+			name:    "nothing_done",
+			tracked: []int64{10, 11, 12},
+		},
+		{
+			name:    "in_order",
+			tracked: []int64{10, 11, 12},
+			done:    []int64{10, 11},
+			expected: []dto.PartitionOffset{
+				{Topic: "topic", Partition: 1, Offset: 12},
+			},
+		},
+		{
+			name:    "gap_blocks_commit",
+			tracked: []int64{10, 11, 12},
+			done:    []int64{11, 12},
+		},
+		{
+			name:    "gap_filled",
+			tracked: []int64{10, 11, 12},
+			done:    []int64{12, 11, 10},
+			expected: []dto.PartitionOffset{
+				{Topic: "topic", Partition: 1, Offset: 13},
+			},
+		},
+		{
+			name:    "non_contiguous_offsets",
+			tracked: []int64{10, 15, 20},
+			done:    []int64{10, 15},
+			expected: []dto.PartitionOffset{
+				{Topic: "topic", Partition: 1, Offset: 16},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := NewOffsetTracker()
+			for _, offset := range test.tracked {
+				tracker.Track(dto.Message{Topic: "topic", Partition: 1, Offset: offset})
+			}
+			for _, offset := range test.done {
+				tracker.Done(dto.Message{Topic: "topic", Partition: 1, Offset: offset})
+			}
+
+			committable := tracker.Committable()
+			require.Equal(t, test.expected, committable)
+
+			tracker.MarkCommitted(committable)
+			require.Empty(t, tracker.Committable())
+		})
+	}
+}
+
+func TestOffsetTracker_Forget(t *testing.T) {
+	tracker := NewOffsetTracker()
+	revoked := dto.Message{Topic: "topic", Partition: 1, Offset: 10}
+	kept := dto.Message{Topic: "topic", Partition: 2, Offset: 10}
+	tracker.Track(revoked)
+	tracker.Track(kept)
+
+	require.True(t, tracker.Tracked(revoked))
+	require.Equal(t, 1, tracker.InFlight([]dto.TopicPartition{{Topic: "topic", Partition: 1}}))
+
+	tracker.Forget([]dto.TopicPartition{{Topic: "topic", Partition: 1}})
+	require.False(t, tracker.Tracked(revoked))
+	require.True(t, tracker.Tracked(kept))
+
+	// processing of a forgotten message finishing late must not be committed
+	tracker.Done(revoked)
+	tracker.Done(kept)
+	require.Equal(t, []dto.PartitionOffset{{Topic: "topic", Partition: 2, Offset: 11}}, tracker.Committable())
+}